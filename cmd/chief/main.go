@@ -1,15 +1,21 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"syscall"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/minicodemonkey/chief/internal/cmd"
 	"github.com/minicodemonkey/chief/internal/config"
+	"github.com/minicodemonkey/chief/internal/loop"
+	"github.com/minicodemonkey/chief/internal/notify"
 	"github.com/minicodemonkey/chief/internal/paths"
 	"github.com/minicodemonkey/chief/internal/prd"
 	"github.com/minicodemonkey/chief/internal/tui"
@@ -26,6 +32,12 @@ type TUIOptions struct {
 	Merge         bool
 	Force         bool
 	NoRetry       bool
+	NoDesktop     bool
+	ReviewPrompt  bool
+	Accessible    bool          // Narrate key transitions as plain text on stderr
+	IterTimeout   time.Duration // Kill and retry an iteration after this long without output
+	Name          string        // PRD name, also where a remote PRD is cached
+	RemoteURL     string        // URL of a remote PRD to fetch before starting
 }
 
 func main() {
@@ -44,6 +56,33 @@ func main() {
 		case "list":
 			runList()
 			return
+		case "convert":
+			runConvert()
+			return
+		case "rename":
+			runRename()
+			return
+		case "clone":
+			runClone()
+			return
+		case "archive":
+			runArchive()
+			return
+		case "export":
+			runExport()
+			return
+		case "logs":
+			runLogs()
+			return
+		case "rebuild-progress":
+			runRebuildProgress()
+			return
+		case "run":
+			runHeadless()
+			return
+		case "test-setup":
+			runTestSetup()
+			return
 		case "help":
 			printHelp()
 			return
@@ -59,6 +98,15 @@ func main() {
 		case "wiggum":
 			printWiggum()
 			return
+		case "completion":
+			runCompletion()
+			return
+		case "__complete":
+			// Hidden helper for the completion scripts: PRD names, one per line
+			for _, name := range listAvailablePRDs() {
+				fmt.Println(name)
+			}
+			return
 		}
 	}
 
@@ -100,7 +148,7 @@ func findAvailablePRD() string {
 	for _, entry := range entries {
 		if entry.IsDir() {
 			prdPath := filepath.Join(prdsDir, entry.Name(), "prd.json")
-			if _, err := os.Stat(prdPath); err == nil {
+			if _, err := os.Stat(prd.ResolvePath(prdPath)); err == nil {
 				return prdPath
 			}
 		}
@@ -110,29 +158,14 @@ func findAvailablePRD() string {
 
 // listAvailablePRDs returns all PRD names in ~/.chief/projects/<project>/prds/
 func listAvailablePRDs() []string {
-	prdsDir := paths.PRDsDir(cwd())
-	entries, err := os.ReadDir(prdsDir)
-	if err != nil {
-		return nil
-	}
-
-	var names []string
-	for _, entry := range entries {
-		if entry.IsDir() {
-			prdPath := filepath.Join(prdsDir, entry.Name(), "prd.json")
-			if _, err := os.Stat(prdPath); err == nil {
-				names = append(names, entry.Name())
-			}
-		}
-	}
-	return names
+	return cmd.AvailablePRDs(cwd())
 }
 
 // parseTUIFlags parses command-line flags for TUI mode
 func parseTUIFlags() *TUIOptions {
 	opts := &TUIOptions{
 		PRDPath:       "", // Will be resolved later
-		MaxIterations: 0,  // 0 signals dynamic calculation (remaining stories + 5)
+		MaxIterations: 0,  // 0 signals dynamic calculation (priority-weighted remaining stories + 5)
 		Verbose:       false,
 		Merge:         false,
 		Force:         false,
@@ -157,6 +190,36 @@ func parseTUIFlags() *TUIOptions {
 			opts.Force = true
 		case arg == "--no-retry":
 			opts.NoRetry = true
+		case arg == "--no-desktop":
+			opts.NoDesktop = true
+		case arg == "--review-prompt":
+			opts.ReviewPrompt = true
+		case arg == "--accessible":
+			opts.Accessible = true
+		case arg == "--name":
+			if i+1 < len(os.Args) {
+				i++
+				opts.Name = os.Args[i]
+			} else {
+				fmt.Fprintf(os.Stderr, "Error: %s requires a value\n", arg)
+				os.Exit(1)
+			}
+		case strings.HasPrefix(arg, "--name="):
+			opts.Name = strings.TrimPrefix(arg, "--name=")
+		case arg == "--iteration-timeout":
+			if i+1 < len(os.Args) {
+				i++
+				opts.IterTimeout = parseTimeout(arg, os.Args[i])
+			} else {
+				fmt.Fprintf(os.Stderr, "Error: %s requires a value\n", arg)
+				os.Exit(1)
+			}
+		case strings.HasPrefix(arg, "--iteration-timeout="):
+			opts.IterTimeout = parseTimeout("--iteration-timeout", strings.TrimPrefix(arg, "--iteration-timeout="))
+		case arg == "--timeout", strings.HasPrefix(arg, "--timeout="):
+			// --timeout bounds a whole headless run, which the TUI has no equivalent of
+			fmt.Fprintf(os.Stderr, "Error: --timeout only applies to 'chief run'; use --iteration-timeout to bound an iteration\n")
+			os.Exit(1)
 		case arg == "--max-iterations" || arg == "-n":
 			// Next argument should be the number
 			if i+1 < len(os.Args) {
@@ -205,8 +268,10 @@ func parseTUIFlags() *TUIOptions {
 			fmt.Fprintf(os.Stderr, "Run 'chief --help' for usage.\n")
 			os.Exit(1)
 		default:
-			// Positional argument: PRD name or path
-			if strings.HasSuffix(arg, ".json") || strings.HasSuffix(arg, "/") {
+			// Positional argument: PRD URL, path, or name
+			if cmd.IsRemotePRD(arg) {
+				opts.RemoteURL = arg
+			} else if strings.HasSuffix(arg, ".json") || strings.HasSuffix(arg, "/") {
 				opts.PRDPath = arg
 			} else {
 				// Treat as PRD name
@@ -215,6 +280,11 @@ func parseTUIFlags() *TUIOptions {
 		}
 	}
 
+	// --name without a URL selects a local PRD by name
+	if opts.Name != "" && opts.RemoteURL == "" {
+		opts.PRDPath = paths.PRDPath(cwd(), opts.Name)
+	}
+
 	return opts
 }
 
@@ -238,10 +308,12 @@ func runNew() {
 func runEdit() {
 	opts := cmd.EditOptions{}
 
-	// Parse arguments: chief edit [name] [--merge] [--force]
+	// Parse arguments: chief edit [name] [--merge] [--force] [--manual]
 	for i := 2; i < len(os.Args); i++ {
 		arg := os.Args[i]
 		switch arg {
+		case "--manual":
+			opts.Manual = true
 		case "--merge":
 			opts.Merge = true
 		case "--force":
@@ -263,9 +335,33 @@ func runEdit() {
 func runStatus() {
 	opts := cmd.StatusOptions{}
 
-	// Parse arguments: chief status [name]
-	if len(os.Args) > 2 && !strings.HasPrefix(os.Args[2], "-") {
-		opts.Name = os.Args[2]
+	// Parse arguments: chief status [name] [--all] [--json] [--strict] [--watch] [--interval N]
+	for i := 2; i < len(os.Args); i++ {
+		arg := os.Args[i]
+		switch {
+		case arg == "--json":
+			opts.JSON = true
+		case arg == "--strict":
+			opts.Strict = true
+		case arg == "--all":
+			opts.All = true
+		case arg == "--watch", arg == "-w":
+			opts.Watch = true
+		case arg == "--interval":
+			if i+1 >= len(os.Args) {
+				fmt.Fprintln(os.Stderr, "Error: --interval requires a value")
+				os.Exit(1)
+			}
+			i++
+			opts.Interval = time.Duration(parseCount(arg, os.Args[i])) * time.Second
+		case strings.HasPrefix(arg, "--interval="):
+			opts.Interval = time.Duration(parseCount("--interval", strings.TrimPrefix(arg, "--interval="))) * time.Second
+		case strings.HasPrefix(arg, "-"):
+			fmt.Fprintf(os.Stderr, "Error: unknown flag: %s\n", arg)
+			os.Exit(1)
+		case opts.Name == "":
+			opts.Name = arg
+		}
 	}
 
 	if err := cmd.RunStatus(opts); err != nil {
@@ -274,6 +370,336 @@ func runStatus() {
 	}
 }
 
+func runConvert() {
+	opts := cmd.ConvertPRDsOptions{}
+
+	// Parse arguments: chief convert [name] [--all] [--merge] [--force] [--offline]
+	for i := 2; i < len(os.Args); i++ {
+		arg := os.Args[i]
+		switch arg {
+		case "--all":
+			opts.All = true
+		case "--merge":
+			opts.Merge = true
+		case "--force":
+			opts.Force = true
+		case "--offline":
+			opts.Offline = true
+		default:
+			if strings.HasPrefix(arg, "-") {
+				fmt.Fprintf(os.Stderr, "Error: unknown flag: %s\n", arg)
+				os.Exit(1)
+			}
+			if opts.Name == "" {
+				opts.Name = arg
+			}
+		}
+	}
+
+	if err := cmd.RunConvertPRDs(opts); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func runExport() {
+	opts := cmd.ExportOptions{}
+
+	// Parse arguments: chief export [--stdout] [--timings [--format csv|json]] [name]
+	for i := 2; i < len(os.Args); i++ {
+		arg := os.Args[i]
+		switch {
+		case arg == "--timings":
+			opts.Timings = true
+		case arg == "--stdout":
+			opts.Stdout = true
+		case arg == "--format":
+			if i+1 >= len(os.Args) {
+				fmt.Fprintln(os.Stderr, "Error: --format requires a value (csv or json)")
+				os.Exit(1)
+			}
+			i++
+			opts.Format = os.Args[i]
+		case strings.HasPrefix(arg, "--format="):
+			opts.Format = strings.TrimPrefix(arg, "--format=")
+		case strings.HasPrefix(arg, "-"):
+			fmt.Fprintf(os.Stderr, "Error: unknown flag: %s\n", arg)
+			os.Exit(1)
+		case opts.Name == "":
+			opts.Name = arg
+		}
+	}
+
+	if err := cmd.RunExport(opts); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func runLogs() {
+	opts := cmd.LogsOptions{}
+
+	// Parse arguments: chief logs [name] [--follow|-f] [--lines N]
+	for i := 2; i < len(os.Args); i++ {
+		arg := os.Args[i]
+		switch {
+		case arg == "--follow" || arg == "-f":
+			opts.Follow = true
+		case arg == "--lines":
+			if i+1 >= len(os.Args) {
+				fmt.Fprintln(os.Stderr, "Error: --lines requires a value")
+				os.Exit(1)
+			}
+			i++
+			opts.Lines = parseCount(arg, os.Args[i])
+		case strings.HasPrefix(arg, "--lines="):
+			opts.Lines = parseCount("--lines", strings.TrimPrefix(arg, "--lines="))
+		case strings.HasPrefix(arg, "-"):
+			fmt.Fprintf(os.Stderr, "Error: unknown flag: %s\n", arg)
+			os.Exit(1)
+		case opts.Name == "":
+			opts.Name = arg
+		}
+	}
+
+	if err := cmd.RunLogs(opts); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func runRebuildProgress() {
+	opts := cmd.RebuildProgressOptions{}
+
+	// Parse arguments: chief rebuild-progress [name]
+	for i := 2; i < len(os.Args); i++ {
+		arg := os.Args[i]
+		switch {
+		case strings.HasPrefix(arg, "-"):
+			fmt.Fprintf(os.Stderr, "Error: unknown flag: %s\n", arg)
+			os.Exit(1)
+		case opts.Name == "":
+			opts.Name = arg
+		}
+	}
+
+	if err := cmd.RunRebuildProgress(opts); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func runTestSetup() {
+	opts := cmd.TestSetupOptions{}
+
+	// Parse arguments: chief test-setup [name]
+	for i := 2; i < len(os.Args); i++ {
+		arg := os.Args[i]
+		switch {
+		case strings.HasPrefix(arg, "-"):
+			fmt.Fprintf(os.Stderr, "Error: unknown flag: %s\n", arg)
+			os.Exit(1)
+		case opts.Name == "":
+			opts.Name = arg
+		}
+	}
+
+	if err := cmd.RunTestSetup(opts); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func runRename() {
+	opts := cmd.RenameOptions{}
+
+	// Parse arguments: chief rename <old> <new> [--rename-branch] [--keep-branch]
+	var names []string
+	for i := 2; i < len(os.Args); i++ {
+		arg := os.Args[i]
+		switch arg {
+		case "--rename-branch":
+			opts.RenameBranch = true
+		case "--keep-branch":
+			opts.KeepBranch = true
+		default:
+			if strings.HasPrefix(arg, "-") {
+				fmt.Fprintf(os.Stderr, "Error: unknown flag: %s\n", arg)
+				os.Exit(1)
+			}
+			names = append(names, arg)
+		}
+	}
+	if len(names) != 2 {
+		fmt.Fprintln(os.Stderr, "Usage: chief rename <old> <new> [--rename-branch|--keep-branch]")
+		os.Exit(1)
+	}
+	opts.OldName = names[0]
+	opts.NewName = names[1]
+
+	if err := cmd.RunRename(opts); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func runClone() {
+	opts := cmd.CloneOptions{}
+
+	// Parse arguments: chief clone <src> <dst> [context...]
+	if len(os.Args) < 4 {
+		fmt.Fprintln(os.Stderr, "Usage: chief clone <src> <dst> [context]")
+		os.Exit(1)
+	}
+	opts.Source = os.Args[2]
+	opts.Name = os.Args[3]
+	if len(os.Args) > 4 {
+		opts.Context = strings.Join(os.Args[4:], " ")
+	}
+
+	if err := cmd.RunClone(opts); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func runArchive() {
+	opts := cmd.ArchiveOptions{}
+
+	// Parse arguments: chief archive <name> [--clean]
+	var names []string
+	for i := 2; i < len(os.Args); i++ {
+		arg := os.Args[i]
+		switch arg {
+		case "--clean":
+			opts.Clean = true
+		default:
+			if strings.HasPrefix(arg, "-") {
+				fmt.Fprintf(os.Stderr, "Error: unknown flag: %s\n", arg)
+				os.Exit(1)
+			}
+			names = append(names, arg)
+		}
+	}
+	if len(names) != 1 {
+		fmt.Fprintln(os.Stderr, "Usage: chief archive <name> [--clean]")
+		os.Exit(1)
+	}
+	opts.Name = names[0]
+
+	if err := cmd.RunArchive(opts); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func runHeadless() {
+	opts := cmd.RunOptions{}
+
+	// Parse arguments: chief run [name] [-n N] [--no-retry] [--timeout D] [--iteration-timeout D] [--merge] [--force] [--strict]
+	for i := 2; i < len(os.Args); i++ {
+		arg := os.Args[i]
+		switch {
+		case arg == "--no-retry":
+			opts.NoRetry = true
+		case arg == "--merge":
+			opts.Merge = true
+		case arg == "--force":
+			opts.Force = true
+		case arg == "--strict":
+			opts.Strict = true
+		case arg == "--max-iterations" || arg == "-n" || arg == "--timeout" || arg == "--iteration-timeout":
+			if i+1 >= len(os.Args) {
+				fmt.Fprintf(os.Stderr, "Error: %s requires a value\n", arg)
+				os.Exit(1)
+			}
+			i++
+			switch arg {
+			case "--timeout":
+				opts.Timeout = parseTimeout(arg, os.Args[i])
+			case "--iteration-timeout":
+				opts.IterTimeout = parseTimeout(arg, os.Args[i])
+			default:
+				opts.MaxIterations = parseCount(arg, os.Args[i])
+			}
+		case strings.HasPrefix(arg, "--max-iterations="):
+			opts.MaxIterations = parseCount("--max-iterations", strings.TrimPrefix(arg, "--max-iterations="))
+		case strings.HasPrefix(arg, "-n="):
+			opts.MaxIterations = parseCount("-n", strings.TrimPrefix(arg, "-n="))
+		case strings.HasPrefix(arg, "--timeout="):
+			opts.Timeout = parseTimeout("--timeout", strings.TrimPrefix(arg, "--timeout="))
+		case strings.HasPrefix(arg, "--iteration-timeout="):
+			opts.IterTimeout = parseTimeout("--iteration-timeout", strings.TrimPrefix(arg, "--iteration-timeout="))
+		case strings.HasPrefix(arg, "-"):
+			fmt.Fprintf(os.Stderr, "Error: unknown flag: %s\n", arg)
+			os.Exit(1)
+		default:
+			if opts.Name == "" {
+				opts.Name = arg
+			}
+		}
+	}
+
+	if err := cmd.RunHeadless(opts); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// completionSounds returns the sounds to play when a PRD completes or fails,
+// or nil unless notifications.sound is on. Sound settings that can't be used
+// are reported and replaced with the defaults.
+func completionSounds() *notify.Sounds {
+	cfg, err := config.Load(cwd())
+	if err != nil || !cfg.Notifications.Sound {
+		return nil
+	}
+	n := cfg.Notifications
+	sounds, problems := notify.NewSounds(n.SoundFile, n.ErrorSoundFile, n.Volume)
+	for _, problem := range problems {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", problem)
+	}
+	return sounds
+}
+
+// parseCount parses a positive count, such as an iteration limit, or exits with an error.
+func parseCount(flag, val string) int {
+	n, err := strconv.Atoi(val)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid value for %s: %s\n", flag, val)
+		os.Exit(1)
+	}
+	if n < 1 {
+		fmt.Fprintf(os.Stderr, "Error: %s must be at least 1\n", flag)
+		os.Exit(1)
+	}
+	return n
+}
+
+// parseTimeout parses a duration like "30m" or "2h" or exits with an error.
+func parseTimeout(flag, val string) time.Duration {
+	d, err := time.ParseDuration(val)
+	if err != nil || d <= 0 {
+		fmt.Fprintf(os.Stderr, "Error: invalid value for %s: %s (use e.g. 30m or 2h)\n", flag, val)
+		os.Exit(1)
+	}
+	return d
+}
+
+func runCompletion() {
+	opts := cmd.CompletionOptions{}
+
+	// Parse arguments: chief completion <bash|zsh|fish>
+	if len(os.Args) > 2 {
+		opts.Shell = os.Args[2]
+	}
+
+	if err := cmd.RunCompletion(opts); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
 func runUpdate() {
 	if err := cmd.RunUpdate(cmd.UpdateOptions{
 		Version: Version,
@@ -286,6 +712,19 @@ func runUpdate() {
 func runList() {
 	opts := cmd.ListOptions{}
 
+	// Parse arguments: chief list [--json] [--archived]
+	for i := 2; i < len(os.Args); i++ {
+		arg := os.Args[i]
+		if arg == "--json" {
+			opts.JSON = true
+		} else if arg == "--archived" {
+			opts.Archived = true
+		} else {
+			fmt.Fprintf(os.Stderr, "Error: unknown argument: %s\n", arg)
+			os.Exit(1)
+		}
+	}
+
 	if err := cmd.RunList(opts); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
@@ -293,13 +732,65 @@ func runList() {
 }
 
 func runTUIWithOptions(opts *TUIOptions) {
+	// Fetch a remote PRD into a local PRD directory, then proceed as if it were local
+	if opts.RemoteURL != "" {
+		fmt.Printf("Fetching PRD from %s...\n", opts.RemoteURL)
+		fetchedPath, err := cmd.FetchRemotePRD(cmd.FetchRemoteOptions{
+			URL:  opts.RemoteURL,
+			Name: opts.Name,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		opts.PRDPath = fetchedPath
+		opts.RemoteURL = ""
+	}
+
+	// Keep a second instance from writing to the same PRDs and worktrees
+	projectLock, readOnly, err := cmd.AcquireLock(cwd())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	defer projectLock.Release()
+
+	// A viewer never runs the agent, so only an instance that can start loops needs it
+	if !readOnly {
+		cfg, err := config.Load(cwd())
+		if err != nil {
+			cfg = config.Default()
+		}
+		agent, err := loop.NewAgent(cfg)
+		if err == nil {
+			err = agent.CheckBinary()
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// Older versions kept a single PRD outside prds/; offer to move it so it's listed like any other
+	if !readOnly {
+		cmd.OfferLegacyMigration(cwd())
+	}
+
 	prdPath := opts.PRDPath
 
+	// With no PRD named, offer to reopen the one the last session was on
+	var resume *cmd.ResumeChoice
+	if prdPath == "" && !readOnly {
+		if resume = cmd.OfferResume(cwd()); resume != nil {
+			prdPath = resume.PRDPath
+		}
+	}
+
 	// If no PRD specified, try to find one
 	if prdPath == "" {
 		// Try "main" first
 		mainPath := paths.PRDPath(cwd(), "main")
-		if _, err := os.Stat(mainPath); err == nil {
+		if _, err := os.Stat(prd.ResolvePath(mainPath)); err == nil {
 			prdPath = mainPath
 		} else {
 			// Look for any available PRD
@@ -307,6 +798,10 @@ func runTUIWithOptions(opts *TUIOptions) {
 		}
 
 		// If still no PRD found, run first-time setup
+		if prdPath == "" && readOnly {
+			fmt.Fprintln(os.Stderr, "Error: no PRD to view yet")
+			os.Exit(1)
+		}
 		if prdPath == "" {
 			dir := cwd()
 
@@ -321,10 +816,16 @@ func runTUIWithOptions(opts *TUIOptions) {
 				return
 			}
 
-			// Save config from setup
-			cfg := config.Default()
+			// Save config from setup, keeping anything already configured (e.g. git.provider)
+			cfg, err := config.Load(dir)
+			if err != nil {
+				cfg = config.Default()
+			}
 			cfg.OnComplete.Push = result.PushOnComplete
 			cfg.OnComplete.CreatePR = result.CreatePROnComplete
+			if result.CreatePROnComplete {
+				cfg.OnComplete.Reviewers = result.Reviewers
+			}
 			if err := config.Save(dir, cfg); err != nil {
 				fmt.Fprintf(os.Stderr, "Warning: failed to save config: %v\n", err)
 			}
@@ -351,14 +852,17 @@ func runTUIWithOptions(opts *TUIOptions) {
 	needsConvert, err := prd.NeedsConversion(prdDir)
 	if err != nil {
 		fmt.Printf("Warning: failed to check conversion status: %v\n", err)
+	} else if needsConvert && readOnly {
+		fmt.Println("prd.md is newer than prd.json; showing prd.json as the other instance hasn't converted it yet.")
 	} else if needsConvert {
 		fmt.Println("prd.md is newer than prd.json, running conversion...")
-		convertOpts := prd.ConvertOptions{
-			PRDDir: prdDir,
-			Merge:  opts.Merge,
-			Force:  opts.Force,
+		convertOpts := cmd.ConvertOptions{
+			PRDDir:  prdDir,
+			Merge:   opts.Merge,
+			Force:   opts.Force,
+			BaseDir: cwd(),
 		}
-		if err := prd.Convert(convertOpts); err != nil {
+		if err := cmd.RunConvertWithOptions(convertOpts); err != nil {
 			fmt.Printf("Error converting PRD: %v\n", err)
 			os.Exit(1)
 		}
@@ -398,14 +902,66 @@ func runTUIWithOptions(opts *TUIOptions) {
 	if opts.NoRetry {
 		app.DisableRetry()
 	}
+	app.SetIterationTimeout(opts.IterTimeout)
+	app.SetReviewPrompt(opts.ReviewPrompt)
+	if resume != nil {
+		app.ResumeSession(resume.Story, resume.Start)
+	}
+	app.SetReadOnly(readOnly)
+	if !readOnly {
+		app.EnableEventSocket()
+	}
+	if opts.Accessible {
+		app.SetAccessible(os.Stderr)
+	}
+
+	// Desktop notifications and sounds; silently skipped when the platform has
+	// no notifier or player
+	sounds := completionSounds()
+	if !opts.NoDesktop || sounds != nil {
+		app.SetCompletionCallback(func(prdName string) {
+			if !opts.NoDesktop {
+				_ = notify.SendDesktop("Chief: PRD complete", fmt.Sprintf("All stories in %s are complete", prdName))
+			}
+			if sounds != nil {
+				_ = sounds.PlayCompletion()
+			}
+		})
+		app.SetErrorCallback(func(prdName string, err error) {
+			if !opts.NoDesktop {
+				body := fmt.Sprintf("The loop for %s failed", prdName)
+				if err != nil {
+					body = fmt.Sprintf("%s: %v", body, err)
+				}
+				_ = notify.SendDesktop("Chief: loop failed", body)
+			}
+			if sounds != nil {
+				_ = sounds.PlayError()
+			}
+		})
+	}
 
-	p := tea.NewProgram(app, tea.WithAltScreen())
+	p := tea.NewProgram(app, tea.WithAltScreen(), tea.WithMouseCellMotion())
 	model, err := p.Run()
+	if errors.Is(err, tea.ErrProgramPanic) {
+		// Bubble Tea has restored the terminal; stop agents and clean up before exiting
+		crashLog := app.CleanupAfterCrash()
+		fmt.Fprintln(os.Stderr, "Chief crashed unexpectedly. All loops were stopped.")
+		if crashLog != "" {
+			fmt.Fprintf(os.Stderr, "Crash report written to %s\n", crashLog)
+		}
+		os.Exit(1)
+	}
 	if err != nil {
 		fmt.Printf("Error running program: %v\n", err)
 		os.Exit(1)
 	}
 
+	// Detached loops keep the event socket open until they finish
+	if finalApp, ok := model.(tui.App); !ok || finalApp.PostExitAction != tui.PostExitDetach {
+		app.CloseEventSocket()
+	}
+
 	// Check for post-exit actions
 	if finalApp, ok := model.(tui.App); ok {
 		dir := cwd()
@@ -437,6 +993,14 @@ func runTUIWithOptions(opts *TUIOptions) {
 			// Restart TUI with the edited PRD
 			opts.PRDPath = paths.PRDPath(dir, finalApp.PostExitPRD)
 			runTUIWithOptions(opts)
+
+		case tui.PostExitDetach:
+			// Keep the loops going without the UI until they finish or are interrupted
+			interrupt := make(chan os.Signal, 1)
+			signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+			finalApp.RunDetached(os.Stdout, interrupt)
+			signal.Stop(interrupt)
+			finalApp.CloseEventSocket()
 		}
 	}
 }
@@ -445,29 +1009,83 @@ func printHelp() {
 	fmt.Println(`Chief - Autonomous PRD Agent
 
 Usage:
-  chief [options] [<name>|<path/to/prd.json>]
+  chief [options] [<name>|<path/to/prd.json>|<url>]
   chief <command> [arguments]
 
 Commands:
   new [name] [context]      Create a new PRD interactively
   edit [name] [options]     Edit an existing PRD interactively
-  status [name]             Show progress for a PRD (default: main)
-  list                      List all PRDs with progress
+  status [name] [--json]    Show progress for a PRD (default: main; --strict fails on PRD warnings)
+  list [--json]             List all PRDs with progress (--archived for archived ones)
+  convert [name] [options]  Convert prd.md to prd.json if the markdown changed
+  rename <old> <new>        Rename a PRD (and its worktree and branch)
+  clone <src> <dst> [note]  Copy a PRD as the starting point for a new one
+  archive <name>            Move a finished PRD out of the list into the archive
+  export [name] [options]   Write a Markdown progress report, or export timings with --timings
+  logs [name] [options]     Print a PRD's claude.log (default: main)
+  rebuild-progress [name]   Regenerate progress.md from claude.log, backing up the old one
+  run [name] [options]      Run the loop without the TUI, logging to stdout
+  test-setup [name]         Try the worktree setup command in a throwaway worktree
+  completion <shell>        Print a bash, zsh or fish completion script
   update                    Update Chief to the latest version
   help                      Show this help message
 
 Global Options:
   --max-iterations N, -n N  Set maximum iterations (default: dynamic)
   --no-retry                Disable auto-retry on Claude crashes
+  --no-desktop              Disable desktop notifications on completion or failure
+  --review-prompt           Review the first prompt before each loop starts
+  --accessible              Narrate story and run transitions as plain text on stderr
+  --iteration-timeout D     Kill and retry an iteration after D without output, e.g. 10m
   --verbose                 Show raw Claude output in log
   --merge                   Auto-merge progress on conversion conflicts
   --force                   Auto-overwrite on conversion conflicts
+  --name NAME               PRD name to run, or where to cache a remote PRD
   --help, -h                Show this help message
   --version, -v             Show version number
 
 Edit Options:
+  --manual                  Open prd.md in $EDITOR instead of Claude
+  --merge                   Auto-merge progress on conversion conflicts
+  --force                   Auto-overwrite on conversion conflicts
+
+Rename Options:
+  --rename-branch           Rename chief/<old> to chief/<new> without asking
+  --keep-branch             Keep the existing branch name
+
+Archive Options:
+  --clean                   Remove the PRD's worktree without asking
+
+Run Options:
+  --max-iterations N, -n N  Set maximum iterations (default: dynamic)
+  --no-retry                Disable auto-retry on Claude crashes
+  --timeout D               Stop after a duration, e.g. 30m or 2h
+  --iteration-timeout D     Kill and retry an iteration after D without output
+  --strict                  Refuse to run a PRD with untitled stories or stories without acceptance criteria
+
+Status Options:
+  --all                     Summarise every PRD: progress, stories in progress and failing stories
+  --watch, -w               Redraw as the PRD changes, until Ctrl+C (with --json, one report per line)
+  --interval N              Also redraw every N seconds while watching (default: 2)
+
+Convert Options:
+  --all                     Convert every PRD whose prd.md changed
   --merge                   Auto-merge progress on conversion conflicts
   --force                   Auto-overwrite on conversion conflicts
+  --offline                 Parse prd.md without Claude (also used when claude isn't installed)
+
+Export Options:
+  --stdout                  Print the Markdown report instead of writing report.md
+  --timings                 Export per-story iterations, retries and durations
+  --format csv|json         Output format (default: csv)
+
+Logs Options:
+  --follow, -f              Keep printing new output until interrupted
+  --lines N                 Only show the last N lines
+
+Environment:
+  CHIEF_CONVERSION_CONFLICT merge|overwrite|cancel: how conversion treats existing
+                            progress without asking (default without a terminal: merge)
 
 Positional Arguments:
   <name>                    PRD name (loads from ~/.chief/projects/<project>/prds/<name>/prd.json)
@@ -480,6 +1098,8 @@ Examples:
   chief                     Launch TUI with default PRD
   chief auth                Launch TUI with named PRD
   chief ./my-prd.json       Launch TUI with specific PRD file
+  chief https://example.com/specs/auth.md --name auth
+                            Fetch a shared PRD and launch it as "auth"
   chief -n 20               Launch with 20 max iterations
   chief --max-iterations=5 auth
                             Launch auth PRD with 5 max iterations
@@ -491,9 +1111,32 @@ Examples:
   chief edit                Edit the "main" PRD
   chief edit auth           Edit the "auth" PRD
   chief edit auth --merge   Edit and auto-merge progress
+  chief edit auth --manual  Tweak the "auth" PRD in $EDITOR
   chief status              Show progress for default PRD
   chief status auth         Show progress for auth PRD
   chief list                List all PRDs with progress
+  chief status auth --json  Print auth progress as JSON for scripts
+  chief status auth --watch Keep auth's progress on screen as it changes
+  chief status --all        Check in on every PRD at once
+  chief rename main auth    Rename the "main" PRD to "auth"
+  chief clone auth sso "Use SAML instead of passwords"
+                            Start "sso" from auth's stories, with a note for Claude
+  chief archive auth        Archive the finished "auth" PRD
+  chief list --archived     List archived PRDs
+  chief export auth         Write auth's progress report to its report.md
+  chief export --timings --format csv auth > auth.csv
+                            Export auth's story timings for a spreadsheet
+  chief run auth --timeout 2h
+                            Run auth headless in CI; exits non-zero unless complete
+  chief logs auth -f --lines 50
+                            Watch auth's agent output as it runs
+  chief rebuild-progress auth
+                            Recover auth's deleted progress.md
+  chief test-setup auth     Check auth's worktree setup command works
+  chief convert --all --merge
+                            Convert all changed PRDs, keeping progress
+  chief completion zsh > ~/.zfunc/_chief
+                            Install zsh completion for subcommands and PRD names
   chief --version           Show version number`)
 }
 
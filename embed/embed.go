@@ -22,6 +22,12 @@ var convertPromptTemplate string
 //go:embed detect_setup_prompt.txt
 var detectSetupPromptTemplate string
 
+//go:embed start_story_prompt.txt
+var startStoryPromptTemplate string
+
+//go:embed next_story_prompt.txt
+var nextStoryPromptTemplate string
+
 // GetPrompt returns the agent prompt with the PRD path and ticket prefix substituted.
 // If ticketPrefix is empty, the placeholder is replaced with "[Story ID]" so the
 // agent falls back to using the story ID in the commit message.
@@ -33,6 +39,18 @@ func GetPrompt(prdPath, ticketPrefix string) string {
 	return strings.ReplaceAll(result, "{{TICKET_PREFIX}}", ticketPrefix)
 }
 
+// GetStartStoryPrompt returns the prompt section that tells the agent to work
+// on storyID first, ahead of the usual priority order.
+func GetStartStoryPrompt(storyID string) string {
+	return strings.ReplaceAll(startStoryPromptTemplate, "{{STORY_ID}}", storyID)
+}
+
+// GetNextStoryPrompt returns the prompt section that tells the agent which
+// story chief picked for the iteration.
+func GetNextStoryPrompt(storyID string) string {
+	return strings.ReplaceAll(nextStoryPromptTemplate, "{{STORY_ID}}", storyID)
+}
+
 // GetInitPrompt returns the PRD generator prompt with the PRD directory and optional context substituted.
 func GetInitPrompt(prdDir, context string) string {
 	if context == "" {
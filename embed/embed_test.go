@@ -130,3 +130,25 @@ func TestGetEditPrompt(t *testing.T) {
 		t.Error("Expected prompt to contain the PRD directory path")
 	}
 }
+
+func TestGetNextStoryPrompt(t *testing.T) {
+	prompt := GetNextStoryPrompt("US-003")
+
+	if strings.Contains(prompt, "{{STORY_ID}}") {
+		t.Error("Expected {{STORY_ID}} to be substituted")
+	}
+	if !strings.Contains(prompt, "`US-003`") {
+		t.Error("Expected prompt to contain story ID US-003")
+	}
+}
+
+func TestGetStartStoryPrompt(t *testing.T) {
+	prompt := GetStartStoryPrompt("US-005")
+
+	if strings.Contains(prompt, "{{STORY_ID}}") {
+		t.Error("Expected {{STORY_ID}} to be substituted")
+	}
+	if !strings.Contains(prompt, "`US-005`") {
+		t.Error("Expected prompt to contain story ID US-005")
+	}
+}
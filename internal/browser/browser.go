@@ -0,0 +1,55 @@
+// Package browser opens URLs in the user's web browser using whichever
+// opener the platform provides.
+package browser
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+)
+
+// ErrUnavailable is returned when no browser opener is installed.
+var ErrUnavailable = errors.New("no browser opener found (install xdg-open)")
+
+// lookPath is swapped out in tests to simulate installed tools.
+var lookPath = exec.LookPath
+
+// Open opens url in the default browser. It returns ErrUnavailable if the
+// platform's opener isn't installed.
+func Open(url string) error {
+	name, args, ok := openCommand(runtime.GOOS)
+	if !ok {
+		return ErrUnavailable
+	}
+
+	cmd := exec.Command(name, append(args, url)...)
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to open browser: %w", err)
+	}
+	// Openers hand off to the browser and exit; don't leave a zombie behind
+	go cmd.Wait()
+	return nil
+}
+
+// openCommand returns the command that opens a URL (appended as the last
+// argument) on goos, or ok=false if no supported opener is installed.
+func openCommand(goos string) (name string, args []string, ok bool) {
+	candidates := [][]string{
+		{"xdg-open"},
+		{"wslview"},
+	}
+	switch goos {
+	case "darwin":
+		candidates = [][]string{{"open"}}
+	case "windows":
+		candidates = [][]string{{"rundll32", "url.dll,FileProtocolHandler"}}
+	}
+
+	for _, c := range candidates {
+		if path, err := lookPath(c[0]); err == nil {
+			return path, c[1:], true
+		}
+	}
+	return "", nil, false
+}
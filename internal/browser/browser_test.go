@@ -0,0 +1,60 @@
+package browser
+
+import (
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// stubLookPath makes only the named tools appear installed.
+func stubLookPath(t *testing.T, installed ...string) {
+	t.Helper()
+	orig := lookPath
+	t.Cleanup(func() { lookPath = orig })
+	lookPath = func(file string) (string, error) {
+		for _, name := range installed {
+			if name == file {
+				return "/usr/bin/" + file, nil
+			}
+		}
+		return "", exec.ErrNotFound
+	}
+}
+
+func TestOpenCommand(t *testing.T) {
+	tests := []struct {
+		goos      string
+		installed []string
+		wantName  string
+		wantArgs  string
+	}{
+		{"darwin", []string{"open"}, "/usr/bin/open", ""},
+		{"windows", []string{"rundll32"}, "/usr/bin/rundll32", "url.dll,FileProtocolHandler"},
+		{"linux", []string{"xdg-open", "wslview"}, "/usr/bin/xdg-open", ""},
+		{"linux", []string{"wslview"}, "/usr/bin/wslview", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.goos+"/"+tt.wantName, func(t *testing.T) {
+			stubLookPath(t, tt.installed...)
+			name, args, ok := openCommand(tt.goos)
+			if !ok {
+				t.Fatal("expected an open command")
+			}
+			if name != tt.wantName {
+				t.Errorf("expected %s, got %s", tt.wantName, name)
+			}
+			if got := strings.Join(args, " "); got != tt.wantArgs {
+				t.Errorf("expected args %q, got %q", tt.wantArgs, got)
+			}
+		})
+	}
+}
+
+func TestOpenUnavailable(t *testing.T) {
+	stubLookPath(t)
+	if err := Open("https://example.com"); !errors.Is(err, ErrUnavailable) {
+		t.Errorf("expected ErrUnavailable when no opener is installed, got %v", err)
+	}
+}
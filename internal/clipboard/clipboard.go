@@ -0,0 +1,56 @@
+// Package clipboard copies text to the system clipboard using whichever
+// clipboard tool the platform provides.
+package clipboard
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// ErrUnavailable is returned when no clipboard tool is installed.
+var ErrUnavailable = errors.New("no clipboard tool found (install pbcopy, wl-copy, xclip or xsel)")
+
+// lookPath is swapped out in tests to simulate installed tools.
+var lookPath = exec.LookPath
+
+// Copy puts text on the system clipboard. It returns ErrUnavailable if the
+// platform's clipboard tool isn't installed.
+func Copy(text string) error {
+	name, args, ok := copyCommand(runtime.GOOS)
+	if !ok {
+		return ErrUnavailable
+	}
+
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = strings.NewReader(text)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to copy to clipboard: %w", err)
+	}
+	return nil
+}
+
+// copyCommand returns the command that reads stdin into the clipboard on
+// goos, or ok=false if no supported tool is installed.
+func copyCommand(goos string) (name string, args []string, ok bool) {
+	candidates := [][]string{
+		{"wl-copy"},
+		{"xclip", "-selection", "clipboard"},
+		{"xsel", "--clipboard", "--input"},
+	}
+	switch goos {
+	case "darwin":
+		candidates = [][]string{{"pbcopy"}}
+	case "windows":
+		candidates = [][]string{{"clip"}}
+	}
+
+	for _, c := range candidates {
+		if path, err := lookPath(c[0]); err == nil {
+			return path, c[1:], true
+		}
+	}
+	return "", nil, false
+}
@@ -0,0 +1,61 @@
+package clipboard
+
+import (
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// stubLookPath makes only the named tools appear installed.
+func stubLookPath(t *testing.T, installed ...string) {
+	t.Helper()
+	orig := lookPath
+	t.Cleanup(func() { lookPath = orig })
+	lookPath = func(file string) (string, error) {
+		for _, name := range installed {
+			if name == file {
+				return "/usr/bin/" + file, nil
+			}
+		}
+		return "", exec.ErrNotFound
+	}
+}
+
+func TestCopyCommand(t *testing.T) {
+	tests := []struct {
+		goos      string
+		installed []string
+		wantName  string
+		wantArgs  string
+	}{
+		{"darwin", []string{"pbcopy"}, "/usr/bin/pbcopy", ""},
+		{"windows", []string{"clip"}, "/usr/bin/clip", ""},
+		{"linux", []string{"wl-copy", "xclip"}, "/usr/bin/wl-copy", ""},
+		{"linux", []string{"xclip", "xsel"}, "/usr/bin/xclip", "-selection clipboard"},
+		{"linux", []string{"xsel"}, "/usr/bin/xsel", "--clipboard --input"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.goos+"/"+tt.wantName, func(t *testing.T) {
+			stubLookPath(t, tt.installed...)
+			name, args, ok := copyCommand(tt.goos)
+			if !ok {
+				t.Fatal("expected a clipboard command")
+			}
+			if name != tt.wantName {
+				t.Errorf("expected %s, got %s", tt.wantName, name)
+			}
+			if got := strings.Join(args, " "); got != tt.wantArgs {
+				t.Errorf("expected args %q, got %q", tt.wantArgs, got)
+			}
+		})
+	}
+}
+
+func TestCopyUnavailable(t *testing.T) {
+	stubLookPath(t)
+	if err := Copy("hello"); !errors.Is(err, ErrUnavailable) {
+		t.Errorf("expected ErrUnavailable when no tool is installed, got %v", err)
+	}
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/minicodemonkey/chief/internal/config"
+	"github.com/minicodemonkey/chief/internal/git"
+	"github.com/minicodemonkey/chief/internal/loop"
+	"github.com/minicodemonkey/chief/internal/paths"
+	"github.com/minicodemonkey/chief/internal/prd"
+)
+
+// ArchiveOptions contains configuration for the archive command.
+type ArchiveOptions struct {
+	Name    string // PRD name to archive
+	BaseDir string // Base directory for .chief/prds/ (default: current directory)
+	Clean   bool   // Remove the PRD's worktree without prompting
+}
+
+// RunArchive moves a PRD into the project's archive, out of `chief list`
+// and the picker. A PRD with a worktree gets the choice to remove it first;
+// otherwise the worktree is left in place.
+func RunArchive(opts ArchiveOptions) error {
+	if opts.BaseDir == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("failed to get current directory: %w", err)
+		}
+		opts.BaseDir = cwd
+	}
+
+	if opts.Name == "" {
+		return fmt.Errorf("usage: chief archive <name>")
+	}
+	if !isValidPRDName(opts.Name) {
+		return fmt.Errorf("invalid PRD name %q: must contain only letters, numbers, hyphens, and underscores", opts.Name)
+	}
+	if _, err := os.Stat(paths.PRDDir(opts.BaseDir, opts.Name)); os.IsNotExist(err) {
+		return fmt.Errorf("PRD %q not found", opts.Name)
+	}
+
+	// Before touching the worktree, which the loop would be working in
+	if err := loop.CheckNotRunning(paths.PRDPath(opts.BaseDir, opts.Name), opts.Name, "archiving"); err != nil {
+		return err
+	}
+
+	cfg, err := config.Load(opts.BaseDir)
+	if err != nil {
+		cfg = config.Default()
+	}
+	worktree := git.WorktreePathForPRD(opts.BaseDir, cfg.Worktree.BaseDir, opts.Name)
+	if _, err := os.Stat(worktree); err == nil {
+		if opts.Clean || confirm(fmt.Sprintf("%s still has a worktree at %s. Remove it first?", opts.Name, worktree)) {
+			if err := git.RemoveWorktree(opts.BaseDir, worktree); err != nil {
+				return err
+			}
+			fmt.Printf("Removed worktree %s\n", worktree)
+		} else {
+			fmt.Printf("Warning: leaving the worktree at %s; remove it with `git worktree remove` when you're done\n", worktree)
+		}
+	}
+
+	dst, err := prd.Archive(opts.BaseDir, opts.Name)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Archived PRD %s to %s\n", opts.Name, dst)
+	return nil
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/minicodemonkey/chief/internal/paths"
+)
+
+func TestRunArchive(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+	baseDir := t.TempDir()
+
+	createRenameTestPRD(t, baseDir, "done", `{"project":"Done","userStories":[{"id":"US-001","passes":true}]}`)
+	createRenameTestPRD(t, baseDir, "active", `{"project":"Active","userStories":[{"id":"US-001"}]}`)
+
+	if err := RunArchive(ArchiveOptions{Name: "done", BaseDir: baseDir}); err != nil {
+		t.Fatalf("RunArchive() returned error: %v", err)
+	}
+
+	// Only the active PRD is listed; --archived shows the other
+	for _, tt := range []struct {
+		archived bool
+		want     string
+	}{
+		{false, "active"},
+		{true, "done"},
+	} {
+		out := captureStdout(t, func() {
+			if err := RunList(ListOptions{BaseDir: baseDir, JSON: true, Archived: tt.archived}); err != nil {
+				t.Errorf("RunList() returned error: %v", err)
+			}
+		})
+		var infos []PRDInfo
+		if err := json.Unmarshal([]byte(out), &infos); err != nil {
+			t.Fatalf("expected valid JSON, got %q: %v", out, err)
+		}
+		if len(infos) != 1 || infos[0].Name != tt.want {
+			t.Errorf("RunList(archived=%v) = %+v, want only %s", tt.archived, infos, tt.want)
+		}
+	}
+
+	if err := RunArchive(ArchiveOptions{Name: "missing", BaseDir: baseDir}); err == nil {
+		t.Error("expected an error for a missing PRD")
+	}
+	if err := RunArchive(ArchiveOptions{Name: "bad name", BaseDir: baseDir}); err == nil {
+		t.Error("expected an error for an invalid name")
+	}
+	if _, err := os.Stat(paths.PRDDir(baseDir, "active")); err != nil {
+		t.Errorf("expected the active PRD to stay put: %v", err)
+	}
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/minicodemonkey/chief/internal/paths"
+	"github.com/minicodemonkey/chief/internal/prd"
+)
+
+// CloneOptions contains configuration for the clone command.
+type CloneOptions struct {
+	Source  string // Name of the PRD to copy
+	Name    string // Name of the new PRD
+	Context string // Optional note prepended to the cloned prd.md
+	BaseDir string // Base directory for .chief/prds/ (default: current directory)
+}
+
+// RunClone copies a PRD's prd.md, prd.json and config.yaml into a new PRD
+// with every story reset to not started. Progress, logs and timings stay with
+// the source, so the clone starts clean.
+func RunClone(opts CloneOptions) (err error) {
+	if opts.BaseDir == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("failed to get current directory: %w", err)
+		}
+		opts.BaseDir = cwd
+	}
+
+	if opts.Source == "" || opts.Name == "" {
+		return fmt.Errorf("usage: chief clone <src> <dst> [context]")
+	}
+	if !isValidPRDName(opts.Source) {
+		return fmt.Errorf("invalid PRD name %q: must contain only letters, numbers, hyphens, and underscores", opts.Source)
+	}
+	if !isValidPRDName(opts.Name) {
+		return fmt.Errorf("invalid PRD name %q: must contain only letters, numbers, hyphens, and underscores", opts.Name)
+	}
+
+	srcDir := paths.PRDDir(opts.BaseDir, opts.Source)
+	dstDir := paths.PRDDir(opts.BaseDir, opts.Name)
+	if _, err := os.Stat(srcDir); os.IsNotExist(err) {
+		return fmt.Errorf("PRD %q not found", opts.Source)
+	}
+	if _, err := os.Stat(dstDir); err == nil {
+		return fmt.Errorf("PRD %q already exists", opts.Name)
+	}
+
+	md, mdErr := os.ReadFile(filepath.Join(srcDir, "prd.md"))
+	if mdErr != nil && !os.IsNotExist(mdErr) {
+		return fmt.Errorf("failed to read prd.md: %w", mdErr)
+	}
+	srcPath := prd.ResolvePath(paths.PRDPath(opts.BaseDir, opts.Source))
+	var p *prd.PRD
+	if _, err := os.Stat(srcPath); err == nil {
+		if p, err = prd.LoadPRD(srcPath); err != nil {
+			return err
+		}
+	}
+	if mdErr != nil && p == nil {
+		return fmt.Errorf("PRD %q has no prd.md or prd.json to clone", opts.Source)
+	}
+
+	if err := os.MkdirAll(dstDir, 0755); err != nil {
+		return fmt.Errorf("failed to create PRD directory: %w", err)
+	}
+	// Don't leave a half-written clone behind
+	defer func() {
+		if err != nil {
+			os.RemoveAll(dstDir)
+		}
+	}()
+
+	// prd.md is written before prd.json so the clone doesn't look like it
+	// needs converting
+	if mdErr == nil {
+		if note := strings.TrimSpace(opts.Context); note != "" {
+			md = append([]byte(fmt.Sprintf("> **Note:** %s\n\n", note)), md...)
+		}
+		if err := os.WriteFile(filepath.Join(dstDir, "prd.md"), md, 0644); err != nil {
+			return fmt.Errorf("failed to write prd.md: %w", err)
+		}
+	}
+	if p != nil {
+		for i := range p.UserStories {
+			p.UserStories[i].Passes = false
+			p.UserStories[i].InProgress = false
+			p.UserStories[i].Skipped = false
+		}
+		if err := p.Save(filepath.Join(dstDir, filepath.Base(srcPath))); err != nil {
+			return err
+		}
+	}
+
+	// Per-PRD settings are part of the starting point; progress is not
+	if cfg, err := os.ReadFile(paths.PRDConfigPath(opts.BaseDir, opts.Source)); err == nil {
+		if err := os.WriteFile(paths.PRDConfigPath(opts.BaseDir, opts.Name), cfg, 0644); err != nil {
+			return fmt.Errorf("failed to write config.yaml: %w", err)
+		}
+	}
+
+	fmt.Printf("Cloned PRD %s → %s\n", opts.Source, opts.Name)
+	fmt.Printf("Edit it with: chief edit %s\n", opts.Name)
+	return nil
+}
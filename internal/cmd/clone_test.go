@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/minicodemonkey/chief/internal/paths"
+	"github.com/minicodemonkey/chief/internal/prd"
+)
+
+func TestRunCloneResetsProgress(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+	baseDir := t.TempDir()
+
+	createRenameTestPRD(t, baseDir, "auth", `{"project":"Auth","userStories":[
+		{"id":"US-001","title":"Login","passes":true},
+		{"id":"US-002","title":"Logout","inProgress":true},
+		{"id":"US-003","title":"SSO","skipped":true}]}`)
+	srcDir := paths.PRDDir(baseDir, "auth")
+	for name, content := range map[string]string{
+		"prd.md":      "# Auth\n",
+		"progress.md": "## US-001\nDone\n",
+		"config.yaml": "maxIterations: 7\n",
+	} {
+		if err := os.WriteFile(filepath.Join(srcDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := RunClone(CloneOptions{Source: "auth", Name: "sso", Context: "Use SAML", BaseDir: baseDir}); err != nil {
+		t.Fatalf("RunClone() error = %v", err)
+	}
+
+	p, err := prd.LoadPRD(paths.PRDPath(baseDir, "sso"))
+	if err != nil {
+		t.Fatalf("LoadPRD() error = %v", err)
+	}
+	if len(p.UserStories) != 3 {
+		t.Fatalf("expected 3 cloned stories, got %d", len(p.UserStories))
+	}
+	for _, story := range p.UserStories {
+		if story.Passes || story.InProgress || story.Skipped {
+			t.Errorf("story %s not reset: %+v", story.ID, story)
+		}
+	}
+
+	dstDir := paths.PRDDir(baseDir, "sso")
+	md, err := os.ReadFile(filepath.Join(dstDir, "prd.md"))
+	if err != nil || !strings.HasPrefix(string(md), "> **Note:** Use SAML\n\n# Auth\n") {
+		t.Errorf("cloned prd.md = %q, %v; want the note before the original", md, err)
+	}
+	if _, err := os.Stat(filepath.Join(dstDir, "progress.md")); !os.IsNotExist(err) {
+		t.Error("expected progress.md not to be cloned")
+	}
+	if _, err := os.Stat(filepath.Join(dstDir, "config.yaml")); err != nil {
+		t.Errorf("expected config.yaml to be cloned: %v", err)
+	}
+	if needs, err := prd.NeedsConversion(dstDir); err != nil || needs {
+		t.Errorf("NeedsConversion() = %v, %v; want a clone that doesn't need converting", needs, err)
+	}
+
+	// The source is untouched
+	src, err := prd.LoadPRD(paths.PRDPath(baseDir, "auth"))
+	if err != nil || !src.UserStories[0].Passes {
+		t.Errorf("source PRD changed: %+v, %v", src, err)
+	}
+}
+
+func TestRunCloneValidation(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+	baseDir := t.TempDir()
+
+	createRenameTestPRD(t, baseDir, "auth", `{"project":"x","userStories":[]}`)
+	createRenameTestPRD(t, baseDir, "taken", `{"project":"y","userStories":[]}`)
+
+	tests := []struct {
+		desc   string
+		source string
+		name   string
+	}{
+		{"invalid name", "auth", "bad name"},
+		{"name exists", "auth", "taken"},
+		{"source missing", "missing", "fresh"},
+		{"invalid source", "../prds/auth", "fresh"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if err := RunClone(CloneOptions{Source: tt.source, Name: tt.name, BaseDir: baseDir}); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+	if _, err := os.Stat(paths.PRDDir(baseDir, "fresh")); !os.IsNotExist(err) {
+		t.Error("expected no PRD directory to be created on failure")
+	}
+}
@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// CompletionOptions contains configuration for the completion command.
+type CompletionOptions struct {
+	Shell string // "bash", "zsh" or "fish"
+}
+
+// completionCommand is a subcommand offered by the completion scripts.
+type completionCommand struct {
+	name        string
+	description string
+	takesPRD    bool // Its argument is a PRD name
+}
+
+// completionCommands lists the subcommands the scripts complete, in the order
+// `chief help` shows them. Descriptions are single-quoted in the zsh and fish
+// scripts, so they must not contain apostrophes.
+var completionCommands = []completionCommand{
+	{"new", "Create a new PRD interactively", false},
+	{"edit", "Edit an existing PRD interactively", true},
+	{"status", "Show progress for a PRD", true},
+	{"list", "List all PRDs with progress", false},
+	{"convert", "Convert prd.md to prd.json", true},
+	{"rename", "Rename a PRD", true},
+	{"clone", "Copy a PRD as the starting point for a new one", true},
+	{"archive", "Archive a finished PRD", true},
+	{"export", "Write a progress report or export timings", true},
+	{"logs", "Print the agent log for a PRD", true},
+	{"rebuild-progress", "Regenerate progress.md from claude.log", true},
+	{"run", "Run the loop without the TUI", true},
+	{"test-setup", "Try the worktree setup command", true},
+	{"completion", "Print a shell completion script", false},
+	{"update", "Update Chief to the latest version", false},
+	{"help", "Show help", false},
+}
+
+// completionShells are the shells `chief completion` writes scripts for.
+var completionShells = []string{"bash", "zsh", "fish"}
+
+// RunCompletion prints the completion script for a shell. The scripts call
+// the hidden `chief __complete` command for PRD names, so new PRDs complete
+// without regenerating them.
+func RunCompletion(opts CompletionOptions) error {
+	return writeCompletion(os.Stdout, opts.Shell)
+}
+
+// writeCompletion writes the completion script for shell to w.
+func writeCompletion(w io.Writer, shell string) error {
+	var script string
+	switch shell {
+	case "bash":
+		script = bashCompletion()
+	case "zsh":
+		script = zshCompletion()
+	case "fish":
+		script = fishCompletion()
+	case "":
+		return fmt.Errorf("missing shell (use %s)", strings.Join(completionShells, ", "))
+	default:
+		return fmt.Errorf("unsupported shell %q (use %s)", shell, strings.Join(completionShells, ", "))
+	}
+	_, err := io.WriteString(w, script)
+	return err
+}
+
+// completionNames returns the names of the subcommands, filtered by takesPRD
+// when prdOnly is set.
+func completionNames(prdOnly bool) []string {
+	var names []string
+	for _, c := range completionCommands {
+		if !prdOnly || c.takesPRD {
+			names = append(names, c.name)
+		}
+	}
+	return names
+}
+
+// bashCompletion returns the bash script, which completes by word list.
+func bashCompletion() string {
+	return fmt.Sprintf(`# bash completion for chief
+# Load it with: source <(chief completion bash)
+
+_chief() {
+    local cur="${COMP_WORDS[COMP_CWORD]}"
+    if [ "$COMP_CWORD" -eq 1 ]; then
+        COMPREPLY=($(compgen -W "%s $(chief __complete 2>/dev/null)" -- "$cur"))
+        return
+    fi
+    case "${COMP_WORDS[1]}" in
+        completion)
+            COMPREPLY=($(compgen -W "%s" -- "$cur"))
+            ;;
+        %s)
+            COMPREPLY=($(compgen -W "$(chief __complete 2>/dev/null)" -- "$cur"))
+            ;;
+    esac
+}
+
+complete -F _chief chief
+`, strings.Join(completionNames(false), " "), strings.Join(completionShells, " "), strings.Join(completionNames(true), "|"))
+}
+
+// zshCompletion returns the zsh script, which shows command descriptions.
+// It works both sourced and installed as _chief on $fpath.
+func zshCompletion() string {
+	var commands strings.Builder
+	for _, c := range completionCommands {
+		fmt.Fprintf(&commands, "    '%s:%s'\n", c.name, c.description)
+	}
+	return fmt.Sprintf(`#compdef chief
+# zsh completion for chief
+# Load it with: source <(chief completion zsh)
+
+_chief() {
+  local -a commands prds
+  commands=(
+%s  )
+  prds=(${(f)"$(chief __complete 2>/dev/null)"})
+
+  if (( CURRENT == 2 )); then
+    _describe -t commands 'command' commands
+    _describe -t prds 'PRD' prds
+    return
+  fi
+
+  case $words[2] in
+    completion)
+      compadd %s
+      ;;
+    %s)
+      _describe -t prds 'PRD' prds
+      ;;
+  esac
+}
+
+if [ "$funcstack[1]" = "_chief" ]; then
+  _chief "$@"
+else
+  compdef _chief chief
+fi
+`, commands.String(), strings.Join(completionShells, " "), strings.Join(completionNames(true), "|"))
+}
+
+// fishCompletion returns the fish script.
+func fishCompletion() string {
+	var b strings.Builder
+	b.WriteString("# fish completion for chief\n")
+	b.WriteString("# Load it with: chief completion fish | source\n\n")
+	for _, c := range completionCommands {
+		fmt.Fprintf(&b, "complete -c chief -n __fish_use_subcommand -a %s -d '%s'\n", c.name, c.description)
+	}
+	b.WriteString("complete -c chief -n __fish_use_subcommand -a '(chief __complete 2>/dev/null)' -d PRD\n")
+	fmt.Fprintf(&b, "complete -c chief -n '__fish_seen_subcommand_from completion' -a '%s'\n", strings.Join(completionShells, " "))
+	fmt.Fprintf(&b, "complete -c chief -n '__fish_seen_subcommand_from %s' -a '(chief __complete 2>/dev/null)' -d PRD\n", strings.Join(completionNames(true), " "))
+	return b.String()
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteCompletion(t *testing.T) {
+	for _, shell := range completionShells {
+		t.Run(shell, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := writeCompletion(&buf, shell); err != nil {
+				t.Fatalf("writeCompletion(%q) error = %v", shell, err)
+			}
+			script := buf.String()
+			for _, want := range []string{"chief __complete", "rebuild-progress", "test-setup"} {
+				if !strings.Contains(script, want) {
+					t.Errorf("%s script missing %q:\n%s", shell, want, script)
+				}
+			}
+		})
+	}
+
+	if err := writeCompletion(&bytes.Buffer{}, "ksh"); err == nil || !strings.Contains(err.Error(), "bash, zsh, fish") {
+		t.Errorf("writeCompletion(ksh) error = %v, want one listing the supported shells", err)
+	}
+}
+
+func TestCompletionDescriptionsAreQuotable(t *testing.T) {
+	for _, c := range completionCommands {
+		if strings.ContainsRune(c.description, '\'') {
+			t.Errorf("description of %q contains an apostrophe, which breaks the zsh and fish scripts", c.name)
+		}
+	}
+}
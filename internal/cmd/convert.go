@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/minicodemonkey/chief/internal/paths"
+	"github.com/minicodemonkey/chief/internal/prd"
+)
+
+// convertPRD runs the conversion for a single PRD directory. Tests replace it
+// to avoid invoking Claude.
+var convertPRD = RunConvertWithOptions
+
+// ConvertPRDsOptions contains configuration for the convert command.
+type ConvertPRDsOptions struct {
+	Name    string // PRD name (default: "main"), ignored when All is set
+	All     bool   // Scan every PRD directory instead of a single PRD
+	BaseDir string // Base directory for .chief/prds/ (default: current directory)
+	Merge   bool   // Auto-merge without prompting on conversion conflicts
+	Force   bool   // Auto-overwrite without prompting on conversion conflicts
+	Offline bool   // Parse prd.md without Claude
+}
+
+// ConvertResult describes the outcome of converting a single PRD.
+type ConvertResult struct {
+	Name      string
+	Converted bool  // True if prd.md was converted to prd.json
+	Err       error // Non-nil if the check or conversion failed
+}
+
+// RunConvertPRDs converts prd.md to prd.json for PRDs whose markdown is newer
+// than their JSON. With All set, every PRD directory is scanned and a summary
+// is printed. Returns an error if any conversion failed.
+func RunConvertPRDs(opts ConvertPRDsOptions) error {
+	// Set defaults
+	if opts.Name == "" {
+		opts.Name = "main"
+	}
+	if opts.BaseDir == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("failed to get current directory: %w", err)
+		}
+		opts.BaseDir = cwd
+	}
+
+	var names []string
+	if opts.All {
+		entries, err := os.ReadDir(paths.PRDsDir(opts.BaseDir))
+		if err != nil {
+			if os.IsNotExist(err) {
+				fmt.Println("No PRDs found. Run 'chief new' to create one.")
+				return nil
+			}
+			return fmt.Errorf("failed to read PRDs directory: %w", err)
+		}
+		for _, entry := range entries {
+			if entry.IsDir() {
+				names = append(names, entry.Name())
+			}
+		}
+		sort.Strings(names)
+	} else {
+		if !isValidPRDName(opts.Name) {
+			return fmt.Errorf("invalid PRD name %q: must contain only letters, numbers, hyphens, and underscores", opts.Name)
+		}
+		if _, err := os.Stat(paths.PRDDir(opts.BaseDir, opts.Name)); os.IsNotExist(err) {
+			return fmt.Errorf("PRD %q not found. Use 'chief new %s' to create it first", opts.Name, opts.Name)
+		}
+		names = []string{opts.Name}
+	}
+
+	results := make([]ConvertResult, 0, len(names))
+	for _, name := range names {
+		results = append(results, convertIfNeeded(opts, name))
+	}
+
+	return reportConvertResults(results)
+}
+
+// convertIfNeeded converts a single PRD if its prd.md is newer than prd.json.
+func convertIfNeeded(opts ConvertPRDsOptions, name string) ConvertResult {
+	result := ConvertResult{Name: name}
+	prdDir := paths.PRDDir(opts.BaseDir, name)
+
+	needsConvert, err := prd.NeedsConversion(prdDir)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	if !needsConvert {
+		return result
+	}
+
+	fmt.Printf("Converting %s...\n", name)
+	if err := convertPRD(ConvertOptions{
+		PRDDir:  prdDir,
+		Merge:   opts.Merge,
+		Force:   opts.Force,
+		BaseDir: opts.BaseDir,
+		Offline: opts.Offline,
+	}); err != nil {
+		result.Err = err
+		return result
+	}
+	result.Converted = true
+	return result
+}
+
+// reportConvertResults prints a summary line per PRD and returns an error if any failed.
+func reportConvertResults(results []ConvertResult) error {
+	if len(results) == 0 {
+		fmt.Println("No PRDs found. Run 'chief new' to create one.")
+		return nil
+	}
+
+	converted, failed := 0, 0
+	fmt.Println()
+	for _, r := range results {
+		switch {
+		case r.Err != nil:
+			failed++
+			fmt.Printf("  ✗ %s: %v\n", r.Name, r.Err)
+		case r.Converted:
+			converted++
+			fmt.Printf("  ✓ %s: converted\n", r.Name)
+		default:
+			fmt.Printf("  - %s: up to date\n", r.Name)
+		}
+	}
+	fmt.Printf("\n%d converted, %d up to date, %d failed\n", converted, len(results)-converted-failed, failed)
+
+	if failed > 0 {
+		return fmt.Errorf("%d PRD(s) failed to convert", failed)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/minicodemonkey/chief/internal/paths"
+)
+
+// writePRDFiles creates prd.md and prd.json for name, with prd.md newer when mdNewer is set.
+func writePRDFiles(t *testing.T, baseDir, name string, mdNewer bool) {
+	t.Helper()
+	prdDir := paths.PRDDir(baseDir, name)
+	if err := os.MkdirAll(prdDir, 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	mdPath := filepath.Join(prdDir, "prd.md")
+	jsonPath := filepath.Join(prdDir, "prd.json")
+	if err := os.WriteFile(mdPath, []byte("# PRD"), 0644); err != nil {
+		t.Fatalf("Failed to write prd.md: %v", err)
+	}
+	if err := os.WriteFile(jsonPath, []byte(`{"project":"x","userStories":[]}`), 0644); err != nil {
+		t.Fatalf("Failed to write prd.json: %v", err)
+	}
+
+	old := time.Now().Add(-time.Hour)
+	if mdNewer {
+		os.Chtimes(jsonPath, old, old)
+	} else {
+		os.Chtimes(mdPath, old, old)
+	}
+}
+
+func stubConvertPRD(t *testing.T, fn func(ConvertOptions) error) {
+	t.Helper()
+	orig := convertPRD
+	convertPRD = fn
+	t.Cleanup(func() { convertPRD = orig })
+}
+
+func TestRunConvertPRDsAllConvertsOnlyChanged(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+	baseDir := t.TempDir()
+
+	writePRDFiles(t, baseDir, "auth", true)
+	writePRDFiles(t, baseDir, "billing", false)
+	writePRDFiles(t, baseDir, "search", true)
+
+	var converted []string
+	stubConvertPRD(t, func(opts ConvertOptions) error {
+		if !opts.Merge {
+			t.Error("expected Merge to be passed through")
+		}
+		converted = append(converted, filepath.Base(opts.PRDDir))
+		return nil
+	})
+
+	err := RunConvertPRDs(ConvertPRDsOptions{All: true, BaseDir: baseDir, Merge: true})
+	if err != nil {
+		t.Fatalf("RunConvertPRDs() returned error: %v", err)
+	}
+	if len(converted) != 2 || converted[0] != "auth" || converted[1] != "search" {
+		t.Errorf("converted = %v, want [auth search]", converted)
+	}
+}
+
+func TestRunConvertPRDsReportsFailures(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+	baseDir := t.TempDir()
+
+	writePRDFiles(t, baseDir, "auth", true)
+	writePRDFiles(t, baseDir, "search", true)
+
+	var attempts int
+	stubConvertPRD(t, func(opts ConvertOptions) error {
+		attempts++
+		if filepath.Base(opts.PRDDir) == "auth" {
+			return errors.New("boom")
+		}
+		return nil
+	})
+
+	err := RunConvertPRDs(ConvertPRDsOptions{All: true, BaseDir: baseDir})
+	if err == nil {
+		t.Fatal("expected error when a conversion fails")
+	}
+	if attempts != 2 {
+		t.Errorf("attempts = %d, want 2 (a failure should not stop the batch)", attempts)
+	}
+}
+
+func TestRunConvertPRDsSingleUpToDate(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+	baseDir := t.TempDir()
+
+	writePRDFiles(t, baseDir, "main", false)
+	stubConvertPRD(t, func(opts ConvertOptions) error {
+		t.Error("did not expect conversion for an up-to-date PRD")
+		return nil
+	})
+
+	if err := RunConvertPRDs(ConvertPRDsOptions{BaseDir: baseDir}); err != nil {
+		t.Errorf("RunConvertPRDs() returned error: %v", err)
+	}
+}
+
+func TestRunConvertPRDsMissingPRD(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+
+	if err := RunConvertPRDs(ConvertPRDsOptions{Name: "nope", BaseDir: t.TempDir()}); err == nil {
+		t.Error("expected error for missing PRD")
+	}
+}
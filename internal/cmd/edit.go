@@ -3,7 +3,10 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"runtime"
+	"strings"
 
 	"github.com/minicodemonkey/chief/embed"
 	"github.com/minicodemonkey/chief/internal/paths"
@@ -15,9 +18,11 @@ type EditOptions struct {
 	BaseDir string // Base directory for .chief/prds/ (default: current directory)
 	Merge   bool   // Auto-merge without prompting on conversion conflicts
 	Force   bool   // Auto-overwrite without prompting on conversion conflicts
+	Manual  bool   // Open prd.md in $EDITOR instead of a Claude session
 }
 
-// RunEdit edits an existing PRD by launching an interactive Claude session.
+// RunEdit edits an existing PRD by launching an interactive Claude session,
+// or $EDITOR when Manual is set, then regenerates prd.json.
 func RunEdit(opts EditOptions) error {
 	// Set defaults
 	if opts.Name == "" {
@@ -45,25 +50,31 @@ func RunEdit(opts EditOptions) error {
 		return fmt.Errorf("PRD not found at %s. Use 'chief new %s' to create it first", prdMdPath, opts.Name)
 	}
 
-	// Get the edit prompt with the PRD directory path
-	prompt := embed.GetEditPrompt(prdDir)
-
-	// Launch interactive Claude session
-	fmt.Printf("Editing PRD at %s...\n", prdDir)
-	fmt.Println("Launching Claude to help you edit your PRD...")
-	fmt.Println()
+	if opts.Manual {
+		fmt.Printf("Opening %s in your editor...\n", prdMdPath)
+		// A failed or aborted edit leaves prd.json as it was
+		if err := runEditor(prdMdPath); err != nil {
+			return fmt.Errorf("editor failed, prd.json left unchanged: %w", err)
+		}
+	} else {
+		// Get the edit prompt with the PRD directory path
+		prompt := embed.GetEditPrompt(prdDir)
 
-	if err := runInteractiveClaude(opts.BaseDir, prompt); err != nil {
-		return fmt.Errorf("Claude session failed: %w", err)
+		// Launch an interactive agent session
+		fmt.Printf("Editing PRD at %s...\n", prdDir)
+		if err := runInteractiveAgent(opts.BaseDir, prompt, "edit"); err != nil {
+			return err
+		}
 	}
 
 	fmt.Println("\nPRD editing complete!")
 
 	// Run conversion from prd.md to prd.json with progress protection
 	convertOpts := ConvertOptions{
-		PRDDir: prdDir,
-		Merge:  opts.Merge,
-		Force:  opts.Force,
+		PRDDir:  prdDir,
+		Merge:   opts.Merge,
+		Force:   opts.Force,
+		BaseDir: opts.BaseDir,
 	}
 	if err := RunConvertWithOptions(convertOpts); err != nil {
 		return fmt.Errorf("conversion failed: %w", err)
@@ -72,3 +83,25 @@ func RunEdit(opts EditOptions) error {
 	fmt.Printf("\nYour PRD is updated! Run 'chief' or 'chief %s' to continue working on it.\n", opts.Name)
 	return nil
 }
+
+// runEditor opens path in the user's editor and waits for it to exit.
+func runEditor(path string) error {
+	editor := editorCommand()
+	cmd := exec.Command(editor[0], append(editor[1:], path)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// editorCommand returns the command from $EDITOR, split on spaces so values
+// like "code --wait" work, falling back to vi (notepad on Windows).
+func editorCommand() []string {
+	if fields := strings.Fields(os.Getenv("EDITOR")); len(fields) > 0 {
+		return fields
+	}
+	if runtime.GOOS == "windows" {
+		return []string{"notepad"}
+	}
+	return []string{"vi"}
+}
@@ -4,6 +4,8 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/minicodemonkey/chief/internal/paths"
 )
 
 func TestRunEditRequiresPRDExists(t *testing.T) {
@@ -120,6 +122,37 @@ func TestEditOptionsDefaults(t *testing.T) {
 	}
 }
 
+func TestRunEditManualAbortsWhenEditorFails(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+
+	baseDir := t.TempDir()
+	writePRDFiles(t, baseDir, "main", false)
+	jsonPath := filepath.Join(paths.PRDDir(baseDir, "main"), "prd.json")
+	before, _ := os.ReadFile(jsonPath)
+	t.Setenv("EDITOR", "false")
+
+	err := RunEdit(EditOptions{BaseDir: baseDir, Manual: true})
+	if err == nil || !contains(err.Error(), "editor failed") {
+		t.Fatalf("Expected editor failure, got %v", err)
+	}
+	if after, _ := os.ReadFile(jsonPath); string(after) != string(before) {
+		t.Error("Expected prd.json to be left alone after the editor failed")
+	}
+}
+
+func TestEditorCommand(t *testing.T) {
+	t.Setenv("EDITOR", "code --wait")
+	if got := editorCommand(); len(got) != 2 || got[0] != "code" || got[1] != "--wait" {
+		t.Errorf("Expected $EDITOR split into fields, got %v", got)
+	}
+
+	t.Setenv("EDITOR", "")
+	if got := editorCommand(); len(got) != 1 || (got[0] != "vi" && got[0] != "notepad") {
+		t.Errorf("Expected fallback editor, got %v", got)
+	}
+}
+
 // Helper function to check if a string contains a substring
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(s) > 0 && containsHelper(s, substr))
@@ -0,0 +1,211 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/minicodemonkey/chief/internal/loop"
+	"github.com/minicodemonkey/chief/internal/paths"
+	"github.com/minicodemonkey/chief/internal/prd"
+)
+
+// Export formats accepted by --format.
+const (
+	ExportCSV  = "csv"
+	ExportJSON = "json"
+)
+
+// ExportOptions contains configuration for the export command.
+type ExportOptions struct {
+	Name    string // PRD name (default: "main")
+	BaseDir string // Base directory for .chief/prds/ (default: current directory)
+	Timings bool   // Export per-story timing and iteration data
+	Format  string // ExportCSV or ExportJSON (default: ExportCSV)
+	Stdout  bool   // Print the Markdown report instead of writing report.md
+}
+
+// TimingsReport is the JSON schema for `chief export --timings --format json`.
+type TimingsReport struct {
+	Name           string        `json:"name"`           // PRD name
+	Project        string        `json:"project"`        // Project name from the PRD
+	State          string        `json:"state"`          // Loop state when it last ran, empty if it never has
+	Iteration      int           `json:"iteration"`      // Iteration the last run reached
+	ElapsedSeconds float64       `json:"elapsedSeconds"` // Wall-clock length of the last run
+	Stories        []StoryTiming `json:"stories"`        // Stories in PRD order, then any timed stories no longer in the PRD
+}
+
+// StoryTiming is the per-story entry in a TimingsReport and one CSV row.
+type StoryTiming struct {
+	ID             string     `json:"id"`
+	Title          string     `json:"title"`
+	Passes         bool       `json:"passes"`
+	Iterations     int        `json:"iterations"`     // Iterations spent on the story
+	Retries        int        `json:"retries"`        // Iterations beyond the first it took
+	TotalSeconds   float64    `json:"totalSeconds"`   // Time across all its iterations
+	AverageSeconds float64    `json:"averageSeconds"` // TotalSeconds / Iterations
+	FirstAt        *time.Time `json:"firstAt"`        // When its first iteration ended
+	LastAt         *time.Time `json:"lastAt"`         // When its last iteration ended
+}
+
+// timingsCSVHeader names the columns written by writeTimingsCSV.
+var timingsCSVHeader = []string{
+	"id", "title", "passes", "iterations", "retries",
+	"total_seconds", "average_seconds", "first_at", "last_at",
+}
+
+// newTimingsReport aggregates recorded iterations per story.
+func newTimingsReport(name string, p *prd.PRD, timings []prd.StoryTime, snap *loop.Snapshot) TimingsReport {
+	report := TimingsReport{
+		Name:    name,
+		Project: p.Project,
+		Stories: make([]StoryTiming, 0, len(p.UserStories)),
+	}
+	if snap != nil {
+		report.State = snap.State
+		report.Iteration = snap.Iteration
+		report.ElapsedSeconds = snap.Elapsed().Seconds()
+	}
+
+	index := make(map[string]int)
+	for _, story := range p.UserStories {
+		index[story.ID] = len(report.Stories)
+		report.Stories = append(report.Stories, StoryTiming{ID: story.ID, Title: story.Title, Passes: story.Passes})
+	}
+
+	for _, t := range timings {
+		i, ok := index[t.StoryID]
+		if !ok {
+			index[t.StoryID] = len(report.Stories)
+			i = len(report.Stories)
+			report.Stories = append(report.Stories, StoryTiming{ID: t.StoryID})
+		}
+		s := &report.Stories[i]
+		s.Iterations++
+		s.TotalSeconds += t.Duration.Seconds()
+		at := t.At
+		if s.FirstAt == nil {
+			s.FirstAt = &at
+		}
+		s.LastAt = &at
+	}
+
+	for i := range report.Stories {
+		if s := &report.Stories[i]; s.Iterations > 0 {
+			s.Retries = s.Iterations - 1
+			s.AverageSeconds = s.TotalSeconds / float64(s.Iterations)
+		}
+	}
+	return report
+}
+
+// writeTimingsCSV writes one row per story under timingsCSVHeader.
+func writeTimingsCSV(w io.Writer, report TimingsReport) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(timingsCSVHeader); err != nil {
+		return err
+	}
+	for _, s := range report.Stories {
+		if err := cw.Write([]string{
+			s.ID,
+			s.Title,
+			strconv.FormatBool(s.Passes),
+			strconv.Itoa(s.Iterations),
+			strconv.Itoa(s.Retries),
+			strconv.FormatFloat(s.TotalSeconds, 'f', 1, 64),
+			strconv.FormatFloat(s.AverageSeconds, 'f', 1, 64),
+			formatExportTime(s.FirstAt),
+			formatExportTime(s.LastAt),
+		}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// formatExportTime formats t as RFC 3339, or "" when there is no time.
+func formatExportTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// RunExport writes a PRD's recorded data for use elsewhere: per-story timings
+// to stdout with Timings, otherwise a Markdown report of the PRD and its
+// progress notes to report.md in the PRD directory.
+func RunExport(opts ExportOptions) error {
+	// Set defaults
+	if opts.Name == "" {
+		opts.Name = "main"
+	}
+	if opts.BaseDir == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("failed to get current directory: %w", err)
+		}
+		opts.BaseDir = cwd
+	}
+
+	if !opts.Timings {
+		if opts.Format != "" {
+			return fmt.Errorf("--format only applies to --timings")
+		}
+		return exportReport(opts)
+	}
+	if opts.Format == "" {
+		opts.Format = ExportCSV
+	}
+	if opts.Format != ExportCSV && opts.Format != ExportJSON {
+		return fmt.Errorf("unknown format %q: must be %s or %s", opts.Format, ExportCSV, ExportJSON)
+	}
+
+	prdPath := paths.PRDPath(opts.BaseDir, opts.Name)
+	p, err := prd.LoadPRD(prdPath)
+	if err != nil {
+		return fmt.Errorf("failed to load PRD %q: %w", opts.Name, err)
+	}
+	timings, err := prd.LoadTimings(prdPath)
+	if err != nil {
+		return fmt.Errorf("failed to load timings for %q: %w", opts.Name, err)
+	}
+	// A missing or unreadable state file only loses the run summary
+	snap, _ := loop.LoadSnapshot(prdPath)
+
+	report := newTimingsReport(opts.Name, p, timings, snap)
+	if opts.Format == ExportJSON {
+		return printJSON(report)
+	}
+	return writeTimingsCSV(os.Stdout, report)
+}
+
+// exportReport writes the PRD's Markdown report to report.md, or to stdout
+// with Stdout.
+func exportReport(opts ExportOptions) error {
+	prdPath := paths.PRDPath(opts.BaseDir, opts.Name)
+	p, err := prd.LoadPRD(prdPath)
+	if err != nil {
+		return fmt.Errorf("failed to load PRD %q: %w", opts.Name, err)
+	}
+	progress, err := prd.ParseProgress(prd.ProgressPath(prdPath))
+	if err != nil {
+		return fmt.Errorf("failed to read progress for %q: %w", opts.Name, err)
+	}
+
+	report := prd.ExportMarkdown(p, progress)
+	if opts.Stdout {
+		fmt.Print(report)
+		return nil
+	}
+	reportPath := filepath.Join(paths.PRDDir(opts.BaseDir, opts.Name), "report.md")
+	if err := os.WriteFile(reportPath, []byte(report), 0644); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	fmt.Printf("Wrote %s\n", reportPath)
+	return nil
+}
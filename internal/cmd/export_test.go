@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minicodemonkey/chief/internal/loop"
+	"github.com/minicodemonkey/chief/internal/paths"
+	"github.com/minicodemonkey/chief/internal/prd"
+)
+
+func TestNewTimingsReport(t *testing.T) {
+	p := &prd.PRD{
+		Project: "Test",
+		UserStories: []prd.UserStory{
+			{ID: "US-001", Title: "First", Passes: true},
+			{ID: "US-002", Title: "Second"},
+			{ID: "US-003", Title: "Untouched"},
+		},
+	}
+	start := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	timings := []prd.StoryTime{
+		{StoryID: "US-001", Duration: 60 * time.Second, At: start.Add(time.Minute)},
+		{StoryID: "US-001", Duration: 120 * time.Second, Passed: true, At: start.Add(3 * time.Minute)},
+		{StoryID: "US-002", Duration: 30 * time.Second, At: start.Add(4 * time.Minute)},
+		{StoryID: "US-OLD", Duration: 10 * time.Second, At: start},
+	}
+	snap := &loop.Snapshot{State: "Paused", Iteration: 4, StartTime: start, UpdatedAt: start.Add(5 * time.Minute)}
+
+	report := newTimingsReport("main", p, timings, snap)
+
+	if report.State != "Paused" || report.Iteration != 4 || report.ElapsedSeconds != 300 {
+		t.Errorf("run summary = %q/%d/%v, want Paused/4/300", report.State, report.Iteration, report.ElapsedSeconds)
+	}
+	if len(report.Stories) != 4 {
+		t.Fatalf("expected 4 stories (3 in the PRD plus US-OLD), got %d", len(report.Stories))
+	}
+
+	first := report.Stories[0]
+	if first.Iterations != 2 || first.Retries != 1 || first.TotalSeconds != 180 || first.AverageSeconds != 90 {
+		t.Errorf("US-001 = %+v, want 2 iterations, 1 retry, 180s total, 90s average", first)
+	}
+	if !first.FirstAt.Equal(start.Add(time.Minute)) || !first.LastAt.Equal(start.Add(3*time.Minute)) {
+		t.Errorf("US-001 first/last = %v/%v", first.FirstAt, first.LastAt)
+	}
+	if untouched := report.Stories[2]; untouched.Iterations != 0 || untouched.FirstAt != nil {
+		t.Errorf("US-003 should have no timings, got %+v", untouched)
+	}
+	if report.Stories[3].ID != "US-OLD" {
+		t.Errorf("timed stories no longer in the PRD should come last, got %q", report.Stories[3].ID)
+	}
+}
+
+func TestWriteTimingsCSV(t *testing.T) {
+	at := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	report := TimingsReport{Stories: []StoryTiming{
+		{ID: "US-001", Title: "Login, with \"quotes\"", Passes: true, Iterations: 2, Retries: 1, TotalSeconds: 180, AverageSeconds: 90, FirstAt: &at, LastAt: &at},
+		{ID: "US-002", Title: "Pending"},
+	}}
+
+	var buf bytes.Buffer
+	if err := writeTimingsCSV(&buf, report); err != nil {
+		t.Fatalf("writeTimingsCSV() returned error: %v", err)
+	}
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v", err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("expected header plus 2 rows, got %d", len(rows))
+	}
+	want := []string{"US-001", "Login, with \"quotes\"", "true", "2", "1", "180.0", "90.0", "2025-01-01T10:00:00Z", "2025-01-01T10:00:00Z"}
+	for i, cell := range want {
+		if rows[1][i] != cell {
+			t.Errorf("column %s = %q, want %q", rows[0][i], rows[1][i], cell)
+		}
+	}
+	if rows[2][7] != "" {
+		t.Errorf("a story without timings should have an empty first_at, got %q", rows[2][7])
+	}
+}
+
+func TestRunExportValidation(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+	baseDir := t.TempDir()
+	createRenameTestPRD(t, baseDir, "main", `{"project":"x","userStories":[]}`)
+
+	tests := []struct {
+		desc string
+		opts ExportOptions
+	}{
+		{"format without timings", ExportOptions{BaseDir: baseDir, Format: ExportJSON}},
+		{"unknown format", ExportOptions{BaseDir: baseDir, Timings: true, Format: "xml"}},
+		{"missing PRD", ExportOptions{BaseDir: baseDir, Timings: true, Name: "missing"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if err := RunExport(tt.opts); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+
+	if err := RunExport(ExportOptions{BaseDir: baseDir, Timings: true, Format: ExportJSON}); err != nil {
+		t.Errorf("exporting a PRD with no timings should succeed, got %v", err)
+	}
+}
+
+func TestRunExportReport(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+	baseDir := t.TempDir()
+	createRenameTestPRD(t, baseDir, "auth", `{"project":"Auth","userStories":[{"id":"US-001","title":"Login","passes":true}]}`)
+	progress := "## 2025-01-01 - US-001\n- Added the login form\n---\n"
+	if err := os.WriteFile(prd.ProgressPath(paths.PRDPath(baseDir, "auth")), []byte(progress), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := RunExport(ExportOptions{Name: "auth", BaseDir: baseDir}); err != nil {
+		t.Fatalf("RunExport() error = %v", err)
+	}
+	report, err := os.ReadFile(filepath.Join(paths.PRDDir(baseDir, "auth"), "report.md"))
+	if err != nil {
+		t.Fatalf("expected report.md: %v", err)
+	}
+	for _, want := range []string{"# Auth", "| US-001 | Login | ✅ Passed |", "- Added the login form"} {
+		if !strings.Contains(string(report), want) {
+			t.Errorf("report missing %q:\n%s", want, report)
+		}
+	}
+
+	out := captureStdout(t, func() {
+		err = RunExport(ExportOptions{Name: "auth", BaseDir: baseDir, Stdout: true})
+	})
+	if err != nil || out != string(report) {
+		t.Errorf("RunExport() with Stdout printed %q, %v; want the report", out, err)
+	}
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/minicodemonkey/chief/internal/paths"
+)
+
+// legacyPRDFiles are the files older versions of Chief kept for their single
+// PRD directly in the project's data directory.
+var legacyPRDFiles = []string{"prd.json", "prd.md", "progress.md", "claude.log", "timings.jsonl"}
+
+// legacyPRDPath returns where older versions of Chief kept the project's only
+// PRD, before PRDs moved into prds/<name>/.
+func legacyPRDPath(baseDir string) string {
+	return filepath.Join(paths.ChiefDir(baseDir), "prd.json")
+}
+
+// HasLegacyPRD reports whether the project still has a PRD at the legacy
+// location.
+func HasLegacyPRD(baseDir string) bool {
+	_, err := os.Stat(legacyPRDPath(baseDir))
+	return err == nil
+}
+
+// MigrateLegacyPRD moves a PRD from the legacy location into prds/main/,
+// along with its markdown, progress, log and timings.
+func MigrateLegacyPRD(baseDir string) error {
+	if !HasLegacyPRD(baseDir) {
+		return fmt.Errorf("no PRD at %s", legacyPRDPath(baseDir))
+	}
+	mainDir := paths.PRDDir(baseDir, "main")
+	if _, err := os.Stat(paths.PRDPath(baseDir, "main")); err == nil {
+		return fmt.Errorf("PRD \"main\" already exists at %s; move or rename it first", mainDir)
+	}
+
+	// Check every destination before moving anything, so a clash can't leave
+	// the PRD split between the two locations
+	chiefDir := paths.ChiefDir(baseDir)
+	var names []string
+	for _, name := range legacyPRDFiles {
+		if _, err := os.Stat(filepath.Join(chiefDir, name)); os.IsNotExist(err) {
+			continue
+		}
+		dst := filepath.Join(mainDir, name)
+		if _, err := os.Stat(dst); err == nil {
+			return fmt.Errorf("%s already exists; not overwriting it", dst)
+		}
+		names = append(names, name)
+	}
+
+	if err := os.MkdirAll(mainDir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", mainDir, err)
+	}
+	for _, name := range names {
+		if err := os.Rename(filepath.Join(chiefDir, name), filepath.Join(mainDir, name)); err != nil {
+			return fmt.Errorf("failed to move %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// OfferLegacyMigration asks whether to move a legacy PRD into prds/main/ and
+// does so if the user agrees. It does nothing when there is no legacy PRD.
+func OfferLegacyMigration(baseDir string) {
+	if !HasLegacyPRD(baseDir) {
+		return
+	}
+
+	fmt.Printf("Found a PRD at the old location %s.\n", legacyPRDPath(baseDir))
+	fmt.Printf("Chief now keeps PRDs under %s.\n", paths.PRDsDir(baseDir))
+	if !confirm("Move it (with prd.md and progress) to prds/main/?") {
+		fmt.Println("Left it in place; it won't be listed until it is moved.")
+		return
+	}
+	if err := MigrateLegacyPRD(baseDir); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to migrate legacy PRD: %v\n", err)
+		return
+	}
+	fmt.Printf("Moved it to %s\n", paths.PRDDir(baseDir, "main"))
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/minicodemonkey/chief/internal/paths"
+)
+
+func TestMigrateLegacyPRD(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+	baseDir := t.TempDir()
+
+	chiefDir := paths.ChiefDir(baseDir)
+	if err := os.MkdirAll(chiefDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range map[string]string{
+		"prd.json":    `{"project":"x","userStories":[]}`,
+		"prd.md":      "# X",
+		"progress.md": "## US-001",
+		"config.yaml": "onComplete:\n  push: true\n",
+	} {
+		if err := os.WriteFile(filepath.Join(chiefDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if !HasLegacyPRD(baseDir) {
+		t.Fatal("expected the legacy PRD to be detected")
+	}
+	if err := MigrateLegacyPRD(baseDir); err != nil {
+		t.Fatalf("MigrateLegacyPRD() error = %v", err)
+	}
+
+	for _, name := range []string{"prd.json", "prd.md", "progress.md"} {
+		if _, err := os.Stat(filepath.Join(paths.PRDDir(baseDir, "main"), name)); err != nil {
+			t.Errorf("expected %s under prds/main: %v", name, err)
+		}
+	}
+	if HasLegacyPRD(baseDir) {
+		t.Error("expected the legacy PRD to be gone")
+	}
+	// The project config shares the directory but isn't part of the PRD
+	if _, err := os.Stat(paths.ConfigPath(baseDir)); err != nil {
+		t.Errorf("expected the project config to stay put: %v", err)
+	}
+}
+
+func TestMigrateLegacyPRDKeepsExistingMain(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+	baseDir := t.TempDir()
+
+	createRenameTestPRD(t, baseDir, "main", `{"project":"new","userStories":[]}`)
+	if err := os.WriteFile(filepath.Join(paths.ChiefDir(baseDir), "prd.json"), []byte(`{"project":"old","userStories":[]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := MigrateLegacyPRD(baseDir); err == nil {
+		t.Error("expected an error when prds/main already exists")
+	}
+	if !HasLegacyPRD(baseDir) {
+		t.Error("expected the legacy PRD to be left in place")
+	}
+}
+
+func TestMigrateLegacyPRDMovesNothingOnClash(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+	baseDir := t.TempDir()
+
+	chiefDir := paths.ChiefDir(baseDir)
+	mainDir := paths.PRDDir(baseDir, "main")
+	if err := os.MkdirAll(mainDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"prd.json", "progress.md"} {
+		if err := os.WriteFile(filepath.Join(chiefDir, name), []byte("legacy"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// A stray progress file in prds/main, without a PRD
+	if err := os.WriteFile(filepath.Join(mainDir, "progress.md"), []byte("stray"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := MigrateLegacyPRD(baseDir); err == nil {
+		t.Fatal("expected an error for the existing progress.md")
+	}
+	if !HasLegacyPRD(baseDir) {
+		t.Error("expected prd.json to stay at the legacy location")
+	}
+	if _, err := os.Stat(paths.PRDPath(baseDir, "main")); !os.IsNotExist(err) {
+		t.Error("expected nothing moved into prds/main")
+	}
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/minicodemonkey/chief/internal/lock"
+	"github.com/minicodemonkey/chief/internal/paths"
+)
+
+// AcquireLock takes the project lock for a session that writes to the
+// project's PRDs and worktrees. When another live chief instance holds it,
+// the user is asked whether to open read-only instead; readOnly is then true
+// and the returned lock is nil. An error means the user chose to abort or the
+// lock couldn't be taken.
+func AcquireLock(baseDir string) (l *lock.Lock, readOnly bool, err error) {
+	l, err = lock.Acquire(paths.LockPath(baseDir))
+	var held *lock.HeldError
+	if !errors.As(err, &held) {
+		return l, false, err
+	}
+
+	if held.PID == 0 {
+		fmt.Println("Another chief instance is starting in this project.")
+	} else {
+		fmt.Printf("Another chief instance (PID %d) is already running in this project.\n", held.PID)
+	}
+	fmt.Println("Running both would have them overwrite each other's PRD and worktree changes.")
+	if !confirm("Open read-only instead?") {
+		return nil, false, fmt.Errorf("aborted: %w", err)
+	}
+	return nil, true, nil
+}
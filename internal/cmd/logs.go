@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/minicodemonkey/chief/internal/paths"
+)
+
+// LogsOptions contains configuration for the logs command.
+type LogsOptions struct {
+	Name    string // PRD name (default: "main")
+	BaseDir string // Base directory for .chief/prds/ (default: current directory)
+	Follow  bool   // Keep printing lines as they are appended, until interrupted
+	Lines   int    // Only print the last N lines (0 = the whole log)
+}
+
+// logPollInterval is how often a followed log is checked for new output.
+var logPollInterval = 250 * time.Millisecond
+
+// RunLogs prints a PRD's claude.log, the raw agent output of every iteration.
+func RunLogs(opts LogsOptions) error {
+	// Set defaults
+	if opts.Name == "" {
+		opts.Name = "main"
+	}
+	if opts.BaseDir == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("failed to get current directory: %w", err)
+		}
+		opts.BaseDir = cwd
+	}
+
+	if !isValidPRDName(opts.Name) {
+		return fmt.Errorf("invalid PRD name %q: must contain only letters, numbers, hyphens, and underscores", opts.Name)
+	}
+	prdDir := paths.PRDDir(opts.BaseDir, opts.Name)
+	if _, err := os.Stat(prdDir); os.IsNotExist(err) {
+		return fmt.Errorf("PRD %q not found", opts.Name)
+	}
+	logPath := filepath.Join(prdDir, "claude.log")
+
+	if !opts.Follow {
+		if _, err := os.Stat(logPath); os.IsNotExist(err) {
+			return fmt.Errorf("no log for %q yet; it is written once the loop runs", opts.Name)
+		}
+		_, err := printLog(os.Stdout, logPath, opts.Lines)
+		return err
+	}
+
+	stop := make(chan struct{})
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(interrupt)
+	go func() {
+		<-interrupt
+		close(stop)
+	}()
+
+	if _, err := os.Stat(logPath); os.IsNotExist(err) {
+		fmt.Fprintf(os.Stderr, "Waiting for %s...\n", logPath)
+	}
+	return followLog(os.Stdout, logPath, opts.Lines, stop)
+}
+
+// printLog writes the log at path to w, or only its last n lines when n > 0.
+// It returns the offset printed up to, where following should resume.
+func printLog(w io.Writer, path string, n int) (int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open log: %w", err)
+	}
+	defer f.Close()
+
+	if n <= 0 {
+		written, err := io.Copy(w, f)
+		return written, err
+	}
+
+	// Keep a ring of the last n lines rather than reading the whole log into memory
+	ring := make([]string, 0, n)
+	next := 0
+	var offset int64
+	reader := bufio.NewReader(f)
+	for {
+		line, err := reader.ReadString('\n')
+		offset += int64(len(line))
+		if line != "" {
+			if len(ring) < n {
+				ring = append(ring, line)
+			} else {
+				ring[next] = line
+				next = (next + 1) % n
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return offset, fmt.Errorf("failed to read log: %w", err)
+		}
+	}
+	for i := range ring {
+		if _, err := io.WriteString(w, ring[(next+i)%len(ring)]); err != nil {
+			return offset, err
+		}
+	}
+	return offset, nil
+}
+
+// followLog prints the log at path like printLog, then keeps printing what
+// is appended until stop is closed. A log that doesn't exist yet is waited
+// for, and one that is truncated or replaced is printed again from the start.
+func followLog(w io.Writer, path string, n int, stop <-chan struct{}) error {
+	var offset int64 = -1 // Nothing printed yet
+	ticker := time.NewTicker(logPollInterval)
+	defer ticker.Stop()
+
+	for {
+		info, err := os.Stat(path)
+		switch {
+		case err != nil && !os.IsNotExist(err):
+			return fmt.Errorf("failed to read log: %w", err)
+		case err != nil:
+			// Not written yet
+		case offset < 0:
+			if offset, err = printLog(w, path, n); err != nil {
+				return err
+			}
+		case info.Size() < offset:
+			fmt.Fprintln(os.Stderr, "Log truncated; following from the start")
+			offset = 0
+			fallthrough
+		case info.Size() > offset:
+			if offset, err = copyFrom(w, path, offset); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-stop:
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// copyFrom writes the content of path from offset on to w and returns the
+// offset it reached.
+func copyFrom(w io.Writer, path string, offset int64) (int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return offset, fmt.Errorf("failed to open log: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return offset, fmt.Errorf("failed to read log: %w", err)
+	}
+	written, err := io.Copy(w, f)
+	return offset + written, err
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPrintLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "claude.log")
+	if err := os.WriteFile(path, []byte("one\ntwo\nthree\nfour\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		lines int
+		want  string
+	}{
+		{0, "one\ntwo\nthree\nfour\n"},
+		{2, "three\nfour\n"},
+		{10, "one\ntwo\nthree\nfour\n"},
+	}
+	for _, tt := range tests {
+		var out bytes.Buffer
+		offset, err := printLog(&out, path, tt.lines)
+		if err != nil {
+			t.Fatalf("printLog(%d) error = %v", tt.lines, err)
+		}
+		if out.String() != tt.want {
+			t.Errorf("printLog(%d) = %q, want %q", tt.lines, out.String(), tt.want)
+		}
+		if offset != 19 {
+			t.Errorf("printLog(%d) offset = %d, want 19", tt.lines, offset)
+		}
+	}
+}
+
+// syncBuffer is a bytes.Buffer safe to read while followLog writes to it.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestFollowLog(t *testing.T) {
+	defer func(orig time.Duration) { logPollInterval = orig }(logPollInterval)
+	logPollInterval = 5 * time.Millisecond
+
+	path := filepath.Join(t.TempDir(), "claude.log")
+	var out syncBuffer
+	stop := make(chan struct{})
+	done := make(chan error)
+	go func() { done <- followLog(&out, path, 1, stop) }()
+
+	waitFor := func(want string) {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for out.String() != want {
+			if time.Now().After(deadline) {
+				t.Fatalf("followed output = %q, want %q", out.String(), want)
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	// The log appears after following starts, written in one go
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte("old\nlast\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		t.Fatal(err)
+	}
+	waitFor("last\n")
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteString("appended\n"); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	waitFor("last\nappended\n")
+
+	close(stop)
+	if err := <-done; err != nil {
+		t.Errorf("followLog() error = %v", err)
+	}
+	if strings.Contains(out.String(), "old") {
+		t.Errorf("expected only the last line before following, got %q", out.String())
+	}
+}
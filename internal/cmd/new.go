@@ -6,12 +6,13 @@ package cmd
 import (
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 
 	"github.com/minicodemonkey/chief/embed"
+	"github.com/minicodemonkey/chief/internal/config"
 	chiefcontext "github.com/minicodemonkey/chief/internal/context"
+	"github.com/minicodemonkey/chief/internal/loop"
 	"github.com/minicodemonkey/chief/internal/paths"
 	"github.com/minicodemonkey/chief/internal/prd"
 )
@@ -67,13 +68,10 @@ func RunNew(opts NewOptions) error {
 	// Get the init prompt with combined context
 	prompt := embed.GetInitPrompt(prdDir, combinedContext)
 
-	// Launch interactive Claude session
+	// Launch an interactive agent session
 	fmt.Printf("Creating PRD in %s...\n", prdDir)
-	fmt.Println("Launching Claude to help you create your PRD...")
-	fmt.Println()
-
-	if err := runInteractiveClaude(opts.BaseDir, prompt); err != nil {
-		return fmt.Errorf("Claude session failed: %w", err)
+	if err := runInteractiveAgent(opts.BaseDir, prompt, "create"); err != nil {
+		return err
 	}
 
 	// Check if prd.md was created
@@ -85,7 +83,7 @@ func RunNew(opts NewOptions) error {
 	fmt.Println("\nPRD created successfully!")
 
 	// Run conversion from prd.md to prd.json
-	if err := RunConvert(prdDir); err != nil {
+	if err := RunConvertWithOptions(ConvertOptions{PRDDir: prdDir, BaseDir: opts.BaseDir}); err != nil {
 		return fmt.Errorf("conversion failed: %w", err)
 	}
 
@@ -93,16 +91,36 @@ func RunNew(opts NewOptions) error {
 	return nil
 }
 
-// runInteractiveClaude launches an interactive Claude session in the specified directory.
-func runInteractiveClaude(workDir, prompt string) error {
-	// Pass prompt as argument (not -p which is print mode / non-interactive)
-	cmd := exec.Command("claude", prompt)
+// runInteractiveAgent launches an interactive session with the agent the
+// project config selects, in the specified directory, to create or edit (verb)
+// a PRD.
+func runInteractiveAgent(workDir, prompt, verb string) error {
+	cfg, err := config.Load(workDir)
+	if err != nil {
+		cfg = config.Default()
+	}
+	agent, err := loop.NewAgent(cfg)
+	if err != nil {
+		return err
+	}
+	if err := agent.CheckBinary(); err != nil {
+		return err
+	}
+	cmd, err := agent.InteractiveCommand(prompt)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Launching %s to help you %s your PRD...\n\n", agent.Name(), verb)
 	cmd.Dir = workDir
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 
-	return cmd.Run()
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s session failed: %w", agent.Name(), err)
+	}
+	return nil
 }
 
 // ConvertOptions contains configuration for the conversion command.
@@ -110,6 +128,12 @@ type ConvertOptions struct {
 	PRDDir string // PRD directory containing prd.md
 	Merge  bool   // Auto-merge without prompting on conversion conflicts
 	Force  bool   // Auto-overwrite without prompting on conversion conflicts
+
+	// BaseDir is the project whose config supplies the retry settings; empty
+	// means no retries
+	BaseDir string
+
+	Offline bool // Parse prd.md without Claude (see prd.ConvertMarkdown)
 }
 
 // RunConvert converts prd.md to prd.json using Claude.
@@ -120,11 +144,24 @@ func RunConvert(prdDir string) error {
 // RunConvertWithOptions converts prd.md to prd.json using Claude with options.
 // The Merge and Force flags will be fully implemented in US-019.
 func RunConvertWithOptions(opts ConvertOptions) error {
-	return prd.Convert(prd.ConvertOptions{
-		PRDDir: opts.PRDDir,
-		Merge:  opts.Merge,
-		Force:  opts.Force,
-	})
+	convertOpts := prd.ConvertOptions{
+		PRDDir:  opts.PRDDir,
+		Merge:   opts.Merge,
+		Force:   opts.Force,
+		Offline: opts.Offline,
+	}
+	if opts.BaseDir != "" {
+		if cfg, err := config.Load(opts.BaseDir); err == nil {
+			convertOpts.Retries = cfg.Convert.Retries
+			convertOpts.RetryDelay = cfg.Convert.RetryDelay
+			agent, err := loop.NewAgent(cfg)
+			if err != nil {
+				return err
+			}
+			convertOpts.Agent = agent
+		}
+	}
+	return prd.Convert(convertOpts)
 }
 
 // buildCombinedContext merges file-based and inline context into one string.
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/minicodemonkey/chief/internal/lock"
+	"github.com/minicodemonkey/chief/internal/loop"
+	"github.com/minicodemonkey/chief/internal/paths"
+	"github.com/minicodemonkey/chief/internal/prd"
+)
+
+// RebuildProgressOptions contains configuration for the rebuild-progress command.
+type RebuildProgressOptions struct {
+	Name    string // PRD name (default: "main")
+	BaseDir string // Base directory for .chief/prds/ (default: current directory)
+}
+
+// RunRebuildProgress regenerates a PRD's progress.md from its claude.log,
+// after it was deleted or corrupted. An existing progress.md is kept as
+// progress.md.bak.
+func RunRebuildProgress(opts RebuildProgressOptions) error {
+	// Set defaults
+	if opts.Name == "" {
+		opts.Name = "main"
+	}
+	if opts.BaseDir == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("failed to get current directory: %w", err)
+		}
+		opts.BaseDir = cwd
+	}
+
+	if !isValidPRDName(opts.Name) {
+		return fmt.Errorf("invalid PRD name %q: must contain only letters, numbers, hyphens, and underscores", opts.Name)
+	}
+	prdDir := paths.PRDDir(opts.BaseDir, opts.Name)
+	if _, err := os.Stat(prdDir); os.IsNotExist(err) {
+		return fmt.Errorf("PRD %q not found", opts.Name)
+	}
+	prdPath := filepath.Join(prdDir, "prd.json")
+
+	logData, err := os.ReadFile(filepath.Join(prdDir, "claude.log"))
+	if os.IsNotExist(err) {
+		return fmt.Errorf("no claude.log for %q to rebuild progress from", opts.Name)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read claude.log: %w", err)
+	}
+	timings, err := prd.LoadTimings(prdPath)
+	if err != nil {
+		return err
+	}
+
+	replay, err := loop.ReplayProgress(bytes.NewReader(logData), timings, time.Now())
+	if err != nil {
+		return err
+	}
+	if replay.Content == "" {
+		return fmt.Errorf("claude.log for %q has no story iterations to rebuild progress from", opts.Name)
+	}
+
+	// A running loop appends to progress.md too
+	projectLock, err := lock.Acquire(paths.LockPath(opts.BaseDir))
+	if err != nil {
+		return err
+	}
+	defer projectLock.Release()
+
+	progressPath := prd.ProgressPath(prdPath)
+	if existing, err := os.ReadFile(progressPath); err == nil {
+		if err := os.WriteFile(progressPath+".bak", existing, 0644); err != nil {
+			return fmt.Errorf("failed to back up progress.md: %w", err)
+		}
+		fmt.Printf("Backed up the existing progress.md to %s\n", progressPath+".bak")
+	}
+	if err := os.WriteFile(progressPath, []byte(replay.Content), 0644); err != nil {
+		return fmt.Errorf("failed to write progress.md: %w", err)
+	}
+
+	fmt.Printf("Rebuilt %s: %d iteration(s) replayed", progressPath, replay.Replayed)
+	if replay.Summarised > 0 {
+		fmt.Printf(", %d summarised from their last message", replay.Summarised)
+	}
+	fmt.Println()
+	return nil
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/minicodemonkey/chief/internal/paths"
+)
+
+func TestRunRebuildProgress(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+	baseDir := t.TempDir()
+
+	createRenameTestPRD(t, baseDir, "main", `{"project":"x","userStories":[]}`)
+	prdDir := paths.PRDDir(baseDir, "main")
+
+	if err := RunRebuildProgress(RebuildProgressOptions{BaseDir: baseDir}); err == nil || !strings.Contains(err.Error(), "no claude.log") {
+		t.Fatalf("expected a missing claude.log error, got %v", err)
+	}
+
+	log := `{"type":"system","subtype":"init"}
+{"type":"assistant","message":{"content":[{"type":"text","text":"<ralph-status>US-001</ralph-status>"}]}}
+{"type":"assistant","message":{"content":[{"type":"tool_use","id":"1","name":"Write","input":{"file_path":"progress.md","content":"## 2024-01-01 - US-001\n- Done\n---\n"}}]}}
+`
+	if err := os.WriteFile(filepath.Join(prdDir, "claude.log"), []byte(log), 0644); err != nil {
+		t.Fatal(err)
+	}
+	progressPath := filepath.Join(prdDir, "progress.md")
+	if err := os.WriteFile(progressPath, []byte("corrupted"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := RunRebuildProgress(RebuildProgressOptions{BaseDir: baseDir}); err != nil {
+		t.Fatalf("RunRebuildProgress() error = %v", err)
+	}
+	got, _ := os.ReadFile(progressPath)
+	if string(got) != "## 2024-01-01 - US-001\n- Done\n---\n" {
+		t.Errorf("progress.md = %q", got)
+	}
+	backup, _ := os.ReadFile(progressPath + ".bak")
+	if string(backup) != "corrupted" {
+		t.Errorf("progress.md.bak = %q, want the previous content", backup)
+	}
+}
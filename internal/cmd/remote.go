@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/minicodemonkey/chief/internal/paths"
+)
+
+const remoteFetchTimeout = 30 * time.Second
+
+// remoteSourceFile records the URL a remote PRD was fetched from, so the PRD
+// directory can be recognised as a read-only mirror.
+const remoteSourceFile = ".source-url"
+
+// FetchRemoteOptions contains configuration for fetching a remote PRD.
+type FetchRemoteOptions struct {
+	URL     string // URL of the PRD markdown (e.g. a raw gist)
+	Name    string // PRD name (default: derived from the URL)
+	BaseDir string // Base directory for .chief/prds/ (default: current directory)
+}
+
+// IsRemotePRD reports whether a positional argument refers to a remote PRD.
+func IsRemotePRD(arg string) bool {
+	return strings.HasPrefix(arg, "http://") || strings.HasPrefix(arg, "https://")
+}
+
+// FetchRemotePRD downloads a PRD markdown file into a local PRD directory and
+// returns the path to its prd.json. The markdown is only rewritten when the
+// remote content changed, so an unchanged spec isn't reconverted. If the
+// download fails but a cached copy exists, the cached copy is used.
+func FetchRemotePRD(opts FetchRemoteOptions) (string, error) {
+	if opts.BaseDir == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return "", fmt.Errorf("failed to get current directory: %w", err)
+		}
+		opts.BaseDir = cwd
+	}
+	if opts.Name == "" {
+		opts.Name = remotePRDName(opts.URL)
+	}
+	if !isValidPRDName(opts.Name) {
+		return "", fmt.Errorf("invalid PRD name %q: must contain only letters, numbers, hyphens, and underscores", opts.Name)
+	}
+
+	prdDir := paths.PRDDir(opts.BaseDir, opts.Name)
+	mdPath := filepath.Join(prdDir, "prd.md")
+	jsonPath := filepath.Join(prdDir, "prd.json")
+
+	// Refuse to overwrite a local PRD that didn't come from this URL
+	if source, err := os.ReadFile(filepath.Join(prdDir, remoteSourceFile)); err == nil {
+		if strings.TrimSpace(string(source)) != opts.URL {
+			return "", fmt.Errorf("PRD %q was fetched from %s; use --name to pick another name", opts.Name, strings.TrimSpace(string(source)))
+		}
+	} else if _, err := os.Stat(mdPath); err == nil {
+		return "", fmt.Errorf("PRD %q already exists locally; use --name to pick another name", opts.Name)
+	}
+
+	content, err := downloadRemotePRD(opts.URL)
+	if err != nil {
+		if _, statErr := os.Stat(mdPath); statErr == nil {
+			fmt.Printf("Warning: %v; using cached copy\n", err)
+			return jsonPath, nil
+		}
+		return "", err
+	}
+
+	if err := os.MkdirAll(prdDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create PRD directory: %w", err)
+	}
+	if existing, err := os.ReadFile(mdPath); err != nil || !bytes.Equal(existing, content) {
+		if err := os.WriteFile(mdPath, content, 0644); err != nil {
+			return "", fmt.Errorf("failed to write prd.md: %w", err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(prdDir, remoteSourceFile), []byte(opts.URL+"\n"), 0644); err != nil {
+		return "", fmt.Errorf("failed to record PRD source: %w", err)
+	}
+
+	return jsonPath, nil
+}
+
+// downloadRemotePRD fetches the raw PRD content from a URL.
+func downloadRemotePRD(rawURL string) ([]byte, error) {
+	client := &http.Client{Timeout: remoteFetchTimeout}
+	resp, err := client.Get(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("fetching remote PRD: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching remote PRD: server returned status %d", resp.StatusCode)
+	}
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading remote PRD: %w", err)
+	}
+	if len(bytes.TrimSpace(content)) == 0 {
+		return nil, fmt.Errorf("remote PRD at %s is empty", rawURL)
+	}
+	return content, nil
+}
+
+var invalidNameChars = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// remotePRDName derives a PRD name from the last path segment of a URL,
+// e.g. https://example.com/specs/auth-flow.md -> "auth-flow".
+func remotePRDName(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "remote"
+	}
+	base := path.Base(u.Path)
+	base = strings.TrimSuffix(base, path.Ext(base))
+	if base == "raw" || base == "prd" {
+		// GitHub/gist raw URLs end in /raw or /prd.md; the parent segment is more descriptive
+		parent := path.Base(path.Dir(u.Path))
+		if parent != "/" && parent != "." {
+			base = parent
+		}
+	}
+	name := strings.Trim(invalidNameChars.ReplaceAllString(base, "-"), "-")
+	if name == "" || name == "/" {
+		return "remote"
+	}
+	return name
+}
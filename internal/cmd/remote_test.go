@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/minicodemonkey/chief/internal/paths"
+)
+
+func TestIsRemotePRD(t *testing.T) {
+	tests := map[string]bool{
+		"https://example.com/prd.md": true,
+		"http://example.com/prd.md":  true,
+		"auth":                       false,
+		"./prd.json":                 false,
+	}
+	for arg, want := range tests {
+		if got := IsRemotePRD(arg); got != want {
+			t.Errorf("IsRemotePRD(%q) = %v, want %v", arg, got, want)
+		}
+	}
+}
+
+func TestRemotePRDName(t *testing.T) {
+	tests := map[string]string{
+		"https://example.com/specs/auth-flow.md":                         "auth-flow",
+		"https://gist.githubusercontent.com/u/abc123/raw/def/billing.md": "billing",
+		"https://gist.githubusercontent.com/u/abc123/raw":                "abc123",
+		"https://example.com/":                                           "remote",
+		"https://example.com/my spec.md":                                 "my-spec",
+	}
+	for url, want := range tests {
+		if got := remotePRDName(url); got != want {
+			t.Errorf("remotePRDName(%q) = %q, want %q", url, got, want)
+		}
+	}
+}
+
+func TestFetchRemotePRD(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+	baseDir := t.TempDir()
+
+	body := "# Auth\n\n## US-001: Login\n"
+	available := true
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !available {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	url := srv.URL + "/specs/auth.md"
+	jsonPath, err := FetchRemotePRD(FetchRemoteOptions{URL: url, Name: "shared", BaseDir: baseDir})
+	if err != nil {
+		t.Fatalf("FetchRemotePRD failed: %v", err)
+	}
+	if jsonPath != paths.PRDPath(baseDir, "shared") {
+		t.Errorf("expected prd.json under the shared PRD, got %s", jsonPath)
+	}
+	mdPath := filepath.Join(paths.PRDDir(baseDir, "shared"), "prd.md")
+	if data, _ := os.ReadFile(mdPath); string(data) != body {
+		t.Errorf("expected prd.md to contain fetched content, got %q", data)
+	}
+
+	// Falls back to the cached copy when the source is unavailable
+	available = false
+	if _, err := FetchRemotePRD(FetchRemoteOptions{URL: url, Name: "shared", BaseDir: baseDir}); err != nil {
+		t.Errorf("expected cached copy to be used, got error: %v", err)
+	}
+
+	// A different URL must not overwrite the cached PRD
+	available = true
+	if _, err := FetchRemotePRD(FetchRemoteOptions{URL: srv.URL + "/other.md", Name: "shared", BaseDir: baseDir}); err == nil {
+		t.Error("expected error when name is taken by another source")
+	}
+}
+
+func TestFetchRemotePRDDoesNotOverwriteLocalPRD(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+	baseDir := t.TempDir()
+	writePRDFiles(t, baseDir, "auth", false)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("# Remote"))
+	}))
+	defer srv.Close()
+
+	if _, err := FetchRemotePRD(FetchRemoteOptions{URL: srv.URL + "/auth.md", BaseDir: baseDir}); err == nil {
+		t.Error("expected error when a local PRD with the same name exists")
+	}
+}
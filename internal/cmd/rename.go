@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/minicodemonkey/chief/internal/config"
+	"github.com/minicodemonkey/chief/internal/git"
+	"github.com/minicodemonkey/chief/internal/lock"
+	"github.com/minicodemonkey/chief/internal/loop"
+	"github.com/minicodemonkey/chief/internal/paths"
+)
+
+// RenameOptions contains configuration for the rename command.
+type RenameOptions struct {
+	OldName      string // Current PRD name
+	NewName      string // New PRD name
+	BaseDir      string // Base directory for .chief/prds/ (default: current directory)
+	RenameBranch bool   // Rename the chief/<old> branch without prompting
+	KeepBranch   bool   // Keep the chief/<old> branch name without prompting
+}
+
+// RunRename renames a PRD directory, moving its worktree and optionally its branch.
+func RunRename(opts RenameOptions) error {
+	if opts.BaseDir == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("failed to get current directory: %w", err)
+		}
+		opts.BaseDir = cwd
+	}
+
+	if opts.OldName == "" || opts.NewName == "" {
+		return fmt.Errorf("usage: chief rename <old> <new>")
+	}
+	if !isValidPRDName(opts.NewName) {
+		return fmt.Errorf("invalid PRD name %q: must contain only letters, numbers, hyphens, and underscores", opts.NewName)
+	}
+	if opts.OldName == opts.NewName {
+		return fmt.Errorf("PRD is already named %q", opts.NewName)
+	}
+
+	// A TUI or chief run holding the project has the PRD registered under its old name
+	projectLock, err := lock.Acquire(paths.LockPath(opts.BaseDir))
+	var held *lock.HeldError
+	if errors.As(err, &held) {
+		return fmt.Errorf("%w; quit it before renaming %q", err, opts.OldName)
+	}
+	if err != nil {
+		return err
+	}
+	defer projectLock.Release()
+
+	oldDir := paths.PRDDir(opts.BaseDir, opts.OldName)
+	newDir := paths.PRDDir(opts.BaseDir, opts.NewName)
+
+	if _, err := os.Stat(oldDir); os.IsNotExist(err) {
+		return fmt.Errorf("PRD %q not found", opts.OldName)
+	}
+	if _, err := os.Stat(newDir); err == nil {
+		return fmt.Errorf("PRD %q already exists", opts.NewName)
+	}
+
+	if err := loop.CheckNotRunning(paths.PRDPath(opts.BaseDir, opts.OldName), opts.OldName, "renaming"); err != nil {
+		return err
+	}
+
+	cfg, err := config.Load(opts.BaseDir)
+	if err != nil {
+		cfg = config.Default()
+	}
+	oldWorktree := git.WorktreePathForPRD(opts.BaseDir, cfg.Worktree.BaseDir, opts.OldName)
+	newWorktree := git.WorktreePathForPRD(opts.BaseDir, cfg.Worktree.BaseDir, opts.NewName)
+	hasWorktree := false
+	if _, err := os.Stat(oldWorktree); err == nil {
+		hasWorktree = true
+		if _, err := os.Stat(newWorktree); err == nil {
+			return fmt.Errorf("worktree for %q already exists at %s", opts.NewName, newWorktree)
+		}
+	}
+
+	isRepo := git.IsGitRepo(opts.BaseDir)
+	pattern := cfg.Git.BranchPattern
+	if pattern == "" {
+		pattern = git.DefaultBranchPattern
+	}
+
+	// Move the worktree first: it's the step most likely to fail, and moving
+	// it back undoes it if the PRD directory can't follow
+	oldBranch := ""
+	movedWorktree := false
+	if isRepo && hasWorktree {
+		if branch, err := git.GetCurrentBranch(oldWorktree); err == nil {
+			oldBranch = branch
+		}
+		if err := git.MoveWorktree(opts.BaseDir, oldWorktree, newWorktree); err != nil {
+			return err
+		}
+		movedWorktree = true
+	}
+
+	if err := os.Rename(oldDir, newDir); err != nil {
+		if movedWorktree {
+			if undoErr := git.MoveWorktree(opts.BaseDir, newWorktree, oldWorktree); undoErr != nil {
+				return fmt.Errorf("failed to rename PRD directory: %w (and moving the worktree back to %s failed: %v)", err, oldWorktree, undoErr)
+			}
+		}
+		return fmt.Errorf("failed to rename PRD directory: %w", err)
+	}
+	fmt.Printf("Renamed PRD %s → %s\n", opts.OldName, opts.NewName)
+	if movedWorktree {
+		fmt.Printf("Moved worktree to %s\n", newWorktree)
+	}
+
+	if !isRepo || opts.KeepBranch {
+		return nil
+	}
+
+	// Only the branch named by the pattern encodes the PRD name; leave custom
+	// branches alone. A {date} keeps the day the branch was made for.
+	var made time.Time
+	if oldBranch != "" {
+		date, ok := git.MatchBranchPattern(pattern, opts.OldName, oldBranch)
+		if !ok {
+			return nil
+		}
+		made = date
+	} else {
+		branch, date, ok := git.FindPatternBranch(opts.BaseDir, pattern, opts.OldName)
+		if !ok {
+			fmt.Printf("No branch matching %s found for %s; no branch to rename\n", pattern, opts.OldName)
+			return nil
+		}
+		oldBranch, made = branch, date
+	}
+	newBranch := git.BranchName(pattern, opts.NewName, made)
+	if exists, _ := git.BranchExists(opts.BaseDir, newBranch); exists {
+		fmt.Printf("Branch %s already exists; keeping %s\n", newBranch, oldBranch)
+		return nil
+	}
+
+	if !opts.RenameBranch && !confirm(fmt.Sprintf("Rename branch %s to %s?", oldBranch, newBranch)) {
+		return nil
+	}
+	if err := git.RenameBranch(opts.BaseDir, oldBranch, newBranch); err != nil {
+		return err
+	}
+	fmt.Printf("Renamed branch %s → %s\n", oldBranch, newBranch)
+
+	return nil
+}
+
+// confirm asks a yes/no question on stdin, defaulting to no.
+func confirm(question string) bool {
+	fmt.Printf("%s [y/N]: ", question)
+	reader := bufio.NewReader(os.Stdin)
+	input, err := reader.ReadString('\n')
+	if err != nil {
+		return false
+	}
+	input = strings.TrimSpace(strings.ToLower(input))
+	return input == "y" || input == "yes"
+}
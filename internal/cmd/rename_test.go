@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/minicodemonkey/chief/internal/config"
+	"github.com/minicodemonkey/chief/internal/git"
+	"github.com/minicodemonkey/chief/internal/lock"
+	"github.com/minicodemonkey/chief/internal/loop"
+	"github.com/minicodemonkey/chief/internal/paths"
+)
+
+func createRenameTestPRD(t *testing.T, baseDir, name, prdJSON string) {
+	t.Helper()
+	prdDir := paths.PRDDir(baseDir, name)
+	if err := os.MkdirAll(prdDir, 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(prdDir, "prd.json"), []byte(prdJSON), 0644); err != nil {
+		t.Fatalf("Failed to create prd.json: %v", err)
+	}
+}
+
+func TestRunRenameMovesPRDDirectory(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+	baseDir := t.TempDir()
+
+	createRenameTestPRD(t, baseDir, "old", `{"project":"x","userStories":[]}`)
+
+	if err := RunRename(RenameOptions{OldName: "old", NewName: "new", BaseDir: baseDir}); err != nil {
+		t.Fatalf("RunRename() returned error: %v", err)
+	}
+	if _, err := os.Stat(paths.PRDPath(baseDir, "new")); err != nil {
+		t.Errorf("expected prd.json under new name: %v", err)
+	}
+	if _, err := os.Stat(paths.PRDDir(baseDir, "old")); !os.IsNotExist(err) {
+		t.Error("expected old PRD directory to be gone")
+	}
+}
+
+func TestRunRenameValidation(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+	baseDir := t.TempDir()
+
+	createRenameTestPRD(t, baseDir, "old", `{"project":"x","userStories":[]}`)
+	createRenameTestPRD(t, baseDir, "taken", `{"project":"y","userStories":[]}`)
+	createRenameTestPRD(t, baseDir, "busy", `{"project":"z","userStories":[{"id":"US-001","inProgress":true}]}`)
+	createRenameTestPRD(t, baseDir, "busy-state", `{"project":"z","userStories":[{"id":"US-001"}]}`)
+	if err := loop.SaveSnapshot(paths.PRDPath(baseDir, "busy-state"), loop.Snapshot{State: loop.LoopStateRunning.String(), Story: "US-001"}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		desc    string
+		oldName string
+		newName string
+	}{
+		{"invalid new name", "old", "bad name"},
+		{"new name exists", "old", "taken"},
+		{"old name missing", "missing", "fresh"},
+		{"loop running", "busy", "fresh"},
+		{"loop running with inProgressInState", "busy-state", "fresh"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if err := RunRename(RenameOptions{OldName: tt.oldName, NewName: tt.newName, BaseDir: baseDir}); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestRunRenameRefusesWhileLocked(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+	baseDir := t.TempDir()
+	createRenameTestPRD(t, baseDir, "old", `{"project":"x","userStories":[]}`)
+
+	// Another live chief process holds the project
+	lockPath := paths.LockPath(baseDir)
+	if err := os.MkdirAll(filepath.Dir(lockPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(lockPath, []byte(strconv.Itoa(os.Getppid())+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := RunRename(RenameOptions{OldName: "old", NewName: "new", BaseDir: baseDir})
+	var held *lock.HeldError
+	if !errors.As(err, &held) {
+		t.Fatalf("RunRename() error = %v, want a *lock.HeldError", err)
+	}
+	if _, err := os.Stat(paths.PRDDir(baseDir, "old")); err != nil {
+		t.Error("expected the PRD to stay under its old name")
+	}
+}
+
+// initGitTestRepo makes dir a git repository with one commit on main.
+func initGitTestRepo(t *testing.T, dir string) {
+	t.Helper()
+	for _, args := range [][]string{
+		{"git", "init", "-b", "main"},
+		{"git", "config", "user.email", "test@test.com"},
+		{"git", "config", "user.name", "Test"},
+		{"git", "commit", "--allow-empty", "-m", "initial"},
+	} {
+		c := exec.Command(args[0], args[1:]...)
+		c.Dir = dir
+		if out, err := c.CombinedOutput(); err != nil {
+			t.Fatalf("setup %v failed: %s", args, out)
+		}
+	}
+}
+
+func TestRunRenameMovesWorktreeAndBranch(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+
+	baseDir := t.TempDir()
+	initGitTestRepo(t, baseDir)
+
+	createRenameTestPRD(t, baseDir, "old", `{"project":"x","userStories":[]}`)
+	if err := git.CreateWorktree(baseDir, paths.WorktreeDir(baseDir, "old"), "chief/old", "", false); err != nil {
+		t.Fatalf("CreateWorktree() error = %v", err)
+	}
+
+	err := RunRename(RenameOptions{OldName: "old", NewName: "new", BaseDir: baseDir, RenameBranch: true})
+	if err != nil {
+		t.Fatalf("RunRename() returned error: %v", err)
+	}
+
+	newWorktree := paths.WorktreeDir(baseDir, "new")
+	if !git.IsWorktree(newWorktree) {
+		t.Fatal("expected worktree at new path")
+	}
+	if branch, _ := git.GetCurrentBranch(newWorktree); branch != "chief/new" {
+		t.Errorf("worktree branch = %q, want chief/new", branch)
+	}
+}
+
+func TestRunRenameFindsDatedBranch(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+
+	baseDir := t.TempDir()
+	initGitTestRepo(t, baseDir)
+	if err := config.Save(baseDir, &config.Config{Git: config.GitConfig{BranchPattern: "ai/{date}-{prd}"}}); err != nil {
+		t.Fatal(err)
+	}
+	branch := exec.Command("git", "branch", "ai/2025-01-02-old")
+	branch.Dir = baseDir
+	if out, err := branch.CombinedOutput(); err != nil {
+		t.Fatalf("git branch failed: %s", out)
+	}
+	createRenameTestPRD(t, baseDir, "old", `{"project":"x","userStories":[]}`)
+
+	if err := RunRename(RenameOptions{OldName: "old", NewName: "new", BaseDir: baseDir, RenameBranch: true}); err != nil {
+		t.Fatalf("RunRename() returned error: %v", err)
+	}
+	if exists, _ := git.BranchExists(baseDir, "ai/2025-01-02-new"); !exists {
+		t.Error("expected the branch renamed with its original date")
+	}
+}
+
+func TestRunRenameLeavesPRDWhenWorktreeCantMove(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+
+	baseDir := t.TempDir()
+	initGitTestRepo(t, baseDir)
+
+	createRenameTestPRD(t, baseDir, "old", `{"project":"x","userStories":[]}`)
+	oldWorktree := paths.WorktreeDir(baseDir, "old")
+	if err := git.CreateWorktree(baseDir, oldWorktree, "chief/old", "", false); err != nil {
+		t.Fatalf("CreateWorktree() error = %v", err)
+	}
+	// git refuses to move a locked worktree
+	lock := exec.Command("git", "worktree", "lock", oldWorktree)
+	lock.Dir = baseDir
+	if out, err := lock.CombinedOutput(); err != nil {
+		t.Fatalf("git worktree lock failed: %s", out)
+	}
+
+	if err := RunRename(RenameOptions{OldName: "old", NewName: "new", BaseDir: baseDir, KeepBranch: true}); err == nil {
+		t.Fatal("expected an error when the worktree can't move")
+	}
+	if _, err := os.Stat(paths.PRDPath(baseDir, "old")); err != nil {
+		t.Errorf("expected the PRD to keep its old name: %v", err)
+	}
+	if _, err := os.Stat(paths.PRDDir(baseDir, "new")); !os.IsNotExist(err) {
+		t.Error("expected no PRD under the new name")
+	}
+	if !git.IsWorktree(oldWorktree) {
+		t.Error("expected the worktree to stay where it was")
+	}
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/minicodemonkey/chief/internal/loop"
+	"github.com/minicodemonkey/chief/internal/paths"
+	"github.com/minicodemonkey/chief/internal/prd"
+)
+
+// ResumeChoice is what the TUI should restore when the user resumes the last
+// session.
+type ResumeChoice struct {
+	PRDPath string // PRD to open
+	Story   string // Story to select; empty keeps the default selection
+	Start   bool   // Start the PRD's loop straight away
+}
+
+// resumableSession returns the last session when it is worth offering to
+// resume: its PRD still exists, has stories left to do, and has run before.
+// Returns nil otherwise.
+func resumableSession(baseDir string) *loop.Session {
+	session, err := loop.LoadSession(paths.SessionPath(baseDir))
+	if err != nil || session == nil || !isValidPRDName(session.PRD) {
+		return nil
+	}
+	prdPath := paths.PRDPath(baseDir, session.PRD)
+	p, err := prd.LoadPRD(prdPath)
+	if err != nil || p.AllComplete() {
+		return nil
+	}
+	if snap, err := loop.LoadSnapshot(prdPath); err != nil || snap == nil {
+		return nil
+	}
+	return session
+}
+
+// OfferResume asks whether to pick up where the last session left off and,
+// if its loop was running when chief exited, whether to start it again.
+// Returns nil when there is nothing to resume or the user declines.
+func OfferResume(baseDir string) *ResumeChoice {
+	session := resumableSession(baseDir)
+	if session == nil {
+		return nil
+	}
+
+	summary := fmt.Sprintf("Last session: PRD %q", session.PRD)
+	if session.Story != "" {
+		summary += fmt.Sprintf(" at %s", session.Story)
+	}
+	if session.Running {
+		summary += " (its loop was running)"
+	}
+	fmt.Println(summary)
+	if !confirm("Resume where you left off?") {
+		// Forget it so the question isn't asked on every launch
+		_ = os.Remove(paths.SessionPath(baseDir))
+		return nil
+	}
+
+	return &ResumeChoice{
+		PRDPath: paths.PRDPath(baseDir, session.PRD),
+		Story:   session.Story,
+		Start:   session.Running && confirm("Start its loop again?"),
+	}
+}
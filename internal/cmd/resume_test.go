@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/minicodemonkey/chief/internal/loop"
+	"github.com/minicodemonkey/chief/internal/paths"
+)
+
+func TestResumableSession(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+	baseDir := t.TempDir()
+
+	createRenameTestPRD(t, baseDir, "auth", `{"project":"x","userStories":[{"id":"US-001"},{"id":"US-002","passes":true}]}`)
+	createRenameTestPRD(t, baseDir, "done", `{"project":"y","userStories":[{"id":"US-001","passes":true}]}`)
+	createRenameTestPRD(t, baseDir, "fresh", `{"project":"z","userStories":[{"id":"US-001"}]}`)
+	for _, name := range []string{"auth", "done"} {
+		if err := loop.SaveSnapshot(paths.PRDPath(baseDir, name), loop.Snapshot{State: "Stopped", UpdatedAt: time.Now()}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := resumableSession(baseDir); got != nil {
+		t.Fatalf("expected nothing to resume without a saved session, got %+v", got)
+	}
+
+	tests := []struct {
+		prd  string
+		want bool
+	}{
+		{"auth", true},
+		{"done", false},    // Every story passes
+		{"fresh", false},   // Never run, so there's no state to pick up
+		{"missing", false}, // Deleted since
+	}
+	for _, tt := range tests {
+		t.Run(tt.prd, func(t *testing.T) {
+			if err := loop.SaveSession(paths.SessionPath(baseDir), loop.Session{PRD: tt.prd, Story: "US-001", Running: true}); err != nil {
+				t.Fatal(err)
+			}
+			got := resumableSession(baseDir)
+			if (got != nil) != tt.want {
+				t.Fatalf("resumableSession() = %+v, want resumable=%v", got, tt.want)
+			}
+			if got != nil && (got.Story != "US-001" || !got.Running) {
+				t.Errorf("expected the saved story and running flag, got %+v", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,307 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/minicodemonkey/chief/internal/config"
+	"github.com/minicodemonkey/chief/internal/git"
+	"github.com/minicodemonkey/chief/internal/lock"
+	"github.com/minicodemonkey/chief/internal/loop"
+	"github.com/minicodemonkey/chief/internal/paths"
+	"github.com/minicodemonkey/chief/internal/prd"
+)
+
+// RunOptions contains configuration for the headless run command.
+type RunOptions struct {
+	Name          string        // PRD name (default: "main")
+	BaseDir       string        // Base directory for .chief/prds/ (default: current directory)
+	MaxIterations int           // Max iterations (0 = the PRD's priority-weighted budget, see loop.iterationsPerStory)
+	NoRetry       bool          // Disable auto-retry on Claude crashes
+	Timeout       time.Duration // Stop the loop after this long (0 = no timeout)
+	IterTimeout   time.Duration // Kill and retry an iteration after this long without output (0 = no timeout)
+	Merge         bool          // Auto-merge progress on conversion conflicts
+	Force         bool          // Auto-overwrite on conversion conflicts
+	Strict        bool          // Refuse to run a PRD with warnings, e.g. a story without acceptance criteria
+}
+
+// RunHeadless runs the agent loop for a PRD without the TUI, printing plain
+// log lines to stdout. It returns nil only when every story completes.
+func RunHeadless(opts RunOptions) error {
+	// Set defaults
+	if opts.Name == "" {
+		opts.Name = "main"
+	}
+	if opts.BaseDir == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("failed to get current directory: %w", err)
+		}
+		opts.BaseDir = cwd
+	}
+
+	if !isValidPRDName(opts.Name) {
+		return fmt.Errorf("invalid PRD name %q: must contain only letters, numbers, hyphens, and underscores", opts.Name)
+	}
+	prdDir := paths.PRDDir(opts.BaseDir, opts.Name)
+	if _, err := os.Stat(prdDir); os.IsNotExist(err) {
+		return fmt.Errorf("PRD %q not found. Use 'chief new %s' to create it first", opts.Name, opts.Name)
+	}
+
+	// Another instance running this project would fight over the same files
+	projectLock, err := lock.Acquire(paths.LockPath(opts.BaseDir))
+	if err != nil {
+		return err
+	}
+	defer projectLock.Release()
+
+	// Convert prd.md if it changed, as the TUI does on startup
+	needsConvert, err := prd.NeedsConversion(prdDir)
+	if err != nil {
+		return fmt.Errorf("failed to check conversion status: %w", err)
+	}
+	if needsConvert {
+		fmt.Println("prd.md is newer than prd.json, running conversion...")
+		if err := convertPRD(ConvertOptions{PRDDir: prdDir, Merge: opts.Merge, Force: opts.Force, BaseDir: opts.BaseDir}); err != nil {
+			return err
+		}
+	}
+
+	prdPath := filepath.Join(prdDir, "prd.json")
+	p, err := prd.LoadPRD(prdPath)
+	if err != nil {
+		return fmt.Errorf("failed to load PRD %q: %w", opts.Name, err)
+	}
+	if opts.Strict {
+		if err := p.Strict(); err != nil {
+			return err
+		}
+	}
+	for _, warning := range p.Warnings() {
+		fmt.Printf("Warning: %s\n", warning)
+	}
+	if p.AllComplete() {
+		fmt.Printf("%s: all stories already complete\n", opts.Name)
+		return nil
+	}
+
+	cfg, err := config.Load(opts.BaseDir)
+	if err != nil {
+		cfg = config.Default()
+	}
+
+	maxIter := opts.MaxIterations
+	if maxIter <= 0 {
+		prdCfg, err := cfg.ForPRD(opts.BaseDir, opts.Name)
+		if err != nil {
+			prdCfg = cfg
+		}
+		maxIter = prdCfg.Loop.IterationBudget(p.IterationAllowance())
+	}
+	agent, err := loop.NewAgent(cfg)
+	if err != nil {
+		return err
+	}
+	if err := agent.CheckBinary(); err != nil {
+		return err
+	}
+
+	manager := loop.NewManager(maxIter)
+	manager.SetBaseDir(opts.BaseDir)
+	manager.SetConfig(cfg)
+	manager.SetRetryPolicy(cfg.Loop.RetryAttempts, cfg.Loop.RetryBackoff)
+	if opts.NoRetry {
+		manager.DisableRetry()
+	}
+	manager.SetIterationTimeout(opts.IterTimeout)
+	if path := cfg.Loop.EventSocketPath(opts.BaseDir); path != "" {
+		if err := manager.EnableEventSocket(path); err != nil {
+			return err
+		}
+		defer manager.CloseEventSocket()
+	}
+
+	// Reuse the PRD's worktree if one was set up from the TUI
+	worktreeDir := git.WorktreePathForPRD(opts.BaseDir, cfg.Worktree.BaseDir, opts.Name)
+	if _, err := os.Stat(worktreeDir); err == nil && git.IsWorktree(worktreeDir) {
+		branch, _ := git.GetCurrentBranch(worktreeDir)
+		manager.RegisterWithWorktree(opts.Name, prdPath, worktreeDir, branch)
+	} else {
+		manager.Register(opts.Name, prdPath)
+	}
+
+	fmt.Printf("Running %s (%d/%d stories complete, max %d iterations)\n", opts.Name, len(p.UserStories)-remainingStories(p), len(p.UserStories), maxIter)
+	if err := manager.Start(opts.Name); err != nil {
+		return err
+	}
+
+	finished := make(chan struct{})
+	go func() {
+		manager.Wait()
+		close(finished)
+	}()
+
+	var timeout <-chan time.Time
+	if opts.Timeout > 0 {
+		timer := time.NewTimer(opts.Timeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(interrupt)
+
+	// The loop's final event decides the exit status
+	var result error
+	sawOutcome := false
+	handle := func(me loop.ManagerEvent) {
+		if line := formatHeadlessEvent(me.Event); line != "" {
+			fmt.Println(line)
+		}
+		switch me.Event.Type {
+		case loop.EventComplete:
+			sawOutcome = true
+		case loop.EventMaxIterationsReached:
+			sawOutcome = true
+			result = fmt.Errorf("max iterations (%d) reached before all stories completed", maxIter)
+		case loop.EventAttention:
+			// Nobody is around to resume a headless run, so a pause ends it
+			sawOutcome = true
+			result = fmt.Errorf("paused for attention: %s", me.Event.Text)
+		case loop.EventError:
+			sawOutcome = true
+			result = me.Event.Err
+			if result == nil {
+				result = errors.New("loop failed")
+			}
+		}
+	}
+
+	for {
+		select {
+		case me := <-manager.Events():
+			handle(me)
+		case <-finished:
+			// Drain events emitted just before the loop exited
+		drain:
+			for {
+				select {
+				case me := <-manager.Events():
+					handle(me)
+				default:
+					break drain
+				}
+			}
+			if sawOutcome {
+				return result
+			}
+			state, _, _ := manager.GetState(opts.Name)
+			return fmt.Errorf("loop ended in state %s before all stories completed", state)
+		case <-timeout:
+			fmt.Printf("Timed out after %s, stopping...\n", opts.Timeout)
+			manager.StopAll()
+			drainUntil(manager, finished, handle)
+			return fmt.Errorf("timed out after %s", opts.Timeout)
+		case <-interrupt:
+			fmt.Println("Interrupted, stopping...")
+			manager.StopAll()
+			drainUntil(manager, finished, handle)
+			return errors.New("interrupted")
+		}
+	}
+}
+
+// drainUntil handles events until every loop has exited after being stopped,
+// so what stopping them did, such as a work-in-progress commit, is reported.
+func drainUntil(manager *loop.Manager, finished <-chan struct{}, handle func(loop.ManagerEvent)) {
+	for {
+		select {
+		case me := <-manager.Events():
+			handle(me)
+		case <-finished:
+			for {
+				select {
+				case me := <-manager.Events():
+					handle(me)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// remainingStories counts stories that neither pass nor were skipped.
+func remainingStories(p *prd.PRD) int {
+	remaining := 0
+	for _, story := range p.UserStories {
+		if !story.Settled() {
+			remaining++
+		}
+	}
+	return remaining
+}
+
+// formatHeadlessEvent renders a loop event as a single plain-text log line.
+// Returns empty for events that aren't worth logging.
+func formatHeadlessEvent(event loop.Event) string {
+	switch event.Type {
+	case loop.EventIterationStart:
+		return fmt.Sprintf("── Iteration %d ──", event.Iteration)
+	case loop.EventAssistantText:
+		return event.Text
+	case loop.EventToolStart:
+		if arg := headlessToolArgument(event); arg != "" {
+			return fmt.Sprintf("→ %s %s", event.Tool, arg)
+		}
+		return "→ " + event.Tool
+	case loop.EventStoryStarted:
+		return "▶ Working on: " + event.StoryID
+	case loop.EventPhaseComplete:
+		return "✓ Phase complete: " + event.Text
+	case loop.EventComplete:
+		return "✓ All stories complete!"
+	case loop.EventMaxIterationsReached:
+		return fmt.Sprintf("✗ Max iterations reached (%d)", event.Iteration)
+	case loop.EventError:
+		if event.Err != nil {
+			return "✗ Error: " + event.Err.Error()
+		}
+		return "✗ Error"
+	case loop.EventRetrying:
+		return "↻ " + event.Text
+	case loop.EventTimeout:
+		return "⏱ " + event.Text
+	case loop.EventBlocked:
+		return "⛔ Blocked: " + event.Text
+	case loop.EventAttention:
+		return "⏸ Paused: " + event.Text
+	case loop.EventOutOfScope, loop.EventWarning:
+		return "⚠ " + event.Text
+	case loop.EventHook, loop.EventStopCommit:
+		if event.Err != nil {
+			return "✗ " + event.Text
+		}
+		return "✓ " + event.Text
+	}
+	return ""
+}
+
+// headlessToolArgument returns the most useful argument of a tool call for logging.
+func headlessToolArgument(event loop.Event) string {
+	for _, key := range []string{"file_path", "notebook_path", "command", "pattern", "url", "query", "description"} {
+		if value, ok := event.ToolInput[key].(string); ok && value != "" {
+			if len(value) > 100 {
+				value = value[:97] + "..."
+			}
+			return value
+		}
+	}
+	return ""
+}
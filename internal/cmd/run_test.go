@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/minicodemonkey/chief/internal/lock"
+	"github.com/minicodemonkey/chief/internal/loop"
+	"github.com/minicodemonkey/chief/internal/paths"
+)
+
+// installFakeClaude puts a "claude" script on PATH that runs the given shell body.
+func installFakeClaude(t *testing.T, body string) {
+	t.Helper()
+	binDir := t.TempDir()
+	script := "#!/bin/sh\n" + body + "\necho '{\"type\":\"assistant\",\"message\":{\"content\":[{\"type\":\"text\",\"text\":\"done\"}]}}'\n"
+	if err := os.WriteFile(filepath.Join(binDir, "claude"), []byte(script), 0755); err != nil {
+		t.Fatalf("Failed to write fake claude: %v", err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func writeRunPRD(t *testing.T, baseDir, name string) string {
+	t.Helper()
+	if err := os.MkdirAll(paths.PRDDir(baseDir, name), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	prdPath := paths.PRDPath(baseDir, name)
+	prdJSON := `{"project": "Test", "userStories": [{"id": "US-001", "title": "Story", "priority": 1, "passes": false}]}`
+	if err := os.WriteFile(prdPath, []byte(prdJSON), 0644); err != nil {
+		t.Fatalf("Failed to create prd.json: %v", err)
+	}
+	return prdPath
+}
+
+func TestRunHeadlessCompletes(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+	baseDir := t.TempDir()
+	prdPath := writeRunPRD(t, baseDir, "auth")
+
+	installFakeClaude(t, `sed -i.bak 's/"passes": false/"passes": true/' "`+prdPath+`"`)
+
+	if err := RunHeadless(RunOptions{Name: "auth", BaseDir: baseDir, NoRetry: true}); err != nil {
+		t.Errorf("expected run to complete, got error: %v", err)
+	}
+}
+
+func TestRunHeadlessMaxIterations(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+	baseDir := t.TempDir()
+	writeRunPRD(t, baseDir, "auth")
+
+	installFakeClaude(t, "true")
+
+	err := RunHeadless(RunOptions{Name: "auth", BaseDir: baseDir, MaxIterations: 1, NoRetry: true})
+	if err == nil || !strings.Contains(err.Error(), "max iterations") {
+		t.Errorf("expected max iterations error, got %v", err)
+	}
+}
+
+func TestRunHeadlessMissingPRD(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+
+	if err := RunHeadless(RunOptions{Name: "missing", BaseDir: t.TempDir()}); err == nil {
+		t.Error("expected error for missing PRD")
+	}
+}
+
+func TestRunHeadlessRefusesWhileLocked(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+	baseDir := t.TempDir()
+	writeRunPRD(t, baseDir, "auth")
+
+	// The test's parent process stands in for another live chief instance
+	if err := os.WriteFile(paths.LockPath(baseDir), []byte(strconv.Itoa(os.Getppid())), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := RunHeadless(RunOptions{Name: "auth", BaseDir: baseDir, NoRetry: true})
+	var held *lock.HeldError
+	if !errors.As(err, &held) || held.PID != os.Getppid() {
+		t.Errorf("expected the held lock to stop the run, got %v", err)
+	}
+}
+
+func TestFormatHeadlessEvent(t *testing.T) {
+	tests := []struct {
+		event loop.Event
+		want  string
+	}{
+		{loop.Event{Type: loop.EventIterationStart, Iteration: 2}, "── Iteration 2 ──"},
+		{loop.Event{Type: loop.EventToolStart, Tool: "Edit", ToolInput: map[string]interface{}{"file_path": "main.go"}}, "→ Edit main.go"},
+		{loop.Event{Type: loop.EventStoryStarted, StoryID: "US-001"}, "▶ Working on: US-001"},
+		{loop.Event{Type: loop.EventError, Err: errors.New("boom")}, "✗ Error: boom"},
+		{loop.Event{Type: loop.EventToolResult}, ""},
+	}
+	for _, tt := range tests {
+		if got := formatHeadlessEvent(tt.event); got != tt.want {
+			t.Errorf("formatHeadlessEvent(%v) = %q, want %q", tt.event.Type, got, tt.want)
+		}
+	}
+}
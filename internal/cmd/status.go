@@ -1,10 +1,16 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
 
+	"github.com/minicodemonkey/chief/internal/loop"
 	"github.com/minicodemonkey/chief/internal/paths"
 	"github.com/minicodemonkey/chief/internal/prd"
 )
@@ -13,15 +19,124 @@ import (
 type StatusOptions struct {
 	Name    string // PRD name (default: "main")
 	BaseDir string // Base directory for .chief/prds/ (default: current directory)
+	JSON    bool   // Emit a StatusReport as JSON instead of human-readable text
+	Strict  bool   // Fail when the PRD has warnings, e.g. a story without acceptance criteria
+	Watch   bool   // Redraw the status whenever prd.json changes and every Interval, until interrupted
+	All     bool   // Summarise every PRD instead of one
+
+	// Interval is how often --watch redraws without a change (default: statusWatchInterval)
+	Interval time.Duration
+}
+
+// statusWatchInterval is how often `chief status --watch` redraws by default.
+const statusWatchInterval = 2 * time.Second
+
+// clearScreen moves the cursor home and clears the terminal.
+const clearScreen = "\033[H\033[2J"
+
+// Loop states reported by --json output. "running" means a loop has a story
+// in progress: either recorded as running in the PRD's state.json, or marked
+// inProgress in the PRD itself.
+const (
+	LoopStateReady    = "ready"
+	LoopStateRunning  = "running"
+	LoopStateComplete = "complete"
+)
+
+// StatusReport is the JSON schema for `chief status --json`.
+type StatusReport struct {
+	Name       string        `json:"name"`               // PRD name
+	Project    string        `json:"project"`            // Project name from the PRD
+	Total      int           `json:"total"`              // Number of stories
+	Completed  int           `json:"completed"`          // Stories with passes: true
+	InProgress int           `json:"inProgress"`         // Stories currently marked in progress
+	Complete   bool          `json:"complete"`           // True when every story passes or was skipped (and there is at least one)
+	Stories    []StoryStatus `json:"stories"`            // Stories in PRD order
+	Warnings   []string      `json:"warnings,omitempty"` // Problems such as stories without acceptance criteria
+}
+
+// StoryStatus is the per-story entry in a StatusReport.
+type StoryStatus struct {
+	ID         string `json:"id"`
+	Title      string `json:"title"`
+	Passes     bool   `json:"passes"`
+	InProgress bool   `json:"inProgress"`
+	Skipped    bool   `json:"skipped,omitempty"`
+}
+
+// newStatusReport summarises a loaded PRD for JSON output.
+func newStatusReport(name string, p *prd.PRD) StatusReport {
+	report := StatusReport{
+		Name:     name,
+		Project:  p.Project,
+		Total:    len(p.UserStories),
+		Stories:  make([]StoryStatus, 0, len(p.UserStories)),
+		Warnings: p.Warnings(),
+	}
+	for _, story := range p.UserStories {
+		if story.Passes {
+			report.Completed++
+		}
+		if story.InProgress {
+			report.InProgress++
+		}
+		report.Stories = append(report.Stories, StoryStatus{
+			ID:         story.ID,
+			Title:      story.Title,
+			Passes:     story.Passes,
+			InProgress: story.InProgress,
+			Skipped:    story.Skipped,
+		})
+	}
+	report.Complete = report.Total > 0 && p.AllComplete()
+	return report
+}
+
+// loadStatusPRD loads a PRD for reporting, with the story a running loop keeps
+// in state.json rather than prd.json marked in progress.
+func loadStatusPRD(prdPath string) (*prd.PRD, error) {
+	p, err := prd.LoadPRD(prdPath)
+	if err != nil {
+		return nil, err
+	}
+	loop.MarkRunningStory(prdPath, p)
+	return p, nil
+}
+
+// printJSON writes v to stdout as indented JSON.
+func printJSON(v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+// AvailablePRDs returns the names of the PRDs in a project that have a
+// prd.json (or prd.yaml), in directory order.
+func AvailablePRDs(baseDir string) []string {
+	prdsDir := paths.PRDsDir(baseDir)
+	entries, err := os.ReadDir(prdsDir)
+	if err != nil {
+		return nil
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			prdPath := filepath.Join(prdsDir, entry.Name(), "prd.json")
+			if _, err := os.Stat(prd.ResolvePath(prdPath)); err == nil {
+				names = append(names, entry.Name())
+			}
+		}
+	}
+	return names
 }
 
 // RunStatus prints progress for a PRD.
 // Returns nil on success, error otherwise. Exit code should be 0 on success.
 func RunStatus(opts StatusOptions) error {
-	// Set defaults
-	if opts.Name == "" {
-		opts.Name = "main"
-	}
 	if opts.BaseDir == "" {
 		cwd, err := os.Getwd()
 		if err != nil {
@@ -29,16 +144,109 @@ func RunStatus(opts StatusOptions) error {
 		}
 		opts.BaseDir = cwd
 	}
+	if opts.All {
+		if opts.Name != "" {
+			return fmt.Errorf("--all shows every PRD; drop the PRD name %q", opts.Name)
+		}
+		if opts.Watch {
+			return fmt.Errorf("--watch can't be combined with --all")
+		}
+		return printAllStatus(opts)
+	}
+
+	// Set defaults
+	if opts.Name == "" {
+		opts.Name = "main"
+	}
 
 	// Build PRD path
 	prdPath := paths.PRDPath(opts.BaseDir, opts.Name)
 
 	// Load PRD
-	p, err := prd.LoadPRD(prdPath)
+	p, err := loadStatusPRD(prdPath)
 	if err != nil {
 		return fmt.Errorf("failed to load PRD %q: %w", opts.Name, err)
 	}
 
+	if opts.Watch {
+		stop := make(chan struct{})
+		interrupt := make(chan os.Signal, 1)
+		signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+		defer signal.Stop(interrupt)
+		go func() {
+			<-interrupt
+			close(stop)
+		}()
+		return watchStatus(opts, prdPath, stop)
+	}
+	return printStatus(opts, p)
+}
+
+// watchStatus clears the screen and prints the PRD's status, again whenever
+// prd.json changes or opts.Interval passes, until stop is closed. With
+// opts.JSON it instead prints each StatusReport as one line of JSON, without
+// clearing the screen, so the output can be piped. A PRD that fails to load
+// mid-write is reported and retried rather than ending the watch, and
+// --strict doesn't apply.
+func watchStatus(opts StatusOptions, prdPath string, stop <-chan struct{}) error {
+	if opts.Interval <= 0 {
+		opts.Interval = statusWatchInterval
+	}
+	opts.Strict = false
+
+	var changes <-chan prd.WatcherEvent
+	if watcher, err := prd.NewWatcher(prdPath); err == nil {
+		if err := watcher.Start(); err == nil {
+			changes = watcher.Events()
+		}
+		defer watcher.Stop()
+	}
+	ticker := time.NewTicker(opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		p, err := loadStatusPRD(prdPath)
+		switch {
+		case opts.JSON && err != nil:
+			fmt.Fprintf(os.Stderr, "Error: failed to load PRD %q: %v\n", opts.Name, err)
+		case opts.JSON:
+			data, err := json.Marshal(newStatusReport(opts.Name, p))
+			if err != nil {
+				return fmt.Errorf("failed to encode JSON: %w", err)
+			}
+			fmt.Println(string(data))
+		default:
+			fmt.Print(clearScreen)
+			if err != nil {
+				fmt.Printf("Error: failed to load PRD %q: %v\n", opts.Name, err)
+			} else if err := printStatus(opts, p); err != nil {
+				return err
+			}
+			fmt.Printf("\nRefreshing every %s (Ctrl+C to exit)\n", opts.Interval)
+		}
+
+		select {
+		case <-stop:
+			return nil
+		case <-ticker.C:
+		case <-changes:
+		}
+	}
+}
+
+// printStatus prints a loaded PRD's progress as text, or as a StatusReport
+// with opts.JSON.
+func printStatus(opts StatusOptions, p *prd.PRD) error {
+	if opts.JSON {
+		if err := printJSON(newStatusReport(opts.Name, p)); err != nil {
+			return err
+		}
+		if opts.Strict {
+			return p.Strict()
+		}
+		return nil
+	}
+
 	// Count completed stories
 	total := len(p.UserStories)
 	completed := 0
@@ -53,6 +261,9 @@ func RunStatus(opts StatusOptions) error {
 
 	// Print project name
 	fmt.Println(p.Project)
+	for _, warning := range p.Warnings() {
+		fmt.Printf("Warning: %s\n", warning)
+	}
 
 	// Print progress summary
 	if total == 0 {
@@ -67,7 +278,9 @@ func RunStatus(opts StatusOptions) error {
 		fmt.Println("\nIncomplete stories:")
 		for _, story := range incomplete {
 			status := ""
-			if story.InProgress {
+			if story.Skipped {
+				status = " (skipped)"
+			} else if story.InProgress {
 				status = " (in progress)"
 			}
 			fmt.Printf("  %s: %s%s\n", story.ID, story.Title, status)
@@ -76,21 +289,115 @@ func RunStatus(opts StatusOptions) error {
 		fmt.Println("\nAll stories complete!")
 	}
 
+	if opts.Strict {
+		return p.Strict()
+	}
 	return nil
 }
 
+// statusBarWidth is the width of the completion bar in `chief status --all`.
+const statusBarWidth = 20
+
+// printAllStatus prints every PRD's completion bar with the stories in
+// progress and the ones whose iterations have ended without them passing, or
+// a JSON array of StatusReport with opts.JSON. With opts.Strict it fails on
+// the first PRD with warnings, after printing them all.
+func printAllStatus(opts StatusOptions) error {
+	names := AvailablePRDs(opts.BaseDir)
+	reports := make([]StatusReport, 0, len(names))
+	var strictErr error
+	printed := 0
+	for _, name := range names {
+		prdPath := paths.PRDPath(opts.BaseDir, name)
+		p, err := loadStatusPRD(prdPath)
+		if err != nil {
+			// Skip PRDs that can't be loaded, like chief list
+			continue
+		}
+		if opts.Strict && strictErr == nil {
+			if err := p.Strict(); err != nil {
+				strictErr = fmt.Errorf("%s: %w", name, err)
+			}
+		}
+		if opts.JSON {
+			reports = append(reports, newStatusReport(name, p))
+			continue
+		}
+
+		if printed > 0 {
+			fmt.Println()
+		}
+		printed++
+		fmt.Printf("%s: %s\n", name, p.Project)
+		// The count leaves out skipped stories, as the percentage does
+		completed, counted, skipped := p.StoryCounts()
+		percent := p.CompletionPercentage()
+		fmt.Printf("  %s %3.0f%%  %d/%d stories", statusBar(percent, statusBarWidth), percent, completed, counted)
+		if skipped > 0 {
+			fmt.Printf(", %d skipped", skipped)
+		}
+		fmt.Println()
+
+		failed := failedAttempts(prdPath)
+		for _, story := range p.UserStories {
+			switch {
+			case story.Settled():
+			case story.InProgress:
+				fmt.Printf("  In progress: %s: %s\n", story.ID, story.Title)
+			case failed[story.ID] > 0:
+				fmt.Printf("  Failing: %s: %s (%d attempts)\n", story.ID, story.Title, failed[story.ID])
+			}
+		}
+	}
+
+	if opts.JSON {
+		if err := printJSON(reports); err != nil {
+			return err
+		}
+	} else if printed == 0 {
+		fmt.Println("No PRDs found. Run 'chief new' to create one.")
+	}
+	return strictErr
+}
+
+// failedAttempts counts, per story, the recorded iterations that ended
+// without the story passing.
+func failedAttempts(prdPath string) map[string]int {
+	timings, _ := prd.LoadTimings(prdPath)
+	failed := make(map[string]int)
+	for _, t := range timings {
+		if !t.Passed {
+			failed[t.StoryID]++
+		}
+	}
+	return failed
+}
+
+// statusBar draws a completion percentage as a bar width characters wide.
+func statusBar(percent float64, width int) string {
+	filled := int(percent / 100 * float64(width))
+	filled = max(0, min(filled, width))
+	return "[" + strings.Repeat("█", filled) + strings.Repeat("░", width-filled) + "]"
+}
+
 // ListOptions contains configuration for the list command.
 type ListOptions struct {
-	BaseDir string // Base directory for .chief/prds/ (default: current directory)
+	BaseDir  string // Base directory for .chief/prds/ (default: current directory)
+	JSON     bool   // Emit a JSON array of PRDInfo instead of human-readable text
+	Archived bool   // List archived PRDs instead of active ones
 }
 
-// PRDInfo holds summary info about a PRD for the list command.
+// PRDInfo holds summary info about a PRD for the list command. Its JSON form
+// is the schema for `chief list --json`.
 type PRDInfo struct {
-	Name       string
-	Title      string
-	Completed  int
-	Total      int
-	Percentage int
+	Name       string `json:"name"`       // PRD name
+	Title      string `json:"title"`      // Project name from the PRD
+	Path       string `json:"path"`       // Absolute path to prd.json
+	Completed  int    `json:"completed"`  // Stories with passes: true
+	Total      int    `json:"total"`      // Number of stories
+	Skipped    int    `json:"skipped"`    // Stories skipped without passing, left out of Percentage
+	Percentage int    `json:"percentage"` // Completion as a whole percentage, weighted by story weight as in the TUI
+	State      string `json:"state"`      // Loop state: "ready", "running", or "complete"
 }
 
 // RunList prints all PRDs with their progress.
@@ -107,17 +414,25 @@ func RunList(opts ListOptions) error {
 
 	// Find all PRDs
 	prdsDir := paths.PRDsDir(opts.BaseDir)
+	none := "No PRDs found. Run 'chief new' to create one."
+	if opts.Archived {
+		prdsDir = paths.ArchiveDir(opts.BaseDir)
+		none = "No archived PRDs."
+	}
 	entries, err := os.ReadDir(prdsDir)
 	if err != nil {
 		if os.IsNotExist(err) {
-			fmt.Println("No PRDs found. Run 'chief new' to create one.")
+			if opts.JSON {
+				return printJSON([]PRDInfo{})
+			}
+			fmt.Println(none)
 			return nil
 		}
 		return fmt.Errorf("failed to read PRDs directory: %w", err)
 	}
 
 	// Collect PRD info
-	var prds []PRDInfo
+	prds := []PRDInfo{}
 	for _, entry := range entries {
 		if !entry.IsDir() {
 			continue
@@ -127,7 +442,7 @@ func RunList(opts ListOptions) error {
 		prdPath := filepath.Join(prdsDir, name, "prd.json")
 
 		// Try to load the PRD
-		p, err := prd.LoadPRD(prdPath)
+		p, err := loadStatusPRD(prdPath)
 		if err != nil {
 			// Skip PRDs that can't be loaded (might be partially created)
 			continue
@@ -135,35 +450,51 @@ func RunList(opts ListOptions) error {
 
 		// Count completed stories
 		total := len(p.UserStories)
-		completed := 0
+		completed, _, skipped := p.StoryCounts()
+		state := LoopStateReady
 		for _, story := range p.UserStories {
-			if story.Passes {
-				completed++
+			if story.InProgress {
+				state = LoopStateRunning
 			}
 		}
+		if total > 0 && p.AllComplete() {
+			state = LoopStateComplete
+		}
 
 		percentage := 0
 		if total > 0 {
-			percentage = (completed * 100) / total
+			percentage = int(p.CompletionPercentage())
 		}
 
 		prds = append(prds, PRDInfo{
 			Name:       name,
 			Title:      p.Project,
+			Path:       prdPath,
 			Completed:  completed,
 			Total:      total,
+			Skipped:    skipped,
 			Percentage: percentage,
+			State:      state,
 		})
 	}
 
+	if opts.JSON {
+		return printJSON(prds)
+	}
+
 	if len(prds) == 0 {
-		fmt.Println("No PRDs found. Run 'chief new' to create one.")
+		fmt.Println(none)
 		return nil
 	}
 
 	// Print PRDs
+	// The count leaves out skipped stories, as the percentage does
 	for _, info := range prds {
-		fmt.Printf("%s: %s (%d/%d, %d%%)\n", info.Name, info.Title, info.Completed, info.Total, info.Percentage)
+		fmt.Printf("%s: %s (%d/%d, %d%%", info.Name, info.Title, info.Completed, info.Total-info.Skipped, info.Percentage)
+		if info.Skipped > 0 {
+			fmt.Printf(", %d skipped", info.Skipped)
+		}
+		fmt.Println(")")
 	}
 
 	return nil
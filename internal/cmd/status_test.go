@@ -1,10 +1,17 @@
 package cmd
 
 import (
+	"encoding/json"
+	"errors"
+	"io"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/minicodemonkey/chief/internal/loop"
 	"github.com/minicodemonkey/chief/internal/paths"
+	"github.com/minicodemonkey/chief/internal/prd"
 )
 
 func TestRunStatusWithValidPRD(t *testing.T) {
@@ -258,3 +265,294 @@ func TestRunStatusEmptyPRD(t *testing.T) {
 		t.Errorf("RunStatus() returned error: %v", err)
 	}
 }
+
+// captureStdout returns everything fn writes to stdout.
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Failed to create pipe: %v", err)
+	}
+	orig := os.Stdout
+	os.Stdout = w
+	fn()
+	w.Close()
+	os.Stdout = orig
+	out, _ := io.ReadAll(r)
+	return string(out)
+}
+
+func TestRunStatusJSON(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+	tmpDir := t.TempDir()
+
+	if err := os.MkdirAll(paths.PRDDir(tmpDir, "auth"), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	prdJSON := `{"project": "Auth", "userStories": [
+		{"id": "US-001", "title": "Login", "passes": true},
+		{"id": "US-002", "title": "Logout", "inProgress": true}
+	]}`
+	if err := os.WriteFile(paths.PRDPath(tmpDir, "auth"), []byte(prdJSON), 0644); err != nil {
+		t.Fatalf("Failed to create prd.json: %v", err)
+	}
+
+	var runErr error
+	out := captureStdout(t, func() {
+		runErr = RunStatus(StatusOptions{Name: "auth", BaseDir: tmpDir, JSON: true})
+	})
+	if runErr != nil {
+		t.Fatalf("RunStatus() returned error: %v", runErr)
+	}
+
+	var report StatusReport
+	if err := json.Unmarshal([]byte(out), &report); err != nil {
+		t.Fatalf("expected valid JSON, got %q: %v", out, err)
+	}
+	if report.Name != "auth" || report.Project != "Auth" {
+		t.Errorf("unexpected name/project: %+v", report)
+	}
+	if report.Total != 2 || report.Completed != 1 || report.InProgress != 1 || report.Complete {
+		t.Errorf("unexpected counts: %+v", report)
+	}
+	if len(report.Stories) != 2 || report.Stories[1].ID != "US-002" || !report.Stories[1].InProgress {
+		t.Errorf("unexpected stories: %+v", report.Stories)
+	}
+}
+
+func TestRunStatusWarnings(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+	tmpDir := t.TempDir()
+
+	if err := os.MkdirAll(paths.PRDDir(tmpDir, "auth"), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	prdJSON := `{"project": "Auth", "userStories": [
+		{"id": "US-001", "title": "Login", "steps": ["Form submits"]},
+		{"id": "US-002", "title": "Logout", "steps": []}
+	]}`
+	if err := os.WriteFile(paths.PRDPath(tmpDir, "auth"), []byte(prdJSON), 0644); err != nil {
+		t.Fatalf("Failed to create prd.json: %v", err)
+	}
+
+	var runErr error
+	out := captureStdout(t, func() {
+		runErr = RunStatus(StatusOptions{Name: "auth", BaseDir: tmpDir})
+	})
+	if runErr != nil {
+		t.Fatalf("RunStatus() returned error: %v", runErr)
+	}
+	if !strings.Contains(out, "Warning: US-002 has no acceptance criteria") {
+		t.Errorf("expected a warning for US-002, got %q", out)
+	}
+
+	out = captureStdout(t, func() {
+		runErr = RunStatus(StatusOptions{Name: "auth", BaseDir: tmpDir, JSON: true, Strict: true})
+	})
+	if !errors.Is(runErr, prd.ErrPRDWarnings) {
+		t.Errorf("RunStatus() with Strict = %v, want ErrPRDWarnings", runErr)
+	}
+	var report StatusReport
+	if err := json.Unmarshal([]byte(out), &report); err != nil || len(report.Warnings) != 1 {
+		t.Errorf("expected one warning in the JSON report, got %q: %v", out, err)
+	}
+}
+
+func TestRunListJSON(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+	tmpDir := t.TempDir()
+
+	// No PRDs yet: still valid JSON
+	out := captureStdout(t, func() {
+		if err := RunList(ListOptions{BaseDir: tmpDir, JSON: true}); err != nil {
+			t.Errorf("RunList() returned error: %v", err)
+		}
+	})
+	var infos []PRDInfo
+	if err := json.Unmarshal([]byte(out), &infos); err != nil || len(infos) != 0 {
+		t.Fatalf("expected empty JSON array, got %q (%v)", out, err)
+	}
+
+	for name, stories := range map[string]string{
+		"api":     `[{"id": "US-001", "passes": true}]`,
+		"auth":    `[{"id": "US-001", "passes": true}, {"id": "US-002", "inProgress": true}, {"id": "US-003", "skipped": true}]`,
+		"billing": `[{"id": "US-001"}]`,
+	} {
+		if err := os.MkdirAll(paths.PRDDir(tmpDir, name), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		prdJSON := `{"project": "` + name + `", "userStories": ` + stories + `}`
+		if err := os.WriteFile(paths.PRDPath(tmpDir, name), []byte(prdJSON), 0644); err != nil {
+			t.Fatalf("Failed to create prd.json: %v", err)
+		}
+	}
+
+	// billing keeps its in-progress story in state.json
+	if err := loop.SaveSnapshot(paths.PRDPath(tmpDir, "billing"), loop.Snapshot{State: loop.LoopStateRunning.String(), Story: "US-001"}); err != nil {
+		t.Fatal(err)
+	}
+
+	out = captureStdout(t, func() {
+		if err := RunList(ListOptions{BaseDir: tmpDir, JSON: true}); err != nil {
+			t.Errorf("RunList() returned error: %v", err)
+		}
+	})
+	if err := json.Unmarshal([]byte(out), &infos); err != nil {
+		t.Fatalf("expected valid JSON, got %q: %v", out, err)
+	}
+	if len(infos) != 3 {
+		t.Fatalf("expected 3 PRDs, got %d", len(infos))
+	}
+	if infos[0].Name != "api" || infos[0].State != LoopStateComplete || infos[0].Path != paths.PRDPath(tmpDir, "api") {
+		t.Errorf("unexpected api entry: %+v", infos[0])
+	}
+	if infos[1].Name != "auth" || infos[1].State != LoopStateRunning || infos[1].Completed != 1 || infos[1].Total != 3 || infos[1].Skipped != 1 || infos[1].Percentage != 50 {
+		t.Errorf("unexpected auth entry: %+v", infos[1])
+	}
+	if infos[2].Name != "billing" || infos[2].State != LoopStateRunning {
+		t.Errorf("unexpected billing entry: %+v", infos[2])
+	}
+
+	// The text count agrees with the percentage
+	out = captureStdout(t, func() {
+		if err := RunList(ListOptions{BaseDir: tmpDir}); err != nil {
+			t.Errorf("RunList() returned error: %v", err)
+		}
+	})
+	if want := "auth: auth (1/2, 50%, 1 skipped)\n"; !strings.Contains(out, want) {
+		t.Errorf("expected %q in output, got:\n%s", want, out)
+	}
+}
+
+func TestWatchStatus(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+	tmpDir := t.TempDir()
+
+	if err := os.MkdirAll(paths.PRDDir(tmpDir, "auth"), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	prdPath := paths.PRDPath(tmpDir, "auth")
+	if err := os.WriteFile(prdPath, []byte(`{"project": "Auth", "userStories": [
+		{"id": "US-001", "title": "Login", "passes": true},
+		{"id": "US-002", "title": "Logout"}
+	]}`), 0644); err != nil {
+		t.Fatalf("Failed to create prd.json: %v", err)
+	}
+
+	stop := make(chan struct{})
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		os.WriteFile(prdPath, []byte(`{"project": "Auth", "userStories": [
+			{"id": "US-001", "title": "Login", "passes": true},
+			{"id": "US-002", "title": "Logout", "passes": true}
+		]}`), 0644)
+		time.Sleep(100 * time.Millisecond)
+		close(stop)
+	}()
+
+	var watchErr error
+	out := captureStdout(t, func() {
+		watchErr = watchStatus(StatusOptions{Name: "auth", Interval: 20 * time.Millisecond}, prdPath, stop)
+	})
+	if watchErr != nil {
+		t.Fatalf("watchStatus() returned error: %v", watchErr)
+	}
+	if !strings.HasPrefix(out, clearScreen) {
+		t.Errorf("expected each render to clear the screen, got %q", out)
+	}
+	if !strings.Contains(out, "1/2 stories complete") || !strings.Contains(out, "2/2 stories complete") {
+		t.Errorf("expected the status before and after the change, got %q", out)
+	}
+
+	stop = make(chan struct{})
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		close(stop)
+	}()
+	out = captureStdout(t, func() {
+		watchErr = watchStatus(StatusOptions{Name: "auth", JSON: true, Interval: 20 * time.Millisecond}, prdPath, stop)
+	})
+	if watchErr != nil {
+		t.Fatalf("watchStatus() with JSON returned error: %v", watchErr)
+	}
+	if strings.Contains(out, clearScreen) || strings.Contains(out, "Refreshing") {
+		t.Errorf("expected only JSON with --json, got %q", out)
+	}
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		var report StatusReport
+		if err := json.Unmarshal([]byte(line), &report); err != nil || report.Completed != 2 {
+			t.Errorf("expected one report per line, got %q (%v)", line, err)
+		}
+	}
+}
+
+func TestRunStatusAll(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+	tmpDir := t.TempDir()
+
+	for name, prdJSON := range map[string]string{
+		"auth": `{"project": "Auth", "userStories": [
+			{"id": "US-001", "title": "Login", "passes": true},
+			{"id": "US-002", "title": "Logout", "inProgress": true},
+			{"id": "US-003", "title": "Reset password"},
+			{"id": "US-004", "title": "Two-factor"}
+		]}`,
+		"billing": `{"project": "Billing", "userStories": [
+			{"id": "US-001", "title": "Invoices", "passes": true},
+			{"id": "US-002", "title": "Refunds", "skipped": true}
+		]}`,
+	} {
+		if err := os.MkdirAll(paths.PRDDir(tmpDir, name), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(paths.PRDPath(tmpDir, name), []byte(prdJSON), 0644); err != nil {
+			t.Fatalf("Failed to create prd.json: %v", err)
+		}
+	}
+	authPath := paths.PRDPath(tmpDir, "auth")
+	for i := 0; i < 2; i++ {
+		if err := prd.AppendTiming(authPath, prd.StoryTime{StoryID: "US-003", Duration: time.Minute}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var runErr error
+	out := captureStdout(t, func() {
+		runErr = RunStatus(StatusOptions{BaseDir: tmpDir, All: true})
+	})
+	if runErr != nil {
+		t.Fatalf("RunStatus() returned error: %v", runErr)
+	}
+	for _, want := range []string{
+		"auth: Auth",
+		"[█████░░░░░░░░░░░░░░░]  25%  1/4 stories",
+		"In progress: US-002: Logout",
+		"Failing: US-003: Reset password (2 attempts)",
+		"billing: Billing",
+		"[████████████████████] 100%  1/1 stories, 1 skipped\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "US-004") {
+		t.Errorf("expected untouched pending stories to be left out, got:\n%s", out)
+	}
+
+	out = captureStdout(t, func() {
+		runErr = RunStatus(StatusOptions{BaseDir: tmpDir, All: true, JSON: true})
+	})
+	var reports []StatusReport
+	if err := json.Unmarshal([]byte(out), &reports); err != nil || len(reports) != 2 {
+		t.Errorf("expected two JSON reports, got %q: %v", out, err)
+	}
+
+	if err := RunStatus(StatusOptions{BaseDir: tmpDir, All: true, Name: "auth"}); err == nil {
+		t.Error("expected an error when --all is given a PRD name")
+	}
+}
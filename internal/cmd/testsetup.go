@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/minicodemonkey/chief/internal/config"
+	"github.com/minicodemonkey/chief/internal/git"
+	"github.com/minicodemonkey/chief/internal/paths"
+)
+
+// TestSetupOptions contains configuration for the test-setup command.
+type TestSetupOptions struct {
+	Name    string    // PRD whose config overrides apply (default: project config only)
+	BaseDir string    // Base directory for .chief/prds/ (default: current directory)
+	Stdout  io.Writer // Where progress and the setup command's stdout go (default: os.Stdout)
+	Stderr  io.Writer // Where the setup command's stderr goes (default: os.Stderr)
+}
+
+// RunTestSetup runs the configured worktree setup command in a throwaway
+// worktree of the default branch, streaming its output, and removes the
+// worktree afterwards whether or not setup succeeded.
+func RunTestSetup(opts TestSetupOptions) error {
+	if opts.BaseDir == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("failed to get current directory: %w", err)
+		}
+		opts.BaseDir = cwd
+	}
+	if opts.Stdout == nil {
+		opts.Stdout = os.Stdout
+	}
+	if opts.Stderr == nil {
+		opts.Stderr = os.Stderr
+	}
+
+	if !git.IsGitRepo(opts.BaseDir) {
+		return fmt.Errorf("%s is not a git repository", opts.BaseDir)
+	}
+
+	var cfg *config.Config
+	var err error
+	if opts.Name != "" {
+		if _, statErr := os.Stat(paths.PRDDir(opts.BaseDir, opts.Name)); os.IsNotExist(statErr) {
+			return fmt.Errorf("PRD %q not found", opts.Name)
+		}
+		cfg, err = config.LoadForPRD(opts.BaseDir, opts.Name)
+	} else {
+		cfg, err = config.Load(opts.BaseDir)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if cfg.Worktree.Setup == "" {
+		return fmt.Errorf("no worktree setup command configured; set worktree.setup in %s", paths.ConfigPath(opts.BaseDir))
+	}
+
+	// A typo shouldn't cost a worktree checkout to find
+	if err := cfg.Worktree.CheckSetup(); err != nil {
+		return err
+	}
+
+	// Check out what a PRD's worktree starts from
+	defaultBranch, err := git.BaseRef(opts.BaseDir, cfg.Git.BaseBranch)
+	if err != nil {
+		return fmt.Errorf("failed to determine base branch: %w", err)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "chief-test-setup-")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+	worktreePath := filepath.Join(tmpDir, "worktree")
+
+	if err := git.CreateDetachedWorktree(opts.BaseDir, worktreePath, defaultBranch); err != nil {
+		return err
+	}
+	defer func() {
+		if err := git.ForceRemoveWorktree(opts.BaseDir, worktreePath); err != nil {
+			fmt.Fprintf(opts.Stderr, "Warning: %v\n", err)
+		}
+	}()
+
+	fmt.Fprintf(opts.Stdout, "Created throwaway worktree of %s at %s\n", defaultBranch, worktreePath)
+	fmt.Fprintf(opts.Stdout, "Running: %s\n\n", cfg.Worktree.Setup)
+
+	start := time.Now()
+	c := exec.Command("sh", "-c", cfg.Worktree.Setup)
+	c.Dir = worktreePath
+	c.Stdout = opts.Stdout
+	c.Stderr = opts.Stderr
+	runErr := c.Run()
+	elapsed := time.Since(start).Round(time.Millisecond)
+
+	fmt.Fprintln(opts.Stdout)
+	if runErr != nil {
+		return fmt.Errorf("setup command failed after %s: %w", elapsed, runErr)
+	}
+	fmt.Fprintf(opts.Stdout, "✓ Setup command succeeded in %s\n", elapsed)
+	return nil
+}
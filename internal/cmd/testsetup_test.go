@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/minicodemonkey/chief/internal/config"
+	"github.com/minicodemonkey/chief/internal/git"
+	"github.com/minicodemonkey/chief/internal/paths"
+)
+
+func initTestSetupRepo(t *testing.T, setup string) string {
+	t.Helper()
+	baseDir := t.TempDir()
+	initGitTestRepo(t, baseDir)
+	cfg := config.Default()
+	cfg.Worktree.Setup = setup
+	if err := config.Save(baseDir, cfg); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	return baseDir
+}
+
+func TestRunTestSetup(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+
+	tests := []struct {
+		desc    string
+		setup   string
+		wantErr bool
+		wantOut string
+	}{
+		{"succeeds", "touch node_modules && echo installed", false, "installed"},
+		{"fails", "echo broken && exit 3", true, "broken"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			baseDir := initTestSetupRepo(t, tt.setup)
+			var stdout, stderr bytes.Buffer
+
+			err := RunTestSetup(TestSetupOptions{BaseDir: baseDir, Stdout: &stdout, Stderr: &stderr})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RunTestSetup() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !strings.Contains(stdout.String(), tt.wantOut) {
+				t.Errorf("expected setup output %q to stream through, got:\n%s", tt.wantOut, stdout.String())
+			}
+
+			// The throwaway worktree is gone even though setup left untracked files
+			worktrees, err := git.ListWorktrees(baseDir)
+			if err != nil {
+				t.Fatalf("ListWorktrees() error = %v", err)
+			}
+			if len(worktrees) != 1 {
+				t.Errorf("expected only the main worktree to remain, got %+v", worktrees)
+			}
+		})
+	}
+}
+
+func TestRunTestSetupWithoutCommand(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+
+	baseDir := initTestSetupRepo(t, "")
+	err := RunTestSetup(TestSetupOptions{BaseDir: baseDir, Stdout: &bytes.Buffer{}})
+	if err == nil || !strings.Contains(err.Error(), "no worktree setup command") {
+		t.Errorf("expected a missing setup command error, got %v", err)
+	}
+}
+
+func TestRunTestSetupSyntaxError(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+
+	baseDir := initTestSetupRepo(t, "npm install &&")
+	err := RunTestSetup(TestSetupOptions{BaseDir: baseDir, Stdout: &bytes.Buffer{}})
+	if err == nil || !strings.Contains(err.Error(), "syntax error") {
+		t.Errorf("expected a syntax error, got %v", err)
+	}
+}
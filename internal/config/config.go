@@ -1,28 +1,417 @@
 package config
 
 import (
+	"fmt"
+	"maps"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
 
+	"github.com/minicodemonkey/chief/internal/git"
 	"github.com/minicodemonkey/chief/internal/paths"
 	"gopkg.in/yaml.v3"
 )
 
 // Config holds project-level settings for Chief.
 type Config struct {
-	Worktree   WorktreeConfig   `yaml:"worktree"`
-	OnComplete OnCompleteConfig `yaml:"onComplete"`
+	Worktree      WorktreeConfig      `yaml:"worktree"`
+	OnComplete    OnCompleteConfig    `yaml:"onComplete"`
+	Phases        PhasesConfig        `yaml:"phases"`
+	Loop          LoopConfig          `yaml:"loop"`
+	Notifications NotificationsConfig `yaml:"notifications"`
+	Confetti      ConfettiConfig      `yaml:"confetti"`
+	Git           GitConfig           `yaml:"git"`
+	Pricing       PricingConfig       `yaml:"pricing"`
+	Theme         ThemeConfig         `yaml:"theme"`
+	Convert       ConvertConfig       `yaml:"convert"`
+	Diff          DiffConfig          `yaml:"diff"`
+	Claude        ClaudeConfig        `yaml:"claude"`
+	Agent         AgentConfig         `yaml:"agent"`
+	Hooks         HooksConfig         `yaml:"hooks"`
+	UI            UIConfig            `yaml:"ui"`
+	// Keybindings remaps TUI actions (start, pause, stop, diff, log, new,
+	// edit, help, sort, startAll, pauseAll, skip) to other keys, e.g. {pause: "z"}.
+	// Unlisted actions keep their default keys, as do ones bound to a key
+	// the TUI reserves for navigation (q, j, k, 1-9, esc, ...).
+	Keybindings map[string]string `yaml:"keybindings"`
 }
 
 // WorktreeConfig holds worktree-related settings.
 type WorktreeConfig struct {
 	Setup string `yaml:"setup"`
+	// Shallow speeds up worktree creation on large repos by checking files out
+	// in parallel. Worktrees share the repository's history, so there is no
+	// history to trim; the tradeoff is a brief spike in CPU and disk I/O.
+	Shallow bool `yaml:"shallow"`
+	// BaseDir is where PRD worktrees are created: an absolute path, one
+	// starting with "~/", or one relative to the project. Each PRD gets
+	// <baseDir>/<project>/<name>; empty keeps them under ~/.chief/projects/<project>/worktrees/.
+	BaseDir string `yaml:"baseDir"`
+	// SharedBranch, set in a PRD's own config.yaml, makes the PRD run in the
+	// worktree of another PRD working on that branch instead of getting its
+	// own, so related PRDs land on one branch. The PRDs take turns: chief
+	// won't run two loops in one worktree at once.
+	SharedBranch string `yaml:"sharedBranch"`
 }
 
 // OnCompleteConfig holds post-completion automation settings.
 type OnCompleteConfig struct {
-	Push     bool `yaml:"push"`
-	CreatePR bool `yaml:"createPR"`
+	Push                bool     `yaml:"push"`
+	CreatePR            bool     `yaml:"createPR"`
+	IntegrationStrategy string   `yaml:"integrationStrategy"` // How m integrates a branch: "merge", "rebase" or "squash"; empty means merge
+	Draft               bool     `yaml:"draft"`               // Open auto-created pull requests as drafts
+	Reviewers           []string `yaml:"reviewers"`           // Reviewers requested on auto-created pull requests
+	PRRequiresAllPass   bool     `yaml:"prRequiresAllPass"`   // Only open a pull request when every story passes, not when the agent finishes early
+}
+
+// Validate reports an integration strategy git.MergeBranch doesn't know.
+func (o OnCompleteConfig) Validate() error {
+	switch o.IntegrationStrategy {
+	case "", git.StrategyMerge, git.StrategyRebase, git.StrategySquash:
+		return nil
+	}
+	return fmt.Errorf("onComplete.integrationStrategy %q is not one of %s, %s or %s", o.IntegrationStrategy, git.StrategyMerge, git.StrategyRebase, git.StrategySquash)
+}
+
+// GitConfig holds git hosting settings.
+type GitConfig struct {
+	Provider      string `yaml:"provider"`      // Where pull requests are opened: "github" (default, via gh) or "gitlab" (via glab)
+	BranchPattern string `yaml:"branchPattern"` // Branch name for a PRD, with {prd} and optional {date} tokens; empty means "chief/{prd}"
+	Remote        string `yaml:"remote"`        // Remote branches are pushed to, e.g. "fork"; empty means "origin"
+	BaseBranch    string `yaml:"baseBranch"`    // Branch pull requests target, e.g. "develop"; empty means the remote's default branch
+
+	// SignCommits signs every commit made for a PRD, the agent's included,
+	// with the key git is configured to use (GPG or SSH, per gpg.format).
+	// Signing is checked before a branch or worktree is created, so a broken
+	// setup shows up there rather than as a rejected push.
+	SignCommits bool `yaml:"signCommits"`
+}
+
+// PhasesConfig holds settings for PRDs whose stories are grouped into phases.
+type PhasesConfig struct {
+	PauseBetween bool `yaml:"pauseBetween"` // Pause the loop for review when a phase completes
+}
+
+// Events that can pause the loop for attention, listed in LoopConfig.PauseOn.
+const (
+	PauseOnError         = "error"          // An iteration fails; pause instead of retrying
+	PauseOnRegression    = "regression"     // A story that was passing no longer passes
+	PauseOnBlocker       = "blocker"        // The agent reports it is blocked
+	PauseOnStoryComplete = "story-complete" // A story newly passes
+	PauseOnPhaseComplete = "phase-complete" // Every story in a phase passes
+	PauseOnOutOfScope    = "out-of-scope"   // An iteration changes files outside its worktree
+)
+
+// LoopConfig holds agent loop settings.
+type LoopConfig struct {
+	PauseOn      []string      `yaml:"pauseOn"`      // Events that pause the loop for attention (PauseOnError, PauseOnRegression, ...)
+	ReviewPrompt bool          `yaml:"reviewPrompt"` // Show the first iteration's prompt for approval before a loop starts
+	CheckInEvery time.Duration `yaml:"checkInEvery"` // Pause for a progress check-in after running this long, e.g. "30m" (0 = never)
+
+	// RetryAttempts is how many times an iteration is run when Claude
+	// crashes or stalls, the first run included (0 = 4, 1 = never retry).
+	// RetryBackoff is the wait before the first retry, doubled for each after
+	// it, e.g. "10s" (0 = waits of 0s, 5s, then 15s).
+	RetryAttempts int           `yaml:"retryAttempts"`
+	RetryBackoff  time.Duration `yaml:"retryBackoff"`
+
+	// MaxConcurrent limits how many PRD loops run at once; starting another
+	// queues it until one finishes (0 = unlimited).
+	MaxConcurrent int `yaml:"maxConcurrent"`
+
+	CheckWriteScope  bool `yaml:"checkWriteScope"`  // After each iteration, check that no other worktree of the repo changed
+	RevertOutOfScope bool `yaml:"revertOutOfScope"` // Restore tracked files the agent changed outside its worktree
+
+	// InProgressInState keeps the story being worked on in state.json and in
+	// memory instead of writing inProgress flags to prd.json, so a versioned
+	// prd.json only changes when stories pass.
+	InProgressInState bool `yaml:"inProgressInState"`
+
+	// EventSocket is a Unix socket path on which chief streams every loop
+	// event as a line of JSON, for tools such as status bar apps. A leading
+	// "~/" is expanded and a relative path is taken from the project. Empty
+	// disables it.
+	EventSocket string `yaml:"eventSocket"`
+
+	// CommitOnStop commits whatever the agent left uncommitted when a running
+	// loop is stopped, as "chief: WIP on <story>", so the next run or a clean
+	// can't lose it. Loops without a worktree are left alone, since the
+	// project root holds the user's own edits too.
+	CommitOnStop bool `yaml:"commitOnStop"`
+
+	// IterationsPerStory and IterationBuffer set the max iterations a loop
+	// gets when none is given: each remaining story's allowance times
+	// IterationsPerStory, plus IterationBuffer for retries and fix-ups
+	// (0 = 1 and 5).
+	IterationsPerStory int `yaml:"iterationsPerStory"`
+	IterationBuffer    int `yaml:"iterationBuffer"`
+}
+
+// Defaults for the dynamic max iterations formula.
+const (
+	DefaultIterationsPerStory = 1
+	DefaultIterationBuffer    = 5
+)
+
+// iterationFactors returns IterationsPerStory and IterationBuffer with their
+// defaults applied.
+func (c LoopConfig) iterationFactors() (perStory, buffer int) {
+	perStory, buffer = c.IterationsPerStory, c.IterationBuffer
+	if perStory <= 0 {
+		perStory = DefaultIterationsPerStory
+	}
+	if buffer <= 0 {
+		buffer = DefaultIterationBuffer
+	}
+	return perStory, buffer
+}
+
+// IterationBudget returns the max iterations for remaining work whose stories
+// are allowed allowance iterations in all, as from PRD.IterationAllowance.
+func (c LoopConfig) IterationBudget(allowance int) int {
+	perStory, buffer := c.iterationFactors()
+	return allowance*perStory + buffer
+}
+
+// IterationFormula shows how IterationBudget arrived at its value, e.g.
+// "4×2 + 5 = 13", so users can see what to tune.
+func (c LoopConfig) IterationFormula(allowance int) string {
+	perStory, buffer := c.iterationFactors()
+	return fmt.Sprintf("%d×%d + %d = %d", allowance, perStory, buffer, allowance*perStory+buffer)
+}
+
+// EventSocketPath returns where the event socket should be opened for the
+// project in baseDir, or empty when it's disabled.
+func (c LoopConfig) EventSocketPath(baseDir string) string {
+	if c.EventSocket == "" {
+		return ""
+	}
+	path := paths.ExpandHome(c.EventSocket)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(baseDir, path)
+	}
+	return path
+}
+
+// PausesOn reports whether the loop should pause for attention on event.
+// Phases.PauseBetween is honoured as an older spelling of PauseOnPhaseComplete.
+func (c *Config) PausesOn(event string) bool {
+	if event == PauseOnPhaseComplete && c.Phases.PauseBetween {
+		return true
+	}
+	for _, e := range c.Loop.PauseOn {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// PauseEvents returns every event the loop should pause on, including
+// PauseOnPhaseComplete when Phases.PauseBetween is set.
+func (c *Config) PauseEvents() []string {
+	events := append([]string(nil), c.Loop.PauseOn...)
+	if c.Phases.PauseBetween {
+		events = append(events, PauseOnPhaseComplete)
+	}
+	return events
+}
+
+// Notification scopes control which PRD completions trigger the completion callback.
+const (
+	NotifyEach        = "each"         // Notify whenever any PRD completes (default)
+	NotifyActiveOnly  = "active-only"  // Notify only when the PRD being viewed completes
+	NotifyAllComplete = "all-complete" // Notify once, when the last running PRD completes
+)
+
+// NotificationsConfig holds completion notification settings.
+type NotificationsConfig struct {
+	Scope      string `yaml:"scope"`      // One of NotifyEach, NotifyActiveOnly, NotifyAllComplete; empty means NotifyEach
+	WebhookURL string `yaml:"webhookURL"` // POST a JSON summary here when a PRD completes or fails (e.g. a Slack incoming webhook)
+
+	Sound          bool     `yaml:"sound"`            // Play a sound when a PRD completes or a loop fails
+	SoundFile      string   `yaml:"soundFile"`        // Completion sound (wav, or mp3/aiff on macOS, ogg/flac on Linux); empty means the system sound
+	ErrorSoundFile string   `yaml:"errorSoundFile"`   // Failure sound; empty means the system error sound
+	Volume         *float64 `yaml:"volume,omitempty"` // 0.0–1.0, where 0 is silent; unset means full volume
+}
+
+// Validate reports a notification scope chief doesn't support.
+func (n NotificationsConfig) Validate() error {
+	switch n.Scope {
+	case "", NotifyEach, NotifyActiveOnly, NotifyAllComplete:
+		return nil
+	}
+	return fmt.Errorf("notifications.scope %q is not one of %s, %s or %s", n.Scope, NotifyEach, NotifyActiveOnly, NotifyAllComplete)
+}
+
+// ConfettiConfig customizes the confetti shown on the completion screen.
+type ConfettiConfig struct {
+	Disabled bool     `yaml:"disabled"` // Skip the confetti animation
+	Chars    []string `yaml:"chars"`    // Single-cell particle glyphs; empty means the built-in set
+	Colors   []string `yaml:"colors"`   // Hex ("#FF6AC1") or ANSI ("205") colors; empty means the built-in palette
+}
+
+// ThemeConfig holds the TUI's color scheme.
+type ThemeConfig struct {
+	Preset string            `yaml:"preset"` // Built-in palette: "dark" (default), "light" or "highContrast"
+	Colors map[string]string `yaml:"colors"` // Per-color overrides as "#RRGGBB", keyed by name (primary, success, warning, error, ...)
+}
+
+// ConvertConfig holds settings for converting prd.md to prd.json.
+type ConvertConfig struct {
+	Retries    int           `yaml:"retries"`    // Extra attempts when Claude is rate limited, overloaded or cut off (0 = none, at most 5)
+	RetryDelay time.Duration `yaml:"retryDelay"` // Wait before the first retry, doubled for each after it, e.g. "5s" (0 = 2s)
+}
+
+// ClaudeConfig holds how the Claude CLI is run.
+type ClaudeConfig struct {
+	Model      string `yaml:"model"`      // Passed as --model, e.g. "sonnet" (empty = Claude's default)
+	BinaryPath string `yaml:"binaryPath"` // The claude executable to run (empty = "claude" on PATH)
+}
+
+// Binary returns the claude executable to run.
+func (c ClaudeConfig) Binary() string {
+	if c.BinaryPath != "" {
+		return c.BinaryPath
+	}
+	return "claude"
+}
+
+// Args returns args with --model prepended when a model is configured.
+func (c ClaudeConfig) Args(args ...string) []string {
+	if c.Model == "" {
+		return args
+	}
+	return append([]string{"--model", c.Model}, args...)
+}
+
+// CheckSetup syntax-checks the setup command with sh -n, so a typo is caught
+// before the command first runs in a new worktree. It doesn't run anything.
+func (w WorktreeConfig) CheckSetup() error {
+	if w.Setup == "" {
+		return nil
+	}
+	out, err := exec.Command("sh", "-n", "-c", w.Setup).CombinedOutput()
+	if err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return fmt.Errorf("worktree setup command has a syntax error: %s", msg)
+		}
+		return fmt.Errorf("worktree setup command has a syntax error: %w", err)
+	}
+	return nil
+}
+
+// CheckBinary returns an error explaining how to fix it when the claude
+// executable can't be found.
+func (c ClaudeConfig) CheckBinary() error {
+	if _, err := exec.LookPath(c.Binary()); err != nil {
+		if c.BinaryPath != "" {
+			return fmt.Errorf("claude.binaryPath %q in chief's config is not an executable; fix the path or remove it to use claude from PATH", c.BinaryPath)
+		}
+		return fmt.Errorf("claude not found on PATH; install Claude Code or set claude.binaryPath in chief's config")
+	}
+	return nil
+}
+
+// Agent backends for AgentConfig.Backend.
+const (
+	AgentBackendClaude  = "claude"  // The claude CLI, configured by ClaudeConfig
+	AgentBackendCommand = "command" // Any CLI agent, run through AgentConfig.Command
+)
+
+// AgentConfig selects the CLI agent the loop runs and PRDs are converted with.
+type AgentConfig struct {
+	Backend string `yaml:"backend"` // AgentBackendClaude (default) or AgentBackendCommand
+	// Command is run through sh -c by the command backend, with the prompt on
+	// stdin, e.g. "aider --yes-always --message-file /dev/stdin". Each line it
+	// prints is shown as the agent's text, and the <ralph-status>,
+	// <chief-blocked> and <chief-complete/> markers the prompt asks for are
+	// picked up from it.
+	Command string `yaml:"command"`
+}
+
+// Validate reports an agent backend chief doesn't know or can't run.
+func (a AgentConfig) Validate() error {
+	switch a.Backend {
+	case "", AgentBackendClaude:
+		return nil
+	case AgentBackendCommand:
+		if strings.TrimSpace(a.Command) == "" {
+			return fmt.Errorf("agent.backend %q needs agent.command", AgentBackendCommand)
+		}
+		return nil
+	}
+	return fmt.Errorf("agent.backend %q is not one of %s or %s", a.Backend, AgentBackendClaude, AgentBackendCommand)
+}
+
+// HooksConfig holds shell commands run in a PRD's working directory as its
+// loop makes progress. They see the PRD path in CHIEF_PRD and the stories
+// they run for in CHIEF_STORIES.
+type HooksConfig struct {
+	PostStory       string `yaml:"postStory"`       // Run after an iteration completes one or more stories, e.g. "go test ./..."
+	PostComplete    string `yaml:"postComplete"`    // Run once every story passes, e.g. a deploy script
+	FailOnPostStory bool   `yaml:"failOnPostStory"` // Stop the loop with an error when PostStory exits non-zero
+}
+
+// Dashboard layouts for UIConfig.ForceLayout.
+const (
+	LayoutAuto    = "auto"    // Side by side on wide terminals, stacked on narrow ones
+	LayoutWide    = "wide"    // Always side by side
+	LayoutStacked = "stacked" // Always stacked
+)
+
+// Bounds for UIConfig.StoriesPanelPct, which keep both panels usable.
+const (
+	MinStoriesPanelPct = 20
+	MaxStoriesPanelPct = 60
+)
+
+// UIConfig holds the TUI's dashboard layout preferences.
+type UIConfig struct {
+	StoriesPanelPct int    `yaml:"storiesPanelPct"` // Share of the width the stories panel takes side by side (0 = 35)
+	ForceLayout     string `yaml:"forceLayout"`     // "auto" (default), "wide" or "stacked"
+}
+
+// Validate reports a stories panel share or layout chief doesn't support.
+func (u UIConfig) Validate() error {
+	if u.StoriesPanelPct != 0 && (u.StoriesPanelPct < MinStoriesPanelPct || u.StoriesPanelPct > MaxStoriesPanelPct) {
+		return fmt.Errorf("ui.storiesPanelPct %d is outside %d-%d", u.StoriesPanelPct, MinStoriesPanelPct, MaxStoriesPanelPct)
+	}
+	switch u.ForceLayout {
+	case "", LayoutAuto, LayoutWide, LayoutStacked:
+		return nil
+	}
+	return fmt.Errorf("ui.forceLayout %q is not one of %s, %s or %s", u.ForceLayout, LayoutAuto, LayoutWide, LayoutStacked)
+}
+
+// DiffConfig holds settings for the TUI's diff view.
+type DiffConfig struct {
+	ContextLines int `yaml:"contextLines"` // Unchanged lines shown around each change (0 = git's default of 3, -1 = none)
+}
+
+// PricingConfig holds per-token prices used to estimate what a run cost.
+type PricingConfig struct {
+	InputPerMTok     float64 `yaml:"inputPerMTok"`     // Price per million input tokens, e.g. 3.00
+	CacheReadPerMTok float64 `yaml:"cacheReadPerMTok"` // Price per million input tokens read from the prompt cache (0 = a tenth of inputPerMTok)
+	OutputPerMTok    float64 `yaml:"outputPerMTok"`    // Price per million output tokens, e.g. 15.00
+}
+
+// Cost estimates the price of the given token counts, where cacheReadTokens
+// is the part of inputTokens read from the prompt cache. ok is false when no
+// prices are configured.
+func (p PricingConfig) Cost(inputTokens, cacheReadTokens, outputTokens int) (cost float64, ok bool) {
+	if p.InputPerMTok == 0 && p.OutputPerMTok == 0 {
+		return 0, false
+	}
+	cacheRead := p.CacheReadPerMTok
+	if cacheRead == 0 {
+		cacheRead = p.InputPerMTok / 10
+	}
+	input := float64(inputTokens-cacheReadTokens)*p.InputPerMTok + float64(cacheReadTokens)*cacheRead
+	return (input + float64(outputTokens)*p.OutputPerMTok) / 1e6, true
 }
 
 // Default returns a Config with zero-value defaults.
@@ -37,7 +426,8 @@ func Exists(baseDir string) bool {
 }
 
 // Load reads the config from ~/.chief/projects/<project>/config.yaml.
-// Returns Default() when the file doesn't exist (no error).
+// Returns Default() when the file doesn't exist (no error), and an error for
+// an integration strategy chief can't use rather than merging with it later.
 func Load(baseDir string) (*Config, error) {
 	path := paths.ConfigPath(baseDir)
 
@@ -53,6 +443,12 @@ func Load(baseDir string) (*Config, error) {
 	if err := yaml.Unmarshal(data, cfg); err != nil {
 		return nil, err
 	}
+	if err := cfg.OnComplete.Validate(); err != nil {
+		return nil, err
+	}
+	if err := cfg.Notifications.Validate(); err != nil {
+		return nil, err
+	}
 
 	return cfg, nil
 }
@@ -73,3 +469,117 @@ func Save(baseDir string, cfg *Config) error {
 
 	return os.WriteFile(path, data, 0o644)
 }
+
+// PRDConfigExists checks if a PRD has its own config overrides.
+func PRDConfigExists(baseDir, prdName string) bool {
+	_, err := os.Stat(paths.PRDConfigPath(baseDir, prdName))
+	return err == nil
+}
+
+// LoadForPRD reads the project config with the PRD's overrides from
+// ~/.chief/projects/<project>/prds/<name>/config.yaml laid over it.
+func LoadForPRD(baseDir, prdName string) (*Config, error) {
+	cfg, err := Load(baseDir)
+	if err != nil {
+		return nil, err
+	}
+	return cfg.ForPRD(baseDir, prdName)
+}
+
+// ForPRD returns a copy of the config with a PRD's overrides laid over it.
+// Settings present in the PRD's config.yaml win; absent ones are inherited.
+// Without overrides the copy matches c.
+func (c *Config) ForPRD(baseDir, prdName string) (*Config, error) {
+	cfg := *c
+	// yaml decodes into existing maps, so give the copy its own
+	cfg.Keybindings = maps.Clone(c.Keybindings)
+	cfg.Theme.Colors = maps.Clone(c.Theme.Colors)
+	data, err := os.ReadFile(paths.PRDConfigPath(baseDir, prdName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &cfg, nil
+		}
+		return nil, err
+	}
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, err
+	}
+	if err := cfg.OnComplete.Validate(); err != nil {
+		return nil, fmt.Errorf("PRD %s: %w", prdName, err)
+	}
+	if err := cfg.Notifications.Validate(); err != nil {
+		return nil, fmt.Errorf("PRD %s: %w", prdName, err)
+	}
+	return &cfg, nil
+}
+
+// SaveForPRD writes cfg as a PRD's overrides. Only settings that differ from
+// the project config, or that the PRD already overrode, are written, so the
+// rest keep following the project config.
+func SaveForPRD(baseDir, prdName string, cfg *Config) error {
+	base, err := Load(baseDir)
+	if err != nil {
+		return err
+	}
+
+	path := paths.PRDConfigPath(baseDir, prdName)
+	existing := map[string]interface{}{}
+	if data, err := os.ReadFile(path); err == nil {
+		if err := yaml.Unmarshal(data, &existing); err != nil {
+			return err
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	full, err := toMap(cfg)
+	if err != nil {
+		return err
+	}
+	baseMap, err := toMap(base)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(overrides(full, baseMap, existing))
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// toMap converts a config to the generic form it takes in YAML.
+func toMap(cfg *Config) (map[string]interface{}, error) {
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	m := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// overrides returns the settings in full that differ from base or are already
+// present in existing, keeping the nesting of sections.
+func overrides(full, base, existing map[string]interface{}) map[string]interface{} {
+	out := map[string]interface{}{}
+	for key, value := range full {
+		if section, ok := value.(map[string]interface{}); ok {
+			baseSection, _ := base[key].(map[string]interface{})
+			existingSection, _ := existing[key].(map[string]interface{})
+			if o := overrides(section, baseSection, existingSection); len(o) > 0 {
+				out[key] = o
+			}
+			continue
+		}
+		if _, present := existing[key]; present || !reflect.DeepEqual(value, base[key]) {
+			out[key] = value
+		}
+	}
+	return out
+}
@@ -1,7 +1,12 @@
 package config
 
 import (
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/minicodemonkey/chief/internal/paths"
 )
@@ -48,6 +53,18 @@ func TestSaveAndLoad(t *testing.T) {
 			Push:     true,
 			CreatePR: true,
 		},
+		Notifications: NotificationsConfig{
+			Scope:      NotifyAllComplete,
+			WebhookURL: "https://hooks.slack.com/services/T/B/X",
+		},
+		Confetti: ConfettiConfig{
+			Disabled: true,
+			Chars:    []string{"*", "+"},
+		},
+		Loop: LoopConfig{
+			PauseOn:      []string{PauseOnError, PauseOnRegression},
+			CheckInEvery: 30 * time.Minute,
+		},
 	}
 
 	if err := Save(dir, cfg); err != nil {
@@ -68,6 +85,190 @@ func TestSaveAndLoad(t *testing.T) {
 	if !loaded.OnComplete.CreatePR {
 		t.Error("expected CreatePR to be true")
 	}
+	if loaded.Notifications.Scope != NotifyAllComplete {
+		t.Errorf("expected notifications scope %q, got %q", NotifyAllComplete, loaded.Notifications.Scope)
+	}
+	if loaded.Notifications.WebhookURL != "https://hooks.slack.com/services/T/B/X" {
+		t.Errorf("expected webhook URL to round-trip, got %q", loaded.Notifications.WebhookURL)
+	}
+	if !loaded.Confetti.Disabled || len(loaded.Confetti.Chars) != 2 {
+		t.Errorf("expected confetti config to round-trip, got %+v", loaded.Confetti)
+	}
+	if len(loaded.Loop.PauseOn) != 2 || loaded.Loop.PauseOn[1] != PauseOnRegression {
+		t.Errorf("expected pauseOn to round-trip, got %v", loaded.Loop.PauseOn)
+	}
+	if loaded.Loop.CheckInEvery != 30*time.Minute {
+		t.Errorf("expected checkInEvery to round-trip, got %v", loaded.Loop.CheckInEvery)
+	}
+}
+
+func TestLoadCheckInEvery(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+	dir := t.TempDir()
+
+	path := paths.ConfigPath(dir)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("loop:\n  checkInEvery: 45m\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.Loop.CheckInEvery != 45*time.Minute {
+		t.Errorf("expected 45m check-in, got %v", cfg.Loop.CheckInEvery)
+	}
+}
+
+func TestLoadRejectsUnknownIntegrationStrategy(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+	dir := t.TempDir()
+
+	path := paths.ConfigPath(dir)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("onComplete:\n  integrationStrategy: squash\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(dir); err != nil {
+		t.Fatalf("Load failed for squash: %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte("onComplete:\n  integrationStrategy: sqaush\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(dir); err == nil || !strings.Contains(err.Error(), "integrationStrategy") {
+		t.Errorf("expected an error naming integrationStrategy, got %v", err)
+	}
+}
+
+func TestPausesOn(t *testing.T) {
+	cfg := &Config{Loop: LoopConfig{PauseOn: []string{PauseOnBlocker}}}
+	if !cfg.PausesOn(PauseOnBlocker) || cfg.PausesOn(PauseOnPhaseComplete) {
+		t.Errorf("unexpected pause policy for %v", cfg.Loop.PauseOn)
+	}
+
+	// The older phases.pauseBetween setting still pauses at phase boundaries
+	cfg.Phases.PauseBetween = true
+	if !cfg.PausesOn(PauseOnPhaseComplete) {
+		t.Error("expected pauseBetween to imply phase-complete")
+	}
+	if got := cfg.PauseEvents(); len(got) != 2 || got[1] != PauseOnPhaseComplete {
+		t.Errorf("expected pause events to include phase-complete, got %v", got)
+	}
+}
+
+func TestEventSocketPath(t *testing.T) {
+	if got := (LoopConfig{}).EventSocketPath("/project"); got != "" {
+		t.Errorf("expected no socket by default, got %q", got)
+	}
+	if got := (LoopConfig{EventSocket: ".chief/events.sock"}).EventSocketPath("/project"); got != "/project/.chief/events.sock" {
+		t.Errorf("expected a relative path to resolve from the project, got %q", got)
+	}
+	if got := (LoopConfig{EventSocket: "/tmp/chief.sock"}).EventSocketPath("/project"); got != "/tmp/chief.sock" {
+		t.Errorf("expected an absolute path unchanged, got %q", got)
+	}
+}
+
+func TestIterationBudget(t *testing.T) {
+	if got := (LoopConfig{}).IterationBudget(4); got != 9 {
+		t.Errorf("expected the default remaining + 5, got %d", got)
+	}
+	if got := (LoopConfig{}).IterationBudget(0); got != 5 {
+		t.Errorf("expected the buffer when nothing remains, got %d", got)
+	}
+	loop := LoopConfig{IterationsPerStory: 3, IterationBuffer: 10}
+	if got := loop.IterationBudget(4); got != 22 {
+		t.Errorf("expected 4*3 + 10, got %d", got)
+	}
+	if got := loop.IterationFormula(4); got != "4×3 + 10 = 22" {
+		t.Errorf("IterationFormula() = %q", got)
+	}
+}
+
+func TestWorktreeCheckSetup(t *testing.T) {
+	for _, setup := range []string{"", "npm install", "make deps && go mod download"} {
+		if err := (WorktreeConfig{Setup: setup}).CheckSetup(); err != nil {
+			t.Errorf("CheckSetup(%q) error = %v", setup, err)
+		}
+	}
+	for _, setup := range []string{"npm install &&", "if true; then make", `echo "unterminated`} {
+		if err := (WorktreeConfig{Setup: setup}).CheckSetup(); err == nil {
+			t.Errorf("CheckSetup(%q) expected a syntax error", setup)
+		}
+	}
+}
+
+func TestLoadRejectsUnknownNotificationScope(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+	dir := t.TempDir()
+
+	path := paths.ConfigPath(dir)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("notifications:\n  scope: all-complete\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(dir); err != nil {
+		t.Fatalf("Load failed for all-complete: %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte("notifications:\n  scope: active_only\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(dir); err == nil || !strings.Contains(err.Error(), "notifications.scope") {
+		t.Errorf("expected an error naming notifications.scope, got %v", err)
+	}
+}
+
+func TestUIConfigValidate(t *testing.T) {
+	valid := []UIConfig{{}, {StoriesPanelPct: MinStoriesPanelPct}, {StoriesPanelPct: MaxStoriesPanelPct, ForceLayout: LayoutStacked}, {ForceLayout: LayoutWide}}
+	for _, ui := range valid {
+		if err := ui.Validate(); err != nil {
+			t.Errorf("Validate(%+v) error = %v", ui, err)
+		}
+	}
+	invalid := []UIConfig{{StoriesPanelPct: 5}, {StoriesPanelPct: 90}, {ForceLayout: "sideways"}}
+	for _, ui := range invalid {
+		if err := ui.Validate(); err == nil {
+			t.Errorf("Validate(%+v) expected an error", ui)
+		}
+	}
+}
+
+func TestClaudeConfig(t *testing.T) {
+	var c ClaudeConfig
+	if c.Binary() != "claude" {
+		t.Errorf("expected claude by default, got %q", c.Binary())
+	}
+	if got := c.Args("-p", "hi"); len(got) != 2 {
+		t.Errorf("expected args unchanged without a model, got %v", got)
+	}
+
+	c = ClaudeConfig{Model: "sonnet", BinaryPath: filepath.Join(t.TempDir(), "missing")}
+	if got := c.Args("-p"); len(got) != 3 || got[0] != "--model" || got[1] != "sonnet" {
+		t.Errorf("expected --model sonnet first, got %v", got)
+	}
+	if err := c.CheckBinary(); err == nil {
+		t.Error("expected a missing binary path to fail the check")
+	}
+
+	binary := filepath.Join(t.TempDir(), "claude")
+	if err := os.WriteFile(binary, []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	c.BinaryPath = binary
+	if err := c.CheckBinary(); err != nil {
+		t.Errorf("CheckBinary() error = %v", err)
+	}
 }
 
 func TestExists(t *testing.T) {
@@ -91,3 +292,134 @@ func TestExists(t *testing.T) {
 		t.Error("expected Exists to return true for existing config")
 	}
 }
+
+func TestLoadForPRD(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+	dir := t.TempDir()
+
+	project := &Config{
+		Worktree:   WorktreeConfig{Setup: "npm install"},
+		OnComplete: OnCompleteConfig{Push: true, CreatePR: true},
+	}
+	if err := Save(dir, project); err != nil {
+		t.Fatal(err)
+	}
+
+	// Without overrides the PRD gets the project config
+	cfg, err := LoadForPRD(dir, "scratch")
+	if err != nil {
+		t.Fatalf("LoadForPRD failed: %v", err)
+	}
+	if !cfg.OnComplete.Push || cfg.Worktree.Setup != "npm install" {
+		t.Errorf("expected the project config, got %+v", cfg)
+	}
+
+	path := paths.PRDConfigPath(dir, "scratch")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("onComplete:\n  push: false\n  createPR: false\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err = LoadForPRD(dir, "scratch")
+	if err != nil {
+		t.Fatalf("LoadForPRD failed: %v", err)
+	}
+	if cfg.OnComplete.Push || cfg.OnComplete.CreatePR {
+		t.Errorf("expected the PRD to turn off push and PRs, got %+v", cfg.OnComplete)
+	}
+	if cfg.Worktree.Setup != "npm install" {
+		t.Errorf("expected setup to be inherited, got %q", cfg.Worktree.Setup)
+	}
+	if !project.OnComplete.Push {
+		t.Error("expected the project config to be left alone")
+	}
+}
+
+func TestForPRDLeavesBaseMapsAlone(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+	dir := t.TempDir()
+
+	base := &Config{
+		Keybindings: map[string]string{"stop": "y"},
+		Theme:       ThemeConfig{Colors: map[string]string{"primary": "#111111"}},
+	}
+	path := paths.PRDConfigPath(dir, "auth")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("keybindings:\n  pause: z\ntheme:\n  colors:\n    primary: \"#222222\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := base.ForPRD(dir, "auth")
+	if err != nil {
+		t.Fatalf("ForPRD failed: %v", err)
+	}
+	if cfg.Keybindings["pause"] != "z" || cfg.Keybindings["stop"] != "y" || cfg.Theme.Colors["primary"] != "#222222" {
+		t.Errorf("expected the PRD's overrides over the base, got %v and %v", cfg.Keybindings, cfg.Theme.Colors)
+	}
+	if len(base.Keybindings) != 1 || base.Keybindings["stop"] != "y" {
+		t.Errorf("expected the base keybindings unchanged, got %v", base.Keybindings)
+	}
+	if base.Theme.Colors["primary"] != "#111111" {
+		t.Errorf("expected the base colors unchanged, got %v", base.Theme.Colors)
+	}
+}
+
+func TestSaveForPRD(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+	dir := t.TempDir()
+
+	if err := Save(dir, &Config{Worktree: WorktreeConfig{Setup: "npm install"}}); err != nil {
+		t.Fatal(err)
+	}
+	path := paths.PRDConfigPath(dir, "auth")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	// An override that happens to match the project config stays an override
+	if err := os.WriteFile(path, []byte("worktree:\n  setup: npm install\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadForPRD(dir, "auth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.OnComplete.CreatePR = true
+	if err := SaveForPRD(dir, "auth", cfg); err != nil {
+		t.Fatalf("SaveForPRD failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "onComplete:\n    createPR: true\nworktree:\n    setup: npm install\n"
+	if string(data) != want {
+		t.Errorf("expected only overrides to be written, got:\n%s", data)
+	}
+}
+
+func TestPricingCost(t *testing.T) {
+	if _, ok := (PricingConfig{}).Cost(1000, 0, 1000); ok {
+		t.Error("expected no estimate without prices")
+	}
+	p := PricingConfig{InputPerMTok: 3, OutputPerMTok: 15}
+	if cost, ok := p.Cost(2_000_000, 0, 100_000); !ok || cost != 7.5 {
+		t.Errorf("Cost() = %v, %v; want 7.5, true", cost, ok)
+	}
+	// Cache reads default to a tenth of the input price
+	if cost, _ := p.Cost(2_000_000, 1_000_000, 0); math.Abs(cost-3.3) > 1e-9 {
+		t.Errorf("Cost() with cache reads = %v, want 3.3", cost)
+	}
+	p.CacheReadPerMTok = 1
+	if cost, _ := p.Cost(2_000_000, 1_000_000, 0); cost != 4 {
+		t.Errorf("Cost() with a cache read price = %v, want 4", cost)
+	}
+}
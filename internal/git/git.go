@@ -2,14 +2,86 @@
 package git
 
 import (
+	"errors"
+	"fmt"
+	"os"
 	"os/exec"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 )
 
 var ticketRe = regexp.MustCompile(`([A-Z]+-\d+)`)
 
+// DefaultBranchPattern is the branch name pattern used when none is configured.
+const DefaultBranchPattern = "chief/{prd}"
+
+// BranchName expands a branch name pattern for a PRD. {prd} becomes the PRD
+// name and {date} the date as YYYY-MM-DD. An empty pattern means
+// DefaultBranchPattern.
+func BranchName(pattern, prdName string, now time.Time) string {
+	if pattern == "" {
+		pattern = DefaultBranchPattern
+	}
+	return strings.NewReplacer("{prd}", prdName, "{date}", now.Format("2006-01-02")).Replace(pattern)
+}
+
+// MatchBranchPattern reports whether branch is the name BranchName gives
+// prdName under pattern on some date, returning that date for patterns with
+// {date} (the zero time otherwise).
+func MatchBranchPattern(pattern, prdName, branch string) (time.Time, bool) {
+	if pattern == "" {
+		pattern = DefaultBranchPattern
+	}
+	expr := strings.NewReplacer(
+		regexp.QuoteMeta("{prd}"), regexp.QuoteMeta(prdName),
+		regexp.QuoteMeta("{date}"), `(\d{4}-\d{2}-\d{2})`,
+	).Replace(regexp.QuoteMeta(pattern))
+	m := regexp.MustCompile("^" + expr + "$").FindStringSubmatch(branch)
+	if m == nil {
+		return time.Time{}, false
+	}
+	if len(m) < 2 {
+		return time.Time{}, true
+	}
+	date, err := time.Parse("2006-01-02", m[1])
+	if err != nil {
+		return time.Time{}, false
+	}
+	return date, true
+}
+
+// FindPatternBranch returns the local branch BranchName gave prdName under
+// pattern, whatever day it was made, and the date it was made for. ok is
+// false when no such branch exists.
+func FindPatternBranch(dir, pattern, prdName string) (branch string, date time.Time, ok bool) {
+	cmd := exec.Command("git", "for-each-ref", "--format=%(refname:short)", "refs/heads")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return "", time.Time{}, false
+	}
+	for _, name := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if date, ok := MatchBranchPattern(pattern, prdName, name); ok {
+			return name, date, true
+		}
+	}
+	return "", time.Time{}, false
+}
+
+// ValidateBranchName checks that name is a legal git branch name.
+func ValidateBranchName(name string) error {
+	if name == "" {
+		return fmt.Errorf("branch name is empty")
+	}
+	cmd := exec.Command("git", "check-ref-format", "--branch", name)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%q is not a valid branch name", name)
+	}
+	return nil
+}
+
 // GetCurrentBranch returns the current git branch name for a directory.
 func GetCurrentBranch(dir string) (string, error) {
 	cmd := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD")
@@ -45,6 +117,16 @@ func BranchExists(dir, branchName string) (bool, error) {
 	return true, nil
 }
 
+// RenameBranch renames a local branch, including one checked out in a worktree.
+func RenameBranch(dir, oldName, newName string) error {
+	cmd := exec.Command("git", "branch", "-m", oldName, newName)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to rename branch %s: %s", oldName, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
 // IsGitRepo returns true if the directory is inside a git repository.
 func IsGitRepo(dir string) bool {
 	cmd := exec.Command("git", "rev-parse", "--git-dir")
@@ -52,30 +134,141 @@ func IsGitRepo(dir string) bool {
 	return cmd.Run() == nil
 }
 
-// CommitCount returns the number of commits on branch that are not on the default branch.
-// Returns 0 if the count cannot be determined.
-func CommitCount(repoDir, branch string) int {
-	defaultBranch, err := GetDefaultBranch(repoDir)
+// StatusPorcelain returns the working tree status of dir as a map from path
+// (relative to the worktree root) to its two-letter porcelain code, e.g. " M"
+// or "??". Untracked files are listed individually. A clean tree returns an
+// empty map.
+func StatusPorcelain(dir string) (map[string]string, error) {
+	cmd := exec.Command("git", "status", "--porcelain", "-z", "--untracked-files=all")
+	cmd.Dir = dir
+	out, err := cmd.Output()
 	if err != nil {
-		return 0
+		return nil, fmt.Errorf("failed to get status of %s: %w", dir, err)
 	}
-	cmd := exec.Command("git", "rev-list", "--count", defaultBranch+".."+branch)
-	cmd.Dir = repoDir
+
+	status := make(map[string]string)
+	entries := strings.Split(string(out), "\x00")
+	for i := 0; i < len(entries); i++ {
+		entry := entries[i]
+		if len(entry) < 4 {
+			continue
+		}
+		code := entry[:2]
+		status[entry[3:]] = code
+		// Renames and copies are followed by the original path
+		if code[0] == 'R' || code[0] == 'C' {
+			i++
+		}
+	}
+	return status, nil
+}
+
+// IsDirty returns true if dir has uncommitted changes, including untracked
+// files. A worktree or branch made from HEAD won't contain them.
+func IsDirty(dir string) (bool, error) {
+	cmd := exec.Command("git", "status", "--porcelain")
+	cmd.Dir = dir
 	out, err := cmd.Output()
 	if err != nil {
-		return 0
+		return false, fmt.Errorf("failed to get status of %s: %w", dir, err)
 	}
-	count, err := strconv.Atoi(strings.TrimSpace(string(out)))
+	return len(strings.TrimSpace(string(out))) > 0, nil
+}
+
+// Stash stashes the uncommitted changes in dir, untracked files included,
+// under the given message so they can be found again with "git stash list".
+func Stash(dir, message string) error {
+	cmd := exec.Command("git", "stash", "push", "--include-untracked", "-m", message)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to stash changes in %s: %s", dir, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// CommitAll commits every change in dir, untracked files included, with the
+// given message and returns the new commit's short hash. It does nothing and
+// returns an empty hash when there is nothing to commit.
+func CommitAll(dir, message string, sign bool) (string, error) {
+	dirty, err := IsDirty(dir)
+	if err != nil || !dirty {
+		return "", err
+	}
+	add := exec.Command("git", "add", "-A")
+	add.Dir = dir
+	if out, err := add.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("failed to stage changes in %s: %s", dir, strings.TrimSpace(string(out)))
+	}
+	commit := exec.Command("git", append([]string{"commit", "-m", message}, signArgs(sign)...)...)
+	commit.Dir = dir
+	if out, err := commit.CombinedOutput(); err != nil {
+		if signErr := signingError(out); signErr != nil {
+			return "", signErr
+		}
+		return "", fmt.Errorf("failed to commit changes in %s: %s", dir, strings.TrimSpace(string(out)))
+	}
+	rev := exec.Command("git", "rev-parse", "--short", "HEAD")
+	rev.Dir = dir
+	out, err := rev.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to read the new commit in %s: %w", dir, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// RestoreFiles discards working tree and index changes to the given tracked
+// paths in dir, restoring them to their committed content.
+func RestoreFiles(dir string, paths []string) error {
+	if len(paths) == 0 {
+		return nil
+	}
+	args := append([]string{"checkout", "HEAD", "--"}, paths...)
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to restore files in %s: %s", dir, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// CommitCount returns the number of commits on branch that are not on base
+// (empty means the default branch). Returns 0 if the count cannot be
+// determined.
+func CommitCount(repoDir, base, branch string) int {
+	count, err := CommitsAhead(repoDir, base, branch)
 	if err != nil {
 		return 0
 	}
 	return count
 }
 
+// CommitsAhead returns the number of commits on branch that are not on base
+// (empty means the default branch), or an error if that cannot be
+// determined.
+func CommitsAhead(repoDir, base, branch string) (int, error) {
+	startRef, err := BaseRef(repoDir, base)
+	if err != nil {
+		return 0, err
+	}
+	cmd := exec.Command("git", "rev-list", "--count", startRef+".."+branch)
+	cmd.Dir = repoDir
+	out, err := cmd.Output()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count commits on %s: %w", branch, err)
+	}
+	return strconv.Atoi(strings.TrimSpace(string(out)))
+}
+
+// DefaultDiffContext is git's own number of context lines around a change,
+// for the diff functions' context argument.
+const DefaultDiffContext = 3
+
 // GetDiff returns the git diff output for the working directory.
-// It shows the diff between the current branch and its merge base with the default branch.
+// It shows the diff between the current branch and its merge base with base
+// (empty means the default branch).
 // If on main/master or if merge-base fails, it shows the last few commits' diff.
-func GetDiff(dir string) (string, error) {
+// context is the number of unchanged lines shown around each change.
+func GetDiff(dir, base string, context int) (string, error) {
 	branch, err := GetCurrentBranch(dir)
 	if err != nil {
 		return "", err
@@ -83,28 +276,28 @@ func GetDiff(dir string) (string, error) {
 
 	// If on a feature branch, diff against merge-base with main/master
 	if !IsProtectedBranch(branch) {
-		baseBranch, err := GetDefaultBranch(dir)
+		baseBranch, err := BaseRef(dir, base)
 		if err == nil && baseBranch != "" {
 			mergeBase, err := getMergeBase(dir, baseBranch, "HEAD")
 			if err == nil && mergeBase != "" {
-				return getDiffOutput(dir, mergeBase, "HEAD")
+				return getDiffOutput(dir, mergeBase, "HEAD", context)
 			}
 		}
 	}
 
 	// Fallback: show diff of recent commits (last 10)
-	return getDiffOutput(dir, "HEAD~10", "HEAD")
+	return getDiffOutput(dir, "HEAD~10", "HEAD", context)
 }
 
-// GetDiffStats returns a short diffstat summary.
-func GetDiffStats(dir string) (string, error) {
+// GetDiffStats returns a short diffstat summary, against base like GetDiff.
+func GetDiffStats(dir, base string) (string, error) {
 	branch, err := GetCurrentBranch(dir)
 	if err != nil {
 		return "", err
 	}
 
 	if !IsProtectedBranch(branch) {
-		baseBranch, err := GetDefaultBranch(dir)
+		baseBranch, err := BaseRef(dir, base)
 		if err == nil && baseBranch != "" {
 			mergeBase, err := getMergeBase(dir, baseBranch, "HEAD")
 			if err == nil && mergeBase != "" {
@@ -128,9 +321,10 @@ func GetDiffStats(dir string) (string, error) {
 	return strings.TrimSpace(string(output)), nil
 }
 
-// GetDiffForCommit returns the diff for a single commit using git show.
-func GetDiffForCommit(dir, commitHash string) (string, error) {
-	cmd := exec.Command("git", "show", "--format=", commitHash)
+// GetDiffForCommit returns the diff for a single commit using git show, with
+// context unchanged lines around each change.
+func GetDiffForCommit(dir, commitHash string, context int) (string, error) {
+	cmd := exec.Command("git", "show", "--format=", unifiedFlag(context), commitHash)
 	cmd.Dir = dir
 	output, err := cmd.Output()
 	if err != nil {
@@ -150,6 +344,84 @@ func GetDiffStatsForCommit(dir, commitHash string) (string, error) {
 	return strings.TrimSpace(string(output)), nil
 }
 
+// DiffStat summarises the size of a change.
+type DiffStat struct {
+	Files   int // Files touched
+	Added   int // Lines added
+	Removed int // Lines removed
+}
+
+// StoryDiffStat returns the size of the commit FindCommitForStory finds for
+// a story, or nil if the story has no commit yet.
+func StoryDiffStat(dir, ticketPrefix, title string) (*DiffStat, error) {
+	hash, err := FindCommitForStory(dir, ticketPrefix, title)
+	if err != nil || hash == "" {
+		return nil, err
+	}
+	cmd := exec.Command("git", "show", "--format=", "--numstat", hash)
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get diff stats for %s: %w", hash, err)
+	}
+	return parseNumstat(string(output)), nil
+}
+
+// parseNumstat totals `git diff --numstat` output. Binary files, shown with
+// "-" counts, add to the file count only.
+func parseNumstat(output string) *DiffStat {
+	stat := &DiffStat{}
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) < 3 {
+			continue
+		}
+		stat.Files++
+		added, _ := strconv.Atoi(fields[0])
+		removed, _ := strconv.Atoi(fields[1])
+		stat.Added += added
+		stat.Removed += removed
+	}
+	return stat
+}
+
+// WorkingTreeDiff returns the uncommitted changes in dir: staged and unstaged
+// changes to tracked files against HEAD, followed by the full content of each
+// untracked file as a new-file diff. Ignored files are left out. context is
+// the number of unchanged lines shown around each change.
+func WorkingTreeDiff(dir string, context int) (string, error) {
+	cmd := exec.Command("git", "diff", unifiedFlag(context), "HEAD")
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to diff working tree of %s: %w", dir, err)
+	}
+	var diff strings.Builder
+	diff.Write(output)
+
+	cmd = exec.Command("git", "ls-files", "--others", "--exclude-standard", "-z")
+	cmd.Dir = dir
+	output, err = cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to list untracked files in %s: %w", dir, err)
+	}
+	for _, file := range strings.Split(string(output), "\x00") {
+		if file == "" {
+			continue
+		}
+		// --no-index exits 1 when the files differ, which they always do here
+		cmd := exec.Command("git", "diff", "--no-index", "--", os.DevNull, file)
+		cmd.Dir = dir
+		out, err := cmd.Output()
+		var exitErr *exec.ExitError
+		if err != nil && !(errors.As(err, &exitErr) && exitErr.ExitCode() == 1) {
+			return "", fmt.Errorf("failed to diff untracked file %s: %w", file, err)
+		}
+		diff.Write(out)
+	}
+	return diff.String(), nil
+}
+
 // FindCommitForStory searches the git log for a commit whose subject line
 // matches the chief commit format "<ticketPrefix>: <title>".
 // The ticketPrefix is typically a Jira ticket (e.g. CCS-1234) extracted from
@@ -172,8 +444,25 @@ func ExtractTicketFromBranch(branch string) string {
 	return ticketRe.FindString(branch)
 }
 
-// getMergeBase returns the merge base commit between two refs.
+// getMergeBase returns the merge base commit between two refs. In a shallow
+// clone the merge base may be missing from the truncated history, so the
+// history is deepened once before giving up.
 func getMergeBase(dir, ref1, ref2 string) (string, error) {
+	base, err := mergeBase(dir, ref1, ref2)
+	if err == nil || !isShallowRepo(dir) {
+		return base, err
+	}
+
+	cmd := exec.Command("git", "fetch", "--deepen=200", "origin")
+	cmd.Dir = dir
+	if cmd.Run() != nil {
+		return "", err
+	}
+	return mergeBase(dir, ref1, ref2)
+}
+
+// mergeBase runs `git merge-base` for two refs.
+func mergeBase(dir, ref1, ref2 string) (string, error) {
 	cmd := exec.Command("git", "merge-base", ref1, ref2)
 	cmd.Dir = dir
 	output, err := cmd.Output()
@@ -183,9 +472,17 @@ func getMergeBase(dir, ref1, ref2 string) (string, error) {
 	return strings.TrimSpace(string(output)), nil
 }
 
+// isShallowRepo reports whether dir is in a shallow clone.
+func isShallowRepo(dir string) bool {
+	cmd := exec.Command("git", "rev-parse", "--is-shallow-repository")
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	return err == nil && strings.TrimSpace(string(output)) == "true"
+}
+
 // getDiffOutput returns the full diff between two refs.
-func getDiffOutput(dir, from, to string) (string, error) {
-	cmd := exec.Command("git", "diff", from, to)
+func getDiffOutput(dir, from, to string, context int) (string, error) {
+	cmd := exec.Command("git", "diff", unifiedFlag(context), from, to)
 	cmd.Dir = dir
 	output, err := cmd.Output()
 	if err != nil {
@@ -193,3 +490,8 @@ func getDiffOutput(dir, from, to string) (string, error) {
 	}
 	return string(output), nil
 }
+
+// unifiedFlag returns git's -U flag for context lines around each change.
+func unifiedFlag(context int) string {
+	return "-U" + strconv.Itoa(max(0, context))
+}
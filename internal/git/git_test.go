@@ -2,8 +2,11 @@ package git
 
 import (
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestAddChiefToGitignore(t *testing.T) {
@@ -176,3 +179,293 @@ func TestIsProtectedBranch(t *testing.T) {
 		})
 	}
 }
+
+func TestGetMergeBaseDeepensShallowClone(t *testing.T) {
+	origin := initTestRepo(t)
+	fork := runGit(t, origin, "rev-parse", "HEAD")
+	runGit(t, origin, "checkout", "-b", "feature")
+	commitFile(t, origin, "feature.txt", "feature\n", "feature change")
+	runGit(t, origin, "checkout", "main")
+	commitFile(t, origin, "main.txt", "main\n", "main change")
+
+	clone := filepath.Join(t.TempDir(), "clone")
+	runGit(t, t.TempDir(), "clone", "--depth", "1", "--no-single-branch", "file://"+origin, clone)
+	if _, err := mergeBase(clone, "origin/main", "origin/feature"); err == nil {
+		t.Fatal("expected the merge base to be missing from the shallow clone")
+	}
+
+	base, err := getMergeBase(clone, "origin/main", "origin/feature")
+	if err != nil {
+		t.Fatalf("getMergeBase() error = %v", err)
+	}
+	if base != fork {
+		t.Errorf("expected merge base %s, got %s", fork, base)
+	}
+}
+
+func TestBranchName(t *testing.T) {
+	now := time.Date(2026, 3, 9, 14, 0, 0, 0, time.UTC)
+	tests := []struct {
+		pattern string
+		want    string
+	}{
+		{"", "chief/auth"},
+		{"chief/{prd}", "chief/auth"},
+		{"feature/{prd}", "feature/auth"},
+		{"ai/{date}-{prd}", "ai/2026-03-09-auth"},
+	}
+	for _, tt := range tests {
+		if got := BranchName(tt.pattern, "auth", now); got != tt.want {
+			t.Errorf("BranchName(%q) = %q, want %q", tt.pattern, got, tt.want)
+		}
+	}
+}
+
+func TestMatchBranchPattern(t *testing.T) {
+	if date, ok := MatchBranchPattern("ai/{date}-{prd}", "auth", "ai/2026-03-09-auth"); !ok || date.Format("2006-01-02") != "2026-03-09" {
+		t.Errorf("expected a dated match on 2026-03-09, got %v %v", date, ok)
+	}
+	if _, ok := MatchBranchPattern("", "auth", "chief/auth"); !ok {
+		t.Error("expected the default pattern to match chief/auth")
+	}
+	misses := []struct{ pattern, branch string }{
+		{"ai/{date}-{prd}", "ai/2026-03-09-other"},
+		{"ai/{date}-{prd}", "ai/auth"},
+		{"", "chief/auth-2"},
+		{"", "xchief/auth"},
+	}
+	for _, tt := range misses {
+		if _, ok := MatchBranchPattern(tt.pattern, "auth", tt.branch); ok {
+			t.Errorf("expected %q not to match %q", tt.branch, tt.pattern)
+		}
+	}
+}
+
+func TestFindPatternBranch(t *testing.T) {
+	dir := initTestRepo(t)
+	runGit(t, dir, "branch", "ai/2026-01-02-auth")
+
+	branch, date, ok := FindPatternBranch(dir, "ai/{date}-{prd}", "auth")
+	if !ok || branch != "ai/2026-01-02-auth" || date.Format("2006-01-02") != "2026-01-02" {
+		t.Errorf("FindPatternBranch() = %q, %v, %v", branch, date, ok)
+	}
+	if _, _, ok := FindPatternBranch(dir, "ai/{date}-{prd}", "billing"); ok {
+		t.Error("expected no branch for another PRD")
+	}
+}
+
+func TestValidateBranchName(t *testing.T) {
+	for _, name := range []string{"chief/auth", "feature/2026-03-09-auth"} {
+		if err := ValidateBranchName(name); err != nil {
+			t.Errorf("expected %q to be valid, got %v", name, err)
+		}
+	}
+	for _, name := range []string{"", "bad..name", "trailing/", "has space", "ends.lock"} {
+		if err := ValidateBranchName(name); err == nil {
+			t.Errorf("expected %q to be rejected", name)
+		}
+	}
+}
+
+func TestStatusPorcelainAndRestoreFiles(t *testing.T) {
+	dir := initTestRepo(t)
+	if status, err := StatusPorcelain(dir); err != nil || len(status) != 0 {
+		t.Fatalf("StatusPorcelain() on a clean tree = %v, %v", status, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("changed\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "new dir"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "new dir", "file.txt"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	status, err := StatusPorcelain(dir)
+	if err != nil {
+		t.Fatalf("StatusPorcelain() error = %v", err)
+	}
+	want := map[string]string{"README.md": " M", "new dir/file.txt": "??"}
+	if len(status) != len(want) {
+		t.Fatalf("StatusPorcelain() = %v, want %v", status, want)
+	}
+	for file, code := range want {
+		if status[file] != code {
+			t.Errorf("status[%q] = %q, want %q", file, status[file], code)
+		}
+	}
+
+	if err := RestoreFiles(dir, []string{"README.md"}); err != nil {
+		t.Fatalf("RestoreFiles() error = %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "README.md")); string(data) != "# Test\n" {
+		t.Errorf("README.md = %q after restore", data)
+	}
+}
+
+func TestIsDirtyAndStash(t *testing.T) {
+	dir := initTestRepo(t)
+	if dirty, err := IsDirty(dir); err != nil || dirty {
+		t.Fatalf("IsDirty() on a clean tree = %v, %v", dirty, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "untracked.txt"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if dirty, err := IsDirty(dir); err != nil || !dirty {
+		t.Fatalf("IsDirty() with an untracked file = %v, %v", dirty, err)
+	}
+
+	if err := Stash(dir, "chief: before auth"); err != nil {
+		t.Fatalf("Stash() error = %v", err)
+	}
+	if dirty, err := IsDirty(dir); err != nil || dirty {
+		t.Errorf("IsDirty() after Stash() = %v, %v", dirty, err)
+	}
+	if list := runGit(t, dir, "stash", "list"); !strings.Contains(list, "chief: before auth") {
+		t.Errorf("stash list = %q, want the stash message", list)
+	}
+}
+
+func TestWorkingTreeDiff(t *testing.T) {
+	dir := initTestRepo(t)
+	if diff, err := WorkingTreeDiff(dir, DefaultDiffContext); err != nil || diff != "" {
+		t.Fatalf("WorkingTreeDiff() on a clean tree = %q, %v", diff, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("# Changed\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "new.txt"), []byte("brand new\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".gitignore"), []byte("ignored.txt\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "ignored.txt"), []byte("secret\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	diff, err := WorkingTreeDiff(dir, DefaultDiffContext)
+	if err != nil {
+		t.Fatalf("WorkingTreeDiff() error = %v", err)
+	}
+	for _, want := range []string{"-# Test", "+# Changed", "b/new.txt", "+brand new", "+ignored.txt"} {
+		if !strings.Contains(diff, want) {
+			t.Errorf("WorkingTreeDiff() missing %q:\n%s", want, diff)
+		}
+	}
+	if strings.Contains(diff, "secret") {
+		t.Errorf("WorkingTreeDiff() should leave out ignored files:\n%s", diff)
+	}
+}
+
+func TestGetDiffForCommitContext(t *testing.T) {
+	dir := initTestRepo(t)
+	lines := "1\n2\n3\n4\n5\n6\n7\n8\n9\n"
+	if err := os.WriteFile(filepath.Join(dir, "lines.txt"), []byte(lines), 0644); err != nil {
+		t.Fatal(err)
+	}
+	commitAll(t, dir, "add lines")
+	if err := os.WriteFile(filepath.Join(dir, "lines.txt"), []byte(strings.Replace(lines, "5\n", "five\n", 1)), 0644); err != nil {
+		t.Fatal(err)
+	}
+	commitAll(t, dir, "change five")
+
+	tests := []struct {
+		context int
+		has     []string
+		hasNot  []string
+	}{
+		{0, []string{"-5", "+five"}, []string{" 4", " 6"}},
+		{1, []string{" 4", " 6"}, []string{" 3", " 7"}},
+		{DefaultDiffContext, []string{" 2", " 8"}, []string{" 1\n", " 9"}},
+	}
+	for _, tt := range tests {
+		diff, err := GetDiffForCommit(dir, "HEAD", tt.context)
+		if err != nil {
+			t.Fatalf("GetDiffForCommit(%d) error = %v", tt.context, err)
+		}
+		for _, want := range tt.has {
+			if !strings.Contains(diff, "\n"+want) {
+				t.Errorf("context %d: missing %q in\n%s", tt.context, want, diff)
+			}
+		}
+		for _, unwanted := range tt.hasNot {
+			if strings.Contains(diff, "\n"+unwanted) {
+				t.Errorf("context %d: unexpected %q in\n%s", tt.context, unwanted, diff)
+			}
+		}
+	}
+}
+
+// commitAll stages everything in dir and commits it.
+func commitAll(t *testing.T, dir, message string) {
+	t.Helper()
+	for _, args := range [][]string{{"git", "add", "-A"}, {"git", "commit", "-m", message}} {
+		cmd := exec.Command(args[0], args[1:]...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("%v failed: %s", args, out)
+		}
+	}
+}
+
+func TestStoryDiffStat(t *testing.T) {
+	dir := initTestRepo(t)
+	if stat, err := StoryDiffStat(dir, "US-001", "Add login"); err != nil || stat != nil {
+		t.Fatalf("StoryDiffStat() with no commit = %+v, %v", stat, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("# Login\nSign in here\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "login.go"), []byte("package login\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, dir, "add", "-A")
+	runGit(t, dir, "commit", "-m", "US-001: Add login")
+
+	stat, err := StoryDiffStat(dir, "US-001", "Add login")
+	if err != nil {
+		t.Fatalf("StoryDiffStat() error = %v", err)
+	}
+	want := DiffStat{Files: 2, Added: 3, Removed: 1}
+	if stat == nil || *stat != want {
+		t.Errorf("StoryDiffStat() = %+v, want %+v", stat, want)
+	}
+}
+
+func TestParseNumstatBinary(t *testing.T) {
+	stat := parseNumstat("-\t-\tlogo.png\n4\t0\tmain.go\n")
+	want := DiffStat{Files: 2, Added: 4, Removed: 0}
+	if *stat != want {
+		t.Errorf("parseNumstat() = %+v, want %+v", *stat, want)
+	}
+}
+
+func TestCommitAll(t *testing.T) {
+	dir := initTestRepo(t)
+	if hash, err := CommitAll(dir, "chief: WIP on US-001", false); err != nil || hash != "" {
+		t.Fatalf("CommitAll() on a clean tree = %q, %v; want nothing committed", hash, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("# Changed\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "new.go"), []byte("package main\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	hash, err := CommitAll(dir, "chief: WIP on US-001", false)
+	if err != nil || hash == "" {
+		t.Fatalf("CommitAll() = %q, %v", hash, err)
+	}
+	if got := strings.TrimSpace(runGit(t, dir, "log", "-1", "--format=%h %s")); got != hash+" chief: WIP on US-001" {
+		t.Errorf("last commit = %q", got)
+	}
+	if dirty, _ := IsDirty(dir); dirty {
+		t.Error("expected untracked and modified files to be committed")
+	}
+}
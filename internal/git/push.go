@@ -2,22 +2,54 @@ package git
 
 import (
 	"fmt"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
+	"text/template"
 
 	"github.com/minicodemonkey/chief/internal/prd"
 )
 
+// Git hosting providers, selected by the git.provider config setting.
+const (
+	ProviderGitHub = "github" // Pull requests via the GitHub CLI (default)
+	ProviderGitLab = "gitlab" // Merge requests via the GitLab CLI
+)
+
+// ProviderCLI describes the command-line tool used to open pull requests on a provider.
+type ProviderCLI struct {
+	Binary     string // Executable name, e.g. "gh"
+	Name       string // Display name, e.g. "GitHub CLI"
+	InstallURL string // Where to get the tool
+}
+
+// CLIFor returns the CLI for a provider. Anything other than ProviderGitLab,
+// including empty, means GitHub.
+func CLIFor(provider string) ProviderCLI {
+	if provider == ProviderGitLab {
+		return ProviderCLI{Binary: "glab", Name: "GitLab CLI", InstallURL: "https://gitlab.com/gitlab-org/cli"}
+	}
+	return ProviderCLI{Binary: "gh", Name: "GitHub CLI", InstallURL: "https://cli.github.com"}
+}
+
 // CheckGHCLI validates that the GitHub CLI is installed and authenticated.
 func CheckGHCLI() (installed bool, authenticated bool, err error) {
-	// Check if gh is installed
-	_, err = exec.LookPath("gh")
+	return CheckProviderCLI(ProviderGitHub)
+}
+
+// CheckProviderCLI validates that the provider's CLI is installed and authenticated.
+func CheckProviderCLI(provider string) (installed bool, authenticated bool, err error) {
+	binary := CLIFor(provider).Binary
+
+	// Check if the CLI is installed
+	_, err = exec.LookPath(binary)
 	if err != nil {
 		return false, false, nil
 	}
 
-	// Check if gh is authenticated
-	cmd := exec.Command("gh", "auth", "status")
+	// Check if the CLI is authenticated
+	cmd := exec.Command(binary, "auth", "status")
 	if err := cmd.Run(); err != nil {
 		return true, false, nil
 	}
@@ -25,23 +57,74 @@ func CheckGHCLI() (installed bool, authenticated bool, err error) {
 	return true, true, nil
 }
 
-// PushBranch pushes the branch to origin.
-func PushBranch(dir, branch string) error {
-	cmd := exec.Command("git", "push", "-u", "origin", branch)
+// DefaultRemote is the remote branches are pushed to unless git.remote says otherwise.
+const DefaultRemote = "origin"
+
+// RemoteOrDefault returns remote, or DefaultRemote when it's empty.
+func RemoteOrDefault(remote string) string {
+	if remote == "" {
+		return DefaultRemote
+	}
+	return remote
+}
+
+// PushBranch pushes the branch to remote (empty means DefaultRemote) and
+// sets it as the branch's upstream.
+func PushBranch(dir, remote, branch string) error {
+	remote = RemoteOrDefault(remote)
+	cmd := exec.Command("git", "push", "-u", remote, branch)
 	cmd.Dir = dir
 	if out, err := cmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("failed to push branch: %s", strings.TrimSpace(string(out)))
+		return fmt.Errorf("failed to push branch to %s: %s", remote, strings.TrimSpace(string(out)))
 	}
 	return nil
 }
 
-// CreatePR creates a pull request via `gh pr create` and returns the PR URL.
-func CreatePR(dir, branch, title, body string) (string, error) {
-	cmd := exec.Command("gh", "pr", "create",
-		"--head", branch,
+// PROptions holds optional settings for a new pull request.
+type PROptions struct {
+	Draft     bool     // Open the pull request as a draft
+	Reviewers []string // Usernames to request reviews from
+	Base      string   // Branch the pull request targets (empty = the repository's default branch)
+
+	// HeadRemote is the remote the branch was pushed to. When it isn't
+	// DefaultRemote, the pull request is opened from that remote's
+	// repository, e.g. a fork, rather than the one it targets.
+	HeadRemote string
+}
+
+// flags returns the create flags for the options, which gh and glab share.
+func (o PROptions) flags() []string {
+	var args []string
+	if o.Draft {
+		args = append(args, "--draft")
+	}
+	if len(o.Reviewers) > 0 {
+		args = append(args, "--reviewer", strings.Join(o.Reviewers, ","))
+	}
+	return args
+}
+
+// CreatePR creates a pull request for the branch on the given provider, via
+// `gh pr create` or `glab mr create`, and returns its URL.
+func CreatePR(provider, dir, branch, title, body string, opts PROptions) (string, error) {
+	if provider == ProviderGitLab {
+		return createMR(dir, branch, title, body, opts)
+	}
+
+	head := branch
+	if repo := opts.headRepo(dir); repo != "" {
+		owner, _, _ := strings.Cut(repo, "/")
+		head = owner + ":" + branch
+	}
+	args := []string{"pr", "create",
+		"--head", head,
 		"--title", title,
 		"--body", body,
-	)
+	}
+	if opts.Base != "" {
+		args = append(args, "--base", opts.Base)
+	}
+	cmd := exec.Command("gh", append(args, opts.flags()...)...)
 	cmd.Dir = dir
 	out, err := cmd.CombinedOutput()
 	if err != nil {
@@ -50,30 +133,156 @@ func CreatePR(dir, branch, title, body string) (string, error) {
 	return strings.TrimSpace(string(out)), nil
 }
 
+// headRepo returns the "owner/repo" path of HeadRemote's repository when the
+// pull request comes from a remote other than DefaultRemote, or "".
+func (o PROptions) headRepo(dir string) string {
+	if o.HeadRemote == "" || o.HeadRemote == DefaultRemote {
+		return ""
+	}
+	return remoteRepo(dir, o.HeadRemote)
+}
+
+// remoteRepo returns the repository path of remote's URL, e.g. "alice/app"
+// for git@github.com:alice/app.git, or "" if it can't be read.
+func remoteRepo(dir, remote string) string {
+	cmd := exec.Command("git", "remote", "get-url", remote)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	url := strings.TrimSuffix(strings.TrimSpace(string(out)), ".git")
+	if _, rest, ok := strings.Cut(url, "://"); ok {
+		// scheme://[user@]host[:port]/path
+		_, path, _ := strings.Cut(rest, "/")
+		return strings.Trim(path, "/")
+	}
+	if _, path, ok := strings.Cut(url, ":"); ok {
+		// [user@]host:path
+		return strings.Trim(path, "/")
+	}
+	return ""
+}
+
+// createMR creates a GitLab merge request via `glab mr create` and returns its URL.
+func createMR(dir, branch, title, body string, opts PROptions) (string, error) {
+	args := []string{"mr", "create",
+		"--source-branch", branch,
+		"--title", title,
+		"--description", body,
+		"--yes",
+	}
+	if opts.Base != "" {
+		args = append(args, "--target-branch", opts.Base)
+	}
+	if repo := opts.headRepo(dir); repo != "" {
+		args = append(args, "--head", repo)
+	}
+	cmd := exec.Command("glab", append(args, opts.flags()...)...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("failed to create merge request: %s", strings.TrimSpace(string(out)))
+	}
+	return mergeRequestURL(string(out)), nil
+}
+
+// mergeRequestURL picks the merge request URL out of glab's output, which
+// prints progress lines before it. Falls back to the whole output.
+func mergeRequestURL(out string) string {
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		if line := strings.TrimSpace(lines[i]); strings.HasPrefix(line, "https://") || strings.HasPrefix(line, "http://") {
+			return line
+		}
+	}
+	return strings.TrimSpace(out)
+}
+
 // PRTitleFromPRD generates a conventional-commits title for a PR.
 // Format: feat(<prd-name>): <project name>
 func PRTitleFromPRD(prdName string, p *prd.PRD) string {
 	return fmt.Sprintf("feat(%s): %s", prdName, p.Project)
 }
 
-// PRBodyFromPRD generates a PR body with a summary and list of completed stories.
-func PRBodyFromPRD(p *prd.PRD) string {
+// PRBodyFromPRD generates the default PR body: the PRD's description, a
+// checklist of its stories and, when prdPath is set, where the PRD lives.
+func PRBodyFromPRD(p *prd.PRD, prdPath string) string {
 	var b strings.Builder
 
 	b.WriteString("## Summary\n\n")
 	b.WriteString(p.Description)
 	b.WriteString("\n\n")
 
-	b.WriteString("## Changes\n\n")
+	b.WriteString("## Stories\n\n")
+	b.WriteString(storyChecklist(p))
+
+	if prdPath != "" {
+		b.WriteString(fmt.Sprintf("\nPRD: `%s`\n", prdPath))
+	}
+
+	return b.String()
+}
+
+// PRTemplateData is what a PR body template can use.
+type PRTemplateData struct {
+	Project     string // PRD project name
+	Description string // PRD description
+	Stories     string // Markdown checklist of every story, checked when it passes
+	PRDPath     string // Where the PRD lives
+}
+
+// PRBody generates a PR body from the template at templatePath, falling back to
+// PRBodyFromPRD when there's no template. A template that fails to parse or
+// execute is an error rather than a silently different body.
+func PRBody(templatePath string, p *prd.PRD, prdPath string) (string, error) {
+	data, err := os.ReadFile(templatePath)
+	if os.IsNotExist(err) {
+		return PRBodyFromPRD(p, prdPath), nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read PR template: %w", err)
+	}
+
+	tmpl, err := template.New(filepath.Base(templatePath)).Parse(string(data))
+	if err != nil {
+		return "", fmt.Errorf("invalid PR template: %w", err)
+	}
+	var b strings.Builder
+	err = tmpl.Execute(&b, PRTemplateData{
+		Project:     p.Project,
+		Description: p.Description,
+		Stories:     storyChecklist(p),
+		PRDPath:     prdPath,
+	})
+	if err != nil {
+		return "", fmt.Errorf("invalid PR template: %w", err)
+	}
+	return b.String(), nil
+}
+
+// storyChecklist renders the stories as a GitHub-flavored markdown task list.
+func storyChecklist(p *prd.PRD) string {
+	var b strings.Builder
 	for _, story := range p.UserStories {
+		mark := " "
 		if story.Passes {
-			b.WriteString(fmt.Sprintf("- %s: %s\n", story.ID, story.Title))
+			mark = "x"
 		}
+		b.WriteString(fmt.Sprintf("- [%s] %s: %s%s\n", mark, story.ID, story.Title, ticketLink(story)))
 	}
-
 	return b.String()
 }
 
+// ticketLink returns a Markdown link to a story's ticket, prefixed with a
+// space, or "" when it has none.
+func ticketLink(story prd.UserStory) string {
+	if story.TicketURL == "" {
+		return ""
+	}
+	return fmt.Sprintf(" ([ticket](%s))", story.TicketURL)
+}
+
 // DeleteBranch deletes a local branch.
 func DeleteBranch(repoDir, branch string) error {
 	cmd := exec.Command("git", "branch", "-D", branch)
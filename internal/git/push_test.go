@@ -1,7 +1,9 @@
 package git
 
 import (
+	"os"
 	"os/exec"
+	"path/filepath"
 	"testing"
 
 	"github.com/minicodemonkey/chief/internal/prd"
@@ -18,14 +20,157 @@ func TestCheckGHCLI(t *testing.T) {
 	})
 }
 
+func TestCLIFor(t *testing.T) {
+	if cli := CLIFor(""); cli.Binary != "gh" {
+		t.Errorf("expected gh by default, got %q", cli.Binary)
+	}
+	if cli := CLIFor(ProviderGitLab); cli.Binary != "glab" || cli.Name != "GitLab CLI" {
+		t.Errorf("unexpected GitLab CLI: %+v", cli)
+	}
+}
+
+func TestCreatePRGitLab(t *testing.T) {
+	// A fake glab that records its arguments and prints progress before the URL
+	binDir := t.TempDir()
+	argsFile := filepath.Join(binDir, "args")
+	script := "#!/bin/sh\nprintf '%s\\n' \"$@\" > " + argsFile + "\n" +
+		"echo 'Creating merge request for chief/auth into main in acme/app'\n" +
+		"echo 'https://gitlab.com/acme/app/-/merge_requests/7'\n"
+	if err := os.WriteFile(filepath.Join(binDir, "glab"), []byte(script), 0755); err != nil {
+		t.Fatalf("failed to create fake glab: %v", err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	url, err := CreatePR(ProviderGitLab, t.TempDir(), "chief/auth", "feat(auth): Auth", "body", PROptions{})
+	if err != nil {
+		t.Fatalf("CreatePR() error = %v", err)
+	}
+	if url != "https://gitlab.com/acme/app/-/merge_requests/7" {
+		t.Errorf("expected the merge request URL, got %q", url)
+	}
+
+	args, _ := os.ReadFile(argsFile)
+	if !contains(string(args), "mr\ncreate\n--source-branch\nchief/auth\n") {
+		t.Errorf("expected glab mr create for the branch, got args:\n%s", args)
+	}
+}
+
+func TestCreatePRDraftWithReviewers(t *testing.T) {
+	binDir := t.TempDir()
+	argsFile := filepath.Join(binDir, "args")
+	script := "#!/bin/sh\nprintf '%s\\n' \"$@\" > " + argsFile + "\n" +
+		"echo 'https://github.com/acme/app/pull/12'\n"
+	if err := os.WriteFile(filepath.Join(binDir, "gh"), []byte(script), 0755); err != nil {
+		t.Fatalf("failed to create fake gh: %v", err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	opts := PROptions{Draft: true, Reviewers: []string{"alice", "bob"}, Base: "develop"}
+	if _, err := CreatePR(ProviderGitHub, t.TempDir(), "chief/auth", "feat(auth): Auth", "body", opts); err != nil {
+		t.Fatalf("CreatePR() error = %v", err)
+	}
+
+	args, _ := os.ReadFile(argsFile)
+	if !contains(string(args), "--draft\n--reviewer\nalice,bob\n") {
+		t.Errorf("expected draft and reviewer flags, got args:\n%s", args)
+	}
+	if !contains(string(args), "--base\ndevelop\n") {
+		t.Errorf("expected the base branch flag, got args:\n%s", args)
+	}
+
+	// Without options, neither flag is passed
+	if _, err := CreatePR(ProviderGitHub, t.TempDir(), "chief/auth", "feat(auth): Auth", "body", PROptions{}); err != nil {
+		t.Fatalf("CreatePR() error = %v", err)
+	}
+	args, _ = os.ReadFile(argsFile)
+	if contains(string(args), "--draft") || contains(string(args), "--reviewer") || contains(string(args), "--base") {
+		t.Errorf("expected no draft, reviewer or base flags, got args:\n%s", args)
+	}
+}
+
 func TestPushBranch(t *testing.T) {
 	t.Run("fails on repo without remote", func(t *testing.T) {
 		dir := initTestRepo(t)
-		err := PushBranch(dir, "main")
+		err := PushBranch(dir, "", "main")
 		if err == nil {
 			t.Error("PushBranch() expected error for repo without remote, got nil")
 		}
 	})
+
+	t.Run("pushes to the named remote", func(t *testing.T) {
+		dir := initTestRepo(t)
+		fork := t.TempDir()
+		runGit(t, fork, "init", "--bare")
+		runGit(t, dir, "remote", "add", "fork", fork)
+
+		if err := PushBranch(dir, "fork", "main"); err != nil {
+			t.Fatalf("PushBranch() error = %v", err)
+		}
+		if upstream := runGit(t, dir, "rev-parse", "--abbrev-ref", "main@{upstream}"); upstream != "fork/main" {
+			t.Errorf("upstream = %q, want fork/main", upstream)
+		}
+	})
+}
+
+func TestBaseBranch(t *testing.T) {
+	dir := initTestRepo(t)
+
+	if got, err := BaseBranch(dir, "release"); err != nil || got != "release" {
+		t.Errorf("BaseBranch(release) = %q, %v, want release", got, err)
+	}
+	// No origin, so main is found by name
+	if got, err := BaseBranch(dir, ""); err != nil || got != "main" {
+		t.Errorf("BaseBranch() = %q, %v, want main", got, err)
+	}
+}
+
+func TestCreatePRFromFork(t *testing.T) {
+	binDir := t.TempDir()
+	argsFile := filepath.Join(binDir, "args")
+	for _, cli := range []string{"gh", "glab"} {
+		script := "#!/bin/sh\nprintf '%s\\n' \"$@\" > " + argsFile + "\necho 'https://example.com/pr/1'\n"
+		if err := os.WriteFile(filepath.Join(binDir, cli), []byte(script), 0755); err != nil {
+			t.Fatalf("failed to create fake %s: %v", cli, err)
+		}
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	dir := initTestRepo(t)
+	runGit(t, dir, "remote", "add", "fork", "git@github.com:alice/app.git")
+	opts := PROptions{HeadRemote: "fork"}
+
+	if _, err := CreatePR(ProviderGitHub, dir, "chief/auth", "feat(auth): Auth", "body", opts); err != nil {
+		t.Fatalf("CreatePR() error = %v", err)
+	}
+	if args, _ := os.ReadFile(argsFile); !contains(string(args), "--head\nalice:chief/auth\n") {
+		t.Errorf("expected the head qualified with the fork's owner, got args:\n%s", args)
+	}
+
+	if _, err := CreatePR(ProviderGitLab, dir, "chief/auth", "feat(auth): Auth", "body", opts); err != nil {
+		t.Fatalf("CreatePR() error = %v", err)
+	}
+	if args, _ := os.ReadFile(argsFile); !contains(string(args), "--head\nalice/app\n") {
+		t.Errorf("expected the fork as the head repository, got args:\n%s", args)
+	}
+}
+
+func TestRemoteRepo(t *testing.T) {
+	dir := initTestRepo(t)
+	urls := map[string]string{
+		"ssh":   "git@github.com:alice/app.git",
+		"https": "https://gitlab.com/acme/team/app",
+		"url":   "ssh://git@gitlab.example.com:2222/acme/app.git",
+	}
+	want := map[string]string{"ssh": "alice/app", "https": "acme/team/app", "url": "acme/app"}
+	for remote, url := range urls {
+		runGit(t, dir, "remote", "add", remote, url)
+		if got := remoteRepo(dir, remote); got != want[remote] {
+			t.Errorf("remoteRepo(%s) = %q, want %q", url, got, want[remote])
+		}
+	}
+	if got := remoteRepo(dir, "missing"); got != "" {
+		t.Errorf("expected no repository for a missing remote, got %q", got)
+	}
 }
 
 func TestDeleteBranch(t *testing.T) {
@@ -85,7 +230,20 @@ func TestPRTitleFromPRD(t *testing.T) {
 }
 
 func TestPRBodyFromPRD(t *testing.T) {
-	t.Run("includes summary and completed stories", func(t *testing.T) {
+	t.Run("links stories to their tickets", func(t *testing.T) {
+		p := &prd.PRD{
+			UserStories: []prd.UserStory{
+				{ID: "US-001", Title: "Config System", Passes: true, TicketURL: "https://github.com/acme/app/issues/12"},
+			},
+		}
+
+		body := PRBodyFromPRD(p, "")
+		if !contains(body, "- [x] US-001: Config System ([ticket](https://github.com/acme/app/issues/12))") {
+			t.Errorf("body missing ticket link:\n%s", body)
+		}
+	})
+
+	t.Run("includes summary, story checklist and PRD path", func(t *testing.T) {
 		p := &prd.PRD{
 			Project:     "Test Project",
 			Description: "This is a test project description.",
@@ -96,7 +254,7 @@ func TestPRBodyFromPRD(t *testing.T) {
 			},
 		}
 
-		body := PRBodyFromPRD(p)
+		body := PRBodyFromPRD(p, "/prds/test/prd.json")
 
 		// Check summary section
 		if got := body; got == "" {
@@ -109,36 +267,89 @@ func TestPRBodyFromPRD(t *testing.T) {
 			t.Error("body missing project description")
 		}
 
-		// Check changes section
-		if !contains(body, "## Changes") {
-			t.Error("body missing ## Changes header")
+		// Check the story checklist
+		if !contains(body, "## Stories") {
+			t.Error("body missing ## Stories header")
 		}
-		if !contains(body, "US-001: Config System") {
-			t.Error("body missing completed story US-001")
+		if !contains(body, "- [x] US-001: Config System") {
+			t.Error("body missing checked story US-001")
 		}
-		if !contains(body, "US-002: Git Worktree Primitives") {
-			t.Error("body missing completed story US-002")
+		if !contains(body, "- [x] US-002: Git Worktree Primitives") {
+			t.Error("body missing checked story US-002")
+		}
+		if !contains(body, "- [ ] US-003: Incomplete Story") {
+			t.Error("body missing unchecked story US-003")
 		}
 
-		// Incomplete stories should not be listed
-		if contains(body, "Incomplete Story") {
-			t.Error("body should not include incomplete stories")
+		if !contains(body, "PRD: `/prds/test/prd.json`") {
+			t.Errorf("body missing the PRD path:\n%s", body)
 		}
 	})
 
-	t.Run("empty stories produces changes header only", func(t *testing.T) {
+	t.Run("empty stories produces stories header only", func(t *testing.T) {
 		p := &prd.PRD{
 			Project:     "Empty Project",
 			Description: "No stories yet.",
 			UserStories: []prd.UserStory{},
 		}
 
-		body := PRBodyFromPRD(p)
+		body := PRBodyFromPRD(p, "")
 		if !contains(body, "## Summary") {
 			t.Error("body missing ## Summary header")
 		}
-		if !contains(body, "## Changes") {
-			t.Error("body missing ## Changes header")
+		if !contains(body, "## Stories") {
+			t.Error("body missing ## Stories header")
+		}
+		if contains(body, "PRD:") {
+			t.Error("body should not mention a PRD path without one")
+		}
+	})
+}
+
+func TestPRBody(t *testing.T) {
+	p := &prd.PRD{
+		Project:     "Auth",
+		Description: "Login and sessions.",
+		UserStories: []prd.UserStory{
+			{ID: "US-001", Title: "Login form", Passes: true},
+			{ID: "US-002", Title: "Sessions"},
+		},
+	}
+
+	t.Run("falls back to the default body without a template", func(t *testing.T) {
+		body, err := PRBody(filepath.Join(t.TempDir(), "pr-template.md"), p, "/prds/auth/prd.json")
+		if err != nil {
+			t.Fatalf("PRBody() error = %v", err)
+		}
+		if body != PRBodyFromPRD(p, "/prds/auth/prd.json") {
+			t.Errorf("expected the default body, got:\n%s", body)
+		}
+	})
+
+	t.Run("fills the template", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "pr-template.md")
+		tmpl := "# {{.Project}}\n\n{{.Description}}\n\n{{.Stories}}\nPRD: {{.PRDPath}}\n"
+		if err := os.WriteFile(path, []byte(tmpl), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		body, err := PRBody(path, p, "/prds/auth/prd.json")
+		if err != nil {
+			t.Fatalf("PRBody() error = %v", err)
+		}
+		want := "# Auth\n\nLogin and sessions.\n\n- [x] US-001: Login form\n- [ ] US-002: Sessions\n\nPRD: /prds/auth/prd.json\n"
+		if body != want {
+			t.Errorf("PRBody() =\n%s\nwant:\n%s", body, want)
+		}
+	})
+
+	t.Run("reports a broken template", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "pr-template.md")
+		if err := os.WriteFile(path, []byte("{{.Missing}"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := PRBody(path, p, ""); err == nil {
+			t.Error("expected an error for an invalid template")
 		}
 	})
 }
@@ -0,0 +1,79 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// ErrSigningFailed is returned when git can't sign a commit chief asked it to
+// sign, usually because gpg.format, user.signingkey or the signing agent is
+// not set up.
+var ErrSigningFailed = errors.New("commit signing failed")
+
+// signArgs returns the flag that makes a git command sign the commits it
+// creates, whichever of GPG or SSH gpg.format selects.
+func signArgs(sign bool) []string {
+	if sign {
+		return []string{"--gpg-sign"}
+	}
+	return nil
+}
+
+// signingError returns an ErrSigningFailed error when git's output shows it
+// couldn't sign a commit, or nil otherwise.
+func signingError(out []byte) error {
+	text := strings.TrimSpace(string(out))
+	lower := strings.ToLower(text)
+	if !strings.Contains(lower, "failed to sign") && !strings.Contains(lower, "gpg failed") && !strings.Contains(lower, "signing failed") {
+		return nil
+	}
+	return fmt.Errorf("%w (check gpg.format and user.signingkey in your git config): %s", ErrSigningFailed, text)
+}
+
+// CheckSigning signs a throwaway commit of the empty tree in dir, so a broken
+// signing setup is reported before chief or the agent starts committing
+// rather than as a rejected push later. The empty tree keeps the check working
+// in a repository with no commits yet. Nothing points at the commit, and git
+// discards it in its next garbage collection.
+func CheckSigning(dir string) error {
+	mktree := exec.Command("git", "mktree")
+	mktree.Dir = dir
+	tree, err := mktree.Output()
+	if err != nil {
+		return fmt.Errorf("failed to create empty tree: %w", err)
+	}
+
+	cmd := exec.Command("git", "commit-tree", "--gpg-sign", "-m", "chief signing check", strings.TrimSpace(string(tree)))
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		if signErr := signingError(out); signErr != nil {
+			return signErr
+		}
+		return fmt.Errorf("%w (check gpg.format and user.signingkey in your git config): %s", ErrSigningFailed, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// SigningEnv returns environ with commit.gpgsign turned on, so git commands
+// run with it, such as the agent's, sign every commit they make. Settings
+// already passed through GIT_CONFIG_COUNT are kept.
+func SigningEnv(environ []string) []string {
+	count := 0
+	env := make([]string, 0, len(environ)+3)
+	for _, kv := range environ {
+		if value, ok := strings.CutPrefix(kv, "GIT_CONFIG_COUNT="); ok {
+			count, _ = strconv.Atoi(value)
+			continue
+		}
+		env = append(env, kv)
+	}
+	return append(env,
+		fmt.Sprintf("GIT_CONFIG_KEY_%d=commit.gpgsign", count),
+		fmt.Sprintf("GIT_CONFIG_VALUE_%d=true", count),
+		fmt.Sprintf("GIT_CONFIG_COUNT=%d", count+1),
+	)
+}
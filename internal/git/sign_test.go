@@ -0,0 +1,72 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSigningEnv(t *testing.T) {
+	got := SigningEnv([]string{"PATH=/bin"})
+	want := []string{"PATH=/bin", "GIT_CONFIG_KEY_0=commit.gpgsign", "GIT_CONFIG_VALUE_0=true", "GIT_CONFIG_COUNT=1"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SigningEnv() = %v, want %v", got, want)
+	}
+
+	// Config already passed through the environment is kept
+	got = SigningEnv([]string{"GIT_CONFIG_COUNT=1", "GIT_CONFIG_KEY_0=core.pager", "GIT_CONFIG_VALUE_0=cat"})
+	want = []string{"GIT_CONFIG_KEY_0=core.pager", "GIT_CONFIG_VALUE_0=cat", "GIT_CONFIG_KEY_1=commit.gpgsign", "GIT_CONFIG_VALUE_1=true", "GIT_CONFIG_COUNT=2"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SigningEnv() = %v, want %v", got, want)
+	}
+}
+
+// breakSigning points git at a signing program that always fails.
+func breakSigning(t *testing.T, dir string) {
+	t.Helper()
+	runGit(t, dir, "config", "gpg.program", "false")
+}
+
+func TestCheckSigning(t *testing.T) {
+	dir := initTestRepo(t)
+	breakSigning(t, dir)
+
+	if err := CheckSigning(dir); !errors.Is(err, ErrSigningFailed) {
+		t.Errorf("CheckSigning() error = %v, want ErrSigningFailed", err)
+	}
+
+	// A repository with no commits yet has no HEAD to sign
+	unborn := t.TempDir()
+	runGit(t, unborn, "init")
+	breakSigning(t, unborn)
+	if err := CheckSigning(unborn); !errors.Is(err, ErrSigningFailed) || strings.Contains(err.Error(), "HEAD") {
+		t.Errorf("CheckSigning() on an unborn HEAD error = %v, want ErrSigningFailed", err)
+	}
+}
+
+func TestMergeBranchSigningFailure(t *testing.T) {
+	for _, strategy := range []string{StrategyMerge, StrategySquash} {
+		t.Run(strategy, func(t *testing.T) {
+			dir := divergedRepo(t, "feature.txt")
+			breakSigning(t, dir)
+			head := runGit(t, dir, "rev-parse", "HEAD")
+
+			if _, err := MergeBranch(dir, "feature", strategy, true); !errors.Is(err, ErrSigningFailed) {
+				t.Fatalf("MergeBranch() error = %v, want ErrSigningFailed", err)
+			}
+			// Main is left as it was, not mid-merge
+			if got := runGit(t, dir, "rev-parse", "HEAD"); got != head {
+				t.Errorf("HEAD moved to %s", got)
+			}
+			if status := runGit(t, dir, "status", "--porcelain"); status != "" {
+				t.Errorf("expected a clean tree, got %q", status)
+			}
+			if _, err := os.Stat(filepath.Join(dir, ".git", "MERGE_HEAD")); err == nil {
+				t.Error("expected the merge to be aborted")
+			}
+		})
+	}
+}
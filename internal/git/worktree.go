@@ -20,8 +20,47 @@ type Worktree struct {
 
 // GetDefaultBranch detects the default branch (main or master) for a repository.
 func GetDefaultBranch(repoDir string) (string, error) {
+	return defaultBranchOf(repoDir, DefaultRemote)
+}
+
+// BaseBranch returns the branch pull requests target: configured when it's
+// set, otherwise the default branch GetDefaultBranch detects.
+func BaseBranch(repoDir, configured string) (string, error) {
+	if configured != "" {
+		return configured, nil
+	}
+	return GetDefaultBranch(repoDir)
+}
+
+// BaseRef returns the ref PRD branches start from and are compared with for
+// base, a configured git.baseBranch: the local branch when there is one,
+// otherwise DefaultRemote's copy of it. An empty base means the default
+// branch.
+func BaseRef(repoDir, base string) (string, error) {
+	if base == "" {
+		return GetDefaultBranch(repoDir)
+	}
+	if exists, _ := BranchExists(repoDir, base); exists {
+		return base, nil
+	}
+	if remoteRef := DefaultRemote + "/" + base; branchRefExists(repoDir, "refs/remotes/"+remoteRef) {
+		return remoteRef, nil
+	}
+	return "", fmt.Errorf("base branch %s not found locally or on %s", base, DefaultRemote)
+}
+
+// branchRefExists reports whether the full ref exists.
+func branchRefExists(repoDir, ref string) bool {
+	cmd := exec.Command("git", "show-ref", "--verify", "--quiet", ref)
+	cmd.Dir = repoDir
+	return cmd.Run() == nil
+}
+
+// defaultBranchOf detects the default branch from remote's HEAD, or failing
+// that whichever of main and master exists.
+func defaultBranchOf(repoDir, remote string) (string, error) {
 	// Try symbolic-ref first (works for repos with remotes)
-	cmd := exec.Command("git", "symbolic-ref", "refs/remotes/origin/HEAD")
+	cmd := exec.Command("git", "symbolic-ref", "refs/remotes/"+remote+"/HEAD")
 	cmd.Dir = repoDir
 	output, err := cmd.Output()
 	if err == nil {
@@ -47,10 +86,16 @@ func GetDefaultBranch(repoDir string) (string, error) {
 	return "", fmt.Errorf("could not detect default branch (tried main, master)")
 }
 
-// CreateWorktree creates a branch from the default branch and adds a worktree at the given path.
+// CreateWorktree creates a branch from base (empty means the default branch)
+// and adds a worktree at the given path.
 // If the worktree path already exists and is a valid worktree on the expected branch, it is reused.
 // If the worktree path exists but is stale (wrong branch or invalid), it is removed and recreated.
-func CreateWorktree(repoDir, worktreePath, branch string) error {
+//
+// Linked worktrees share the repository's object store, so they never copy
+// history; on large repos the slow part is writing the files. With shallow
+// set, the files are checked out in parallel (git 2.32+; older versions
+// check out serially as usual).
+func CreateWorktree(repoDir, worktreePath, branch, base string, shallow bool) error {
 	absWorktreePath, err := filepath.Abs(worktreePath)
 	if err != nil {
 		return fmt.Errorf("failed to resolve worktree path: %w", err)
@@ -70,18 +115,18 @@ func CreateWorktree(repoDir, worktreePath, branch string) error {
 		}
 	}
 
-	defaultBranch, err := GetDefaultBranch(repoDir)
+	startRef, err := BaseRef(repoDir, base)
 	if err != nil {
-		return fmt.Errorf("failed to detect default branch: %w", err)
+		return fmt.Errorf("failed to find the base branch: %w", err)
 	}
 
-	// Create the branch from the default branch if it doesn't exist
+	// Create the branch from the base branch if it doesn't exist
 	exists, err := BranchExists(repoDir, branch)
 	if err != nil {
 		return fmt.Errorf("failed to check branch existence: %w", err)
 	}
 	if !exists {
-		cmd := exec.Command("git", "branch", branch, defaultBranch)
+		cmd := exec.Command("git", "branch", branch, startRef)
 		cmd.Dir = repoDir
 		if out, err := cmd.CombinedOutput(); err != nil {
 			return fmt.Errorf("failed to create branch %s: %s", branch, strings.TrimSpace(string(out)))
@@ -89,12 +134,25 @@ func CreateWorktree(repoDir, worktreePath, branch string) error {
 	}
 
 	// Add the worktree
-	cmd := exec.Command("git", "worktree", "add", absWorktreePath, branch)
+	args := []string{"worktree", "add", absWorktreePath, branch}
+	if shallow {
+		args = []string{"worktree", "add", "--no-checkout", absWorktreePath, branch}
+	}
+	cmd := exec.Command("git", args...)
 	cmd.Dir = repoDir
 	if out, err := cmd.CombinedOutput(); err != nil {
 		return fmt.Errorf("failed to add worktree: %s", strings.TrimSpace(string(out)))
 	}
 
+	if shallow {
+		// checkout.workers=0 uses one worker per CPU
+		cmd := exec.Command("git", "-c", "checkout.workers=0", "checkout", "HEAD", "--", ".")
+		cmd.Dir = absWorktreePath
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to check out worktree: %s", strings.TrimSpace(string(out)))
+		}
+	}
+
 	return nil
 }
 
@@ -108,6 +166,38 @@ func RemoveWorktree(repoDir, worktreePath string) error {
 	return nil
 }
 
+// CreateDetachedWorktree adds a worktree at worktreePath with ref checked out
+// as a detached HEAD, so no branch is created for it.
+func CreateDetachedWorktree(repoDir, worktreePath, ref string) error {
+	cmd := exec.Command("git", "worktree", "add", "--detach", worktreePath, ref)
+	cmd.Dir = repoDir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to add worktree: %s", strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// ForceRemoveWorktree removes a git worktree even if it has untracked or
+// modified files.
+func ForceRemoveWorktree(repoDir, worktreePath string) error {
+	cmd := exec.Command("git", "worktree", "remove", "--force", worktreePath)
+	cmd.Dir = repoDir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to remove worktree: %s", strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// MoveWorktree moves a git worktree from oldPath to newPath.
+func MoveWorktree(repoDir, oldPath, newPath string) error {
+	cmd := exec.Command("git", "worktree", "move", oldPath, newPath)
+	cmd.Dir = repoDir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to move worktree: %s", strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
 // ListWorktrees parses `git worktree list --porcelain` and returns all worktrees.
 func ListWorktrees(repoDir string) ([]Worktree, error) {
 	cmd := exec.Command("git", "worktree", "list", "--porcelain")
@@ -182,9 +272,27 @@ func IsWorktree(path string) bool {
 	return true
 }
 
-// WorktreePathForPRD returns the worktree path for a given PRD name.
-func WorktreePathForPRD(baseDir, prdName string) string {
-	return paths.WorktreeDir(baseDir, prdName)
+// WorktreesDir returns the directory worktrees are created in: a directory
+// named after the project inside the configured worktree.baseDir, with "~/"
+// expanded and a relative path taken from the project directory, or chief's
+// worktrees directory when it's empty. The project level keeps projects
+// sharing one baseDir from colliding or seeing each other's worktrees as
+// orphans.
+func WorktreesDir(baseDir, configured string) string {
+	if configured == "" {
+		return paths.WorktreesDir(baseDir)
+	}
+	dir := paths.ExpandHome(configured)
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(baseDir, dir)
+	}
+	return filepath.Join(dir, paths.ProjectID(baseDir))
+}
+
+// WorktreePathForPRD returns the worktree path for a given PRD name, inside
+// WorktreesDir(baseDir, configured).
+func WorktreePathForPRD(baseDir, configured, prdName string) string {
+	return filepath.Join(WorktreesDir(baseDir, configured), prdName)
 }
 
 // PruneWorktrees runs `git worktree prune` to clean up stale worktree tracking.
@@ -197,11 +305,11 @@ func PruneWorktrees(repoDir string) error {
 	return nil
 }
 
-// DetectOrphanedWorktrees scans the worktrees directory and returns a map of PRD name -> absolute worktree path
-// for worktrees that exist on disk. The caller is responsible for determining which are orphaned
-// (i.e., have no corresponding registered/running PRD).
-func DetectOrphanedWorktrees(baseDir string) map[string]string {
-	worktreesDir := paths.WorktreesDir(baseDir)
+// DetectOrphanedWorktrees scans the worktrees directory (see WorktreesDir) and returns a map of PRD name ->
+// absolute worktree path for worktrees that exist on disk. The caller is responsible for determining which
+// are orphaned (i.e., have no corresponding registered/running PRD).
+func DetectOrphanedWorktrees(baseDir, configured string) map[string]string {
+	worktreesDir := WorktreesDir(baseDir, configured)
 	entries, err := os.ReadDir(worktreesDir)
 	if err != nil {
 		return nil
@@ -218,12 +326,41 @@ func DetectOrphanedWorktrees(baseDir string) map[string]string {
 	return result
 }
 
-// MergeBranch merges a branch into the current branch, returning conflicting file list on failure.
-func MergeBranch(repoDir, branch string) ([]string, error) {
-	cmd := exec.Command("git", "merge", branch)
+// Integration strategies for MergeBranch.
+const (
+	StrategyMerge  = "merge"  // Merge the branch, creating a merge commit unless it fast-forwards
+	StrategyRebase = "rebase" // Rebase the branch onto the current branch, then fast-forward
+	StrategySquash = "squash" // Squash the branch into a single commit on the current branch
+)
+
+// MergeBranch integrates a branch into the current branch using strategy
+// (StrategyMerge, StrategyRebase or StrategySquash; empty means StrategyMerge),
+// returning conflicting file list on failure. With sign, the commits it
+// creates are signed, and a signing failure returns ErrSigningFailed.
+func MergeBranch(repoDir, branch, strategy string, sign bool) ([]string, error) {
+	switch strategy {
+	case StrategyRebase:
+		return rebaseBranch(repoDir, branch, sign)
+	case StrategySquash:
+		return squashBranch(repoDir, branch, sign)
+	default:
+		return mergeBranch(repoDir, branch, sign)
+	}
+}
+
+// mergeBranch merges a branch into the current branch.
+func mergeBranch(repoDir, branch string, sign bool) ([]string, error) {
+	cmd := exec.Command("git", append(append([]string{"merge"}, signArgs(sign)...), branch)...)
 	cmd.Dir = repoDir
 	out, err := cmd.CombinedOutput()
 	if err != nil {
+		if signErr := signingError(out); signErr != nil {
+			// Leave the current branch as it was rather than mid-merge
+			abortCmd := exec.Command("git", "merge", "--abort")
+			abortCmd.Dir = repoDir
+			_ = abortCmd.Run()
+			return nil, signErr
+		}
 		// Parse conflicting files from merge output
 		conflicts := parseConflicts(repoDir)
 		if len(conflicts) > 0 {
@@ -238,6 +375,111 @@ func MergeBranch(repoDir, branch string) ([]string, error) {
 	return nil, nil
 }
 
+// squashBranch squashes a branch's changes into a single commit on the current branch.
+func squashBranch(repoDir, branch string, sign bool) ([]string, error) {
+	cmd := exec.Command("git", "merge", "--squash", branch)
+	cmd.Dir = repoDir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		conflicts := parseConflicts(repoDir)
+		if len(conflicts) > 0 {
+			// A squash merge has no MERGE_HEAD, so reset rather than --abort
+			resetCmd := exec.Command("git", "reset", "--merge")
+			resetCmd.Dir = repoDir
+			_ = resetCmd.Run()
+			return conflicts, fmt.Errorf("merge conflict: %s", strings.TrimSpace(string(out)))
+		}
+		return nil, fmt.Errorf("squash failed: %s", strings.TrimSpace(string(out)))
+	}
+
+	// Commit with the message git prepared, which lists the squashed commits
+	cmd = exec.Command("git", append([]string{"commit", "--no-edit"}, signArgs(sign)...)...)
+	cmd.Dir = repoDir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		if signErr := signingError(out); signErr != nil {
+			resetCmd := exec.Command("git", "reset", "--merge")
+			resetCmd.Dir = repoDir
+			_ = resetCmd.Run()
+			return nil, signErr
+		}
+		return nil, fmt.Errorf("squash commit failed: %s", strings.TrimSpace(string(out)))
+	}
+	return nil, nil
+}
+
+// rebaseBranch rebases a branch onto the current branch and fast-forwards the
+// current branch to it. If the branch is checked out in a worktree the rebase
+// runs there, since git won't check it out a second time.
+func rebaseBranch(repoDir, branch string, sign bool) ([]string, error) {
+	target, err := GetCurrentBranch(repoDir)
+	if err != nil {
+		return nil, err
+	}
+
+	dir := repoDir
+	args := append(append([]string{"rebase"}, signArgs(sign)...), target, branch)
+	if wt := worktreeForBranch(repoDir, branch); wt != "" {
+		dir = wt
+		args = args[:len(args)-1]
+	}
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		conflicts := parseConflicts(dir)
+		// Abort the rebase to leave a clean state
+		abortCmd := exec.Command("git", "rebase", "--abort")
+		abortCmd.Dir = dir
+		_ = abortCmd.Run()
+		if dir == repoDir {
+			// The rebase switched to the branch before it stopped; switch back
+			checkoutCmd := exec.Command("git", "checkout", target)
+			checkoutCmd.Dir = repoDir
+			_ = checkoutCmd.Run()
+		}
+		if len(conflicts) > 0 {
+			return conflicts, fmt.Errorf("rebase conflict: %s", strings.TrimSpace(string(out)))
+		}
+		if signErr := signingError(out); signErr != nil {
+			return nil, signErr
+		}
+		return nil, fmt.Errorf("rebase failed: %s", strings.TrimSpace(string(out)))
+	}
+
+	// Rebasing in the main checkout leaves the branch checked out
+	if dir == repoDir {
+		cmd = exec.Command("git", "checkout", target)
+		cmd.Dir = repoDir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return nil, fmt.Errorf("failed to check out %s: %s", target, strings.TrimSpace(string(out)))
+		}
+	}
+
+	cmd = exec.Command("git", "merge", "--ff-only", branch)
+	cmd.Dir = repoDir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("fast-forward failed: %s", strings.TrimSpace(string(out)))
+	}
+	return nil, nil
+}
+
+// worktreeForBranch returns the path of the linked worktree that has branch
+// checked out, or empty if there is none.
+func worktreeForBranch(repoDir, branch string) string {
+	worktrees, err := ListWorktrees(repoDir)
+	if err != nil {
+		return ""
+	}
+	// The first entry is the main worktree
+	for i, wt := range worktrees {
+		if i > 0 && wt.Branch == branch {
+			return wt.Path
+		}
+	}
+	return ""
+}
+
 // parseConflicts uses `git diff --name-only --diff-filter=U` to find conflicting files.
 func parseConflicts(repoDir string) []string {
 	cmd := exec.Command("git", "diff", "--name-only", "--diff-filter=U")
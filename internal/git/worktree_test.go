@@ -105,7 +105,7 @@ func TestCreateWorktree(t *testing.T) {
 		dir := initTestRepo(t)
 		wtPath := filepath.Join(dir, "worktrees", "test-prd")
 
-		err := CreateWorktree(dir, wtPath, "chief/test-prd")
+		err := CreateWorktree(dir, wtPath, "chief/test-prd", "", false)
 		if err != nil {
 			t.Fatalf("CreateWorktree() error = %v", err)
 		}
@@ -120,12 +120,51 @@ func TestCreateWorktree(t *testing.T) {
 		}
 	})
 
+	t.Run("branches from the configured base", func(t *testing.T) {
+		dir := initTestRepo(t)
+		runGit(t, dir, "checkout", "-b", "develop")
+		runGit(t, dir, "commit", "--allow-empty", "-m", "develop only")
+		runGit(t, dir, "checkout", "main")
+		wtPath := filepath.Join(dir, "worktrees", "test-prd")
+
+		if err := CreateWorktree(dir, wtPath, "chief/test-prd", "develop", false); err != nil {
+			t.Fatalf("CreateWorktree() error = %v", err)
+		}
+		if got := runGit(t, wtPath, "log", "-1", "--format=%s"); got != "develop only" {
+			t.Errorf("expected the branch to start from develop, got tip %q", got)
+		}
+		if ahead, err := CommitsAhead(dir, "develop", "chief/test-prd"); err != nil || ahead != 0 {
+			t.Errorf("CommitsAhead(develop) = %d, %v, want 0", ahead, err)
+		}
+		if ahead, err := CommitsAhead(dir, "", "chief/test-prd"); err != nil || ahead != 1 {
+			t.Errorf("CommitsAhead(main) = %d, %v, want develop's commit", ahead, err)
+		}
+		if err := CreateWorktree(dir, filepath.Join(dir, "worktrees", "other"), "chief/other", "missing", false); err == nil {
+			t.Error("expected an error for a base branch that doesn't exist")
+		}
+	})
+
+	t.Run("shallow checkout populates the worktree", func(t *testing.T) {
+		dir := initTestRepo(t)
+		wtPath := filepath.Join(dir, "worktrees", "test-prd")
+
+		if err := CreateWorktree(dir, wtPath, "chief/test-prd", "", true); err != nil {
+			t.Fatalf("CreateWorktree() error = %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(wtPath, "README.md")); err != nil {
+			t.Errorf("expected README.md checked out: %v", err)
+		}
+		if status := runGit(t, wtPath, "status", "--porcelain"); status != "" {
+			t.Errorf("expected a clean worktree, got: %s", status)
+		}
+	})
+
 	t.Run("reuses existing valid worktree", func(t *testing.T) {
 		dir := initTestRepo(t)
 		wtPath := filepath.Join(dir, "worktrees", "test-prd")
 
 		// Create worktree first time
-		if err := CreateWorktree(dir, wtPath, "chief/test-prd"); err != nil {
+		if err := CreateWorktree(dir, wtPath, "chief/test-prd", "", false); err != nil {
 			t.Fatalf("first CreateWorktree() error = %v", err)
 		}
 
@@ -136,7 +175,7 @@ func TestCreateWorktree(t *testing.T) {
 		}
 
 		// Create again - should reuse
-		if err := CreateWorktree(dir, wtPath, "chief/test-prd"); err != nil {
+		if err := CreateWorktree(dir, wtPath, "chief/test-prd", "", false); err != nil {
 			t.Fatalf("second CreateWorktree() error = %v", err)
 		}
 
@@ -151,12 +190,12 @@ func TestCreateWorktree(t *testing.T) {
 		wtPath := filepath.Join(dir, "worktrees", "test-prd")
 
 		// Create worktree with one branch
-		if err := CreateWorktree(dir, wtPath, "chief/branch-a"); err != nil {
+		if err := CreateWorktree(dir, wtPath, "chief/branch-a", "", false); err != nil {
 			t.Fatalf("first CreateWorktree() error = %v", err)
 		}
 
 		// Create again with a different branch - should remove and recreate
-		if err := CreateWorktree(dir, wtPath, "chief/branch-b"); err != nil {
+		if err := CreateWorktree(dir, wtPath, "chief/branch-b", "", false); err != nil {
 			t.Fatalf("second CreateWorktree() error = %v", err)
 		}
 
@@ -175,7 +214,7 @@ func TestRemoveWorktree(t *testing.T) {
 		dir := initTestRepo(t)
 		wtPath := filepath.Join(dir, "worktrees", "test-prd")
 
-		if err := CreateWorktree(dir, wtPath, "chief/test-prd"); err != nil {
+		if err := CreateWorktree(dir, wtPath, "chief/test-prd", "", false); err != nil {
 			t.Fatalf("CreateWorktree() error = %v", err)
 		}
 
@@ -196,7 +235,7 @@ func TestListWorktrees(t *testing.T) {
 		dir := initTestRepo(t)
 		wtPath := filepath.Join(dir, "worktrees", "test-prd")
 
-		if err := CreateWorktree(dir, wtPath, "chief/test-prd"); err != nil {
+		if err := CreateWorktree(dir, wtPath, "chief/test-prd", "", false); err != nil {
 			t.Fatalf("CreateWorktree() error = %v", err)
 		}
 
@@ -230,7 +269,7 @@ func TestIsWorktree(t *testing.T) {
 		dir := initTestRepo(t)
 		wtPath := filepath.Join(dir, "worktrees", "test-prd")
 
-		if err := CreateWorktree(dir, wtPath, "chief/test-prd"); err != nil {
+		if err := CreateWorktree(dir, wtPath, "chief/test-prd", "", false); err != nil {
 			t.Fatalf("CreateWorktree() error = %v", err)
 		}
 
@@ -258,11 +297,25 @@ func TestWorktreePathForPRD(t *testing.T) {
 	restore := paths.SetHomeDir(tmpHome)
 	defer restore()
 
-	result := WorktreePathForPRD("/home/user/project", "auth")
+	result := WorktreePathForPRD("/home/user/project", "", "auth")
 	expected := paths.WorktreeDir("/home/user/project", "auth")
 	if result != expected {
 		t.Errorf("WorktreePathForPRD() = %q, want %q", result, expected)
 	}
+
+	tests := []struct {
+		configured string
+		want       string
+	}{
+		{"/srv/worktrees", "/srv/worktrees/project/auth"},
+		{"~/worktrees/", filepath.Join(tmpHome, "worktrees", "project", "auth")},
+		{"../project-worktrees", "/home/user/project-worktrees/project/auth"},
+	}
+	for _, tt := range tests {
+		if got := WorktreePathForPRD("/home/user/project", tt.configured, "auth"); got != tt.want {
+			t.Errorf("WorktreePathForPRD(%q) = %q, want %q", tt.configured, got, tt.want)
+		}
+	}
 }
 
 func TestPruneWorktrees(t *testing.T) {
@@ -308,7 +361,7 @@ func TestMergeBranch(t *testing.T) {
 			t.Fatalf("checkout main failed: %s", string(out))
 		}
 
-		conflicts, err := MergeBranch(dir, "feature")
+		conflicts, err := MergeBranch(dir, "feature", StrategyMerge, false)
 		if err != nil {
 			t.Fatalf("MergeBranch() error = %v", err)
 		}
@@ -368,7 +421,7 @@ func TestMergeBranch(t *testing.T) {
 			t.Fatalf("checkout main failed: %s", string(out))
 		}
 
-		conflicts, err := MergeBranch(dir, "feature")
+		conflicts, err := MergeBranch(dir, "feature", StrategyMerge, false)
 		if err == nil {
 			t.Fatal("MergeBranch() expected error for conflict, got nil")
 		}
@@ -396,10 +449,126 @@ func TestMergeBranch(t *testing.T) {
 	})
 }
 
+// runGit runs a git command in dir, failing the test on error.
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v failed: %s", args, string(out))
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// commitFile writes a file in dir and commits it.
+func commitFile(t *testing.T, dir, name, content, message string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+	runGit(t, dir, "add", ".")
+	runGit(t, dir, "commit", "-m", message)
+}
+
+// divergedRepo returns a repo on main where main and feature have each
+// gained a commit since they forked.
+func divergedRepo(t *testing.T, featureFile string) string {
+	t.Helper()
+	dir := initTestRepo(t)
+	runGit(t, dir, "checkout", "-b", "feature")
+	commitFile(t, dir, featureFile, "feature\n", "feature one")
+	commitFile(t, dir, "feature2.txt", "feature\n", "feature two")
+	runGit(t, dir, "checkout", "main")
+	commitFile(t, dir, "main.txt", "main\n", "main change")
+	return dir
+}
+
+func TestMergeBranchSquash(t *testing.T) {
+	dir := divergedRepo(t, "feature.txt")
+
+	if _, err := MergeBranch(dir, "feature", StrategySquash, false); err != nil {
+		t.Fatalf("MergeBranch() error = %v", err)
+	}
+
+	// One new commit with a single parent, carrying both feature commits
+	if parents := runGit(t, dir, "log", "-1", "--format=%P"); strings.Contains(parents, " ") {
+		t.Errorf("expected a single-parent commit, got parents %q", parents)
+	}
+	if got := runGit(t, dir, "rev-list", "--count", "HEAD"); got != "3" {
+		t.Errorf("expected 3 commits on main, got %s", got)
+	}
+	for _, f := range []string{"feature.txt", "feature2.txt"} {
+		if _, err := os.Stat(filepath.Join(dir, f)); err != nil {
+			t.Errorf("%s not present after squash", f)
+		}
+	}
+}
+
+func TestMergeBranchSquashConflict(t *testing.T) {
+	dir := divergedRepo(t, "main.txt")
+
+	conflicts, err := MergeBranch(dir, "feature", StrategySquash, false)
+	if err == nil || len(conflicts) != 1 || conflicts[0] != "main.txt" {
+		t.Fatalf("expected a conflict on main.txt, got %v (err %v)", conflicts, err)
+	}
+	if status := runGit(t, dir, "status", "--porcelain"); status != "" {
+		t.Errorf("expected clean working tree after squash conflict, got: %s", status)
+	}
+}
+
+func TestMergeBranchRebase(t *testing.T) {
+	t.Run("branch not checked out", func(t *testing.T) {
+		dir := divergedRepo(t, "feature.txt")
+
+		if _, err := MergeBranch(dir, "feature", StrategyRebase, false); err != nil {
+			t.Fatalf("MergeBranch() error = %v", err)
+		}
+		if branch := runGit(t, dir, "rev-parse", "--abbrev-ref", "HEAD"); branch != "main" {
+			t.Errorf("expected main checked out after rebase, got %s", branch)
+		}
+		// Linear history: no merge commits, all four commits on main
+		if merges := runGit(t, dir, "rev-list", "--merges", "HEAD"); merges != "" {
+			t.Errorf("expected no merge commits, got %s", merges)
+		}
+		if got := runGit(t, dir, "rev-list", "--count", "HEAD"); got != "4" {
+			t.Errorf("expected 4 commits on main, got %s", got)
+		}
+	})
+
+	t.Run("branch checked out in a worktree", func(t *testing.T) {
+		dir := divergedRepo(t, "feature.txt")
+		wtPath := filepath.Join(t.TempDir(), "wt")
+		runGit(t, dir, "worktree", "add", wtPath, "feature")
+
+		if _, err := MergeBranch(dir, "feature", StrategyRebase, false); err != nil {
+			t.Fatalf("MergeBranch() error = %v", err)
+		}
+		if main, feature := runGit(t, dir, "rev-parse", "main"), runGit(t, dir, "rev-parse", "feature"); main != feature {
+			t.Errorf("expected main fast-forwarded to feature, got %s vs %s", main, feature)
+		}
+	})
+
+	t.Run("conflict aborts the rebase", func(t *testing.T) {
+		dir := divergedRepo(t, "main.txt")
+
+		conflicts, err := MergeBranch(dir, "feature", StrategyRebase, false)
+		if err == nil || len(conflicts) != 1 || conflicts[0] != "main.txt" {
+			t.Fatalf("expected a conflict on main.txt, got %v (err %v)", conflicts, err)
+		}
+		if branch := runGit(t, dir, "rev-parse", "--abbrev-ref", "HEAD"); branch != "main" {
+			t.Errorf("expected main checked out after abort, got %s", branch)
+		}
+		if status := runGit(t, dir, "status", "--porcelain"); status != "" {
+			t.Errorf("expected clean working tree after abort, got: %s", status)
+		}
+	})
+}
+
 func TestDetectOrphanedWorktrees(t *testing.T) {
 	t.Run("returns nil when worktrees directory does not exist", func(t *testing.T) {
 		dir := t.TempDir()
-		result := DetectOrphanedWorktrees(dir)
+		result := DetectOrphanedWorktrees(dir, "")
 		if result != nil {
 			t.Errorf("expected nil, got %v", result)
 		}
@@ -411,7 +580,7 @@ func TestDetectOrphanedWorktrees(t *testing.T) {
 		if err := os.MkdirAll(worktreesDir, 0755); err != nil {
 			t.Fatalf("failed to create worktrees dir: %v", err)
 		}
-		result := DetectOrphanedWorktrees(dir)
+		result := DetectOrphanedWorktrees(dir, "")
 		if len(result) != 0 {
 			t.Errorf("expected empty map, got %v", result)
 		}
@@ -432,7 +601,7 @@ func TestDetectOrphanedWorktrees(t *testing.T) {
 			}
 		}
 
-		result := DetectOrphanedWorktrees(dir)
+		result := DetectOrphanedWorktrees(dir, "")
 		if len(result) != 2 {
 			t.Fatalf("expected 2 entries, got %d: %v", len(result), result)
 		}
@@ -473,7 +642,7 @@ func TestDetectOrphanedWorktrees(t *testing.T) {
 			t.Fatalf("failed to create file: %v", err)
 		}
 
-		result := DetectOrphanedWorktrees(dir)
+		result := DetectOrphanedWorktrees(dir, "")
 		if len(result) != 1 {
 			t.Fatalf("expected 1 entry (only dirs), got %d: %v", len(result), result)
 		}
@@ -481,4 +650,62 @@ func TestDetectOrphanedWorktrees(t *testing.T) {
 			t.Error("expected 'auth' in result")
 		}
 	})
+
+	t.Run("ignores other projects sharing a configured baseDir", func(t *testing.T) {
+		shared := t.TempDir()
+		api := filepath.Join(t.TempDir(), "api")
+		web := filepath.Join(t.TempDir(), "web")
+		if err := os.MkdirAll(WorktreePathForPRD(web, shared, "auth"), 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		if result := DetectOrphanedWorktrees(api, shared); len(result) != 0 {
+			t.Errorf("expected none of web's worktrees for api, got %v", result)
+		}
+		if result := DetectOrphanedWorktrees(web, shared); len(result) != 1 {
+			t.Errorf("expected web's worktree, got %v", result)
+		}
+	})
+}
+
+func TestMoveWorktree(t *testing.T) {
+	dir := initTestRepo(t)
+	oldPath := filepath.Join(dir, "worktrees", "old")
+	newPath := filepath.Join(dir, "worktrees", "new")
+
+	if err := CreateWorktree(dir, oldPath, "chief/old", "", false); err != nil {
+		t.Fatalf("CreateWorktree() error = %v", err)
+	}
+	if err := MoveWorktree(dir, oldPath, newPath); err != nil {
+		t.Fatalf("MoveWorktree() error = %v", err)
+	}
+	if _, err := os.Stat(oldPath); !os.IsNotExist(err) {
+		t.Error("old worktree path still exists after move")
+	}
+	if !IsWorktree(newPath) {
+		t.Error("expected worktree at new path")
+	}
+}
+
+func TestRenameBranch(t *testing.T) {
+	dir := initTestRepo(t)
+	wtPath := filepath.Join(dir, "worktrees", "old")
+	if err := CreateWorktree(dir, wtPath, "chief/old", "", false); err != nil {
+		t.Fatalf("CreateWorktree() error = %v", err)
+	}
+
+	// Renaming a branch checked out in a worktree should work
+	if err := RenameBranch(dir, "chief/old", "chief/new"); err != nil {
+		t.Fatalf("RenameBranch() error = %v", err)
+	}
+	if exists, _ := BranchExists(dir, "chief/new"); !exists {
+		t.Error("expected chief/new to exist")
+	}
+	if branch, _ := GetCurrentBranch(wtPath); branch != "chief/new" {
+		t.Errorf("worktree branch = %q, want chief/new", branch)
+	}
+
+	if err := RenameBranch(dir, "missing", "other"); err == nil {
+		t.Error("expected error renaming a missing branch")
+	}
 }
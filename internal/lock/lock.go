@@ -0,0 +1,153 @@
+// Package lock keeps two chief processes from working on the same project at
+// once. The lock is a file holding the owner's PID; a lock left behind by a
+// process that has since died is treated as stale and taken over.
+package lock
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// A new lock file is empty between its creation and the PID write. An
+// unreadable file is re-read a few times, and one younger than startGrace is
+// taken to belong to an instance still starting up rather than a dead one.
+const (
+	readAttempts = 5
+	readDelay    = 20 * time.Millisecond
+	startGrace   = 5 * time.Second
+)
+
+// HeldError is returned by Acquire when another live process holds the lock.
+type HeldError struct {
+	Path string // Lock file
+	PID  int    // Process holding it; 0 while its PID is not yet written
+}
+
+func (e *HeldError) Error() string {
+	if e.PID == 0 {
+		return "another chief instance is starting in this project"
+	}
+	return fmt.Sprintf("another chief instance (PID %d) is already running in this project", e.PID)
+}
+
+// Lock is a held project lock.
+type Lock struct {
+	path string
+}
+
+// processAlive reports whether a process with the given PID is running.
+// Replaced in tests.
+var processAlive = func(pid int) bool {
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	// On Windows FindProcess opens the process, so it only succeeds while the
+	// process exists; elsewhere it always succeeds and signal 0 probes it
+	if runtime.GOOS == "windows" {
+		return true
+	}
+	err = p.Signal(syscall.Signal(0))
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
+
+// Acquire takes the lock at path, creating its directory if needed. It
+// returns a *HeldError when a live process other than this one holds it. A
+// lock held by this process is returned as is, so a restarted TUI in the same
+// process keeps it.
+func Acquire(path string) (*Lock, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create lock directory: %w", err)
+	}
+
+	// Two attempts: a stale lock is removed after the first
+	for attempt := 0; attempt < 2; attempt++ {
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if err == nil {
+			_, err = f.WriteString(strconv.Itoa(os.Getpid()) + "\n")
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				_ = os.Remove(path)
+				return nil, fmt.Errorf("failed to write lock file: %w", err)
+			}
+			return &Lock{path: path}, nil
+		}
+		if !os.IsExist(err) {
+			return nil, fmt.Errorf("failed to create lock file: %w", err)
+		}
+
+		pid, err := readOwner(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err == nil && pid == os.Getpid() {
+			return &Lock{path: path}, nil
+		}
+		if err == nil && processAlive(pid) {
+			return nil, &HeldError{Path: path, PID: pid}
+		}
+		if err != nil {
+			if info, statErr := os.Stat(path); statErr == nil && time.Since(info.ModTime()) < startGrace {
+				return nil, &HeldError{Path: path}
+			}
+		}
+		// Stale, or unreadable for longer than a start takes: the owner is gone
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to remove stale lock file: %w", err)
+		}
+	}
+	return nil, fmt.Errorf("failed to acquire lock %s", path)
+}
+
+// Release removes the lock file if it still belongs to this process.
+func (l *Lock) Release() error {
+	if l == nil {
+		return nil
+	}
+	if pid, err := readPID(l.path); err != nil || pid != os.Getpid() {
+		return nil
+	}
+	if err := os.Remove(l.path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to release lock: %w", err)
+	}
+	return nil
+}
+
+// readOwner reads a lock file's PID, retrying briefly while the file is
+// empty or partly written.
+func readOwner(path string) (int, error) {
+	var pid int
+	var err error
+	for attempt := 0; attempt < readAttempts; attempt++ {
+		if attempt > 0 {
+			time.Sleep(readDelay)
+		}
+		pid, err = readPID(path)
+		if err == nil || os.IsNotExist(err) {
+			break
+		}
+	}
+	return pid, err
+}
+
+// readPID returns the PID stored in a lock file.
+func readPID(path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || pid <= 0 {
+		return 0, fmt.Errorf("invalid lock file %s", path)
+	}
+	return pid, nil
+}
@@ -0,0 +1,117 @@
+package lock
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestAcquireAndRelease(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "project", ".lock")
+
+	l, err := Acquire(path)
+	if err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	if pid, err := readPID(path); err != nil || pid != os.Getpid() {
+		t.Fatalf("lock file PID = %d, %v; want %d", pid, err, os.Getpid())
+	}
+
+	// Re-acquiring from the same process keeps the lock
+	if _, err := Acquire(path); err != nil {
+		t.Fatalf("Acquire() by the owner error = %v", err)
+	}
+
+	if err := l.Release(); err != nil {
+		t.Fatalf("Release() error = %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("lock file still exists after Release()")
+	}
+}
+
+func TestAcquireHeldByLiveProcess(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".lock")
+	if err := os.WriteFile(path, []byte("4242\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	defer func(orig func(int) bool) { processAlive = orig }(processAlive)
+	processAlive = func(pid int) bool { return pid == 4242 }
+
+	_, err := Acquire(path)
+	var held *HeldError
+	if !errors.As(err, &held) || held.PID != 4242 {
+		t.Fatalf("Acquire() error = %v, want HeldError for PID 4242", err)
+	}
+
+	// Releasing someone else's lock leaves it alone
+	if err := (&Lock{path: path}).Release(); err != nil {
+		t.Fatalf("Release() error = %v", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("another process's lock file was removed: %v", err)
+	}
+}
+
+func TestAcquireTakesOverStaleLock(t *testing.T) {
+	for name, content := range map[string]string{
+		"dead process": "4242\n",
+		"garbage":      "not a pid",
+	} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), ".lock")
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			old := time.Now().Add(-time.Minute)
+			if err := os.Chtimes(path, old, old); err != nil {
+				t.Fatal(err)
+			}
+			defer func(orig func(int) bool) { processAlive = orig }(processAlive)
+			processAlive = func(int) bool { return false }
+
+			if _, err := Acquire(path); err != nil {
+				t.Fatalf("Acquire() error = %v", err)
+			}
+			data, _ := os.ReadFile(path)
+			if want := strconv.Itoa(os.Getpid()) + "\n"; string(data) != want {
+				t.Errorf("lock file = %q, want %q", data, want)
+			}
+		})
+	}
+}
+
+func TestAcquireWaitsForStartingInstance(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".lock")
+	// Created but not yet written by a starter between its O_EXCL create and the PID write
+	if err := os.WriteFile(path, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := Acquire(path)
+	var held *HeldError
+	if !errors.As(err, &held) {
+		t.Fatalf("Acquire() error = %v, want HeldError for a lock file still being written", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("a starting instance's lock file was removed: %v", err)
+	}
+
+	// A PID written while the lock is re-read is picked up
+	if err := os.WriteFile(path, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	defer func(orig func(int) bool) { processAlive = orig }(processAlive)
+	processAlive = func(pid int) bool { return pid == 4242 }
+	go func() {
+		time.Sleep(readDelay / 2)
+		_ = os.WriteFile(path, []byte("4242\n"), 0644)
+	}()
+	_, err = Acquire(path)
+	if !errors.As(err, &held) || held.PID != 4242 {
+		t.Fatalf("Acquire() error = %v, want HeldError for PID 4242", err)
+	}
+}
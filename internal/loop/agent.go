@@ -0,0 +1,160 @@
+package loop
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/minicodemonkey/chief/internal/config"
+)
+
+// Agent is a CLI coding agent chief drives: the loop runs it once per
+// iteration, and PRD conversion asks it one-shot questions. The caller owns
+// the commands it builds, setting their working directory and environment,
+// wiring their output and killing them when stopped or stalled.
+type Agent interface {
+	// Name is how messages refer to the agent, e.g. "Claude".
+	Name() string
+	// CheckBinary returns an error explaining how to fix it when the agent's
+	// executable can't be found.
+	CheckBinary() error
+	// StreamingCommand builds the command for one loop iteration: it works
+	// through prompt without asking for permission, reporting its progress on
+	// stdout as it goes, one ParseLine line at a time.
+	StreamingCommand(ctx context.Context, prompt string) *exec.Cmd
+	// OneShotCommand builds a command that reads a prompt on stdin and prints
+	// only its answer, without using tools.
+	OneShotCommand() *exec.Cmd
+	// InteractiveCommand builds a session the user drives from the terminal,
+	// starting from prompt, as chief new and chief edit run. It returns an
+	// error when the agent has no such mode.
+	InteractiveCommand(prompt string) (*exec.Cmd, error)
+	// ParseLine turns a line of StreamingCommand output into an event, or
+	// returns nil for lines that aren't one.
+	ParseLine(line string) *Event
+	// ParseUsage returns the token usage a line of StreamingCommand output
+	// reports. ok is false for any other line.
+	ParseUsage(line string) (usage Usage, ok bool)
+}
+
+// NewAgent returns the agent cfg selects with agent.backend.
+func NewAgent(cfg *config.Config) (Agent, error) {
+	if err := cfg.Agent.Validate(); err != nil {
+		return nil, err
+	}
+	if cfg.Agent.Backend == config.AgentBackendCommand {
+		return CommandAgent{Command: cfg.Agent.Command}, nil
+	}
+	return ClaudeAgent{Config: cfg.Claude}, nil
+}
+
+// ClaudeAgent runs the claude CLI, parsing its stream-json output. The zero
+// value runs claude from PATH with its default model.
+type ClaudeAgent struct {
+	Config config.ClaudeConfig
+}
+
+// Name implements Agent.
+func (c ClaudeAgent) Name() string {
+	return "Claude"
+}
+
+// CheckBinary implements Agent.
+func (c ClaudeAgent) CheckBinary() error {
+	return c.Config.CheckBinary()
+}
+
+// StreamingCommand implements Agent.
+func (c ClaudeAgent) StreamingCommand(ctx context.Context, prompt string) *exec.Cmd {
+	return exec.CommandContext(ctx, c.Config.Binary(), c.Config.Args(
+		"--dangerously-skip-permissions",
+		"-p", prompt,
+		"--output-format", "stream-json",
+		"--verbose",
+	)...)
+}
+
+// OneShotCommand implements Agent.
+func (c ClaudeAgent) OneShotCommand() *exec.Cmd {
+	return exec.Command(c.Config.Binary(), c.Config.Args("-p", "--tools", "")...)
+}
+
+// InteractiveCommand implements Agent. The prompt is an argument rather than
+// -p, which would make the session non-interactive.
+func (c ClaudeAgent) InteractiveCommand(prompt string) (*exec.Cmd, error) {
+	return exec.Command(c.Config.Binary(), c.Config.Args(prompt)...), nil
+}
+
+// ParseLine implements Agent.
+func (c ClaudeAgent) ParseLine(line string) *Event {
+	return ParseLine(line)
+}
+
+// ParseUsage implements Agent.
+func (c ClaudeAgent) ParseUsage(line string) (Usage, bool) {
+	return ParseUsage(line)
+}
+
+// CommandAgent runs any CLI agent through a shell command that takes the
+// prompt on stdin and prints plain text. Usage isn't reported.
+type CommandAgent struct {
+	Command string
+}
+
+// Name implements Agent.
+func (c CommandAgent) Name() string {
+	if fields := strings.Fields(c.Command); len(fields) > 0 {
+		return fields[0]
+	}
+	return "agent"
+}
+
+// CheckBinary implements Agent. Only the command's first word is looked up,
+// so commands starting with a shell builtin or variable aren't checked.
+func (c CommandAgent) CheckBinary() error {
+	fields := strings.Fields(c.Command)
+	if len(fields) == 0 {
+		return fmt.Errorf("agent.command is empty; set it in chief's config or switch agent.backend back to %s", config.AgentBackendClaude)
+	}
+	if strings.ContainsAny(fields[0], "$=") {
+		return nil
+	}
+	if _, err := exec.LookPath(fields[0]); err != nil {
+		return fmt.Errorf("%s from agent.command in chief's config is not on PATH", fields[0])
+	}
+	return nil
+}
+
+// StreamingCommand implements Agent.
+func (c CommandAgent) StreamingCommand(ctx context.Context, prompt string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, "sh", "-c", c.Command)
+	cmd.Stdin = strings.NewReader(prompt)
+	return cmd
+}
+
+// OneShotCommand implements Agent.
+func (c CommandAgent) OneShotCommand() *exec.Cmd {
+	return exec.Command("sh", "-c", c.Command)
+}
+
+// InteractiveCommand implements Agent. A command that takes its prompt on
+// stdin can't also take the user's input there, so there is no interactive
+// session.
+func (c CommandAgent) InteractiveCommand(prompt string) (*exec.Cmd, error) {
+	return nil, fmt.Errorf("agent.backend %q has no interactive session; write prd.md yourself with 'chief edit --manual' or switch agent.backend to %s", config.AgentBackendCommand, config.AgentBackendClaude)
+}
+
+// ParseLine implements Agent.
+func (c CommandAgent) ParseLine(line string) *Event {
+	line = strings.TrimRight(line, " \t\r")
+	if strings.TrimSpace(line) == "" {
+		return nil
+	}
+	return parseText(line)
+}
+
+// ParseUsage implements Agent.
+func (c CommandAgent) ParseUsage(string) (Usage, bool) {
+	return Usage{}, false
+}
@@ -0,0 +1,91 @@
+package loop
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/minicodemonkey/chief/internal/config"
+)
+
+func TestNewAgent(t *testing.T) {
+	agent, err := NewAgent(&config.Config{Claude: config.ClaudeConfig{Model: "haiku"}})
+	if err != nil {
+		t.Fatalf("NewAgent() error = %v", err)
+	}
+	if claude, ok := agent.(ClaudeAgent); !ok || claude.Config.Model != "haiku" {
+		t.Errorf("expected Claude with its config by default, got %#v", agent)
+	}
+
+	agent, err = NewAgent(&config.Config{Agent: config.AgentConfig{Backend: config.AgentBackendCommand, Command: "aider --yes"}})
+	if err != nil {
+		t.Fatalf("NewAgent() error = %v", err)
+	}
+	if agent.Name() != "aider" {
+		t.Errorf("expected the command backend to be named after its command, got %q", agent.Name())
+	}
+
+	for _, bad := range []config.AgentConfig{{Backend: "codex"}, {Backend: config.AgentBackendCommand}} {
+		if _, err := NewAgent(&config.Config{Agent: bad}); err == nil {
+			t.Errorf("NewAgent(%+v) expected an error", bad)
+		}
+	}
+}
+
+func TestInteractiveCommand(t *testing.T) {
+	cmd, err := ClaudeAgent{}.InteractiveCommand("Write a PRD")
+	if err != nil || cmd.Args[len(cmd.Args)-1] != "Write a PRD" || strings.Contains(strings.Join(cmd.Args, " "), "-p") {
+		t.Errorf("expected claude with the prompt as its argument, got %v (%v)", cmd, err)
+	}
+
+	_, err = CommandAgent{Command: "aider"}.InteractiveCommand("Write a PRD")
+	if err == nil || !strings.Contains(err.Error(), "agent.backend") {
+		t.Errorf("expected an error naming agent.backend, got %v", err)
+	}
+}
+
+func TestCommandAgentParseLine(t *testing.T) {
+	agent := CommandAgent{Command: "agent"}
+	if event := agent.ParseLine("   "); event != nil {
+		t.Errorf("expected blank lines to be skipped, got %+v", event)
+	}
+	if event := agent.ParseLine("Editing main.go"); event == nil || event.Type != EventAssistantText || event.Text != "Editing main.go" {
+		t.Errorf("expected plain output as assistant text, got %+v", event)
+	}
+	if event := agent.ParseLine("<ralph-status>US-002</ralph-status>"); event == nil || event.Type != EventStoryStarted || event.StoryID != "US-002" {
+		t.Errorf("expected a story marker, got %+v", event)
+	}
+	if event := agent.ParseLine("<chief-complete/>"); event == nil || event.Type != EventComplete {
+		t.Errorf("expected the completion marker, got %+v", event)
+	}
+	if _, ok := agent.ParseUsage(`{"type":"result","usage":{"input_tokens":5}}`); ok {
+		t.Error("expected the command backend not to report usage")
+	}
+}
+
+func TestLoop_CommandAgent(t *testing.T) {
+	dir := t.TempDir()
+	promptFile := filepath.Join(dir, "prompt.txt")
+	prdPath := createTestPRD(t, dir, false)
+
+	l := NewLoop(prdPath, "base prompt", 1)
+	l.SetAgent(CommandAgent{Command: "cat > " + promptFile + "; echo '<ralph-status>US-001</ralph-status>'; echo 'Wrote the code'"})
+	events, err := runCollecting(t, l)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	prompt, err := os.ReadFile(promptFile)
+	if err != nil || !strings.Contains(string(prompt), "base prompt") {
+		t.Errorf("expected the prompt on stdin, got %q (%v)", prompt, err)
+	}
+	var started, text bool
+	for _, event := range events {
+		started = started || (event.Type == EventStoryStarted && event.StoryID == "US-001")
+		text = text || (event.Type == EventAssistantText && event.Text == "Wrote the code")
+	}
+	if !started || !text {
+		t.Errorf("expected story and text events from the command's output, got %+v", events)
+	}
+}
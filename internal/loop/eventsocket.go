@@ -0,0 +1,171 @@
+package loop
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+// socketClientBuffer is how many events a slow event socket client may fall
+// behind before events are dropped for it.
+const socketClientBuffer = 256
+
+// SocketEvent is the JSON object written, one per line, to event socket
+// clients for every loop event.
+type SocketEvent struct {
+	PRD        string                 `json:"prd"`
+	Type       string                 `json:"type"` // EventType name, e.g. "StoryStarted"
+	Time       time.Time              `json:"time"`
+	Iteration  int                    `json:"iteration"`
+	StoryID    string                 `json:"storyId,omitempty"`
+	Text       string                 `json:"text,omitempty"`
+	Tool       string                 `json:"tool,omitempty"`
+	ToolInput  map[string]interface{} `json:"toolInput,omitempty"`
+	Error      string                 `json:"error,omitempty"`
+	RetryCount int                    `json:"retryCount,omitempty"`
+	RetryMax   int                    `json:"retryMax,omitempty"`
+	Completed  bool                   `json:"completed,omitempty"` // The PRD just completed all stories
+}
+
+// newSocketEvent converts a manager event for the event socket.
+func newSocketEvent(me ManagerEvent) SocketEvent {
+	e := me.Event
+	se := SocketEvent{
+		PRD:        me.PRDName,
+		Type:       e.Type.String(),
+		Time:       time.Now(),
+		Iteration:  e.Iteration,
+		StoryID:    e.StoryID,
+		Text:       e.Text,
+		Tool:       e.Tool,
+		ToolInput:  e.ToolInput,
+		RetryCount: e.RetryCount,
+		RetryMax:   e.RetryMax,
+		Completed:  me.Completed,
+	}
+	if e.Err != nil {
+		se.Error = e.Err.Error()
+	}
+	return se
+}
+
+// eventSocket fans loop events out to the clients connected to a Unix
+// socket. Each client has its own buffer and writer, so a slow or stuck
+// client loses events rather than holding up the loops or the TUI.
+type eventSocket struct {
+	path     string
+	listener net.Listener
+	mu       sync.Mutex
+	clients  map[net.Conn]chan []byte
+	closed   bool
+}
+
+// listenEventSocket listens on a Unix socket at path. A socket file left
+// behind by a chief that didn't exit cleanly is replaced; one that another
+// process is still serving is not.
+func listenEventSocket(path string) (*eventSocket, error) {
+	if _, err := os.Stat(path); err == nil {
+		if conn, err := net.Dial("unix", path); err == nil {
+			conn.Close()
+			return nil, fmt.Errorf("event socket %s is already in use", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("failed to remove stale event socket: %w", err)
+		}
+	}
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open event socket: %w", err)
+	}
+	s := &eventSocket{
+		path:     path,
+		listener: listener,
+		clients:  make(map[net.Conn]chan []byte),
+	}
+	go s.accept()
+	return s, nil
+}
+
+// accept adds clients until the listener is closed.
+func (s *eventSocket) accept() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			continue
+		}
+		out := make(chan []byte, socketClientBuffer)
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			conn.Close()
+			return
+		}
+		s.clients[conn] = out
+		s.mu.Unlock()
+		go s.write(conn, out)
+	}
+}
+
+// write sends a client its events until it disconnects or the socket closes.
+func (s *eventSocket) write(conn net.Conn, out <-chan []byte) {
+	defer conn.Close()
+	for line := range out {
+		if _, err := conn.Write(line); err != nil {
+			s.drop(conn)
+			return
+		}
+	}
+}
+
+// drop forgets a client whose connection failed.
+func (s *eventSocket) drop(conn net.Conn) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if out, ok := s.clients[conn]; ok {
+		delete(s.clients, conn)
+		close(out)
+	}
+}
+
+// publish queues an event for every client without blocking.
+func (s *eventSocket) publish(me ManagerEvent) {
+	data, err := json.Marshal(newSocketEvent(me))
+	if err != nil {
+		return
+	}
+	line := append(data, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, out := range s.clients {
+		select {
+		case out <- line:
+		default:
+			// The client isn't keeping up; skip it rather than wait
+		}
+	}
+}
+
+// close disconnects every client and removes the socket file.
+func (s *eventSocket) close() {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return
+	}
+	s.closed = true
+	for conn, out := range s.clients {
+		delete(s.clients, conn)
+		close(out)
+	}
+	s.mu.Unlock()
+	s.listener.Close()
+	os.Remove(s.path)
+}
@@ -0,0 +1,65 @@
+package loop
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestManagerEventSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.sock")
+	// A socket file left behind by a crashed chief is replaced
+	if err := os.WriteFile(path, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewManager(10)
+	if err := m.EnableEventSocket(path); err != nil {
+		t.Fatalf("EnableEventSocket() error = %v", err)
+	}
+	if err := NewManager(10).EnableEventSocket(path); err == nil {
+		t.Error("expected an error opening a socket that is in use")
+	}
+
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	go func() {
+		for range m.Events() {
+		}
+	}()
+	// The client is registered asynchronously, so emit until it hears one
+	lines := make(chan string, 1)
+	go func() {
+		line, _ := bufio.NewReader(conn).ReadString('\n')
+		lines <- line
+	}()
+	var line string
+	for line == "" {
+		m.emit(ManagerEvent{PRDName: "auth", Event: Event{Type: EventStoryStarted, Iteration: 2, StoryID: "US-001"}})
+		select {
+		case line = <-lines:
+		case <-time.After(20 * time.Millisecond):
+		}
+	}
+
+	var got SocketEvent
+	if err := json.Unmarshal([]byte(line), &got); err != nil {
+		t.Fatalf("invalid JSON %q: %v", line, err)
+	}
+	if got.PRD != "auth" || got.Type != "StoryStarted" || got.Iteration != 2 || got.StoryID != "US-001" {
+		t.Errorf("unexpected event %+v", got)
+	}
+
+	m.CloseEventSocket()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("expected the socket file to be removed, got %v", err)
+	}
+}
@@ -0,0 +1,70 @@
+package loop
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Hook names, as spelled in the hooks section of the config.
+const (
+	HookPostStory    = "postStory"
+	HookPostComplete = "postComplete"
+)
+
+// hookOutputLines caps how much of a hook's output goes into its event. The
+// whole output is still written to claude.log.
+const hookOutputLines = 20
+
+// runHook runs a hook's shell command in the loop's working directory and
+// emits EventHook with the outcome and the tail of its output. The hook sees
+// the PRD path in CHIEF_PRD and the stories it is run for in CHIEF_STORIES.
+// It returns an error when the command fails.
+func (l *Loop) runHook(ctx context.Context, name, command string, iteration int, stories []string) error {
+	l.mu.Lock()
+	workDir := l.effectiveWorkDir()
+	l.mu.Unlock()
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Dir = workDir
+	cmd.Env = append(os.Environ(),
+		"CHIEF_PRD="+l.prdPath,
+		"CHIEF_STORIES="+strings.Join(stories, ","),
+	)
+	out, err := cmd.CombinedOutput()
+
+	output := strings.TrimRight(string(out), "\n")
+	l.logLine(fmt.Sprintf("[hook] %s: %s", name, command))
+	if output != "" {
+		for _, line := range strings.Split(output, "\n") {
+			l.logLine("[hook] " + line)
+		}
+	}
+
+	text := fmt.Sprintf("%s hook passed: %s", name, command)
+	if err != nil {
+		text = fmt.Sprintf("%s hook failed (%v): %s", name, err, command)
+	}
+	if output != "" {
+		lines := strings.Split(output, "\n")
+		if len(lines) > hookOutputLines {
+			lines = append([]string{fmt.Sprintf("... %d earlier lines in claude.log", len(lines)-hookOutputLines)}, lines[len(lines)-hookOutputLines:]...)
+		}
+		text += "\n" + strings.Join(lines, "\n")
+	}
+	l.events <- Event{
+		Type:      EventHook,
+		Iteration: iteration,
+		Tool:      name,
+		StoryID:   strings.Join(stories, ","),
+		Text:      text,
+		Err:       err,
+	}
+
+	if err != nil {
+		return fmt.Errorf("%s hook failed: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package loop
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/minicodemonkey/chief/internal/config"
+)
+
+func TestLoop_Hooks(t *testing.T) {
+	prdPath := filepath.Join(t.TempDir(), "prd.json")
+	writeStories(t, prdPath, false, false)
+	writeStories(t, prdPath+".after", true, true)
+	installClaudeScript(t, "cp "+prdPath+".after "+prdPath+"\n")
+
+	l := NewLoop(prdPath, "test prompt", 2)
+	l.SetHooks(config.HooksConfig{
+		PostStory:    `echo "verified $CHIEF_STORIES"`,
+		PostComplete: "echo deployed",
+	})
+	events, err := runCollecting(t, l)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	var order []string
+	for _, e := range events {
+		switch e.Type {
+		case EventHook:
+			order = append(order, e.Tool)
+			if e.Err != nil {
+				t.Errorf("Expected %s to pass, got %v", e.Tool, e.Err)
+			}
+			if e.Tool == HookPostStory && !strings.HasSuffix(e.Text, "\nverified US-001,US-002") {
+				t.Errorf("Expected postStory output to name the completed stories, got %q", e.Text)
+			}
+		case EventComplete:
+			order = append(order, "complete")
+		}
+	}
+	if strings.Join(order, " ") != "postStory postComplete complete" {
+		t.Errorf("Expected hooks to run before completion, got %v", order)
+	}
+}
+
+func TestLoop_PostStoryFailure(t *testing.T) {
+	tests := []struct {
+		name    string
+		failOn  bool
+		wantErr bool
+	}{
+		{"reported only", false, false},
+		{"fails the loop", true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prdPath := filepath.Join(t.TempDir(), "prd.json")
+			writeStories(t, prdPath, false, false)
+			writeStories(t, prdPath+".after", true, false)
+			installClaudeScript(t, "cp "+prdPath+".after "+prdPath+"\n")
+
+			l := NewLoop(prdPath, "test prompt", 1)
+			l.SetHooks(config.HooksConfig{PostStory: "echo broken; exit 1", FailOnPostStory: tt.failOn})
+			events, err := runCollecting(t, l)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var hook *Event
+			sawError := false
+			for i, e := range events {
+				switch e.Type {
+				case EventHook:
+					hook = &events[i]
+				case EventError:
+					sawError = true
+				}
+			}
+			if hook == nil || hook.Err == nil || !strings.Contains(hook.Text, "broken") {
+				t.Fatalf("Expected a failed postStory event with its output, got %+v", hook)
+			}
+			if sawError != tt.wantErr {
+				t.Errorf("Expected error event %v, got %v", tt.wantErr, sawError)
+			}
+		})
+	}
+}
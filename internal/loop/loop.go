@@ -7,18 +7,26 @@ package loop
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/minicodemonkey/chief/embed"
+	"github.com/minicodemonkey/chief/internal/config"
+	"github.com/minicodemonkey/chief/internal/git"
 	"github.com/minicodemonkey/chief/internal/prd"
 )
 
+// ErrIterationTimeout is returned when Claude produces no stream output for
+// longer than the iteration timeout and its process is killed.
+var ErrIterationTimeout = errors.New("iteration timed out")
+
 // RetryConfig configures automatic retry behavior on Claude crashes.
 type RetryConfig struct {
 	MaxRetries  int           // Maximum number of retry attempts (default: 3)
@@ -35,6 +43,31 @@ func DefaultRetryConfig() RetryConfig {
 	}
 }
 
+// maxRetryBackoff caps the exponential wait between retries.
+const maxRetryBackoff = 5 * time.Minute
+
+// RetryPolicy returns a retry configuration allowing maxAttempts runs of an
+// iteration in all, the first included, waiting baseBackoff before the first
+// retry and doubling the wait for each after it, up to 5 minutes. Zero
+// maxAttempts or baseBackoff keeps the default for that setting, and one
+// attempt disables retrying.
+func RetryPolicy(maxAttempts int, baseBackoff time.Duration) RetryConfig {
+	config := DefaultRetryConfig()
+	if maxAttempts > 0 {
+		config.MaxRetries = maxAttempts - 1
+		config.Enabled = maxAttempts > 1
+	}
+	if baseBackoff > 0 {
+		config.RetryDelays = make([]time.Duration, max(config.MaxRetries, 1))
+		delay := baseBackoff
+		for i := range config.RetryDelays {
+			config.RetryDelays[i] = delay
+			delay = min(delay*2, maxRetryBackoff)
+		}
+	}
+	return config
+}
+
 // Loop manages the core agent loop that invokes Claude repeatedly until all stories are complete.
 type Loop struct {
 	prdPath     string
@@ -49,6 +82,31 @@ type Loop struct {
 	stopped     bool
 	paused      bool
 	retryConfig RetryConfig
+
+	pauseOn map[string]bool // Events that pause the loop for attention (config.PauseOn* names)
+	blocker string          // Blocker reported by Claude during the current iteration
+	story   string          // Story Claude announced during the current iteration
+	warned  map[string]bool // Stderr warnings already emitted, masked by digitRuns
+
+	iterationTimeout time.Duration // Kill Claude after this long without output (0 = no timeout)
+	checkInEvery     time.Duration // Pause for a check-in after this long running (0 = never)
+	lastOutput       time.Time     // When Claude last produced stream output
+	timedOut         bool          // Whether the current iteration's process was killed as stalled
+	skipping         bool          // Whether the current iteration's process was killed to skip its story
+	pendingSkips     []string      // Stories to mark skipped in prd.json once no agent is running
+
+	startStory  string // Story to work on first, ahead of the usual order (empty = none)
+	firstPrompt string // Prompt sent verbatim for the first iteration instead of the usual one (empty = none)
+
+	usage Usage              // Tokens used by this loop's agent invocations so far
+	agent Agent              // The CLI agent each iteration runs (nil = claude from PATH)
+	hooks config.HooksConfig // Shell commands to run as stories and the PRD complete
+
+	scopeCheck  bool            // Check that iterations leave other worktrees alone
+	scopeRevert bool            // Restore tracked files changed outside the working directory
+	scopeShared func() []string // Directories other loops work in, which aren't out of scope
+
+	signCommits bool // Make the agent's commits signed, checking signing works before the first iteration
 }
 
 // NewLoop creates a new Loop instance.
@@ -94,6 +152,13 @@ func (l *Loop) Iteration() int {
 	return l.iteration
 }
 
+// Usage returns the tokens this loop has used so far.
+func (l *Loop) Usage() Usage {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.usage
+}
+
 // Run executes the agent loop until completion or max iterations.
 func (l *Loop) Run(ctx context.Context) error {
 	// Open log file in PRD directory
@@ -106,6 +171,32 @@ func (l *Loop) Run(ctx context.Context) error {
 	}
 	defer l.logFile.Close()
 	defer close(l.events)
+	defer l.applyPendingSkips()
+
+	l.mu.Lock()
+	signCommits := l.signCommits
+	workDir := l.effectiveWorkDir()
+	l.mu.Unlock()
+	if signCommits {
+		if err := git.CheckSigning(workDir); err != nil {
+			l.events <- Event{Type: EventError, Err: err}
+			return err
+		}
+	}
+
+	// Track the current phase and passing stories so we can detect changes between iterations
+	phase := ""
+	var passing map[string]bool
+	if p, err := prd.LoadPRD(l.prdPath); err == nil {
+		phase = p.CurrentPhase()
+		passing = passingStories(p)
+	}
+
+	started := time.Now()
+	l.mu.Lock()
+	checkInEvery := l.checkInEvery
+	l.mu.Unlock()
+	nextCheckIn := started.Add(checkInEvery)
 
 	for {
 		l.mu.Lock()
@@ -121,6 +212,17 @@ func (l *Loop) Run(ctx context.Context) error {
 		currentIter := l.iteration
 		l.mu.Unlock()
 
+		// Pick up skips requested between iterations, and stop rather than
+		// burn iterations when every remaining story waits on another
+		if err := l.applyPendingSkips(); err != nil {
+			l.events <- Event{Type: EventError, Err: err}
+			return err
+		}
+		if err := l.checkWorkable(); err != nil {
+			l.events <- Event{Type: EventError, Err: err}
+			return err
+		}
+
 		// Check if max iterations reached
 		if currentIter > l.maxIter {
 			l.events <- Event{
@@ -137,7 +239,21 @@ func (l *Loop) Run(ctx context.Context) error {
 		}
 
 		// Run a single iteration with retry logic
-		if err := l.runIterationWithRetry(ctx); err != nil {
+		iterStart := time.Now()
+		scope := l.takeScopeSnapshot()
+		err := l.runIterationWithRetry(ctx)
+		// A reviewed first prompt covers the first iteration, including its retries
+		l.mu.Lock()
+		l.firstPrompt = ""
+		l.mu.Unlock()
+		if skipErr := l.applyPendingSkips(); skipErr != nil && err == nil {
+			err = skipErr
+		}
+		if err != nil {
+			if ctx.Err() == nil && l.pausesOn(config.PauseOnError) {
+				l.pauseForAttention(currentIter, "Iteration failed: "+err.Error())
+				return nil
+			}
 			l.events <- Event{
 				Type: EventError,
 				Err:  err,
@@ -162,7 +278,48 @@ func (l *Loop) Run(ctx context.Context) error {
 			return err
 		}
 
+		// Record how long the iteration spent on its story, for remaining-time estimates
+		l.mu.Lock()
+		story := l.story
+		l.mu.Unlock()
+		if story != "" {
+			_ = prd.AppendTiming(l.prdPath, prd.StoryTime{
+				StoryID:  story,
+				Duration: time.Since(iterStart),
+				Passed:   storyPasses(p, story),
+				At:       time.Now(),
+			})
+		}
+
+		nowPassing := passingStories(p)
+		regressed, completed := storyChanges(p, passing, nowPassing)
+		passing = nowPassing
+
+		// Verify newly passing stories rather than trusting the agent's word
+		l.mu.Lock()
+		hooks := l.hooks
+		l.mu.Unlock()
+		if len(completed) > 0 && hooks.PostStory != "" {
+			if err := l.runHook(ctx, HookPostStory, hooks.PostStory, currentIter, completed); err != nil && hooks.FailOnPostStory {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				l.events <- Event{
+					Type: EventError,
+					Err:  err,
+				}
+				return err
+			}
+		}
+
 		if p.AllComplete() {
+			if hooks.PostComplete != "" {
+				// A failure is reported in its event; the stories still pass
+				_ = l.runHook(ctx, HookPostComplete, hooks.PostComplete, currentIter, nil)
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+			}
 			l.events <- Event{
 				Type:      EventComplete,
 				Iteration: currentIter,
@@ -170,6 +327,62 @@ func (l *Loop) Run(ctx context.Context) error {
 			return nil
 		}
 
+		// Once the targeted story passes or is skipped, go back to the usual order
+		l.mu.Lock()
+		if l.startStory != "" && !storyPending(p, l.startStory) {
+			l.startStory = ""
+		}
+		l.mu.Unlock()
+
+		// Collect everything the pause policy should stop for
+		var reasons []string
+		if stray := l.strayChanges(scope); len(stray) > 0 {
+			text := describeStrayChanges(stray)
+			l.events <- Event{
+				Type:      EventOutOfScope,
+				Iteration: currentIter,
+				Text:      text,
+			}
+			if l.pausesOn(config.PauseOnOutOfScope) {
+				reasons = append(reasons, text)
+			}
+		}
+		l.mu.Lock()
+		blocker := l.blocker
+		l.mu.Unlock()
+		if blocker != "" && l.pausesOn(config.PauseOnBlocker) {
+			reasons = append(reasons, "Blocked: "+blocker)
+		}
+		if len(regressed) > 0 && l.pausesOn(config.PauseOnRegression) {
+			reasons = append(reasons, "Regression: "+strings.Join(regressed, ", ")+" no longer passing")
+		}
+		if len(completed) > 0 && l.pausesOn(config.PauseOnStoryComplete) {
+			reasons = append(reasons, "Story complete: "+strings.Join(completed, ", "))
+		}
+
+		// Checkpoint at phase boundaries
+		if next := p.CurrentPhase(); phase != "" && next != phase {
+			l.events <- Event{
+				Type:      EventPhaseComplete,
+				Iteration: currentIter,
+				Text:      phase,
+			}
+			if l.pausesOn(config.PauseOnPhaseComplete) {
+				reasons = append(reasons, "Phase complete: "+phase)
+			}
+			phase = next
+		}
+
+		// Time-based check-in, so a long run can't go on unsupervised
+		if checkInEvery > 0 && !time.Now().Before(nextCheckIn) {
+			reasons = append(reasons, checkInSummary(time.Since(started), p))
+		}
+
+		if len(reasons) > 0 {
+			l.pauseForAttention(currentIter, strings.Join(reasons, "; "))
+			return nil
+		}
+
 		// Check pause flag after iteration (loop stops after current iteration completes)
 		l.mu.Lock()
 		if l.paused {
@@ -180,8 +393,64 @@ func (l *Loop) Run(ctx context.Context) error {
 	}
 }
 
+// pauseForAttention pauses the loop and tells listeners why.
+func (l *Loop) pauseForAttention(iteration int, reason string) {
+	l.mu.Lock()
+	l.paused = true
+	l.mu.Unlock()
+	l.events <- Event{
+		Type:      EventAttention,
+		Iteration: iteration,
+		Text:      reason,
+	}
+}
+
+// checkInSummary describes the run so far for a time-based check-in,
+// e.g. "Check-in: 30m elapsed, 3 stories done, 4 remaining".
+func checkInSummary(elapsed time.Duration, p *prd.PRD) string {
+	done := 0
+	for _, story := range p.UserStories {
+		if story.Passes {
+			done++
+		}
+	}
+
+	elapsed = elapsed.Round(time.Minute)
+	took := fmt.Sprintf("%dm", int(elapsed.Minutes()))
+	if elapsed >= time.Hour {
+		took = fmt.Sprintf("%dh%02dm", int(elapsed.Hours()), int(elapsed.Minutes())%60)
+	}
+	return fmt.Sprintf("Check-in: %s elapsed, %d stories done, %d remaining", took, done, len(p.UserStories)-done)
+}
+
+// passingStories returns the IDs of the stories that currently pass.
+func passingStories(p *prd.PRD) map[string]bool {
+	passing := make(map[string]bool)
+	for _, story := range p.UserStories {
+		if story.Passes {
+			passing[story.ID] = true
+		}
+	}
+	return passing
+}
+
+// storyChanges compares two passingStories snapshots, returning the stories
+// that stopped passing and the ones that started, in PRD order.
+func storyChanges(p *prd.PRD, before, after map[string]bool) (regressed, completed []string) {
+	for _, story := range p.UserStories {
+		switch {
+		case before[story.ID] && !after[story.ID]:
+			regressed = append(regressed, story.ID)
+		case !before[story.ID] && after[story.ID]:
+			completed = append(completed, story.ID)
+		}
+	}
+	return regressed, completed
+}
+
 // runIterationWithRetry wraps runIteration with retry logic for crash recovery.
 func (l *Loop) runIterationWithRetry(ctx context.Context) error {
+	pauseOnError := l.pausesOn(config.PauseOnError)
 	l.mu.Lock()
 	config := l.retryConfig
 	l.mu.Unlock()
@@ -190,7 +459,8 @@ func (l *Loop) runIterationWithRetry(ctx context.Context) error {
 	for attempt := 0; attempt <= config.MaxRetries; attempt++ {
 		// Check if retry is enabled (except for first attempt)
 		if attempt > 0 {
-			if !config.Enabled {
+			// Hand failures straight back when retry is off or they should pause the loop
+			if !config.Enabled || pauseOnError {
 				return lastErr
 			}
 
@@ -205,12 +475,16 @@ func (l *Loop) runIterationWithRetry(ctx context.Context) error {
 			l.mu.Lock()
 			iter := l.iteration
 			l.mu.Unlock()
+			reason := "crashed"
+			if errors.Is(lastErr, ErrIterationTimeout) {
+				reason = "stalled"
+			}
 			l.events <- Event{
 				Type:       EventRetrying,
 				Iteration:  iter,
 				RetryCount: attempt,
 				RetryMax:   config.MaxRetries,
-				Text:       fmt.Sprintf("Claude crashed, retrying (%d/%d)...", attempt, config.MaxRetries),
+				Text:       fmt.Sprintf("Claude %s, retrying (attempt %d/%d)...", reason, attempt+1, config.MaxRetries+1),
 			}
 
 			// Wait before retry
@@ -253,21 +527,44 @@ func (l *Loop) runIterationWithRetry(ctx context.Context) error {
 		lastErr = err
 	}
 
-	return fmt.Errorf("max retries (%d) exceeded: %w", config.MaxRetries, lastErr)
+	if config.MaxRetries == 0 {
+		return lastErr
+	}
+	return fmt.Errorf("gave up after %d attempts: %w", config.MaxRetries+1, lastErr)
 }
 
-// runIteration spawns Claude and processes its output.
+// runIteration spawns the agent and processes its output.
 func (l *Loop) runIteration(ctx context.Context) error {
-	// Build Claude command with required flags
-	l.mu.Lock()
-	l.claudeCmd = exec.CommandContext(ctx, "claude",
-		"--dangerously-skip-permissions",
-		"-p", l.prompt,
-		"--output-format", "stream-json",
-		"--verbose",
-	)
+	// Build the agent's command with required flags
+	l.mu.Lock()
+	prompt := l.prompt
+	if l.startStory != "" {
+		prompt += embed.GetStartStoryPrompt(l.startStory)
+	} else if p, err := prd.LoadPRD(l.prdPath); err == nil {
+		if next := p.NextStory(); next != nil {
+			prompt += embed.GetNextStoryPrompt(next.ID)
+		}
+	}
+	if l.firstPrompt != "" {
+		prompt = l.firstPrompt
+	}
+	agent := l.agentLocked()
+	l.claudeCmd = agent.StreamingCommand(ctx, prompt)
 	// Set working directory: use workDir if configured, otherwise default to PRD directory
 	l.claudeCmd.Dir = l.effectiveWorkDir()
+	if l.signCommits {
+		env := l.claudeCmd.Env
+		if env == nil {
+			env = os.Environ()
+		}
+		l.claudeCmd.Env = git.SigningEnv(env)
+	}
+	timeout := l.iterationTimeout
+	l.lastOutput = time.Now()
+	l.timedOut = false
+	l.skipping = false
+	l.blocker = ""
+	l.story = ""
 	l.mu.Unlock()
 
 	// Create pipes for stdout and stderr
@@ -283,7 +580,7 @@ func (l *Loop) runIteration(ctx context.Context) error {
 
 	// Start the command
 	if err := l.claudeCmd.Start(); err != nil {
-		return fmt.Errorf("failed to start Claude: %w", err)
+		return fmt.Errorf("failed to start %s: %w", agent.Name(), err)
 	}
 
 	// Process stdout in a separate goroutine
@@ -295,14 +592,27 @@ func (l *Loop) runIteration(ctx context.Context) error {
 		l.processOutput(stdout)
 	}()
 
-	// Log stderr to the log file
+	// Log stderr to the log file, surfacing notable warnings
 	go func() {
 		defer wg.Done()
-		l.logStream(stderr, "[stderr] ")
+		l.processStderr(stderr)
 	}()
 
+	// Kill Claude if it stops producing output for too long
+	var watchdog sync.WaitGroup
+	stopWatchdog := make(chan struct{})
+	if timeout > 0 {
+		watchdog.Add(1)
+		go func() {
+			defer watchdog.Done()
+			l.watchForStall(timeout, stopWatchdog)
+		}()
+	}
+
 	// Wait for output processing to complete
 	wg.Wait()
+	close(stopWatchdog)
+	watchdog.Wait()
 
 	// Wait for the command to finish
 	if err := l.claudeCmd.Wait(); err != nil {
@@ -310,14 +620,20 @@ func (l *Loop) runIteration(ctx context.Context) error {
 		if ctx.Err() != nil {
 			return ctx.Err()
 		}
-		// Check if we were stopped intentionally
 		l.mu.Lock()
-		stopped := l.stopped
+		timedOut := l.timedOut
+		l.mu.Unlock()
+		if timedOut {
+			return fmt.Errorf("%w: no output from %s for %s", ErrIterationTimeout, agent.Name(), timeout)
+		}
+		// Check if we were stopped or skipped intentionally
+		l.mu.Lock()
+		stopped := l.stopped || l.skipping
 		l.mu.Unlock()
 		if stopped {
 			return nil
 		}
-		return fmt.Errorf("Claude exited with error: %w", err)
+		return fmt.Errorf("%s exited with error: %w", agent.Name(), err)
 	}
 
 	l.mu.Lock()
@@ -327,8 +643,52 @@ func (l *Loop) runIteration(ctx context.Context) error {
 	return nil
 }
 
-// processOutput reads stdout line by line, logs it, and parses events.
+// watchForStall kills the Claude process and emits EventTimeout if no stream
+// output arrives for longer than timeout. It returns when done is closed.
+func (l *Loop) watchForStall(timeout time.Duration, done <-chan struct{}) {
+	interval := timeout / 10
+	if interval > time.Second {
+		interval = time.Second
+	} else if interval < time.Millisecond {
+		interval = time.Millisecond
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			l.mu.Lock()
+			if time.Since(l.lastOutput) < timeout {
+				l.mu.Unlock()
+				continue
+			}
+			l.timedOut = true
+			if l.claudeCmd != nil && l.claudeCmd.Process != nil {
+				l.claudeCmd.Process.Kill()
+			}
+			iter := l.iteration
+			l.mu.Unlock()
+
+			l.events <- Event{
+				Type:      EventTimeout,
+				Iteration: iter,
+				Text:      fmt.Sprintf("No output from Claude for %s, killed the stalled process", timeout),
+			}
+			return
+		}
+	}
+}
+
+// processOutput reads stdout line by line, logs it, and parses events with
+// the loop's agent.
 func (l *Loop) processOutput(r io.Reader) {
+	l.mu.Lock()
+	agent := l.agentLocked()
+	l.mu.Unlock()
+
 	scanner := bufio.NewScanner(r)
 	// Increase buffer size for long lines (Claude can output large JSON)
 	buf := make([]byte, 0, 64*1024)
@@ -337,27 +697,35 @@ func (l *Loop) processOutput(r io.Reader) {
 	for scanner.Scan() {
 		line := scanner.Text()
 
+		l.mu.Lock()
+		l.lastOutput = time.Now()
+		l.mu.Unlock()
+
 		// Log raw output
 		l.logLine(line)
 
+		if usage, ok := agent.ParseUsage(line); ok {
+			l.mu.Lock()
+			l.usage = l.usage.Add(usage)
+			l.mu.Unlock()
+		}
+
 		// Parse the line and emit event if valid
-		if event := ParseLine(line); event != nil {
+		if event := agent.ParseLine(line); event != nil {
 			l.mu.Lock()
 			event.Iteration = l.iteration
+			switch event.Type {
+			case EventBlocked:
+				l.blocker = event.Text
+			case EventStoryStarted:
+				l.story = event.StoryID
+			}
 			l.mu.Unlock()
 			l.events <- *event
 		}
 	}
 }
 
-// logStream logs a stream with a prefix.
-func (l *Loop) logStream(r io.Reader, prefix string) {
-	scanner := bufio.NewScanner(r)
-	for scanner.Scan() {
-		l.logLine(prefix + scanner.Text())
-	}
-}
-
 // logLine writes a line to the log file.
 func (l *Loop) logLine(line string) {
 	if l.logFile != nil {
@@ -378,6 +746,106 @@ func (l *Loop) Stop() {
 	}
 }
 
+// SkipIteration abandons the current iteration by killing its Claude
+// process. The loop carries on with the next iteration, so mark the story
+// skipped in the PRD first for it to move on to another one.
+func (l *Loop) SkipIteration() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.claudeCmd != nil && l.claudeCmd.Process != nil {
+		l.skipping = true
+		l.claudeCmd.Process.Kill()
+	}
+}
+
+// SkipStory asks the loop to mark a story skipped. The agent may be writing
+// prd.json while it runs, so the change is saved once no agent is running:
+// right away if the agent is working on the story, whose iteration is
+// abandoned, otherwise when the current iteration ends.
+func (l *Loop) SkipStory(storyID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.pendingSkips = append(l.pendingSkips, storyID)
+	if l.story == storyID && l.claudeCmd != nil && l.claudeCmd.Process != nil {
+		l.skipping = true
+		l.claudeCmd.Process.Kill()
+	}
+}
+
+// applyPendingSkips marks the stories SkipStory was asked to skip as skipped
+// in prd.json. It must only run while no agent is running.
+func (l *Loop) applyPendingSkips() error {
+	l.mu.Lock()
+	skips := l.pendingSkips
+	l.pendingSkips = nil
+	l.mu.Unlock()
+	if len(skips) == 0 {
+		return nil
+	}
+
+	p, err := prd.LoadPRD(l.prdPath)
+	if err != nil {
+		return fmt.Errorf("failed to load PRD: %w", err)
+	}
+	for _, id := range skips {
+		for i := range p.UserStories {
+			if story := &p.UserStories[i]; story.ID == id && !story.Passes {
+				story.Skipped = true
+				story.InProgress = false
+			}
+		}
+	}
+	if err := p.Save(l.prdPath); err != nil {
+		return fmt.Errorf("failed to save PRD: %w", err)
+	}
+	return nil
+}
+
+// checkWorkable returns an error naming what the remaining stories wait on
+// when none of them can be picked, because each depends on a story that
+// isn't settled. A targeted story is always workable.
+func (l *Loop) checkWorkable() error {
+	p, err := prd.LoadPRD(l.prdPath)
+	if err != nil || p.AllComplete() || p.NextStory() != nil {
+		return nil
+	}
+	l.mu.Lock()
+	startStory := l.startStory
+	l.mu.Unlock()
+	if startStory != "" && storyPending(p, startStory) {
+		return nil
+	}
+
+	var waits []string
+	for i := range p.UserStories {
+		story := &p.UserStories[i]
+		if blockers := p.BlockedBy(story); !story.Settled() && len(blockers) > 0 {
+			waits = append(waits, story.ID+" waits on "+strings.Join(blockers, ", "))
+		}
+	}
+	return fmt.Errorf("no story can be worked on: %s", strings.Join(waits, "; "))
+}
+
+// SetPauseOn sets the events that pause the loop for attention, using the
+// config.PauseOn* names. Unknown names are ignored.
+func (l *Loop) SetPauseOn(events []string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.pauseOn = make(map[string]bool, len(events))
+	for _, e := range events {
+		l.pauseOn[e] = true
+	}
+}
+
+// pausesOn reports whether event is in the pause policy.
+func (l *Loop) pausesOn(event string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.pauseOn[event]
+}
+
 // Pause sets the pause flag. The loop will stop after the current iteration completes.
 func (l *Loop) Pause() {
 	l.mu.Lock()
@@ -436,6 +904,96 @@ func (l *Loop) MaxIterations() int {
 	return l.maxIter
 }
 
+// SetStartStory makes the loop work on storyID first, overriding the usual
+// priority and phase order until that story passes.
+func (l *Loop) SetStartStory(storyID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.startStory = storyID
+}
+
+// StartStory returns the story the loop is targeting ahead of the usual order, if any.
+func (l *Loop) StartStory() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.startStory
+}
+
+// storyPending reports whether the PRD has a story with the given ID that
+// neither passes nor was skipped.
+func storyPending(p *prd.PRD, storyID string) bool {
+	for _, story := range p.UserStories {
+		if story.ID == storyID {
+			return !story.Settled()
+		}
+	}
+	return false
+}
+
+// storyPasses reports whether the PRD has a passing story with the given ID.
+func storyPasses(p *prd.PRD, storyID string) bool {
+	for _, story := range p.UserStories {
+		if story.ID == storyID {
+			return story.Passes
+		}
+	}
+	return false
+}
+
+// SetFirstPrompt sets a prompt to send verbatim for the first iteration in
+// place of the usual one. Empty means no override.
+func (l *Loop) SetFirstPrompt(prompt string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.firstPrompt = prompt
+}
+
+// SetIterationTimeout sets how long an iteration may go without stream output
+// before the Claude process is killed. Zero disables the timeout.
+func (l *Loop) SetIterationTimeout(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.iterationTimeout = d
+}
+
+// SetCheckInEvery makes the loop pause for a check-in once it has run for d,
+// summarising progress so far. Zero disables check-ins.
+func (l *Loop) SetCheckInEvery(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.checkInEvery = d
+}
+
+// SetSignCommits sets whether the agent's commits are signed.
+func (l *Loop) SetSignCommits(sign bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.signCommits = sign
+}
+
+// SetAgent sets the CLI agent later iterations run.
+func (l *Loop) SetAgent(agent Agent) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.agent = agent
+}
+
+// agentLocked returns the agent iterations run, defaulting to claude from
+// PATH. The caller must hold l.mu.
+func (l *Loop) agentLocked() Agent {
+	if l.agent == nil {
+		return ClaudeAgent{}
+	}
+	return l.agent
+}
+
+// SetHooks sets the hook scripts run as stories and the PRD complete.
+func (l *Loop) SetHooks(hooks config.HooksConfig) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.hooks = hooks
+}
+
 // SetRetryConfig updates the retry configuration.
 func (l *Loop) SetRetryConfig(config RetryConfig) {
 	l.mu.Lock()
@@ -3,11 +3,16 @@ package loop
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/minicodemonkey/chief/embed"
+	"github.com/minicodemonkey/chief/internal/config"
 	"github.com/minicodemonkey/chief/internal/prd"
 )
 
@@ -345,6 +350,27 @@ func TestLoop_ChiefCompleteEvent(t *testing.T) {
 	}
 }
 
+func TestLoop_Usage(t *testing.T) {
+	l := NewLoop("/test/prd.json", "test", 5)
+	go func() {
+		for range l.Events() {
+		}
+	}()
+
+	r, w, _ := os.Pipe()
+	go func() {
+		w.WriteString(`{"type":"result","usage":{"input_tokens":100,"output_tokens":20}}` + "\n")
+		w.WriteString(`{"type":"result","usage":{"input_tokens":50,"output_tokens":5}}` + "\n")
+		w.Close()
+	}()
+	l.processOutput(r)
+	close(l.events)
+
+	if got := l.Usage(); got.InputTokens != 150 || got.OutputTokens != 25 {
+		t.Errorf("Usage() = %+v, want 150 input and 25 output tokens", got)
+	}
+}
+
 // TestLoop_SetMaxIterations tests setting max iterations at runtime.
 func TestLoop_SetMaxIterations(t *testing.T) {
 	l := NewLoop("/test/prd.json", "test", 5)
@@ -375,6 +401,28 @@ func TestDefaultRetryConfig(t *testing.T) {
 	}
 }
 
+func TestRetryPolicy(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxAttempts int
+		backoff     time.Duration
+		want        RetryConfig
+	}{
+		{"defaults", 0, 0, DefaultRetryConfig()},
+		{"attempts only", 2, 0, RetryConfig{MaxRetries: 1, RetryDelays: DefaultRetryConfig().RetryDelays, Enabled: true}},
+		{"exponential backoff", 4, 10 * time.Second, RetryConfig{MaxRetries: 3, RetryDelays: []time.Duration{10 * time.Second, 20 * time.Second, 40 * time.Second}, Enabled: true}},
+		{"capped backoff", 3, 4 * time.Minute, RetryConfig{MaxRetries: 2, RetryDelays: []time.Duration{4 * time.Minute, 5 * time.Minute}, Enabled: true}},
+		{"single attempt", 1, 0, RetryConfig{MaxRetries: 0, RetryDelays: DefaultRetryConfig().RetryDelays, Enabled: false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RetryPolicy(tt.maxAttempts, tt.backoff); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RetryPolicy(%d, %v) = %+v, want %+v", tt.maxAttempts, tt.backoff, got, tt.want)
+			}
+		})
+	}
+}
+
 // TestLoop_SetRetryConfig tests setting retry config.
 func TestLoop_SetRetryConfig(t *testing.T) {
 	l := NewLoop("/test/prd.json", "test", 5)
@@ -402,3 +450,448 @@ func TestLoop_SetRetryConfig(t *testing.T) {
 		t.Errorf("Expected MaxRetries 5, got %d", l.retryConfig.MaxRetries)
 	}
 }
+
+// installStalledClaude puts a fake claude on PATH that emits one event and then hangs.
+func installStalledClaude(t *testing.T) {
+	t.Helper()
+
+	binDir := t.TempDir()
+	script := "#!/bin/sh\necho '{\"type\":\"system\",\"subtype\":\"init\"}'\nexec sleep 30\n"
+	if err := os.WriteFile(filepath.Join(binDir, "claude"), []byte(script), 0755); err != nil {
+		t.Fatalf("Failed to create fake claude: %v", err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func TestLoop_SkipIteration(t *testing.T) {
+	installStalledClaude(t)
+	tmpDir := t.TempDir()
+	prdPath := createTestPRD(t, tmpDir, false)
+
+	l := NewLoop(prdPath, "test prompt", 1)
+	l.DisableRetry()
+
+	done := make(chan struct{})
+	go func() {
+		starts := 0
+		for event := range l.Events() {
+			// The second start comes from Claude itself, so it is running
+			if event.Type == EventIterationStart {
+				if starts++; starts == 2 {
+					l.SkipIteration()
+				}
+			}
+		}
+		close(done)
+	}()
+
+	start := time.Now()
+	err := l.Run(context.Background())
+	<-done
+
+	if err != nil {
+		t.Fatalf("Expected a skipped iteration not to be an error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("Expected the iteration to be abandoned promptly, took %s", elapsed)
+	}
+}
+
+func TestLoop_SkipStorySavedAfterAgentExits(t *testing.T) {
+	prdPath := createTestPRD(t, t.TempDir(), false)
+	l := NewLoop(prdPath, "test prompt", 1)
+	l.SetAgent(CommandAgent{Command: "cat >/dev/null; echo '<ralph-status>US-001</ralph-status>'; exec sleep 30"})
+	l.DisableRetry()
+
+	done := make(chan struct{})
+	go func() {
+		for event := range l.Events() {
+			if event.Type == EventStoryStarted {
+				l.SkipStory("US-001")
+				// Nothing is written while the agent may still be running
+				if p, err := prd.LoadPRD(prdPath); err == nil && p.UserStories[0].Skipped {
+					t.Error("Expected the skip to wait for the agent to exit")
+				}
+			}
+		}
+		close(done)
+	}()
+
+	start := time.Now()
+	if err := l.Run(context.Background()); err != nil {
+		t.Fatalf("Expected a skipped story not to be an error, got %v", err)
+	}
+	<-done
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("Expected the story's iteration to be abandoned promptly, took %s", elapsed)
+	}
+
+	p, err := prd.LoadPRD(prdPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !p.UserStories[0].Skipped {
+		t.Error("Expected the story to be skipped once the agent exited")
+	}
+}
+
+func TestLoop_StopsWhenEveryStoryIsBlocked(t *testing.T) {
+	ran := filepath.Join(t.TempDir(), "ran")
+	installClaudeScript(t, "touch "+ran+"\n")
+
+	prdPath := filepath.Join(t.TempDir(), "prd.json")
+	p := &prd.PRD{Project: "Test", UserStories: []prd.UserStory{
+		{ID: "US-001", Title: "Blocked", Priority: 1, DependsOn: []string{"US-999"}},
+	}}
+	if err := p.Save(prdPath); err != nil {
+		t.Fatal(err)
+	}
+
+	l := NewLoop(prdPath, "test prompt", 5)
+	events, err := runCollecting(t, l)
+	if err == nil || !strings.Contains(err.Error(), "US-001 waits on US-999") {
+		t.Fatalf("Expected an error naming the missing dependency, got %v", err)
+	}
+	if _, statErr := os.Stat(ran); statErr == nil {
+		t.Error("Expected no iteration to run")
+	}
+	if len(events) == 0 || events[len(events)-1].Type != EventError {
+		t.Errorf("Expected an error event, got %v", events)
+	}
+}
+
+func TestLoop_IterationTimeoutKillsStalledProcess(t *testing.T) {
+	installStalledClaude(t)
+	tmpDir := t.TempDir()
+	prdPath := createTestPRD(t, tmpDir, false)
+
+	l := NewLoop(prdPath, "test prompt", 1)
+	l.DisableRetry()
+	l.SetIterationTimeout(200 * time.Millisecond)
+
+	var events []Event
+	done := make(chan struct{})
+	go func() {
+		for event := range l.Events() {
+			events = append(events, event)
+		}
+		close(done)
+	}()
+
+	start := time.Now()
+	err := l.Run(context.Background())
+	<-done
+
+	if !errors.Is(err, ErrIterationTimeout) {
+		t.Fatalf("Expected ErrIterationTimeout, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("Expected stalled process to be killed promptly, took %s", elapsed)
+	}
+
+	var sawTimeout, sawError bool
+	for _, e := range events {
+		switch e.Type {
+		case EventTimeout:
+			sawTimeout = true
+		case EventError:
+			sawError = true
+		}
+	}
+	if !sawTimeout {
+		t.Error("Expected a Timeout event")
+	}
+	if !sawError {
+		t.Error("Expected an Error event once retries are exhausted")
+	}
+}
+
+func TestLoop_IterationTimeoutRetries(t *testing.T) {
+	installStalledClaude(t)
+	tmpDir := t.TempDir()
+	prdPath := createTestPRD(t, tmpDir, false)
+
+	l := NewLoop(prdPath, "test prompt", 1)
+	l.SetRetryConfig(RetryConfig{MaxRetries: 1, RetryDelays: []time.Duration{0}, Enabled: true})
+	l.SetIterationTimeout(200 * time.Millisecond)
+
+	var retries []Event
+	done := make(chan struct{})
+	go func() {
+		for event := range l.Events() {
+			if event.Type == EventRetrying {
+				retries = append(retries, event)
+			}
+		}
+		close(done)
+	}()
+
+	err := l.Run(context.Background())
+	<-done
+
+	if !errors.Is(err, ErrIterationTimeout) {
+		t.Fatalf("Expected ErrIterationTimeout after retries, got %v", err)
+	}
+	if len(retries) != 1 {
+		t.Fatalf("Expected 1 retry, got %d", len(retries))
+	}
+	if !strings.Contains(retries[0].Text, "stalled") || !strings.Contains(retries[0].Text, "attempt 2/2") {
+		t.Errorf("Expected retry text to mention the stall and attempt, got %q", retries[0].Text)
+	}
+	if !strings.Contains(err.Error(), "gave up after 2 attempts") {
+		t.Errorf("Expected the error to count the attempts, got %q", err.Error())
+	}
+}
+
+func TestLoop_StartStoryTargetsPrompt(t *testing.T) {
+	binDir := t.TempDir()
+	promptFile := filepath.Join(binDir, "prompt.txt")
+	// The prompt is the third argument: --dangerously-skip-permissions -p <prompt>
+	script := "#!/bin/sh\nprintf '%s' \"$3\" > " + promptFile + "\n"
+	if err := os.WriteFile(filepath.Join(binDir, "claude"), []byte(script), 0755); err != nil {
+		t.Fatalf("Failed to create fake claude: %v", err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	prdPath := createTestPRD(t, t.TempDir(), false)
+	l := NewLoop(prdPath, "base prompt", 1)
+	l.SetStartStory("US-001")
+
+	go func() {
+		for range l.Events() {
+		}
+	}()
+	if err := l.Run(context.Background()); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	prompt, err := os.ReadFile(promptFile)
+	if err != nil {
+		t.Fatalf("Failed to read recorded prompt: %v", err)
+	}
+	if !strings.HasPrefix(string(prompt), "base prompt") || !strings.Contains(string(prompt), "`US-001`") {
+		t.Errorf("Expected prompt to target US-001, got %q", prompt)
+	}
+	if l.StartStory() != "US-001" {
+		t.Errorf("Expected start story to remain while US-001 is pending, got %q", l.StartStory())
+	}
+}
+
+func TestLoop_ClaudeBinaryAndModel(t *testing.T) {
+	binDir := t.TempDir()
+	argsFile := filepath.Join(binDir, "args.txt")
+	binary := filepath.Join(binDir, "custom-claude")
+	script := "#!/bin/sh\necho \"$1 $2 $3\" > " + argsFile + "\n"
+	if err := os.WriteFile(binary, []byte(script), 0755); err != nil {
+		t.Fatalf("Failed to create fake claude: %v", err)
+	}
+	// Nothing called claude on PATH, so only the configured binary can run
+	t.Setenv("PATH", t.TempDir())
+
+	prdPath := createTestPRD(t, t.TempDir(), false)
+	l := NewLoop(prdPath, "base prompt", 1)
+	l.SetAgent(ClaudeAgent{Config: config.ClaudeConfig{Model: "haiku", BinaryPath: binary}})
+	if _, err := runCollecting(t, l); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	args, err := os.ReadFile(argsFile)
+	if err != nil {
+		t.Fatalf("Expected the configured binary to run: %v", err)
+	}
+	if got := strings.TrimSpace(string(args)); got != "--model haiku --dangerously-skip-permissions" {
+		t.Errorf("Expected --model before the usual flags, got %q", got)
+	}
+}
+
+// installClaudeScript puts a fake claude running the given shell script on PATH.
+func installClaudeScript(t *testing.T, script string) {
+	t.Helper()
+
+	binDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(binDir, "claude"), []byte("#!/bin/sh\n"+script), 0755); err != nil {
+		t.Fatalf("Failed to create fake claude: %v", err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+// writeStories writes a PRD with US-001 and US-002 passing as given.
+func writeStories(t *testing.T, prdPath string, first, second bool) {
+	t.Helper()
+
+	data, _ := json.MarshalIndent(&prd.PRD{
+		Project: "Test Project",
+		UserStories: []prd.UserStory{
+			{ID: "US-001", Title: "First", Priority: 1, Passes: first},
+			{ID: "US-002", Title: "Second", Priority: 2, Passes: second},
+		},
+	}, "", "  ")
+	if err := os.WriteFile(prdPath, data, 0644); err != nil {
+		t.Fatalf("Failed to write PRD: %v", err)
+	}
+}
+
+// runCollecting runs the loop to the end and returns its error and events.
+func runCollecting(t *testing.T, l *Loop) ([]Event, error) {
+	t.Helper()
+
+	var events []Event
+	done := make(chan struct{})
+	go func() {
+		for event := range l.Events() {
+			events = append(events, event)
+		}
+		close(done)
+	}()
+	err := l.Run(context.Background())
+	<-done
+	return events, err
+}
+
+func TestLoop_PauseOn(t *testing.T) {
+	tests := []struct {
+		name          string
+		pauseOn       []string
+		before, after [2]bool // US-001/US-002 passes before and after the iteration
+		output        string
+		wantReason    string
+	}{
+		{"story complete", []string{"story-complete"}, [2]bool{false, false}, [2]bool{true, false}, "", "Story complete: US-001"},
+		{"regression", []string{"regression"}, [2]bool{true, false}, [2]bool{false, true}, "", "Regression: US-001 no longer passing"},
+		{"blocker", []string{"blocker"}, [2]bool{false, false}, [2]bool{false, false}, "<chief-blocked>Needs an API key</chief-blocked>", "Blocked: Needs an API key"},
+		{"not listed", []string{"regression"}, [2]bool{false, false}, [2]bool{true, false}, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prdPath := filepath.Join(t.TempDir(), "prd.json")
+			writeStories(t, prdPath, tt.before[0], tt.before[1])
+			writeStories(t, prdPath+".after", tt.after[0], tt.after[1])
+
+			script := "cp " + prdPath + ".after " + prdPath + "\n"
+			if tt.output != "" {
+				line, _ := json.Marshal(map[string]any{
+					"type":    "assistant",
+					"message": map[string]any{"content": []map[string]string{{"type": "text", "text": tt.output}}},
+				})
+				script += "echo '" + string(line) + "'\n"
+			}
+			installClaudeScript(t, script)
+
+			l := NewLoop(prdPath, "test prompt", 2)
+			l.SetPauseOn(tt.pauseOn)
+			events, err := runCollecting(t, l)
+			if err != nil {
+				t.Fatalf("Run failed: %v", err)
+			}
+
+			var attention []Event
+			for _, e := range events {
+				if e.Type == EventAttention {
+					attention = append(attention, e)
+				}
+			}
+			if tt.wantReason == "" {
+				if len(attention) != 0 || l.IsPaused() {
+					t.Fatalf("Expected no pause, got %+v", attention)
+				}
+				return
+			}
+			if len(attention) != 1 || attention[0].Text != tt.wantReason {
+				t.Fatalf("Expected one Attention event %q, got %+v", tt.wantReason, attention)
+			}
+			if !l.IsPaused() || l.Iteration() != 1 {
+				t.Errorf("Expected the loop to pause after iteration 1, paused=%v iteration=%d", l.IsPaused(), l.Iteration())
+			}
+		})
+	}
+}
+
+func TestLoop_CheckIn(t *testing.T) {
+	prdPath := filepath.Join(t.TempDir(), "prd.json")
+	writeStories(t, prdPath, true, false)
+	installClaudeScript(t, "true\n")
+
+	l := NewLoop(prdPath, "test prompt", 5)
+	l.SetCheckInEvery(time.Nanosecond)
+	events, err := runCollecting(t, l)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	var attention []string
+	for _, e := range events {
+		if e.Type == EventAttention {
+			attention = append(attention, e.Text)
+		}
+	}
+	want := "Check-in: 0m elapsed, 1 stories done, 1 remaining"
+	if len(attention) != 1 || attention[0] != want {
+		t.Fatalf("Expected one check-in %q, got %v", want, attention)
+	}
+	if !l.IsPaused() || l.Iteration() != 1 {
+		t.Errorf("Expected the loop to pause after iteration 1, paused=%v iteration=%d", l.IsPaused(), l.Iteration())
+	}
+}
+
+func TestCheckInSummary(t *testing.T) {
+	p := &prd.PRD{UserStories: []prd.UserStory{{ID: "US-001", Passes: true}, {ID: "US-002"}, {ID: "US-003"}}}
+	if got := checkInSummary(100*time.Minute+20*time.Second, p); got != "Check-in: 1h40m elapsed, 1 stories done, 2 remaining" {
+		t.Errorf("unexpected summary: %q", got)
+	}
+}
+
+func TestLoop_PauseOnErrorSkipsRetries(t *testing.T) {
+	installStalledClaude(t)
+	prdPath := createTestPRD(t, t.TempDir(), false)
+
+	l := NewLoop(prdPath, "test prompt", 1)
+	l.SetRetryConfig(RetryConfig{MaxRetries: 2, RetryDelays: []time.Duration{0}, Enabled: true})
+	l.SetIterationTimeout(200 * time.Millisecond)
+	l.SetPauseOn([]string{"error"})
+
+	events, err := runCollecting(t, l)
+	if err != nil {
+		t.Fatalf("Expected the loop to pause rather than fail, got %v", err)
+	}
+	if !l.IsPaused() {
+		t.Error("Expected the loop to be paused")
+	}
+
+	var sawAttention bool
+	for _, e := range events {
+		switch e.Type {
+		case EventRetrying:
+			t.Error("Expected no retries when pausing on error")
+		case EventError:
+			t.Error("Expected no Error event when pausing on error")
+		case EventAttention:
+			sawAttention = strings.Contains(e.Text, "timed out")
+		}
+	}
+	if !sawAttention {
+		t.Error("Expected an Attention event describing the failure")
+	}
+}
+
+func TestLoop_FirstPromptOnlyCoversFirstIteration(t *testing.T) {
+	binDir := t.TempDir()
+	promptFile := filepath.Join(binDir, "prompts.txt")
+	// The prompt is the third argument: --dangerously-skip-permissions -p <prompt>
+	installClaudeScript(t, "printf '%s\\n' \"$3\" >> "+promptFile+"\n")
+
+	prdPath := createTestPRD(t, t.TempDir(), false)
+	l := NewLoop(prdPath, "usual prompt", 2)
+	l.SetFirstPrompt("reviewed prompt")
+	if _, err := runCollecting(t, l); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	data, err := os.ReadFile(promptFile)
+	if err != nil {
+		t.Fatalf("Failed to read recorded prompts: %v", err)
+	}
+	if got, want := string(data), "reviewed prompt\nusual prompt"+embed.GetNextStoryPrompt("US-001")+"\n"; got != want {
+		t.Errorf("Expected the reviewed prompt for iteration 1 only, got %q", got)
+	}
+}
@@ -3,6 +3,8 @@ package loop
 import (
 	"context"
 	"fmt"
+	"path/filepath"
+	"sort"
 	"sync"
 	"time"
 
@@ -22,6 +24,7 @@ const (
 	LoopStateStopped
 	LoopStateComplete
 	LoopStateError
+	LoopStateQueued // Waiting for a free slot under the concurrency limit
 )
 
 func (s LoopState) String() string {
@@ -38,6 +41,8 @@ func (s LoopState) String() string {
 		return "Complete"
 	case LoopStateError:
 		return "Error"
+	case LoopStateQueued:
+		return "Queued"
 	default:
 		return "Unknown"
 	}
@@ -53,7 +58,10 @@ type LoopInstance struct {
 	State       LoopState
 	Iteration   int
 	StartTime   time.Time
+	Story       string // Story the loop is working on; kept after a pause or stop so it can resume
+	Joined      bool   // Shares another PRD's worktree on purpose, through worktree.sharedBranch or the join flow
 	Error       error
+	usage       Usage // Tokens used by earlier loops for this PRD
 	ctx         context.Context
 	cancel      context.CancelFunc
 	mu          sync.Mutex
@@ -66,18 +74,30 @@ type ManagerEvent struct {
 	Completed bool // True if this PRD just completed all stories
 }
 
+// queuedStart is a start waiting for a free slot under the concurrency limit.
+type queuedStart struct {
+	name        string
+	startStory  string
+	firstPrompt string
+}
+
 // Manager manages multiple Loop instances for parallel PRD execution.
 type Manager struct {
-	instances   map[string]*LoopInstance
-	events      chan ManagerEvent
-	maxIter     int
-	retryConfig RetryConfig
-	baseDir        string                               // Project root directory (for CLAUDE.md etc.)
-	config         *config.Config                       // Project config for post-completion actions
+	instances      map[string]*LoopInstance
+	events         chan ManagerEvent
+	maxIter        int
+	maxConcurrent  int           // Loops that may run at once (0 = unlimited)
+	queue          []queuedStart // Starts waiting for a slot, oldest first
+	slotMu         sync.Mutex    // Serialises decisions to start or queue a loop
+	retryConfig    RetryConfig
+	iterTimeout    time.Duration  // Per-iteration stall timeout for new loops (0 = none)
+	baseDir        string         // Project root directory (for CLAUDE.md etc.)
+	config         *config.Config // Project config for post-completion actions
 	mu             sync.RWMutex
 	wg             sync.WaitGroup
 	onComplete     func(prdName string)                  // Callback when a PRD completes
 	onPostComplete func(prdName, branch, workDir string) // Callback for post-completion actions (push, PR)
+	socket         *eventSocket                          // Streams events to external tools; nil unless enabled
 }
 
 // NewManager creates a new loop manager.
@@ -90,6 +110,49 @@ func NewManager(maxIter int) *Manager {
 	}
 }
 
+// EnableEventSocket streams every loop event, as a line of JSON
+// (SocketEvent), to each client connected to a Unix socket at path. Clients
+// that fall behind miss events instead of slowing the loops down. Call
+// CloseEventSocket on exit to remove the socket.
+func (m *Manager) EnableEventSocket(path string) error {
+	socket, err := listenEventSocket(path)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	old := m.socket
+	m.socket = socket
+	m.mu.Unlock()
+	if old != nil {
+		old.close()
+	}
+	return nil
+}
+
+// CloseEventSocket disconnects event socket clients and removes the socket.
+// It does nothing if the socket isn't enabled.
+func (m *Manager) CloseEventSocket() {
+	m.mu.Lock()
+	socket := m.socket
+	m.socket = nil
+	m.mu.Unlock()
+	if socket != nil {
+		socket.close()
+	}
+}
+
+// emit publishes an event to the event socket, if enabled, and sends it to
+// the Events channel.
+func (m *Manager) emit(event ManagerEvent) {
+	m.mu.RLock()
+	socket := m.socket
+	m.mu.RUnlock()
+	if socket != nil {
+		socket.publish(event)
+	}
+	m.events <- event
+}
+
 // SetRetryConfig sets the retry configuration for new loops.
 func (m *Manager) SetRetryConfig(config RetryConfig) {
 	m.mu.Lock()
@@ -97,6 +160,12 @@ func (m *Manager) SetRetryConfig(config RetryConfig) {
 	m.retryConfig = config
 }
 
+// SetRetryPolicy sets how often new loops retry a crashed or stalled
+// iteration and how long they wait in between; see RetryPolicy.
+func (m *Manager) SetRetryPolicy(maxAttempts int, baseBackoff time.Duration) {
+	m.SetRetryConfig(RetryPolicy(maxAttempts, baseBackoff))
+}
+
 // DisableRetry disables automatic retry for new loops.
 func (m *Manager) DisableRetry() {
 	m.mu.Lock()
@@ -104,6 +173,32 @@ func (m *Manager) DisableRetry() {
 	m.retryConfig.Enabled = false
 }
 
+// SetIterationTimeout sets how long an iteration of a new loop may go without
+// output from Claude before the process is killed and the iteration retried.
+// Zero (the default) disables the timeout.
+func (m *Manager) SetIterationTimeout(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.iterTimeout = d
+}
+
+// SetMaxConcurrent limits how many loops run at once; starting another
+// queues it until a running loop ends. Zero (the default) means no limit.
+// Raising the limit starts queued loops that now fit.
+func (m *Manager) SetMaxConcurrent(n int) {
+	m.mu.Lock()
+	m.maxConcurrent = n
+	m.mu.Unlock()
+	m.startQueued()
+}
+
+// MaxConcurrent returns the limit on loops running at once (0 = unlimited).
+func (m *Manager) MaxConcurrent() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.maxConcurrent
+}
+
 // SetCompletionCallback sets a callback that is called when any PRD completes.
 func (m *Manager) SetCompletionCallback(fn func(prdName string)) {
 	m.mu.Lock()
@@ -140,6 +235,19 @@ func (m *Manager) Config() *config.Config {
 	return m.config
 }
 
+// configFor returns the config for a PRD, with its overrides applied.
+// Callers must hold m.mu.
+func (m *Manager) configFor(name string) *config.Config {
+	if m.config == nil || m.baseDir == "" {
+		return m.config
+	}
+	cfg, err := m.config.ForPRD(m.baseDir, name)
+	if err != nil {
+		return m.config
+	}
+	return cfg
+}
+
 // Events returns the channel for receiving events from all loops.
 func (m *Manager) Events() <-chan ManagerEvent {
 	return m.events
@@ -155,11 +263,13 @@ func (m *Manager) Register(name, prdPath string) error {
 		return fmt.Errorf("PRD %s is already registered", name)
 	}
 
-	m.instances[name] = &LoopInstance{
+	instance := &LoopInstance{
 		Name:    name,
 		PRDPath: prdPath,
 		State:   LoopStateReady,
 	}
+	restoreSnapshot(instance)
+	m.instances[name] = instance
 
 	return nil
 }
@@ -174,17 +284,55 @@ func (m *Manager) RegisterWithWorktree(name, prdPath, worktreeDir, branch string
 		return fmt.Errorf("PRD %s is already registered", name)
 	}
 
-	m.instances[name] = &LoopInstance{
+	instance := &LoopInstance{
 		Name:        name,
 		PRDPath:     prdPath,
 		WorktreeDir: worktreeDir,
 		Branch:      branch,
 		State:       LoopStateReady,
 	}
+	restoreSnapshot(instance)
+	m.instances[name] = instance
 
 	return nil
 }
 
+// restoreSnapshot seeds a newly registered instance with the loop state saved
+// by a previous chief session, if any.
+func restoreSnapshot(instance *LoopInstance) {
+	snap, err := LoadSnapshot(instance.PRDPath)
+	if err != nil || snap == nil {
+		return
+	}
+	instance.State = snap.LoopState()
+	// Stories may have been added or reopened since the run completed
+	if instance.State == LoopStateComplete {
+		if p, err := prd.LoadPRD(instance.PRDPath); err != nil || !p.AllComplete() {
+			instance.State = LoopStateStopped
+		}
+	}
+	instance.Iteration = snap.Iteration
+	instance.StartTime = snap.StartTime
+	if instance.State != LoopStateComplete {
+		instance.Story = snap.Story
+	}
+}
+
+// saveSnapshot persists an instance's state so a later session can restore it.
+func saveSnapshot(instance *LoopInstance) {
+	instance.mu.Lock()
+	snap := Snapshot{
+		State:     instance.State.String(),
+		Iteration: instance.Iteration,
+		StartTime: instance.StartTime,
+		Story:     instance.Story,
+		UpdatedAt: time.Now(),
+	}
+	prdPath := instance.PRDPath
+	instance.mu.Unlock()
+	_ = SaveSnapshot(prdPath, snap)
+}
+
 // Unregister removes a PRD from the manager (stops it first if running).
 func (m *Manager) Unregister(name string) error {
 	m.mu.Lock()
@@ -202,6 +350,7 @@ func (m *Manager) Unregister(name string) error {
 
 	m.mu.Lock()
 	delete(m.instances, name)
+	m.dequeue(name)
 	m.mu.Unlock()
 
 	return nil
@@ -209,20 +358,45 @@ func (m *Manager) Unregister(name string) error {
 
 // Start starts the loop for a specific PRD.
 func (m *Manager) Start(name string) error {
-	m.mu.Lock()
+	return m.start(name, "", "")
+}
+
+// StartAt starts the loop for a PRD, working on storyID first even if other
+// stories would normally come before it. The usual order resumes once it passes.
+func (m *Manager) StartAt(name, storyID string) error {
+	return m.start(name, storyID, "")
+}
+
+// StartWithPrompt starts the loop like StartAt, but sends firstPrompt verbatim
+// for the first iteration, e.g. after the user has reviewed and edited the
+// result of Prompt. Later iterations use the usual prompt.
+func (m *Manager) StartWithPrompt(name, storyID, firstPrompt string) error {
+	return m.start(name, storyID, firstPrompt)
+}
+
+// Prompt returns the prompt the first iteration of a run started with
+// StartAt(name, storyID) would send to Claude.
+func (m *Manager) Prompt(name, storyID string) (string, error) {
+	m.mu.RLock()
 	instance, exists := m.instances[name]
-	m.mu.Unlock()
+	m.mu.RUnlock()
 
 	if !exists {
-		return fmt.Errorf("PRD %s not found", name)
+		return "", fmt.Errorf("PRD %s not found", name)
 	}
 
 	instance.mu.Lock()
-	if instance.State == LoopStateRunning {
-		instance.mu.Unlock()
-		return fmt.Errorf("PRD %s is already running", name)
+	prompt := m.basePrompt(instance)
+	instance.mu.Unlock()
+	if storyID != "" {
+		prompt += embed.GetStartStoryPrompt(storyID)
 	}
+	return prompt, nil
+}
 
+// basePrompt builds the agent prompt for an instance. The caller must hold
+// instance.mu.
+func (m *Manager) basePrompt(instance *LoopInstance) string {
 	// Extract ticket prefix from the branch name (e.g. CCS-1234 from feature/CCS-1234-foo).
 	// If no branch is stored on the instance, detect the current branch from the working directory.
 	branch := instance.Branch
@@ -241,19 +415,94 @@ func (m *Manager) Start(name string) error {
 	}
 	ticketPrefix := git.ExtractTicketFromBranch(branch)
 
+	// Point Claude at the file it should update, which may be a prd.yaml
+	return embed.GetPrompt(prd.ResolvePath(instance.PRDPath), ticketPrefix)
+}
+
+// start creates and runs a new loop for a PRD, optionally targeting a story
+// first and overriding the first iteration's prompt. When the concurrency
+// limit is reached the PRD is queued instead and started once a slot frees.
+func (m *Manager) start(name, startStory, firstPrompt string) error {
+	m.slotMu.Lock()
+	defer m.slotMu.Unlock()
+	return m.startLocked(name, startStory, firstPrompt)
+}
+
+// startLocked is start for callers that hold m.slotMu.
+func (m *Manager) startLocked(name, startStory, firstPrompt string) error {
+	m.mu.Lock()
+	instance, exists := m.instances[name]
+	m.mu.Unlock()
+
+	if !exists {
+		return fmt.Errorf("PRD %s not found", name)
+	}
+
+	// Two loops committing to the same worktree or branch would trample each other
+	if other := m.RunningConflict(name); other != "" {
+		return fmt.Errorf("PRD %s shares its worktree or branch with running PRD %s", name, other)
+	}
+
+	// A PRD whose config names an agent chief can't run doesn't start or queue
+	var agent Agent
+	m.mu.RLock()
+	cfg := m.configFor(name)
+	m.mu.RUnlock()
+	if cfg != nil {
+		var err error
+		if agent, err = NewAgent(cfg); err != nil {
+			return err
+		}
+	}
+
+	// Starts are serialised by slotMu, so the count can only drop before this
+	// loop is marked running
+	full := m.atLimit(name)
+
+	instance.mu.Lock()
+	if instance.State == LoopStateRunning {
+		instance.mu.Unlock()
+		return fmt.Errorf("PRD %s is already running", name)
+	}
+	if full {
+		instance.State = LoopStateQueued
+		instance.mu.Unlock()
+		m.mu.Lock()
+		m.dequeue(name)
+		m.queue = append(m.queue, queuedStart{name: name, startStory: startStory, firstPrompt: firstPrompt})
+		m.mu.Unlock()
+		return nil
+	}
+
 	// Create a new loop instance, using worktree-aware constructor if WorktreeDir is set.
 	// When no worktree is configured, run from the project root (baseDir) so that
 	// CLAUDE.md and other project-level files are visible to Claude.
-	prompt := embed.GetPrompt(instance.PRDPath, ticketPrefix)
+	prompt := m.basePrompt(instance)
 	workDir := instance.WorktreeDir
 	if workDir == "" {
 		m.mu.RLock()
 		workDir = m.baseDir
 		m.mu.RUnlock()
 	}
+	if instance.Loop != nil {
+		instance.usage = instance.usage.Add(instance.Loop.Usage())
+	}
 	instance.Loop = NewLoopWithWorkDir(instance.PRDPath, workDir, prompt, m.maxIter)
 	m.mu.RLock()
 	instance.Loop.SetRetryConfig(m.retryConfig)
+	instance.Loop.SetIterationTimeout(m.iterTimeout)
+	instance.Loop.SetStartStory(startStory)
+	instance.Loop.SetFirstPrompt(firstPrompt)
+	if cfg := m.configFor(instance.Name); cfg != nil {
+		instance.Loop.SetPauseOn(cfg.PauseEvents())
+		instance.Loop.SetCheckInEvery(cfg.Loop.CheckInEvery)
+		instance.Loop.SetAgent(agent)
+		instance.Loop.SetSignCommits(cfg.Git.SignCommits)
+		instance.Loop.SetHooks(cfg.Hooks)
+		instance.Loop.SetWriteScope(cfg.Loop.CheckWriteScope, cfg.Loop.RevertOutOfScope, func() []string {
+			return m.runningWorkDirs(name)
+		})
+	}
 	m.mu.RUnlock()
 	instance.ctx, instance.cancel = context.WithCancel(context.Background())
 	instance.State = LoopStateRunning
@@ -285,17 +534,24 @@ func (m *Manager) runLoop(instance *LoopInstance) {
 
 				instance.mu.Lock()
 				instance.Iteration = event.Iteration
+				switch event.Type {
+				case EventStoryStarted:
+					instance.Story = event.StoryID
+				case EventComplete, EventError, EventMaxIterationsReached:
+					instance.Story = ""
+				}
 				instance.mu.Unlock()
+				saveSnapshot(instance)
 
 				// Check if this is a completion event
 				completed := event.Type == EventComplete
 
 				// Forward event to manager channel
-				m.events <- ManagerEvent{
+				m.emit(ManagerEvent{
 					PRDName:   instance.Name,
 					Event:     event,
 					Completed: completed,
-				}
+				})
 
 				// If completed, trigger callbacks
 				if completed {
@@ -343,9 +599,165 @@ func (m *Manager) runLoop(instance *LoopInstance) {
 			instance.State = LoopStatePaused
 		}
 	}
+	stopped := instance.State == LoopStateStopped
 	instance.mu.Unlock()
+	saveSnapshot(instance)
 
 	<-done
+
+	if stopped {
+		m.commitOnStop(instance)
+	}
+
+	// This loop's slot is free for the next queued one
+	m.startQueued()
+}
+
+// commitOnStop commits the changes a stopped loop left in its working
+// directory when loop.commitOnStop is set, emitting EventStopCommit with the
+// outcome. Nothing is emitted when there was nothing to commit.
+func (m *Manager) commitOnStop(instance *LoopInstance) {
+	m.mu.RLock()
+	cfg := m.configFor(instance.Name)
+	m.mu.RUnlock()
+	if cfg == nil || !cfg.Loop.CommitOnStop {
+		return
+	}
+
+	instance.mu.Lock()
+	workDir := instance.WorktreeDir
+	subject := instance.Story
+	iteration := instance.Iteration
+	instance.mu.Unlock()
+	// A loop in the project root shares it with the user's own edits, which
+	// a blanket commit would sweep up
+	if workDir == "" || !git.IsGitRepo(workDir) {
+		return
+	}
+	if subject == "" {
+		subject = instance.Name
+	}
+
+	event := Event{Type: EventStopCommit, Iteration: iteration}
+	hash, err := git.CommitAll(workDir, "chief: WIP on "+subject, cfg.Git.SignCommits)
+	switch {
+	case err != nil:
+		event.Err = err
+		event.Text = "Couldn't commit work in progress: " + err.Error()
+	case hash == "":
+		return
+	default:
+		event.Text = fmt.Sprintf("Committed work in progress on %s as %s", subject, hash)
+	}
+	m.emit(ManagerEvent{PRDName: instance.Name, Event: event})
+}
+
+// atLimit reports whether starting another loop would exceed the concurrency
+// limit, not counting the named PRD.
+func (m *Manager) atLimit(name string) bool {
+	limit := m.MaxConcurrent()
+	if limit <= 0 {
+		return false
+	}
+	running := 0
+	for _, other := range m.GetRunningPRDs() {
+		if other != name {
+			running++
+		}
+	}
+	return running >= limit
+}
+
+// startQueued starts queued loops, oldest first, while there are free slots.
+// A queued loop that can no longer start, e.g. because a PRD sharing its
+// worktree started meanwhile, is put in the error state.
+func (m *Manager) startQueued() {
+	var failed []ManagerEvent
+	defer func() {
+		// Sent once slotMu is released, so a full channel can't hold up Stop
+		for _, event := range failed {
+			m.emit(event)
+		}
+	}()
+	m.slotMu.Lock()
+	defer m.slotMu.Unlock()
+
+	for !m.atLimit("") {
+		m.mu.Lock()
+		if len(m.queue) == 0 {
+			m.mu.Unlock()
+			return
+		}
+		next := m.queue[0]
+		m.queue = m.queue[1:]
+		instance := m.instances[next.name]
+		m.mu.Unlock()
+
+		if instance == nil {
+			continue
+		}
+		instance.mu.Lock()
+		queued := instance.State == LoopStateQueued
+		instance.mu.Unlock()
+		if !queued {
+			continue
+		}
+
+		if err := m.startLocked(next.name, next.startStory, next.firstPrompt); err != nil {
+			instance.mu.Lock()
+			instance.State = LoopStateError
+			instance.Error = err
+			instance.mu.Unlock()
+			failed = append(failed, ManagerEvent{
+				PRDName: next.name,
+				Event:   Event{Type: EventError, Err: err},
+			})
+		}
+	}
+}
+
+// unqueue takes a queued PRD out of the start queue, leaving it in state.
+// It reports whether the PRD was queued.
+func (m *Manager) unqueue(instance *LoopInstance, state LoopState) bool {
+	m.slotMu.Lock()
+	defer m.slotMu.Unlock()
+
+	instance.mu.Lock()
+	queued := instance.State == LoopStateQueued
+	if queued {
+		instance.State = state
+	}
+	name := instance.Name
+	instance.mu.Unlock()
+
+	if queued {
+		m.mu.Lock()
+		m.dequeue(name)
+		m.mu.Unlock()
+	}
+	return queued
+}
+
+// dequeue removes a PRD from the start queue. Callers must hold m.mu.
+func (m *Manager) dequeue(name string) {
+	for i, q := range m.queue {
+		if q.name == name {
+			m.queue = append(m.queue[:i], m.queue[i+1:]...)
+			return
+		}
+	}
+}
+
+// Queued returns the names of PRDs waiting for a slot, in the order they
+// will start.
+func (m *Manager) Queued() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	names := make([]string, len(m.queue))
+	for i, q := range m.queue {
+		names[i] = q.name
+	}
+	return names
 }
 
 // Pause pauses the loop for a specific PRD (stops after current iteration).
@@ -358,6 +770,11 @@ func (m *Manager) Pause(name string) error {
 		return fmt.Errorf("PRD %s not found", name)
 	}
 
+	// A queued loop never started, so just give up its place in the queue
+	if m.unqueue(instance, LoopStatePaused) {
+		return nil
+	}
+
 	instance.mu.Lock()
 	defer instance.mu.Unlock()
 
@@ -382,6 +799,10 @@ func (m *Manager) Stop(name string) error {
 		return fmt.Errorf("PRD %s not found", name)
 	}
 
+	if m.unqueue(instance, LoopStateStopped) {
+		return nil
+	}
+
 	instance.mu.Lock()
 	defer instance.mu.Unlock()
 
@@ -401,6 +822,51 @@ func (m *Manager) Stop(name string) error {
 	return nil
 }
 
+// SkipStory marks a story skipped in the PRD so loops move past it. While the
+// PRD's loop is running the loop saves the change, so it can't race the
+// agent's own writes to prd.json; if it's working on the story, its current
+// iteration is abandoned and the loop carries on with the next story.
+func (m *Manager) SkipStory(name, storyID string) error {
+	m.mu.RLock()
+	instance, exists := m.instances[name]
+	m.mu.RUnlock()
+
+	if !exists {
+		return fmt.Errorf("PRD %s not found", name)
+	}
+
+	instance.mu.Lock()
+	defer instance.mu.Unlock()
+
+	p, err := prd.LoadPRD(instance.PRDPath)
+	if err != nil {
+		return fmt.Errorf("failed to load PRD: %w", err)
+	}
+	var story *prd.UserStory
+	for i := range p.UserStories {
+		if p.UserStories[i].ID == storyID {
+			story = &p.UserStories[i]
+		}
+	}
+	if story == nil {
+		return fmt.Errorf("story %s not found in %s", storyID, name)
+	}
+	if story.Passes {
+		return fmt.Errorf("story %s already passes", storyID)
+	}
+	if instance.State == LoopStateRunning && instance.Loop != nil {
+		instance.Loop.SkipStory(storyID)
+		return nil
+	}
+
+	story.Skipped = true
+	story.InProgress = false
+	if err := p.Save(instance.PRDPath); err != nil {
+		return fmt.Errorf("failed to save PRD: %w", err)
+	}
+	return nil
+}
+
 // UpdateWorktreeInfo updates the worktree directory and branch for an existing PRD instance.
 func (m *Manager) UpdateWorktreeInfo(name, worktreeDir, branch string) error {
 	m.mu.RLock()
@@ -416,10 +882,26 @@ func (m *Manager) UpdateWorktreeInfo(name, worktreeDir, branch string) error {
 
 	instance.WorktreeDir = worktreeDir
 	instance.Branch = branch
+	instance.Joined = false
 
 	return nil
 }
 
+// JoinWorktree points a PRD at another PRD's worktree and branch, marking it
+// as sharing them on purpose so Collisions leaves the pair out.
+func (m *Manager) JoinWorktree(name, worktreeDir, branch string) error {
+	if err := m.UpdateWorktreeInfo(name, worktreeDir, branch); err != nil {
+		return err
+	}
+	m.mu.RLock()
+	instance := m.instances[name]
+	m.mu.RUnlock()
+	instance.mu.Lock()
+	instance.Joined = true
+	instance.mu.Unlock()
+	return nil
+}
+
 // ClearWorktreeInfo clears the worktree directory and optionally the branch for a PRD instance.
 func (m *Manager) ClearWorktreeInfo(name string, clearBranch bool) error {
 	m.mu.RLock()
@@ -434,6 +916,7 @@ func (m *Manager) ClearWorktreeInfo(name string, clearBranch bool) error {
 	defer instance.mu.Unlock()
 
 	instance.WorktreeDir = ""
+	instance.Joined = false
 	if clearBranch {
 		instance.Branch = ""
 	}
@@ -441,6 +924,39 @@ func (m *Manager) ClearWorktreeInfo(name string, clearBranch bool) error {
 	return nil
 }
 
+// ReconcileBranches clears the branch and worktree of every PRD that isn't
+// running whose branch no longer exists, e.g. because it was deleted outside
+// chief, so merge and clean don't act on a ref that's gone. It returns the
+// names of the PRDs it cleared, sorted.
+func (m *Manager) ReconcileBranches() []string {
+	m.mu.RLock()
+	baseDir := m.baseDir
+	instances := make([]*LoopInstance, 0, len(m.instances))
+	for _, instance := range m.instances {
+		instances = append(instances, instance)
+	}
+	m.mu.RUnlock()
+
+	if baseDir == "" || !git.IsGitRepo(baseDir) {
+		return nil
+	}
+
+	var cleared []string
+	for _, instance := range instances {
+		instance.mu.Lock()
+		if instance.Branch != "" && instance.State != LoopStateRunning && instance.State != LoopStateQueued {
+			if exists, err := git.BranchExists(baseDir, instance.Branch); err == nil && !exists {
+				instance.Branch = ""
+				instance.WorktreeDir = ""
+				cleared = append(cleared, instance.Name)
+			}
+		}
+		instance.mu.Unlock()
+	}
+	sort.Strings(cleared)
+	return cleared
+}
+
 // GetState returns the state of a specific PRD loop.
 func (m *Manager) GetState(name string) (LoopState, int, error) {
 	m.mu.RLock()
@@ -457,6 +973,27 @@ func (m *Manager) GetState(name string) (LoopState, int, error) {
 	return instance.State, instance.Iteration, instance.Error
 }
 
+// GetUsage returns the tokens used by every loop run for a PRD since it was
+// registered.
+func (m *Manager) GetUsage(name string) Usage {
+	m.mu.RLock()
+	instance, exists := m.instances[name]
+	m.mu.RUnlock()
+
+	if !exists {
+		return Usage{}
+	}
+
+	instance.mu.Lock()
+	defer instance.mu.Unlock()
+
+	usage := instance.usage
+	if instance.Loop != nil {
+		usage = usage.Add(instance.Loop.Usage())
+	}
+	return usage
+}
+
 // GetInstance returns a copy of the loop instance data for a specific PRD.
 func (m *Manager) GetInstance(name string) *LoopInstance {
 	m.mu.RLock()
@@ -479,7 +1016,9 @@ func (m *Manager) GetInstance(name string) *LoopInstance {
 		State:       instance.State,
 		Iteration:   instance.Iteration,
 		StartTime:   instance.StartTime,
+		Story:       instance.Story,
 		Error:       instance.Error,
+		Joined:      instance.Joined,
 	}
 }
 
@@ -499,7 +1038,9 @@ func (m *Manager) GetAllInstances() []*LoopInstance {
 			State:       instance.State,
 			Iteration:   instance.Iteration,
 			StartTime:   instance.StartTime,
+			Story:       instance.Story,
 			Error:       instance.Error,
+			Joined:      instance.Joined,
 		}
 		instance.mu.Unlock()
 		result = append(result, copy)
@@ -508,6 +1049,87 @@ func (m *Manager) GetAllInstances() []*LoopInstance {
 	return result
 }
 
+// sharesWorkspace reports whether two instances point at the same worktree or branch.
+// Instances without a worktree or branch run in the project root, which is
+// handled separately by the TUI's start dialog.
+func sharesWorkspace(a, b *LoopInstance) bool {
+	if a.WorktreeDir != "" && b.WorktreeDir != "" && filepath.Clean(a.WorktreeDir) == filepath.Clean(b.WorktreeDir) {
+		return true
+	}
+	return a.Branch != "" && a.Branch == b.Branch
+}
+
+// WorktreeForBranch returns the worktree of a PRD other than except that
+// works on branch in a worktree, and that PRD's name, so except can join it.
+// Both are empty if there is none; with several, the first by name wins.
+func (m *Manager) WorktreeForBranch(branch, except string) (worktreeDir, owner string) {
+	if branch == "" {
+		return "", ""
+	}
+	instances := m.GetAllInstances()
+	sort.Slice(instances, func(i, j int) bool { return instances[i].Name < instances[j].Name })
+	for _, inst := range instances {
+		if inst.Name != except && inst.Branch == branch && inst.WorktreeDir != "" {
+			return inst.WorktreeDir, inst.Name
+		}
+	}
+	return "", ""
+}
+
+// Conflicts returns the sorted names of other registered PRDs that share the
+// named PRD's worktree path or branch.
+func (m *Manager) Conflicts(name string) []string {
+	var self *LoopInstance
+	instances := m.GetAllInstances()
+	for _, inst := range instances {
+		if inst.Name == name {
+			self = inst
+			break
+		}
+	}
+	if self == nil {
+		return nil
+	}
+
+	var conflicts []string
+	for _, inst := range instances {
+		if inst.Name != name && sharesWorkspace(self, inst) {
+			conflicts = append(conflicts, inst.Name)
+		}
+	}
+	sort.Strings(conflicts)
+	return conflicts
+}
+
+// Collisions returns the sorted names of other registered PRDs that share the
+// named PRD's worktree path or branch by accident: it leaves out pairs where
+// either PRD joined the other's worktree on purpose. Those still take turns
+// through RunningConflict.
+func (m *Manager) Collisions(name string) []string {
+	self := m.GetInstance(name)
+	if self == nil {
+		return nil
+	}
+	var collisions []string
+	for _, other := range m.Conflicts(name) {
+		if inst := m.GetInstance(other); inst != nil && !self.Joined && !inst.Joined {
+			collisions = append(collisions, other)
+		}
+	}
+	return collisions
+}
+
+// RunningConflict returns the name of a running PRD that shares the named
+// PRD's worktree path or branch, or empty if there is none.
+func (m *Manager) RunningConflict(name string) string {
+	for _, other := range m.Conflicts(name) {
+		if state, _, err := m.GetState(other); err == nil && state == LoopStateRunning {
+			return other
+		}
+	}
+	return ""
+}
+
 // GetRunningPRDs returns the names of all currently running PRDs.
 func (m *Manager) GetRunningPRDs() []string {
 	m.mu.RLock()
@@ -525,12 +1147,36 @@ func (m *Manager) GetRunningPRDs() []string {
 	return result
 }
 
+// runningWorkDirs returns the directories running loops other than the named
+// one work in, so one loop's write-scope check doesn't flag another's changes.
+func (m *Manager) runningWorkDirs(except string) []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var dirs []string
+	for name, instance := range m.instances {
+		if name == except {
+			continue
+		}
+		instance.mu.Lock()
+		if instance.State == LoopStateRunning {
+			if instance.WorktreeDir != "" {
+				dirs = append(dirs, instance.WorktreeDir)
+			} else if m.baseDir != "" {
+				dirs = append(dirs, m.baseDir)
+			}
+		}
+		instance.mu.Unlock()
+	}
+	return dirs
+}
+
 // GetRunningCount returns the number of currently running loops.
 func (m *Manager) GetRunningCount() int {
 	return len(m.GetRunningPRDs())
 }
 
-// StopAll stops all running loops.
+// StopAll stops all running loops and empties the start queue.
 func (m *Manager) StopAll() {
 	m.mu.RLock()
 	names := make([]string, 0, len(m.instances))
@@ -539,6 +1185,11 @@ func (m *Manager) StopAll() {
 	}
 	m.mu.RUnlock()
 
+	// Stopping a running loop frees a slot; don't let a queued one take it
+	for _, name := range m.Queued() {
+		m.Stop(name)
+	}
+
 	for _, name := range names {
 		m.Stop(name)
 	}
@@ -547,6 +1198,11 @@ func (m *Manager) StopAll() {
 	m.wg.Wait()
 }
 
+// Wait blocks until all started loops have finished.
+func (m *Manager) Wait() {
+	m.wg.Wait()
+}
+
 // IsAnyRunning returns true if any loop is currently running.
 func (m *Manager) IsAnyRunning() bool {
 	return m.GetRunningCount() > 0
@@ -2,12 +2,17 @@ package loop
 
 import (
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/minicodemonkey/chief/internal/config"
+	"github.com/minicodemonkey/chief/internal/git"
+	"github.com/minicodemonkey/chief/internal/paths"
+	"github.com/minicodemonkey/chief/internal/prd"
 )
 
 // createTestPRDWithName creates a minimal test PRD file with a given name and returns its path.
@@ -213,6 +218,28 @@ func TestManagerStopNonRunning(t *testing.T) {
 	}
 }
 
+func TestManagerPrompt(t *testing.T) {
+	m := NewManager(5)
+	m.Register("auth", createTestPRDWithName(t, t.TempDir(), "auth"))
+
+	prompt, err := m.Prompt("auth", "")
+	if err != nil {
+		t.Fatalf("Prompt failed: %v", err)
+	}
+	if !strings.Contains(prompt, filepath.Join("auth", "prd.json")) {
+		t.Errorf("expected the prompt to point at the PRD, got %q", prompt)
+	}
+
+	targeted, _ := m.Prompt("auth", "US-001")
+	if !strings.HasPrefix(targeted, prompt) || !strings.Contains(targeted, "`US-001`") {
+		t.Errorf("expected the start-story note after the usual prompt, got %q", targeted)
+	}
+
+	if _, err := m.Prompt("missing", ""); err == nil {
+		t.Error("expected an error for an unregistered PRD")
+	}
+}
+
 func TestManagerStartNonExistent(t *testing.T) {
 	m := NewManager(10)
 
@@ -256,6 +283,7 @@ func TestLoopStateString(t *testing.T) {
 		{LoopStateStopped, "Stopped"},
 		{LoopStateComplete, "Complete"},
 		{LoopStateError, "Error"},
+		{LoopStateQueued, "Queued"},
 		{LoopState(99), "Unknown"},
 	}
 
@@ -391,6 +419,166 @@ func TestManagerRegisterWithWorktree(t *testing.T) {
 	}
 }
 
+func TestManagerConflicts(t *testing.T) {
+	tmpDir := t.TempDir()
+	m := NewManager(10)
+
+	m.RegisterWithWorktree("auth", createTestPRDWithName(t, tmpDir, "auth"), "/tmp/worktrees/auth", "chief/auth")
+	m.RegisterWithWorktree("login", createTestPRDWithName(t, tmpDir, "login"), "/tmp/worktrees/auth/", "chief/login")
+	m.RegisterWithWorktree("billing", createTestPRDWithName(t, tmpDir, "billing"), "/tmp/worktrees/billing", "chief/auth")
+	m.RegisterWithWorktree("search", createTestPRDWithName(t, tmpDir, "search"), "/tmp/worktrees/search", "chief/search")
+	m.Register("root-a", createTestPRDWithName(t, tmpDir, "root-a"))
+	m.Register("root-b", createTestPRDWithName(t, tmpDir, "root-b"))
+
+	if got := m.Conflicts("auth"); len(got) != 2 || got[0] != "billing" || got[1] != "login" {
+		t.Errorf("expected auth to conflict with [billing login], got %v", got)
+	}
+	if got := m.Conflicts("search"); len(got) != 0 {
+		t.Errorf("expected no conflicts for search, got %v", got)
+	}
+	// PRDs in the project root are handled by the start dialog, not flagged as conflicts
+	if got := m.Conflicts("root-a"); len(got) != 0 {
+		t.Errorf("expected no conflicts for root PRDs, got %v", got)
+	}
+
+	if got := m.RunningConflict("login"); got != "" {
+		t.Errorf("expected no running conflict, got %q", got)
+	}
+	m.instances["auth"].State = LoopStateRunning
+	if got := m.RunningConflict("login"); got != "auth" {
+		t.Errorf("expected running conflict with auth, got %q", got)
+	}
+	if err := m.Start("login"); err == nil || !strings.Contains(err.Error(), "auth") {
+		t.Errorf("expected Start to refuse a PRD sharing a running worktree, got %v", err)
+	}
+}
+
+func TestManagerCollisionsSkipJoinedWorktrees(t *testing.T) {
+	tmpDir := t.TempDir()
+	m := NewManager(10)
+
+	m.RegisterWithWorktree("auth", createTestPRDWithName(t, tmpDir, "auth"), "/tmp/worktrees/auth", "chief/auth")
+	m.Register("login", createTestPRDWithName(t, tmpDir, "login"))
+	m.RegisterWithWorktree("billing", createTestPRDWithName(t, tmpDir, "billing"), "/tmp/worktrees/billing", "chief/auth")
+
+	if err := m.JoinWorktree("login", "/tmp/worktrees/auth", "chief/auth"); err != nil {
+		t.Fatalf("JoinWorktree failed: %v", err)
+	}
+	if got := m.Conflicts("login"); len(got) != 2 {
+		t.Errorf("expected login to still share auth's worktree with [auth billing], got %v", got)
+	}
+	if got := m.Collisions("login"); len(got) != 0 {
+		t.Errorf("expected no collisions for a joined PRD, got %v", got)
+	}
+	// billing picked the same branch by accident, so it is still flagged
+	if got := m.Collisions("auth"); len(got) != 1 || got[0] != "billing" {
+		t.Errorf("expected auth to collide only with billing, got %v", got)
+	}
+
+	// Joined PRDs still take turns
+	m.instances["auth"].State = LoopStateRunning
+	if got := m.RunningConflict("login"); got != "auth" {
+		t.Errorf("expected running conflict with auth, got %q", got)
+	}
+
+	// Moving to a worktree of its own drops the join
+	m.UpdateWorktreeInfo("login", "/tmp/worktrees/billing", "chief/login")
+	if got := m.Collisions("login"); len(got) != 1 || got[0] != "billing" {
+		t.Errorf("expected login to collide with billing after leaving the join, got %v", got)
+	}
+}
+
+func TestManagerMaxConcurrentQueues(t *testing.T) {
+	tmpDir := t.TempDir()
+	m := NewManager(10)
+	m.RegisterWithWorktree("busy", createTestPRDWithName(t, tmpDir, "busy"), "/tmp/worktrees/busy", "chief/busy")
+	m.RegisterWithWorktree("next", createTestPRDWithName(t, tmpDir, "next"), "/tmp/worktrees/next", "chief/next")
+	m.RegisterWithWorktree("later", createTestPRDWithName(t, tmpDir, "later"), "/tmp/worktrees/later", "chief/later")
+	m.RegisterWithWorktree("sibling", createTestPRDWithName(t, tmpDir, "sibling"), "/tmp/worktrees/next", "chief/sibling")
+	m.SetMaxConcurrent(1)
+	m.instances["busy"].State = LoopStateRunning
+
+	if err := m.Start("next"); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if err := m.StartAt("later", "US-001"); err != nil {
+		t.Fatalf("StartAt() error = %v", err)
+	}
+	if state, _, _ := m.GetState("next"); state != LoopStateQueued {
+		t.Errorf("expected next to be queued, got %v", state)
+	}
+	if got := m.Queued(); len(got) != 2 || got[0] != "next" || got[1] != "later" {
+		t.Errorf("expected queue [next later], got %v", got)
+	}
+
+	// Pausing or stopping a queued PRD just takes it out of the queue
+	if err := m.Pause("later"); err != nil {
+		t.Errorf("Pause() error = %v", err)
+	}
+	if state, _, _ := m.GetState("later"); state != LoopStatePaused {
+		t.Errorf("expected later to be paused, got %v", state)
+	}
+	if got := m.Queued(); len(got) != 1 || got[0] != "next" {
+		t.Errorf("expected queue [next], got %v", got)
+	}
+
+	// When the slot frees, a queued PRD that now shares a running worktree
+	// can't start and is reported instead
+	m.instances["busy"].State = LoopStatePaused
+	m.instances["sibling"].State = LoopStateRunning
+	m.SetMaxConcurrent(2)
+	if state, _, _ := m.GetState("next"); state != LoopStateError {
+		t.Errorf("expected next to fail to start, got %v", state)
+	}
+	if len(m.Queued()) != 0 {
+		t.Errorf("expected an empty queue, got %v", m.Queued())
+	}
+	select {
+	case event := <-m.Events():
+		if event.PRDName != "next" || event.Event.Type != EventError {
+			t.Errorf("expected an error event for next, got %+v", event)
+		}
+	default:
+		t.Error("expected an error event for the queued PRD")
+	}
+
+	m.SetMaxConcurrent(1)
+	m.instances["sibling"].State = LoopStatePaused
+	m.instances["busy"].State = LoopStateRunning
+	m.Start("later")
+	if err := m.Stop("later"); err != nil {
+		t.Errorf("Stop() error = %v", err)
+	}
+	if state, _, _ := m.GetState("later"); state != LoopStateStopped {
+		t.Errorf("expected later to be stopped, got %v", state)
+	}
+}
+
+func TestManagerSkipStory(t *testing.T) {
+	tmpDir := t.TempDir()
+	prdPath := createTestPRDWithName(t, tmpDir, "test-prd")
+	m := NewManager(10)
+	m.Register("test-prd", prdPath)
+
+	if err := m.SkipStory("test-prd", "US-001"); err != nil {
+		t.Fatalf("SkipStory() error = %v", err)
+	}
+	p, err := prd.LoadPRD(prdPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !p.UserStories[0].Skipped || !p.AllComplete() {
+		t.Errorf("expected US-001 to be skipped, got %+v", p.UserStories[0])
+	}
+
+	if err := m.SkipStory("test-prd", "US-999"); err == nil {
+		t.Error("expected an error for an unknown story")
+	}
+	if err := m.SkipStory("missing", "US-001"); err == nil {
+		t.Error("expected an error for an unknown PRD")
+	}
+}
+
 func TestManagerRegisterWithWorktreeFieldsInGetAllInstances(t *testing.T) {
 	tmpDir := t.TempDir()
 	prd1Path := createTestPRDWithName(t, tmpDir, "prd1")
@@ -611,3 +799,124 @@ func TestManagerConcurrentAccessWithWorktreeFields(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+func TestManagerReconcileBranches(t *testing.T) {
+	repo, worktree := initScopeRepo(t)
+	tmpDir := t.TempDir()
+
+	m := NewManager(10)
+	m.SetBaseDir(repo)
+	if err := m.RegisterWithWorktree("kept", createTestPRDWithName(t, tmpDir, "kept"), worktree, "feature"); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.RegisterWithWorktree("gone", createTestPRDWithName(t, tmpDir, "gone"), "/tmp/worktree/gone", "chief/gone"); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Register("plain", createTestPRDWithName(t, tmpDir, "plain")); err != nil {
+		t.Fatal(err)
+	}
+
+	if cleared := m.ReconcileBranches(); len(cleared) != 1 || cleared[0] != "gone" {
+		t.Fatalf("ReconcileBranches() = %v, want [gone]", cleared)
+	}
+	if gone := m.GetInstance("gone"); gone.Branch != "" || gone.WorktreeDir != "" {
+		t.Errorf("gone kept branch %q and worktree %q", gone.Branch, gone.WorktreeDir)
+	}
+	if kept := m.GetInstance("kept"); kept.Branch != "feature" || kept.WorktreeDir != worktree {
+		t.Errorf("kept lost its branch or worktree: %q, %q", kept.Branch, kept.WorktreeDir)
+	}
+	if cleared := m.ReconcileBranches(); len(cleared) != 0 {
+		t.Errorf("second ReconcileBranches() = %v, want nothing", cleared)
+	}
+}
+
+func TestManagerWorktreeForBranch(t *testing.T) {
+	tmpDir := t.TempDir()
+	m := NewManager(10)
+	m.RegisterWithWorktree("api", createTestPRDWithName(t, tmpDir, "api"), "/tmp/worktrees/api", "chief/api")
+	m.RegisterWithWorktree("web", createTestPRDWithName(t, tmpDir, "web"), "", "chief/web")
+	m.Register("api-tests", createTestPRDWithName(t, tmpDir, "api-tests"))
+
+	if dir, owner := m.WorktreeForBranch("chief/api", "api-tests"); dir != "/tmp/worktrees/api" || owner != "api" {
+		t.Errorf("WorktreeForBranch(chief/api) = %q, %q; want api's worktree", dir, owner)
+	}
+	if dir, _ := m.WorktreeForBranch("chief/api", "api"); dir != "" {
+		t.Errorf("expected a PRD not to find its own worktree, got %q", dir)
+	}
+	if dir, _ := m.WorktreeForBranch("chief/web", "api-tests"); dir != "" {
+		t.Errorf("expected no worktree for a branch checked out in the project root, got %q", dir)
+	}
+}
+
+func TestManagerCommitOnStop(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+	repo, worktree := initScopeRepo(t)
+	started := filepath.Join(t.TempDir(), "started")
+	installClaudeScript(t, "echo wip > "+filepath.Join(worktree, "wip.txt")+"\ntouch "+started+"\nexec sleep 30\n")
+
+	m := NewManager(10)
+	m.SetBaseDir(repo)
+	m.SetConfig(&config.Config{Loop: config.LoopConfig{CommitOnStop: true}})
+	if err := m.RegisterWithWorktree("wip", createTestPRDWithName(t, t.TempDir(), "wip"), worktree, "feature"); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Start("wip"); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		if _, err := os.Stat(started); err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("the agent never started")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	m.Stop("wip")
+
+	timeout := time.After(10 * time.Second)
+	for {
+		select {
+		case me := <-m.Events():
+			if me.Event.Type != EventStopCommit {
+				continue
+			}
+			if me.Event.Err != nil || !strings.Contains(me.Event.Text, "Committed work in progress on wip") {
+				t.Fatalf("unexpected stop commit event %+v", me.Event)
+			}
+			out, err := exec.Command("git", "-C", worktree, "log", "-1", "--format=%s").Output()
+			if err != nil || strings.TrimSpace(string(out)) != "chief: WIP on wip" {
+				t.Errorf("expected the WIP commit on the branch, got %q (%v)", out, err)
+			}
+			if dirty, _ := git.IsDirty(worktree); dirty {
+				t.Error("expected the worktree to be clean after the commit")
+			}
+			return
+		case <-timeout:
+			t.Fatal("no stop commit event")
+		}
+	}
+}
+
+func TestManagerCommitOnStopSkipsProjectRoot(t *testing.T) {
+	repo, _ := initScopeRepo(t)
+	if err := os.WriteFile(filepath.Join(repo, "mine.txt"), []byte("user's own edit\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewManager(10)
+	m.SetBaseDir(repo)
+	m.SetConfig(&config.Config{Loop: config.LoopConfig{CommitOnStop: true}})
+	m.commitOnStop(&LoopInstance{Name: "root", Story: "US-001"})
+
+	if dirty, _ := git.IsDirty(repo); !dirty {
+		t.Error("expected the project root to be left uncommitted")
+	}
+	select {
+	case me := <-m.Events():
+		t.Errorf("expected no event, got %+v", me.Event)
+	default:
+	}
+}
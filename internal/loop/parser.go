@@ -31,6 +31,24 @@ const (
 	EventError
 	// EventRetrying is emitted when retrying after a crash.
 	EventRetrying
+	// EventPhaseComplete is emitted when every story in a phase passes. Text holds the phase name.
+	EventPhaseComplete
+	// EventTimeout is emitted when a stalled Claude process is killed after the iteration timeout.
+	EventTimeout
+	// EventBlocked is emitted when Claude reports a blocker with <chief-blocked>. Text holds the reason.
+	EventBlocked
+	// EventAttention is emitted when the loop pauses for attention under the pause policy. Text holds why.
+	EventAttention
+	// EventOutOfScope is emitted when an iteration changed files outside its working directory. Text lists them.
+	EventOutOfScope
+	// EventWarning is emitted when Claude writes a notable warning to stderr, such as a deprecation. Text holds it.
+	EventWarning
+	// EventHook is emitted when a configured hook script has run. Tool names the hook, Text
+	// summarises the outcome followed by the tail of its output, and Err is set when it failed.
+	EventHook
+	// EventStopCommit is emitted when work left uncommitted by a stopped loop has been committed
+	// under loop.commitOnStop. Text describes the commit, and Err is set when it failed.
+	EventStopCommit
 )
 
 // String returns the string representation of an EventType.
@@ -56,6 +74,22 @@ func (e EventType) String() string {
 		return "Error"
 	case EventRetrying:
 		return "Retrying"
+	case EventPhaseComplete:
+		return "PhaseComplete"
+	case EventTimeout:
+		return "Timeout"
+	case EventBlocked:
+		return "Blocked"
+	case EventAttention:
+		return "Attention"
+	case EventOutOfScope:
+		return "OutOfScope"
+	case EventWarning:
+		return "Warning"
+	case EventHook:
+		return "Hook"
+	case EventStopCommit:
+		return "StopCommit"
 	default:
 		return "Unknown"
 	}
@@ -107,6 +141,63 @@ type toolResultBlock struct {
 	Content   string `json:"content"`
 }
 
+// Usage counts the tokens Claude consumed.
+type Usage struct {
+	InputTokens     int // Prompt tokens, including cache reads and writes
+	CacheReadTokens int // The part of InputTokens read from the prompt cache
+	OutputTokens    int // Generated tokens
+}
+
+// Add returns the sum of u and other.
+func (u Usage) Add(other Usage) Usage {
+	return Usage{
+		InputTokens:     u.InputTokens + other.InputTokens,
+		CacheReadTokens: u.CacheReadTokens + other.CacheReadTokens,
+		OutputTokens:    u.OutputTokens + other.OutputTokens,
+	}
+}
+
+// Total returns input and output tokens combined.
+func (u Usage) Total() int {
+	return u.InputTokens + u.OutputTokens
+}
+
+// resultMessage represents the usage totals in a result line, which Claude
+// writes once at the end of each invocation.
+type resultMessage struct {
+	Usage struct {
+		InputTokens              int `json:"input_tokens"`
+		CacheCreationInputTokens int `json:"cache_creation_input_tokens"`
+		CacheReadInputTokens     int `json:"cache_read_input_tokens"`
+		OutputTokens             int `json:"output_tokens"`
+	} `json:"usage"`
+}
+
+// ParseUsage returns the token usage reported by a result line of
+// stream-json output. ok is false for any other line.
+func ParseUsage(line string) (usage Usage, ok bool) {
+	line = strings.TrimSpace(line)
+	if !strings.Contains(line, `"usage"`) {
+		return Usage{}, false
+	}
+
+	var msg streamMessage
+	if err := json.Unmarshal([]byte(line), &msg); err != nil || msg.Type != "result" {
+		return Usage{}, false
+	}
+	var result resultMessage
+	if err := json.Unmarshal([]byte(line), &result); err != nil {
+		return Usage{}, false
+	}
+
+	u := result.Usage
+	return Usage{
+		InputTokens:     u.InputTokens + u.CacheCreationInputTokens + u.CacheReadInputTokens,
+		CacheReadTokens: u.CacheReadInputTokens,
+		OutputTokens:    u.OutputTokens,
+	}, true
+}
+
 // ParseLine parses a single line of stream-json output and returns an Event.
 // If the line cannot be parsed or is not relevant, it returns nil.
 func ParseLine(line string) *Event {
@@ -160,26 +251,7 @@ func parseAssistantMessage(raw json.RawMessage) *Event {
 	for _, block := range msg.Content {
 		switch block.Type {
 		case "text":
-			text := block.Text
-			// Check for <chief-complete/> tag
-			if strings.Contains(text, "<chief-complete/>") {
-				return &Event{
-					Type: EventComplete,
-					Text: text,
-				}
-			}
-			// Check for story markers using ralph-status tags
-			if storyID := extractStoryID(text, "<ralph-status>", "</ralph-status>"); storyID != "" {
-				return &Event{
-					Type:    EventStoryStarted,
-					Text:    text,
-					StoryID: storyID,
-				}
-			}
-			return &Event{
-				Type: EventAssistantText,
-				Text: text,
-			}
+			return parseText(block.Text)
 
 		case "tool_use":
 			return &Event{
@@ -193,6 +265,37 @@ func parseAssistantMessage(raw json.RawMessage) *Event {
 	return nil
 }
 
+// parseText turns the agent's text into an event, picking up the completion,
+// blocker and story markers the prompt asks it to write.
+func parseText(text string) *Event {
+	// Check for <chief-complete/> tag
+	if strings.Contains(text, "<chief-complete/>") {
+		return &Event{
+			Type: EventComplete,
+			Text: text,
+		}
+	}
+	// Check for a reported blocker
+	if reason := extractStoryID(text, "<chief-blocked>", "</chief-blocked>"); reason != "" {
+		return &Event{
+			Type: EventBlocked,
+			Text: reason,
+		}
+	}
+	// Check for story markers using ralph-status tags
+	if storyID := extractStoryID(text, "<ralph-status>", "</ralph-status>"); storyID != "" {
+		return &Event{
+			Type:    EventStoryStarted,
+			Text:    text,
+			StoryID: storyID,
+		}
+	}
+	return &Event{
+		Type: EventAssistantText,
+		Text: text,
+	}
+}
+
 // parseUserMessage parses a user message (typically tool results).
 func parseUserMessage(raw json.RawMessage) *Event {
 	if raw == nil {
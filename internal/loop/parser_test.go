@@ -20,6 +20,10 @@ func TestEventTypeString(t *testing.T) {
 		{EventMaxIterationsReached, "MaxIterationsReached"},
 		{EventError, "Error"},
 		{EventRetrying, "Retrying"},
+		{EventBlocked, "Blocked"},
+		{EventAttention, "Attention"},
+		{EventWarning, "Warning"},
+		{EventHook, "Hook"},
 	}
 
 	for _, tt := range tests {
@@ -114,6 +118,21 @@ func TestParseLineChiefComplete(t *testing.T) {
 	}
 }
 
+func TestParseLineChiefBlocked(t *testing.T) {
+	line := `{"type":"assistant","message":{"content":[{"type":"text","text":"I can't run the tests.\n<chief-blocked> Needs a STRIPE_API_KEY </chief-blocked>"}]}}`
+
+	event := ParseLine(line)
+	if event == nil {
+		t.Fatal("ParseLine returned nil, want event")
+	}
+	if event.Type != EventBlocked {
+		t.Errorf("event.Type = %v, want EventBlocked", event.Type)
+	}
+	if event.Text != "Needs a STRIPE_API_KEY" {
+		t.Errorf("event.Text = %q, want the trimmed reason", event.Text)
+	}
+}
+
 func TestParseLineToolUse(t *testing.T) {
 	line := `{"type":"assistant","message":{"content":[{"type":"tool_use","id":"toolu_123","name":"Read","input":{"file_path":"/test/file.go"}}]}}`
 
@@ -175,6 +194,23 @@ func TestParseLineResultMessage(t *testing.T) {
 	}
 }
 
+func TestParseUsage(t *testing.T) {
+	line := `{"type":"result","subtype":"success","result":"Done","usage":{"input_tokens":12,"cache_creation_input_tokens":300,"cache_read_input_tokens":5000,"output_tokens":450}}`
+
+	usage, ok := ParseUsage(line)
+	if !ok {
+		t.Fatal("ParseUsage returned ok = false for a result line with usage")
+	}
+	if usage.InputTokens != 5312 || usage.CacheReadTokens != 5000 || usage.OutputTokens != 450 {
+		t.Errorf("ParseUsage = %+v, want 5312 input (5000 from the cache) and 450 output tokens", usage)
+	}
+
+	// Assistant turns carry usage too, but the result line already totals them
+	if _, ok := ParseUsage(`{"type":"assistant","message":{"content":[],"usage":{"input_tokens":10,"output_tokens":5}}}`); ok {
+		t.Error("ParseUsage returned ok = true for an assistant line")
+	}
+}
+
 func TestParseLineUnknownType(t *testing.T) {
 	line := `{"type":"unknown_type"}`
 
@@ -0,0 +1,212 @@
+package loop
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/minicodemonkey/chief/internal/prd"
+)
+
+// ProgressReplay is progress.md rebuilt from a PRD's claude.log.
+type ProgressReplay struct {
+	Content    string // The rebuilt progress.md
+	Replayed   int    // Iterations whose own writes to progress.md were replayed
+	Summarised int    // Iterations with a story but no recoverable write, given a summary section instead
+}
+
+// heredocAppend matches a shell heredoc appended to progress.md, e.g.
+// cat >> progress.md << 'EOF', capturing the delimiter.
+var heredocAppend = regexp.MustCompile(`>>\s*["']?\S*progress\.md["']?[^\n]*<<-?\s*["']?(\w+)["']?|<<-?\s*["']?(\w+)["']?[^\n]*>>\s*["']?\S*progress\.md`)
+
+// ReplayProgress rebuilds progress.md from the raw agent output in a
+// claude.log, parsed into the same events the loop emitted. The agent's own
+// writes to progress.md (Write, Edit and heredoc appends in Bash) are
+// replayed in order. An iteration that worked on a story without a write that
+// could be replayed gets a short section summarising its last message, dated
+// from the story's recorded timings or now.
+func ReplayProgress(r io.Reader, timings []prd.StoryTime, now time.Time) (ProgressReplay, error) {
+	var (
+		replay   ProgressReplay
+		doc      string
+		story    string
+		lastText string
+		wrote    bool
+		started  bool
+	)
+	seen := make(map[string]int) // Iterations per story so far, to match timings
+
+	finishIteration := func() {
+		switch {
+		case !started || story == "":
+		case wrote:
+			replay.Replayed++
+		default:
+			date := now
+			if t, ok := nthTiming(timings, story, seen[story]); ok {
+				date = t.At
+			}
+			if doc != "" && !strings.HasSuffix(doc, "\n") {
+				doc += "\n"
+			}
+			if doc != "" && !strings.HasSuffix(doc, "\n\n") {
+				doc += "\n"
+			}
+			doc += fmt.Sprintf("## %s - %s\n", date.Format("2006-01-02"), story)
+			doc += "- Rebuilt from claude.log; the iteration's own notes could not be recovered\n"
+			if summary := summariseText(lastText); summary != "" {
+				doc += "- Last update: " + summary + "\n"
+			}
+			doc += "---\n"
+			replay.Summarised++
+		}
+		if story != "" {
+			seen[story]++
+		}
+		story, lastText, wrote = "", "", false
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		event := ParseLine(scanner.Text())
+		if event == nil {
+			continue
+		}
+		switch event.Type {
+		case EventIterationStart:
+			finishIteration()
+			started = true
+		case EventStoryStarted:
+			story = event.StoryID
+		case EventAssistantText:
+			lastText = event.Text
+		case EventToolStart:
+			if next, ok := applyProgressWrite(doc, event.Tool, event.ToolInput); ok {
+				doc = next
+				wrote = true
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return replay, fmt.Errorf("failed to read claude.log: %w", err)
+	}
+	finishIteration()
+
+	replay.Content = doc
+	return replay, nil
+}
+
+// applyProgressWrite applies a tool call to the progress.md content in doc.
+// ok is false when the call doesn't write progress.md or can't be replayed.
+func applyProgressWrite(doc, tool string, input map[string]interface{}) (string, bool) {
+	str := func(key string) string {
+		s, _ := input[key].(string)
+		return s
+	}
+
+	switch tool {
+	case "Write":
+		if !isProgressFile(str("file_path")) {
+			return doc, false
+		}
+		return str("content"), true
+
+	case "Edit":
+		if !isProgressFile(str("file_path")) {
+			return doc, false
+		}
+		oldText, newText := str("old_string"), str("new_string")
+		switch {
+		case oldText == "":
+			return newText, true
+		case strings.Contains(doc, oldText):
+			if all, _ := input["replace_all"].(bool); all {
+				return strings.ReplaceAll(doc, oldText, newText), true
+			}
+			return strings.Replace(doc, oldText, newText, 1), true
+		case strings.HasPrefix(newText, oldText):
+			// An append anchored on text that is missing from the rebuilt
+			// file, e.g. because earlier history was lost; keep what was added
+			return doc + strings.TrimPrefix(newText, oldText), true
+		}
+		return doc, false
+
+	case "Bash":
+		body, ok := heredocBody(str("command"))
+		if !ok {
+			return doc, false
+		}
+		if doc != "" && !strings.HasSuffix(doc, "\n") {
+			doc += "\n"
+		}
+		return doc + body, true
+	}
+	return doc, false
+}
+
+// heredocBody returns the text a shell command appends to progress.md with
+// a heredoc.
+func heredocBody(command string) (string, bool) {
+	m := heredocAppend.FindStringSubmatchIndex(command)
+	if m == nil {
+		return "", false
+	}
+	delim := ""
+	for _, group := range []int{2, 4} {
+		if m[group] >= 0 {
+			delim = command[m[group]:m[group+1]]
+		}
+	}
+	rest := command[m[1]:]
+	start := strings.Index(rest, "\n")
+	if delim == "" || start < 0 {
+		return "", false
+	}
+	lines := strings.Split(rest[start+1:], "\n")
+	for i, line := range lines {
+		if strings.TrimSpace(line) == delim {
+			return strings.Join(lines[:i], "\n") + "\n", true
+		}
+	}
+	return "", false
+}
+
+// isProgressFile reports whether path names a progress.md.
+func isProgressFile(path string) bool {
+	return filepath.Base(path) == "progress.md"
+}
+
+// nthTiming returns the n-th (0-based) recorded iteration on storyID.
+func nthTiming(timings []prd.StoryTime, storyID string, n int) (prd.StoryTime, bool) {
+	for _, t := range timings {
+		if t.StoryID != storyID {
+			continue
+		}
+		if n == 0 {
+			return t, true
+		}
+		n--
+	}
+	return prd.StoryTime{}, false
+}
+
+// summariseText reduces an assistant message to its first non-empty line,
+// without chief's status tags, capped at 200 characters.
+func summariseText(text string) string {
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "<ralph-status>") || strings.HasPrefix(line, "<chief-") {
+			continue
+		}
+		if runes := []rune(line); len(runes) > 200 {
+			line = string(runes[:197]) + "..."
+		}
+		return line
+	}
+	return ""
+}
@@ -0,0 +1,107 @@
+package loop
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minicodemonkey/chief/internal/prd"
+)
+
+// streamLines builds claude.log lines for ReplayProgress tests.
+type streamLines []string
+
+func (s *streamLines) init() {
+	*s = append(*s, `{"type":"system","subtype":"init"}`)
+}
+
+func (s *streamLines) text(text string) {
+	s.assistant(map[string]interface{}{"type": "text", "text": text})
+}
+
+func (s *streamLines) tool(name string, input map[string]interface{}) {
+	s.assistant(map[string]interface{}{"type": "tool_use", "id": "t", "name": name, "input": input})
+}
+
+func (s *streamLines) assistant(block map[string]interface{}) {
+	line, _ := json.Marshal(map[string]interface{}{
+		"type":    "assistant",
+		"message": map[string]interface{}{"content": []interface{}{block}},
+	})
+	*s = append(*s, string(line))
+}
+
+func TestReplayProgress(t *testing.T) {
+	var log streamLines
+
+	// Iteration 1 creates progress.md
+	log.init()
+	log.text("<ralph-status>US-001</ralph-status>")
+	log.tool("Write", map[string]interface{}{
+		"file_path": "/repo/.chief/prds/main/progress.md",
+		"content":   "## Codebase Patterns\n- Use sqlc\n\n## 2024-01-01 - US-001\n- Added table\n---\n",
+	})
+
+	// Iteration 2 edits a pattern in and appends with a heredoc
+	log.init()
+	log.text("<ralph-status>US-002</ralph-status>")
+	log.tool("Edit", map[string]interface{}{
+		"file_path":  "progress.md",
+		"old_string": "- Use sqlc\n",
+		"new_string": "- Use sqlc\n- Run make gen\n",
+	})
+	log.tool("Bash", map[string]interface{}{
+		"command": "cat >> progress.md << 'EOF'\n\n## 2024-01-02 - US-002\n- Added API\n---\nEOF",
+	})
+
+	// Iteration 3 never writes progress.md
+	log.init()
+	log.text("<ralph-status>US-003</ralph-status>")
+	log.tool("Write", map[string]interface{}{"file_path": "main.go", "content": "package main\n"})
+	log.text("Implemented the badge component.\nAll checks pass.")
+
+	// Iteration 4 has no story
+	log.init()
+	log.text("Nothing to do")
+
+	timings := []prd.StoryTime{
+		{StoryID: "US-003", At: time.Date(2024, 1, 3, 10, 0, 0, 0, time.UTC)},
+	}
+	replay, err := ReplayProgress(strings.NewReader(strings.Join(log, "\n")), timings, time.Now())
+	if err != nil {
+		t.Fatalf("ReplayProgress() error = %v", err)
+	}
+
+	want := "## Codebase Patterns\n- Use sqlc\n- Run make gen\n\n" +
+		"## 2024-01-01 - US-001\n- Added table\n---\n" +
+		"\n## 2024-01-02 - US-002\n- Added API\n---\n" +
+		"\n## 2024-01-03 - US-003\n" +
+		"- Rebuilt from claude.log; the iteration's own notes could not be recovered\n" +
+		"- Last update: Implemented the badge component.\n---\n"
+	if replay.Content != want {
+		t.Errorf("Content =\n%s\nwant\n%s", replay.Content, want)
+	}
+	if replay.Replayed != 2 || replay.Summarised != 1 {
+		t.Errorf("Replayed, Summarised = %d, %d, want 2, 1", replay.Replayed, replay.Summarised)
+	}
+}
+
+func TestHeredocBody(t *testing.T) {
+	tests := []struct {
+		command string
+		want    string
+		ok      bool
+	}{
+		{"cat >> progress.md <<EOF\nline\nEOF", "line\n", true},
+		{"cat <<'NOTES' >> \"./progress.md\"\na\nb\nNOTES\n", "a\nb\n", true},
+		{"cat > other.md <<EOF\nline\nEOF", "", false},
+		{"echo hi >> progress.md", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := heredocBody(tt.command)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("heredocBody(%q) = %q, %v, want %q, %v", tt.command, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package loop
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/minicodemonkey/chief/internal/git"
+)
+
+// scopeSnapshot records the working tree status of the repository's other
+// worktrees before an iteration, so changes the agent makes outside its own
+// working directory can be spotted afterwards.
+type scopeSnapshot map[string]map[string]string // worktree path -> file -> state
+
+// strayChange is a file outside the loop's working directory that an
+// iteration changed.
+type strayChange struct {
+	dir      string // Worktree the file belongs to
+	file     string // Path relative to dir
+	restored bool   // Whether the change was reverted
+}
+
+func (c strayChange) String() string {
+	return filepath.Join(c.dir, c.file)
+}
+
+// SetWriteScope makes the loop check, after each iteration, that the agent
+// left the repository's other worktrees alone. With revert set, tracked files
+// it changed there are restored. shared returns directories other loops are
+// working in, whose changes are theirs and not out of scope; it may be nil.
+func (l *Loop) SetWriteScope(check, revert bool, shared func() []string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.scopeCheck = check
+	l.scopeRevert = revert
+	l.scopeShared = shared
+}
+
+// outOfScopeDirs returns the worktrees of the loop's repository that the
+// agent has no business writing to.
+func (l *Loop) outOfScopeDirs() []string {
+	l.mu.Lock()
+	check, shared := l.scopeCheck, l.scopeShared
+	l.mu.Unlock()
+	if !check || l.workDir == "" {
+		return nil
+	}
+
+	worktrees, err := git.ListWorktrees(l.workDir)
+	if err != nil {
+		return nil
+	}
+	skip := map[string]bool{cleanDir(l.workDir): true}
+	if shared != nil {
+		for _, dir := range shared() {
+			skip[cleanDir(dir)] = true
+		}
+	}
+
+	var dirs []string
+	for _, wt := range worktrees {
+		if wt.Prunable || skip[cleanDir(wt.Path)] {
+			continue
+		}
+		dirs = append(dirs, wt.Path)
+	}
+	return dirs
+}
+
+// cleanDir normalises a directory for comparison, resolving symlinks such as
+// macOS's /var -> /private/var so git's paths match ours.
+func cleanDir(dir string) string {
+	if resolved, err := filepath.EvalSymlinks(dir); err == nil {
+		return resolved
+	}
+	return filepath.Clean(dir)
+}
+
+// takeScopeSnapshot records the status of every out-of-scope worktree.
+// Returns nil when the write scope isn't being checked.
+func (l *Loop) takeScopeSnapshot() scopeSnapshot {
+	dirs := l.outOfScopeDirs()
+	if len(dirs) == 0 {
+		return nil
+	}
+	snap := make(scopeSnapshot, len(dirs))
+	for _, dir := range dirs {
+		if files, err := fileStates(dir); err == nil {
+			snap[dir] = files
+		}
+	}
+	return snap
+}
+
+// fileStates returns the dirty files in dir with their porcelain code and
+// modification time, so a further edit to an already modified file shows up.
+func fileStates(dir string) (map[string]string, error) {
+	status, err := git.StatusPorcelain(dir)
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string]string, len(status))
+	for file, code := range status {
+		state := code
+		if info, err := os.Stat(filepath.Join(dir, file)); err == nil {
+			state += " " + info.ModTime().Format(time.RFC3339Nano)
+		}
+		files[file] = state
+	}
+	return files, nil
+}
+
+// strayChanges compares the out-of-scope worktrees against snap and returns the
+// files that changed, reverting tracked files that were clean beforehand when
+// the loop is set to. Untracked files are never deleted, only reported.
+func (l *Loop) strayChanges(snap scopeSnapshot) []strayChange {
+	l.mu.Lock()
+	revert := l.scopeRevert
+	l.mu.Unlock()
+
+	var changes []strayChange
+	for dir, before := range snap {
+		after, err := fileStates(dir)
+		if err != nil {
+			continue
+		}
+
+		var stray, restorable []string
+		for file, state := range after {
+			if before[file] == state {
+				continue
+			}
+			stray = append(stray, file)
+			if _, wasDirty := before[file]; !wasDirty && !strings.HasPrefix(state, "??") {
+				restorable = append(restorable, file)
+			}
+		}
+		// A file that was dirty and is now clean was changed too (committed or reverted)
+		for file := range before {
+			if _, ok := after[file]; !ok {
+				stray = append(stray, file)
+			}
+		}
+		sort.Strings(stray)
+
+		restored := make(map[string]bool)
+		if revert && len(restorable) > 0 {
+			if err := git.RestoreFiles(dir, restorable); err == nil {
+				for _, file := range restorable {
+					restored[file] = true
+				}
+			}
+		}
+		for _, file := range stray {
+			changes = append(changes, strayChange{dir: dir, file: file, restored: restored[file]})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].String() < changes[j].String()
+	})
+	return changes
+}
+
+// describeStrayChanges summarises out-of-scope changes for an event, e.g.
+// "Changed outside the worktree: /repo/main.go (reverted), /repo/notes.txt".
+func describeStrayChanges(changes []strayChange) string {
+	parts := make([]string, len(changes))
+	for i, c := range changes {
+		parts[i] = c.String()
+		if c.restored {
+			parts[i] += " (reverted)"
+		}
+	}
+	return "Changed outside the worktree: " + strings.Join(parts, ", ")
+}
@@ -0,0 +1,128 @@
+package loop
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// initScopeRepo creates a repository with a committed tracked.txt and a second
+// worktree for the loop to work in. Returns the main checkout and the worktree.
+func initScopeRepo(t *testing.T) (string, string) {
+	t.Helper()
+
+	repo := t.TempDir()
+	worktree := filepath.Join(t.TempDir(), "wt")
+	if err := os.WriteFile(filepath.Join(repo, "tracked.txt"), []byte("original\n"), 0644); err != nil {
+		t.Fatalf("Failed to write tracked.txt: %v", err)
+	}
+	for _, args := range [][]string{
+		{"git", "init", "-b", "main"},
+		{"git", "config", "user.email", "test@test.com"},
+		{"git", "config", "user.name", "Test"},
+		{"git", "add", "."},
+		{"git", "commit", "-m", "initial"},
+		{"git", "worktree", "add", "-b", "feature", worktree},
+	} {
+		c := exec.Command(args[0], args[1:]...)
+		c.Dir = repo
+		if out, err := c.CombinedOutput(); err != nil {
+			t.Fatalf("setup %v failed: %s", args, out)
+		}
+	}
+	return repo, worktree
+}
+
+func TestLoop_WriteScope(t *testing.T) {
+	tests := []struct {
+		name         string
+		revert       bool
+		pauseOn      []string
+		shared       bool // The main checkout belongs to another running loop
+		wantText     string
+		wantOriginal bool // tracked.txt in the main checkout is back to its committed content
+	}{
+		{"warns", false, nil, false, "tracked.txt, REPO/untracked.txt", false},
+		{"reverts tracked files", true, nil, false, "tracked.txt (reverted), REPO/untracked.txt", true},
+		{"pauses", false, []string{"out-of-scope"}, false, "tracked.txt", false},
+		{"ignores other loops' worktrees", true, nil, true, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, worktree := initScopeRepo(t)
+			prdPath := filepath.Join(t.TempDir(), "prd.json")
+			writeStories(t, prdPath, false, false)
+			installClaudeScript(t, "echo changed > "+filepath.Join(repo, "tracked.txt")+"\n"+
+				"touch "+filepath.Join(repo, "untracked.txt")+"\n"+
+				"echo ours > "+filepath.Join(worktree, "tracked.txt")+"\n")
+
+			l := NewLoopWithWorkDir(prdPath, worktree, "test prompt", 1)
+			l.SetPauseOn(tt.pauseOn)
+			var shared func() []string
+			if tt.shared {
+				shared = func() []string { return []string{repo} }
+			}
+			l.SetWriteScope(true, tt.revert, shared)
+			events, err := runCollecting(t, l)
+			if err != nil {
+				t.Fatalf("Run failed: %v", err)
+			}
+
+			var scope []string
+			paused := false
+			for _, e := range events {
+				switch e.Type {
+				case EventOutOfScope:
+					scope = append(scope, e.Text)
+				case EventAttention:
+					paused = strings.Contains(e.Text, "Changed outside the worktree")
+				}
+			}
+			if tt.wantText == "" {
+				if len(scope) != 0 {
+					t.Fatalf("Expected no out-of-scope warning, got %v", scope)
+				}
+			} else {
+				want := strings.ReplaceAll(tt.wantText, "REPO", cleanDir(repo))
+				if len(scope) != 1 || !strings.Contains(scope[0], want) {
+					t.Fatalf("Expected one warning containing %q, got %v", want, scope)
+				}
+				if strings.Contains(scope[0], worktree) {
+					t.Errorf("Changes in the loop's own worktree should not be flagged: %q", scope[0])
+				}
+			}
+			if paused != (tt.pauseOn != nil) {
+				t.Errorf("Expected paused=%v for pauseOn %v", tt.pauseOn != nil, tt.pauseOn)
+			}
+
+			data, _ := os.ReadFile(filepath.Join(repo, "tracked.txt"))
+			if got := string(data) == "original\n"; got != tt.wantOriginal {
+				t.Errorf("tracked.txt in the main checkout = %q, want reverted=%v", data, tt.wantOriginal)
+			}
+			if _, err := os.Stat(filepath.Join(repo, "untracked.txt")); err != nil {
+				t.Errorf("Untracked files should never be deleted: %v", err)
+			}
+		})
+	}
+}
+
+func TestLoop_WriteScopeDisabled(t *testing.T) {
+	repo, worktree := initScopeRepo(t)
+	prdPath := filepath.Join(t.TempDir(), "prd.json")
+	writeStories(t, prdPath, false, false)
+	installClaudeScript(t, "echo changed > "+filepath.Join(repo, "tracked.txt")+"\n")
+
+	l := NewLoopWithWorkDir(prdPath, worktree, "test prompt", 1)
+	events, err := runCollecting(t, l)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	for _, e := range events {
+		if e.Type == EventOutOfScope {
+			t.Fatalf("Expected no check without SetWriteScope, got %q", e.Text)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package loop
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/minicodemonkey/chief/internal/prd"
+)
+
+// Snapshot is the last known state of a PRD's loop, saved to state.json next
+// to prd.json so that restarting chief doesn't lose the run's iteration count
+// and timing.
+type Snapshot struct {
+	State     string    `json:"state"`           // LoopState.String()
+	Iteration int       `json:"iteration"`       // Iteration the loop had reached
+	StartTime time.Time `json:"startTime"`       // When the run started
+	Story     string    `json:"story,omitempty"` // Story being worked on, for PRDs that keep inProgress out of prd.json
+	UpdatedAt time.Time `json:"updatedAt"`       // When the snapshot was written
+}
+
+// Elapsed returns how long the run had been going when the snapshot was written.
+func (s *Snapshot) Elapsed() time.Duration {
+	if s.StartTime.IsZero() || s.UpdatedAt.Before(s.StartTime) {
+		return 0
+	}
+	return s.UpdatedAt.Sub(s.StartTime)
+}
+
+// LoopState returns the state to restore the loop in. A loop that was running
+// or failed when chief exited is no longer running, so it comes back stopped.
+func (s *Snapshot) LoopState() LoopState {
+	switch s.State {
+	case LoopStatePaused.String():
+		return LoopStatePaused
+	case LoopStateComplete.String():
+		return LoopStateComplete
+	case LoopStateRunning.String(), LoopStateStopped.String(), LoopStateError.String():
+		return LoopStateStopped
+	default:
+		return LoopStateReady
+	}
+}
+
+// StatePath returns the state.json path for a given prd.json path.
+func StatePath(prdPath string) string {
+	return filepath.Join(filepath.Dir(prdPath), "state.json")
+}
+
+// CheckNotRunning returns an error naming the PRD and the action when a loop
+// appears to be running on it, so moving it would pull the PRD out from under
+// the loop.
+func CheckNotRunning(prdPath, name, action string) error {
+	story, running := runningStory(prdPath)
+	switch {
+	case !running:
+		return nil
+	case story == "":
+		return fmt.Errorf("a loop appears to be running for %q; stop it before %s", name, action)
+	default:
+		return fmt.Errorf("a loop appears to be running for %q (story %s is in progress); stop it before %s", name, story, action)
+	}
+}
+
+// runningStory reports whether a loop appears to be running on a PRD, and the
+// story it's on. It reads the inProgress flags in prd.json and, for PRDs that
+// keep them in state.json instead, the saved loop state.
+func runningStory(prdPath string) (string, bool) {
+	if snap, err := LoadSnapshot(prdPath); err == nil && snap != nil && snap.State == LoopStateRunning.String() {
+		return snap.Story, true
+	}
+	if p, err := prd.LoadPRD(prdPath); err == nil {
+		for _, story := range p.UserStories {
+			if story.InProgress {
+				return story.ID, true
+			}
+		}
+	}
+	return "", false
+}
+
+// MarkRunningStory sets InProgress on the story the saved loop state says a
+// running loop is on, so a PRD loaded from a prd.json that doesn't record it
+// still shows the story in progress.
+func MarkRunningStory(prdPath string, p *prd.PRD) {
+	snap, err := LoadSnapshot(prdPath)
+	if err != nil || snap == nil || snap.State != LoopStateRunning.String() {
+		return
+	}
+	for i := range p.UserStories {
+		if p.UserStories[i].ID == snap.Story {
+			p.UserStories[i].InProgress = true
+		}
+	}
+}
+
+// LoadSnapshot reads the saved loop state for a PRD. Returns nil (no error)
+// when no state has been saved yet.
+func LoadSnapshot(prdPath string) (*Snapshot, error) {
+	data, err := os.ReadFile(StatePath(prdPath))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var s Snapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", StatePath(prdPath), err)
+	}
+	return &s, nil
+}
+
+// SaveSnapshot writes the loop state for a PRD, replacing the file atomically
+// so a crash mid-write can't leave it truncated.
+func SaveSnapshot(prdPath string, s Snapshot) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode loop state: %w", err)
+	}
+	path := StatePath(prdPath)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write loop state: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// Session records what the TUI was showing when chief last exited, so the
+// next launch can offer to pick up where it left off. There is one per
+// project, at paths.SessionPath.
+type Session struct {
+	PRD       string    `json:"prd"`             // Name of the PRD being viewed
+	Story     string    `json:"story,omitempty"` // ID of the selected story
+	Running   bool      `json:"running"`         // Whether that PRD's loop was running at exit
+	UpdatedAt time.Time `json:"updatedAt"`       // When the session was saved
+}
+
+// LoadSession reads the session saved at path. Returns nil (no error) when
+// none has been saved.
+func LoadSession(path string) (*Session, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var s Session
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return &s, nil
+}
+
+// SaveSession writes the session to path, creating its directory if needed.
+func SaveSession(path string, s Session) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode session: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	return os.WriteFile(path, data, 0644)
+}
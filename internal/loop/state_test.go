@@ -0,0 +1,110 @@
+package loop
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minicodemonkey/chief/internal/prd"
+)
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	prdPath := createTestPRDWithName(t, t.TempDir(), "auth")
+
+	if snap, err := LoadSnapshot(prdPath); err != nil || snap != nil {
+		t.Fatalf("LoadSnapshot() = %v, %v; want nil, nil before anything is saved", snap, err)
+	}
+
+	start := time.Date(2026, 3, 4, 9, 0, 0, 0, time.UTC)
+	want := Snapshot{State: "Running", Iteration: 4, StartTime: start, UpdatedAt: start.Add(12 * time.Minute)}
+	if err := SaveSnapshot(prdPath, want); err != nil {
+		t.Fatalf("SaveSnapshot() error = %v", err)
+	}
+	got, err := LoadSnapshot(prdPath)
+	if err != nil || got == nil {
+		t.Fatalf("LoadSnapshot() = %v, %v", got, err)
+	}
+	if got.Iteration != 4 || !got.StartTime.Equal(start) || got.Elapsed() != 12*time.Minute {
+		t.Errorf("unexpected snapshot: %+v", got)
+	}
+	// The process died with chief, so a running loop comes back stopped
+	if got.LoopState() != LoopStateStopped {
+		t.Errorf("LoopState() = %v, want Stopped", got.LoopState())
+	}
+}
+
+func TestCheckNotRunning(t *testing.T) {
+	prdPath := createTestPRDWithName(t, t.TempDir(), "auth")
+	if err := CheckNotRunning(prdPath, "auth", "renaming"); err != nil {
+		t.Fatalf("CheckNotRunning() error = %v with no loop", err)
+	}
+
+	// With inProgressInState, only state.json knows the story
+	if err := SaveSnapshot(prdPath, Snapshot{State: LoopStateRunning.String(), Story: "US-001"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := CheckNotRunning(prdPath, "auth", "renaming"); err == nil || !strings.Contains(err.Error(), "US-001") {
+		t.Errorf("CheckNotRunning() error = %v, want one naming US-001", err)
+	}
+	p, err := prd.LoadPRD(prdPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	MarkRunningStory(prdPath, p)
+	if !p.UserStories[0].InProgress {
+		t.Error("expected MarkRunningStory to mark US-001 in progress")
+	}
+
+	if err := SaveSnapshot(prdPath, Snapshot{State: LoopStateStopped.String(), Story: "US-001"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := CheckNotRunning(prdPath, "auth", "renaming"); err != nil {
+		t.Errorf("CheckNotRunning() error = %v after the loop stopped", err)
+	}
+}
+
+func TestManagerRegisterRestoresSnapshot(t *testing.T) {
+	prdPath := createTestPRDWithName(t, t.TempDir(), "auth")
+	start := time.Now().Add(-time.Hour)
+	if err := SaveSnapshot(prdPath, Snapshot{State: "Complete", Iteration: 7, StartTime: start, UpdatedAt: start.Add(time.Minute)}); err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewManager(10)
+	if err := m.Register("auth", prdPath); err != nil {
+		t.Fatal(err)
+	}
+
+	state, iteration, _ := m.GetState("auth")
+	if iteration != 7 {
+		t.Errorf("expected iteration 7, got %d", iteration)
+	}
+	// The test PRD still has a pending story, so it can't be complete any more
+	if state != LoopStateStopped {
+		t.Errorf("expected a stale Complete to restore as Stopped, got %v", state)
+	}
+	if instance := m.GetInstance("auth"); !instance.StartTime.Equal(start) {
+		t.Errorf("expected start time %v, got %v", start, instance.StartTime)
+	}
+}
+
+func TestManagerRestoresCurrentStory(t *testing.T) {
+	prdPath := createTestPRDWithName(t, t.TempDir(), "auth")
+	if err := SaveSnapshot(prdPath, Snapshot{State: "Paused", Iteration: 2, Story: "US-001", UpdatedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewManager(10)
+	if err := m.Register("auth", prdPath); err != nil {
+		t.Fatal(err)
+	}
+	if story := m.GetInstance("auth").Story; story != "US-001" {
+		t.Errorf("expected the paused story US-001 to be restored, got %q", story)
+	}
+
+	// Saving writes the story back for the next session
+	saveSnapshot(m.instances["auth"])
+	if snap, err := LoadSnapshot(prdPath); err != nil || snap.Story != "US-001" {
+		t.Errorf("expected story US-001 in state.json, got %+v, %v", snap, err)
+	}
+}
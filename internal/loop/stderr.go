@@ -0,0 +1,72 @@
+package loop
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// stderrKinds classify the agent's stderr lines worth surfacing, most
+// specific first.
+var stderrKinds = []struct {
+	kind    string
+	pattern *regexp.Regexp
+}{
+	{"Deprecation", regexp.MustCompile(`(?i)deprecat`)},
+	{"Auth", regexp.MustCompile(`(?i)unauthori[sz]ed|authenticat|api[ _-]?key|oauth|credential|(token|session|login)( has)? expired|not logged in|log ?in again`)},
+	{"Warning", regexp.MustCompile(`(?i)\bwarn(ing)?\b`)},
+}
+
+// digitRuns matches runs of digits, which are masked so that a warning
+// repeated with a different PID or timestamp counts as the same warning.
+var digitRuns = regexp.MustCompile(`\d+`)
+
+// ClassifyStderr reports whether a line the agent wrote to stderr is a notable
+// warning, and of what kind: "Deprecation", "Auth" or "Warning".
+func ClassifyStderr(line string) (string, bool) {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return "", false
+	}
+	for _, k := range stderrKinds {
+		if k.pattern.MatchString(line) {
+			return k.kind, true
+		}
+	}
+	return "", false
+}
+
+// processStderr logs the agent's stderr and emits EventWarning for notable
+// lines, even when the process goes on to exit successfully. Each warning is
+// emitted once per loop rather than once per iteration.
+func (l *Loop) processStderr(r io.Reader) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		l.logLine("[stderr] " + line)
+
+		kind, ok := ClassifyStderr(line)
+		if !ok {
+			continue
+		}
+		key := digitRuns.ReplaceAllString(strings.TrimSpace(line), "#")
+		l.mu.Lock()
+		if l.warned == nil {
+			l.warned = make(map[string]bool)
+		}
+		seen := l.warned[key]
+		l.warned[key] = true
+		iter := l.iteration
+		l.mu.Unlock()
+		if seen {
+			continue
+		}
+
+		l.events <- Event{
+			Type:      EventWarning,
+			Iteration: iter,
+			Text:      kind + ": " + strings.TrimSpace(line),
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package loop
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestClassifyStderr(t *testing.T) {
+	tests := []struct {
+		line string
+		kind string
+		ok   bool
+	}{
+		{"(node:1234) [DEP0040] DeprecationWarning: The `punycode` module is deprecated.", "Deprecation", true},
+		{"Warning: OAuth token has expired, run claude login again", "Auth", true},
+		{"Invalid API key · Please run /login", "Auth", true},
+		{"warning: model claude-x is nearing end of life", "Warning", true},
+		{"Loading configuration...", "", false},
+		{"   ", "", false},
+	}
+	for _, tt := range tests {
+		kind, ok := ClassifyStderr(tt.line)
+		if kind != tt.kind || ok != tt.ok {
+			t.Errorf("ClassifyStderr(%q) = %q, %v, want %q, %v", tt.line, kind, ok, tt.kind, tt.ok)
+		}
+	}
+}
+
+func TestProcessStderrEmitsEachWarningOnce(t *testing.T) {
+	l := NewLoop("prd.json", "prompt", 1)
+	l.iteration = 2
+
+	stderr := "(node:11) DeprecationWarning: punycode is deprecated\n" +
+		"plain progress output\n" +
+		"(node:12) DeprecationWarning: punycode is deprecated\n" +
+		"Warning: token expired\n"
+	l.processStderr(strings.NewReader(stderr))
+	close(l.events)
+
+	var got []Event
+	for e := range l.events {
+		got = append(got, e)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 warnings, got %+v", got)
+	}
+	if got[0].Type != EventWarning || got[0].Iteration != 2 || got[0].Text != "Deprecation: (node:11) DeprecationWarning: punycode is deprecated" {
+		t.Errorf("unexpected first warning %+v", got[0])
+	}
+	if got[1].Text != "Auth: Warning: token expired" {
+		t.Errorf("unexpected second warning %+v", got[1])
+	}
+}
@@ -0,0 +1,85 @@
+// Package notify sends desktop notifications, e.g. when a PRD completes or a
+// loop fails, using whichever notification tool the platform provides.
+package notify
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// ErrUnsupported is returned when no desktop notification tool is available.
+var ErrUnsupported = errors.New("desktop notifications are not supported on this system")
+
+// lookPath is swapped out in tests to simulate installed tools.
+var lookPath = exec.LookPath
+
+// SendDesktop shows a desktop notification with the given title and body.
+// The notification tool runs in the background so callers never block on it.
+// It returns ErrUnsupported if the platform's tool isn't installed; callers
+// can ignore the error to degrade gracefully.
+func SendDesktop(title, body string) error {
+	name, args, ok := desktopCommand(runtime.GOOS, title, body)
+	if !ok {
+		return ErrUnsupported
+	}
+
+	cmd := exec.Command(name, args...)
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to send desktop notification: %w", err)
+	}
+	go cmd.Wait()
+	return nil
+}
+
+// desktopCommand returns the command that shows a notification on goos, or
+// ok=false if no supported tool is installed.
+func desktopCommand(goos, title, body string) (name string, args []string, ok bool) {
+	switch goos {
+	case "darwin":
+		if path, err := lookPath("terminal-notifier"); err == nil {
+			return path, []string{"-title", title, "-message", body, "-group", "chief"}, true
+		}
+		if path, err := lookPath("osascript"); err == nil {
+			script := fmt.Sprintf("display notification %s with title %s", appleScriptString(body), appleScriptString(title))
+			return path, []string{"-e", script}, true
+		}
+	case "windows":
+		if path, err := lookPath("powershell"); err == nil {
+			return path, []string{"-NoProfile", "-NonInteractive", "-Command", windowsToastScript(title, body)}, true
+		}
+	default:
+		if path, err := lookPath("notify-send"); err == nil {
+			return path, []string{"--app-name=chief", title, body}, true
+		}
+	}
+	return "", nil, false
+}
+
+// appleScriptString quotes s as an AppleScript string literal.
+func appleScriptString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
+
+// powerShellString quotes s as a single-quoted PowerShell string literal.
+func powerShellString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// windowsToastScript builds a PowerShell script that shows a balloon
+// notification from the system tray, which needs no extra modules.
+func windowsToastScript(title, body string) string {
+	return strings.Join([]string{
+		"Add-Type -AssemblyName System.Windows.Forms",
+		"$n = New-Object System.Windows.Forms.NotifyIcon",
+		"$n.Icon = [System.Drawing.SystemIcons]::Information",
+		"$n.Visible = $true",
+		fmt.Sprintf("$n.ShowBalloonTip(5000, %s, %s, 'Info')", powerShellString(title), powerShellString(body)),
+		"Start-Sleep -Seconds 6",
+		"$n.Dispose()",
+	}, "; ")
+}
@@ -0,0 +1,70 @@
+package notify
+
+import (
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// stubLookPath makes only the named tools appear installed.
+func stubLookPath(t *testing.T, installed ...string) {
+	t.Helper()
+	orig := lookPath
+	t.Cleanup(func() { lookPath = orig })
+	lookPath = func(file string) (string, error) {
+		for _, name := range installed {
+			if name == file {
+				return "/usr/bin/" + file, nil
+			}
+		}
+		return "", exec.ErrNotFound
+	}
+}
+
+func TestDesktopCommand(t *testing.T) {
+	tests := []struct {
+		goos      string
+		installed []string
+		wantName  string
+		wantArg   string
+	}{
+		{"linux", []string{"notify-send"}, "/usr/bin/notify-send", "Done"},
+		{"darwin", []string{"terminal-notifier", "osascript"}, "/usr/bin/terminal-notifier", "-message"},
+		{"darwin", []string{"osascript"}, "/usr/bin/osascript", `display notification "Done" with title "Chief"`},
+		{"windows", []string{"powershell"}, "/usr/bin/powershell", "ShowBalloonTip(5000, 'Chief', 'Done', 'Info')"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.goos+"/"+tt.wantName, func(t *testing.T) {
+			stubLookPath(t, tt.installed...)
+			name, args, ok := desktopCommand(tt.goos, "Chief", "Done")
+			if !ok {
+				t.Fatal("expected a notification command")
+			}
+			if name != tt.wantName {
+				t.Errorf("expected %s, got %s", tt.wantName, name)
+			}
+			joined := strings.Join(args, " ")
+			if !strings.Contains(joined, tt.wantArg) {
+				t.Errorf("expected args to contain %q, got %q", tt.wantArg, joined)
+			}
+		})
+	}
+}
+
+func TestSendDesktopUnsupported(t *testing.T) {
+	stubLookPath(t)
+	if err := SendDesktop("Chief", "Done"); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("expected ErrUnsupported when no tool is installed, got %v", err)
+	}
+}
+
+func TestQuoting(t *testing.T) {
+	if got := appleScriptString(`say "hi" \ bye`); got != `"say \"hi\" \\ bye"` {
+		t.Errorf("unexpected AppleScript quoting: %s", got)
+	}
+	if got := powerShellString("it's"); got != "'it''s'" {
+		t.Errorf("unexpected PowerShell quoting: %s", got)
+	}
+}
@@ -0,0 +1,165 @@
+package notify
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+// ErrUnsupportedSound is returned for a sound file the platform's player
+// can't play.
+var ErrUnsupportedSound = errors.New("unsupported sound format")
+
+// soundFormats lists the file extensions each platform's player handles:
+// afplay on macOS, paplay elsewhere on Unix and SoundPlayer on Windows.
+var soundFormats = map[string][]string{
+	"darwin":  {".aif", ".aiff", ".caf", ".m4a", ".mp3", ".wav"},
+	"windows": {".wav"},
+	"":        {".flac", ".oga", ".ogg", ".wav"},
+}
+
+// builtinSounds are the system sounds played when no sound file is set, as
+// {completion, error}. Windows uses its system sounds by name instead.
+var builtinSounds = map[string][2]string{
+	"darwin": {"/System/Library/Sounds/Glass.aiff", "/System/Library/Sounds/Basso.aiff"},
+	"":       {"/usr/share/sounds/freedesktop/stereo/complete.oga", "/usr/share/sounds/freedesktop/stereo/dialog-error.oga"},
+}
+
+// Sounds plays a sound when a PRD completes or a loop fails.
+type Sounds struct {
+	CompletionFile string  // Played by PlayCompletion; empty means the built-in sound
+	ErrorFile      string  // Played by PlayError; empty means the built-in sound
+	Volume         float64 // 0.0–1.0, where 0 plays nothing; otherwise ignored on Windows
+}
+
+// NewSounds returns Sounds for the configured files and volume. A file that
+// doesn't exist or can't be played, or a volume outside 0–1, is reported and
+// replaced by the built-in sound or full volume, so a bad setting never
+// silences notifications. A nil volume means full volume.
+func NewSounds(completionFile, errorFile string, volume *float64) (*Sounds, []error) {
+	var problems []error
+	check := func(setting, path string) string {
+		if path == "" {
+			return ""
+		}
+		if err := CheckSoundFile(runtime.GOOS, path); err != nil {
+			problems = append(problems, fmt.Errorf("%s: %w; using the built-in sound", setting, err))
+			return ""
+		}
+		return path
+	}
+	s := &Sounds{
+		CompletionFile: check("notifications.soundFile", completionFile),
+		ErrorFile:      check("notifications.errorSoundFile", errorFile),
+		Volume:         1,
+	}
+	if volume != nil {
+		if *volume < 0 || *volume > 1 {
+			problems = append(problems, fmt.Errorf("notifications.volume %g is outside 0.0–1.0; using full volume", *volume))
+		} else {
+			s.Volume = *volume
+		}
+	}
+	return s, problems
+}
+
+// CheckSoundFile returns an error if path doesn't exist or goos's player
+// can't play its format.
+func CheckSoundFile(goos, path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("sound file %s: %w", path, err)
+	}
+	if info.IsDir() {
+		return fmt.Errorf("sound file %s is a directory", path)
+	}
+	formats := formatsFor(goos)
+	if ext := strings.ToLower(filepath.Ext(path)); !slices.Contains(formats, ext) {
+		return fmt.Errorf("%w %q for %s (use %s)", ErrUnsupportedSound, ext, path, strings.Join(formats, ", "))
+	}
+	return nil
+}
+
+// formatsFor returns the sound file extensions goos's player handles.
+func formatsFor(goos string) []string {
+	if formats, ok := soundFormats[goos]; ok {
+		return formats
+	}
+	return soundFormats[""]
+}
+
+// PlayCompletion plays the completion sound in the background. It returns
+// ErrUnsupported if the platform has no player.
+func (s *Sounds) PlayCompletion() error {
+	return s.play(s.CompletionFile, false)
+}
+
+// PlayError plays the error sound in the background. It returns
+// ErrUnsupported if the platform has no player.
+func (s *Sounds) PlayError() error {
+	return s.play(s.ErrorFile, true)
+}
+
+// play starts the platform's player for file, or the built-in sound when
+// file is empty, without waiting for it to finish. Nothing plays at volume 0.
+func (s *Sounds) play(file string, isError bool) error {
+	if s.Volume == 0 {
+		return nil
+	}
+	name, args, ok := soundCommand(runtime.GOOS, file, isError, s.Volume)
+	if !ok {
+		return ErrUnsupported
+	}
+	cmd := exec.Command(name, args...)
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to play sound: %w", err)
+	}
+	go cmd.Wait()
+	return nil
+}
+
+// soundCommand returns the command that plays file on goos at volume, or
+// ok=false if no supported player is installed.
+func soundCommand(goos, file string, isError bool, volume float64) (name string, args []string, ok bool) {
+	if goos == "windows" {
+		path, err := lookPath("powershell")
+		if err != nil {
+			return "", nil, false
+		}
+		script := "[System.Media.SystemSounds]::Asterisk.Play(); Start-Sleep -Seconds 1"
+		if isError {
+			script = "[System.Media.SystemSounds]::Hand.Play(); Start-Sleep -Seconds 1"
+		}
+		if file != "" {
+			script = fmt.Sprintf("(New-Object System.Media.SoundPlayer %s).PlaySync()", powerShellString(file))
+		}
+		return path, []string{"-NoProfile", "-NonInteractive", "-Command", script}, true
+	}
+
+	if file == "" {
+		sounds, known := builtinSounds[goos]
+		if !known {
+			sounds = builtinSounds[""]
+		}
+		file = sounds[0]
+		if isError {
+			file = sounds[1]
+		}
+	}
+	if goos == "darwin" {
+		if path, err := lookPath("afplay"); err == nil {
+			return path, []string{"-v", fmt.Sprintf("%.2f", volume), file}, true
+		}
+		return "", nil, false
+	}
+	if path, err := lookPath("paplay"); err == nil {
+		// paplay's volume is linear, with 65536 as 100%
+		return path, []string{fmt.Sprintf("--volume=%d", int(volume*65536)), file}, true
+	}
+	return "", nil, false
+}
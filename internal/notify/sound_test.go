@@ -0,0 +1,97 @@
+package notify
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSoundCommand(t *testing.T) {
+	tests := []struct {
+		name      string
+		goos      string
+		installed []string
+		file      string
+		isError   bool
+		wantName  string
+		wantArgs  string
+	}{
+		{"darwin file", "darwin", []string{"afplay"}, "/tmp/done.mp3", false, "/usr/bin/afplay", "-v 0.50 /tmp/done.mp3"},
+		{"darwin error", "darwin", []string{"afplay"}, "", true, "/usr/bin/afplay", "-v 0.50 /System/Library/Sounds/Basso.aiff"},
+		{"linux builtin", "linux", []string{"paplay"}, "", false, "/usr/bin/paplay", "--volume=32768 /usr/share/sounds/freedesktop/stereo/complete.oga"},
+		{"windows file", "windows", []string{"powershell"}, `C:\done.wav`, false, "/usr/bin/powershell", `SoundPlayer 'C:\done.wav'`},
+		{"windows error", "windows", []string{"powershell"}, "", true, "/usr/bin/powershell", "SystemSounds]::Hand.Play()"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stubLookPath(t, tt.installed...)
+			name, args, ok := soundCommand(tt.goos, tt.file, tt.isError, 0.5)
+			if !ok {
+				t.Fatal("expected a sound command")
+			}
+			if name != tt.wantName {
+				t.Errorf("expected %s, got %s", tt.wantName, name)
+			}
+			if joined := strings.Join(args, " "); !strings.Contains(joined, tt.wantArgs) {
+				t.Errorf("expected args to contain %q, got %q", tt.wantArgs, joined)
+			}
+		})
+	}
+}
+
+func TestSoundCommandUnsupported(t *testing.T) {
+	stubLookPath(t)
+	if _, _, ok := soundCommand("linux", "", false, 1); ok {
+		t.Error("expected no sound command when no player is installed")
+	}
+}
+
+func TestCheckSoundFile(t *testing.T) {
+	dir := t.TempDir()
+	mp3 := filepath.Join(dir, "done.mp3")
+	if err := os.WriteFile(mp3, []byte("ID3"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := CheckSoundFile("darwin", mp3); err != nil {
+		t.Errorf("expected mp3 to play on macOS, got %v", err)
+	}
+	if err := CheckSoundFile("windows", mp3); !errors.Is(err, ErrUnsupportedSound) {
+		t.Errorf("expected ErrUnsupportedSound for mp3 on Windows, got %v", err)
+	}
+	if err := CheckSoundFile("darwin", filepath.Join(dir, "missing.wav")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
+
+func TestNewSoundsFallsBack(t *testing.T) {
+	loud := 3.0
+	sounds, problems := NewSounds(filepath.Join(t.TempDir(), "missing.wav"), "", &loud)
+	if len(problems) != 2 {
+		t.Fatalf("expected problems with the file and the volume, got %v", problems)
+	}
+	if sounds.CompletionFile != "" || sounds.Volume != 1 {
+		t.Errorf("expected the built-in sound at full volume, got %+v", sounds)
+	}
+
+	sounds, problems = NewSounds("", "", nil)
+	if len(problems) != 0 || sounds.Volume != 1 {
+		t.Errorf("expected an unset volume to mean full volume, got %+v, %v", sounds, problems)
+	}
+}
+
+func TestNewSoundsZeroVolumeIsSilent(t *testing.T) {
+	silent := 0.0
+	sounds, problems := NewSounds("", "", &silent)
+	if len(problems) != 0 || sounds.Volume != 0 {
+		t.Fatalf("expected volume 0 to be kept, got %+v, %v", sounds, problems)
+	}
+	restore := lookPath
+	lookPath = func(string) (string, error) { t.Fatal("expected no player to be looked up"); return "", nil }
+	defer func() { lookPath = restore }()
+	if err := sounds.PlayCompletion(); err != nil {
+		t.Errorf("PlayCompletion() error = %v", err)
+	}
+}
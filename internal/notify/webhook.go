@@ -0,0 +1,75 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const webhookTimeout = 10 * time.Second
+
+// Webhook states reported in NotificationPayload.State.
+const (
+	StateComplete = "complete"
+	StateError    = "error"
+)
+
+// NotificationPayload is the JSON body posted to a notification webhook. Text
+// holds a one-line summary so Slack incoming webhooks can display it as-is.
+type NotificationPayload struct {
+	Text      string `json:"text"`
+	PRD       string `json:"prd"`
+	State     string `json:"state"` // StateComplete or StateError
+	Completed int    `json:"completed"`
+	Total     int    `json:"total"`
+	Branch    string `json:"branch,omitempty"`
+	PRURL     string `json:"prUrl,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Summary builds the one-line Text for a payload from its other fields.
+func (p NotificationPayload) Summary() string {
+	var b bytes.Buffer
+	if p.State == StateError {
+		fmt.Fprintf(&b, "Chief: %s failed (%d/%d stories complete)", p.PRD, p.Completed, p.Total)
+	} else {
+		fmt.Fprintf(&b, "Chief: %s complete (%d/%d stories)", p.PRD, p.Completed, p.Total)
+	}
+	if p.Branch != "" {
+		fmt.Fprintf(&b, " on %s", p.Branch)
+	}
+	if p.PRURL != "" {
+		fmt.Fprintf(&b, " - %s", p.PRURL)
+	}
+	if p.Error != "" {
+		fmt.Fprintf(&b, ": %s", p.Error)
+	}
+	return b.String()
+}
+
+// PostWebhook POSTs the payload as JSON to url. If payload.Text is empty it is
+// filled in from Summary. It blocks for at most webhookTimeout, so callers that
+// mustn't block should run it in the background.
+func PostWebhook(url string, payload NotificationPayload) error {
+	if payload.Text == "" {
+		payload.Text = payload.Summary()
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	client := &http.Client{Timeout: webhookTimeout}
+	resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package notify
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPostWebhook(t *testing.T) {
+	var got NotificationPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("expected JSON content type, got %q", r.Header.Get("Content-Type"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("failed to decode payload: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	err := PostWebhook(server.URL, NotificationPayload{
+		PRD:       "auth",
+		State:     StateComplete,
+		Completed: 5,
+		Total:     5,
+		Branch:    "chief/auth",
+		PRURL:     "https://github.com/acme/app/pull/7",
+	})
+	if err != nil {
+		t.Fatalf("PostWebhook failed: %v", err)
+	}
+
+	if got.PRD != "auth" || got.State != StateComplete || got.Completed != 5 || got.PRURL == "" {
+		t.Errorf("unexpected payload: %+v", got)
+	}
+	if got.Text != "Chief: auth complete (5/5 stories) on chief/auth - https://github.com/acme/app/pull/7" {
+		t.Errorf("unexpected summary text: %q", got.Text)
+	}
+}
+
+func TestPostWebhookErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	err := PostWebhook(server.URL, NotificationPayload{PRD: "auth", State: StateError})
+	if err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("expected status error, got %v", err)
+	}
+}
+
+func TestNotificationPayloadSummaryError(t *testing.T) {
+	p := NotificationPayload{PRD: "auth", State: StateError, Completed: 2, Total: 5, Error: "max retries exceeded"}
+	if got := p.Summary(); got != "Chief: auth failed (2/5 stories complete): max retries exceeded" {
+		t.Errorf("unexpected summary: %q", got)
+	}
+}
@@ -3,6 +3,7 @@ package paths
 import (
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 // homeDir returns the user's home directory, panicking if it can't be resolved.
@@ -22,14 +23,14 @@ func SetHomeDir(dir string) func() {
 	return func() { homeDir = old }
 }
 
-// projectID returns the directory name used to identify a project.
-func projectID(projectDir string) string {
+// ProjectID returns the directory name used to identify a project.
+func ProjectID(projectDir string) string {
 	return filepath.Base(projectDir)
 }
 
 // ChiefDir returns ~/.chief/projects/<project-dir-name>/
 func ChiefDir(projectDir string) string {
-	return filepath.Join(homeDir(), ".chief", "projects", projectID(projectDir))
+	return filepath.Join(homeDir(), ".chief", "projects", ProjectID(projectDir))
 }
 
 // PRDsDir returns ~/.chief/projects/<project-dir-name>/prds/
@@ -47,6 +48,11 @@ func PRDPath(projectDir string, name string) string {
 	return filepath.Join(PRDDir(projectDir, name), "prd.json")
 }
 
+// PRDConfigPath returns ~/.chief/projects/<project-dir-name>/prds/<name>/config.yaml
+func PRDConfigPath(projectDir string, name string) string {
+	return filepath.Join(PRDDir(projectDir, name), "config.yaml")
+}
+
 // ConfigPath returns ~/.chief/projects/<project-dir-name>/config.yaml
 func ConfigPath(projectDir string) string {
 	return filepath.Join(ChiefDir(projectDir), "config.yaml")
@@ -62,7 +68,48 @@ func WorktreesDir(projectDir string) string {
 	return filepath.Join(ChiefDir(projectDir), "worktrees")
 }
 
+// ExpandHome replaces a leading "~" in path with the home directory.
+func ExpandHome(path string) string {
+	if path == "~" {
+		return homeDir()
+	}
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		return filepath.Join(homeDir(), rest)
+	}
+	return path
+}
+
 // ContextDir returns ~/.chief/projects/<project-dir-name>/context/
 func ContextDir(projectDir string) string {
 	return filepath.Join(ChiefDir(projectDir), "context")
 }
+
+// CrashLogPath returns ~/.chief/projects/<project-dir-name>/crash.log
+func CrashLogPath(projectDir string) string {
+	return filepath.Join(ChiefDir(projectDir), "crash.log")
+}
+
+// PRTemplatePath returns ~/.chief/projects/<project-dir-name>/pr-template.md
+func PRTemplatePath(projectDir string) string {
+	return filepath.Join(ChiefDir(projectDir), "pr-template.md")
+}
+
+// SessionPath returns ~/.chief/projects/<project-dir-name>/session.json
+func SessionPath(projectDir string) string {
+	return filepath.Join(ChiefDir(projectDir), "session.json")
+}
+
+// LockPath returns ~/.chief/projects/<project-dir-name>/.lock
+func LockPath(projectDir string) string {
+	return filepath.Join(ChiefDir(projectDir), ".lock")
+}
+
+// ArchiveDir returns ~/.chief/projects/<project-dir-name>/archive/
+func ArchiveDir(projectDir string) string {
+	return filepath.Join(ChiefDir(projectDir), "archive")
+}
+
+// ArchivedPRDDir returns ~/.chief/projects/<project-dir-name>/archive/<name>/
+func ArchivedPRDDir(projectDir string, name string) string {
+	return filepath.Join(ArchiveDir(projectDir), name)
+}
@@ -0,0 +1,32 @@
+package prd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/minicodemonkey/chief/internal/paths"
+)
+
+// Archive moves a PRD's directory from the project's PRDs into its archive,
+// so it no longer shows in `chief list` or the picker, and returns where it
+// went. Callers check that no loop is running on it first, with
+// loop.CheckNotRunning.
+func Archive(projectDir, name string) (string, error) {
+	src := paths.PRDDir(projectDir, name)
+	dst := paths.ArchivedPRDDir(projectDir, name)
+
+	if _, err := os.Stat(src); os.IsNotExist(err) {
+		return "", fmt.Errorf("PRD %q not found", name)
+	}
+	if _, err := os.Stat(dst); err == nil {
+		return "", fmt.Errorf("an archived PRD named %q already exists at %s", name, dst)
+	}
+
+	if err := os.MkdirAll(paths.ArchiveDir(projectDir), 0755); err != nil {
+		return "", fmt.Errorf("failed to create archive directory: %w", err)
+	}
+	if err := os.Rename(src, dst); err != nil {
+		return "", fmt.Errorf("failed to archive PRD: %w", err)
+	}
+	return dst, nil
+}
@@ -0,0 +1,50 @@
+package prd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/minicodemonkey/chief/internal/paths"
+)
+
+func TestArchive(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+	projectDir := t.TempDir()
+
+	write := func(name, content string) {
+		t.Helper()
+		dir := paths.PRDDir(projectDir, name)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "prd.json"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("done", `{"project":"Done","userStories":[{"id":"US-001","passes":true}]}`)
+
+	dst, err := Archive(projectDir, "done")
+	if err != nil {
+		t.Fatalf("Archive() error = %v", err)
+	}
+	if dst != paths.ArchivedPRDDir(projectDir, "done") {
+		t.Errorf("Archive() = %q, want %q", dst, paths.ArchivedPRDDir(projectDir, "done"))
+	}
+	if _, err := os.Stat(filepath.Join(dst, "prd.json")); err != nil {
+		t.Errorf("expected prd.json in the archive: %v", err)
+	}
+	if _, err := os.Stat(paths.PRDDir(projectDir, "done")); !os.IsNotExist(err) {
+		t.Error("expected the PRD to be gone from the active PRDs")
+	}
+
+	// Archiving again under the same name would overwrite the archived copy
+	write("done", `{"project":"Done again","userStories":[]}`)
+	if _, err := Archive(projectDir, "done"); err == nil {
+		t.Error("expected an error when the archive already has the name")
+	}
+	if _, err := Archive(projectDir, "missing"); err == nil {
+		t.Error("expected an error for a missing PRD")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/rand"
 	"os"
@@ -58,8 +59,104 @@ type ConvertOptions struct {
 	PRDDir string // Directory containing prd.md
 	Merge  bool   // Auto-merge progress on conversion conflicts
 	Force  bool   // Auto-overwrite on conversion conflicts
+
+	// Retries is how many more times to run the conversion when Claude fails
+	// transiently (its error output names a rate limit, an overloaded or
+	// unavailable server or a dropped connection), capped at
+	// MaxConvertRetries. Output that isn't valid JSON is not retried here; it
+	// goes through the JSON fix instead.
+	Retries int
+	// RetryDelay is the wait before the first retry, doubled for each one
+	// after it. Zero means DefaultConvertRetryDelay.
+	RetryDelay time.Duration
+
+	// Offline converts with ConvertMarkdown instead of Claude. Conversion
+	// also falls back to it when the agent's executable can't be found.
+	Offline bool
+
+	// Agent does the conversion (nil = claude from PATH).
+	Agent ConversionAgent
+}
+
+// ConversionAgent is the CLI agent a conversion asks to turn prd.md into
+// JSON. loop.Agent satisfies it.
+type ConversionAgent interface {
+	Name() string
+	// CheckBinary returns an error when the agent's executable can't be found.
+	CheckBinary() error
+	// OneShotCommand builds a command that reads a prompt on stdin and prints
+	// only its answer, without using tools.
+	OneShotCommand() *exec.Cmd
+}
+
+// claudeOnPath is the ConversionAgent used when none is configured.
+type claudeOnPath struct{}
+
+func (claudeOnPath) Name() string { return "claude" }
+
+func (claudeOnPath) CheckBinary() error {
+	_, err := exec.LookPath("claude")
+	return err
 }
 
+func (claudeOnPath) OneShotCommand() *exec.Cmd {
+	return exec.Command("claude", "-p", "--tools", "")
+}
+
+// agent returns the agent the conversion runs.
+func (o ConvertOptions) agent() ConversionAgent {
+	if o.Agent != nil {
+		return o.Agent
+	}
+	return claudeOnPath{}
+}
+
+// Limits for retrying a conversion after a transient failure.
+const (
+	MaxConvertRetries        = 5
+	DefaultConvertRetryDelay = 2 * time.Second
+	maxConvertRetryDelay     = time.Minute
+)
+
+// transientError marks a Claude failure that may succeed if tried again, as
+// opposed to a setup problem such as a missing prd.md or claude binary.
+type transientError struct {
+	err error
+}
+
+func (e *transientError) Error() string { return e.err.Error() }
+func (e *transientError) Unwrap() error { return e.err }
+
+// isTransient reports whether err is worth retrying.
+func isTransient(err error) bool {
+	var te *transientError
+	return errors.As(err, &te)
+}
+
+// transientMarkers are phrases in an agent's error output that mark a
+// failure worth retrying: rate limits, overloaded or unavailable servers and
+// dropped connections. Anything else, such as a bad API key, fails at once.
+var transientMarkers = []string{
+	"overloaded", "rate limit", "rate_limit", "too many requests",
+	"429", "502", "503", "504", "529", "temporarily unavailable", "service unavailable",
+	"timed out", "timeout", "connection reset", "connection refused", "econnreset", "etimedout", "network error",
+}
+
+// looksTransient reports whether an agent's error output names a failure
+// that may go away if tried again.
+func looksTransient(output string) bool {
+	output = strings.ToLower(output)
+	for _, marker := range transientMarkers {
+		if strings.Contains(output, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// sleep waits between conversion retries. Tests replace it.
+var sleep = time.Sleep
+
 // ProgressConflictChoice represents the user's choice when a progress conflict is detected.
 type ProgressConflictChoice int
 
@@ -69,8 +166,11 @@ const (
 	ChoiceCancel                                  // Cancel conversion
 )
 
-// Convert converts prd.md to prd.json using Claude one-shot mode.
-// Claude receives the PRD content inline and returns JSON on stdout.
+// Convert converts prd.md to prd.json, or to the prd.yaml a PRD is kept in,
+// using Claude one-shot mode.
+// Claude receives the PRD content inline and returns JSON on stdout. With
+// opts.Offline, or when claude isn't on PATH, ConvertMarkdown parses prd.md
+// instead.
 // This function is called:
 // - After chief new (new PRD creation)
 // - After chief edit (PRD modification)
@@ -104,12 +204,69 @@ func Convert(opts ConvertOptions) error {
 		hasProgress = HasProgress(existing)
 	}
 
-	// Run Claude to convert prd.md → JSON string
-	rawJSON, err := runClaudeConversion(absPRDDir)
+	newPRD, err := convertPRDMarkdown(absPRDDir, opts)
 	if err != nil {
 		return err
 	}
 
+	// Handle progress protection if existing prd.json has progress
+	if hasProgress && existingPRD != nil {
+		choice, err := resolveProgressConflict(opts, existingPRD, newPRD)
+		if err != nil {
+			return err
+		}
+
+		switch choice {
+		case ChoiceCancel:
+			return fmt.Errorf("conversion cancelled by user")
+		case ChoiceMerge:
+			// Merge progress from existing PRD into new PRD
+			MergeProgress(existingPRD, newPRD)
+		case ChoiceOverwrite:
+			// Use the new PRD as-is (no progress)
+		}
+	}
+
+	// Save through Go's encoders to guarantee proper escaping and formatting,
+	// back to prd.yaml for a PRD kept as YAML so a new prd.json doesn't shadow it
+	if err := newPRD.Save(prdJsonPath); err != nil {
+		return err
+	}
+
+	fmt.Println(lipgloss.NewStyle().Foreground(cSuccess).Render("✓ PRD converted successfully"))
+	return nil
+}
+
+// convertPRDMarkdown turns prd.md into a PRD with the agent or, when running
+// offline or the agent can't be found, with ConvertMarkdown.
+func convertPRDMarkdown(absPRDDir string, opts ConvertOptions) (*PRD, error) {
+	if !opts.Offline {
+		if err := opts.agent().CheckBinary(); err == nil {
+			return convertWithClaude(absPRDDir, opts)
+		}
+		fmt.Printf("%s not found; converting prd.md without it\n", opts.agent().Name())
+	}
+
+	content, err := os.ReadFile(filepath.Join(absPRDDir, "prd.md"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read prd.md: %w", err)
+	}
+	newPRD, err := ConvertMarkdown(content)
+	if err != nil {
+		return nil, fmt.Errorf("offline conversion failed: %w", err)
+	}
+	return newPRD, nil
+}
+
+// convertWithClaude has Claude convert prd.md, retrying transient failures
+// and asking it to fix invalid JSON once.
+func convertWithClaude(absPRDDir string, opts ConvertOptions) (*PRD, error) {
+	// Run Claude to convert prd.md → JSON string
+	rawJSON, err := runClaudeConversionWithRetry(absPRDDir, opts)
+	if err != nil {
+		return nil, err
+	}
+
 	// Clean up output (strip markdown fences if any)
 	cleanedJSON := cleanJSONOutput(rawJSON)
 
@@ -119,69 +276,52 @@ func Convert(opts ConvertOptions) error {
 		// Retry once: ask Claude to fix the invalid JSON
 		fmt.Println("Conversion produced invalid JSON, retrying...")
 		fmt.Printf("Raw output:\n---\n%s\n---\n", cleanedJSON)
-		fixedJSON, retryErr := runClaudeJSONFix(cleanedJSON, err)
+		fixedJSON, retryErr := runClaudeJSONFix(opts, cleanedJSON, err)
 		if retryErr != nil {
-			return fmt.Errorf("conversion retry failed: %w", retryErr)
+			return nil, fmt.Errorf("conversion retry failed: %w", retryErr)
 		}
 
 		cleanedJSON = cleanJSONOutput(fixedJSON)
 		newPRD, err = parseAndValidatePRD(cleanedJSON)
 		if err != nil {
-			return fmt.Errorf("conversion produced invalid JSON after retry:\n---\n%s\n---\n%w", cleanedJSON, err)
+			return nil, fmt.Errorf("conversion produced invalid JSON after retry:\n---\n%s\n---\n%w", cleanedJSON, err)
 		}
 	}
+	return newPRD, nil
+}
 
-	// Re-save through Go's JSON encoder to guarantee proper escaping and formatting
-	normalizedContent, err := json.MarshalIndent(newPRD, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal PRD: %w", err)
+// runClaudeConversionWithRetry runs the conversion, retrying up to
+// opts.Retries times (capped at MaxConvertRetries) with exponential backoff
+// when Claude fails transiently. Other errors are returned straight away.
+func runClaudeConversionWithRetry(absPRDDir string, opts ConvertOptions) (string, error) {
+	retries, delay := opts.Retries, opts.RetryDelay
+	if retries > MaxConvertRetries {
+		retries = MaxConvertRetries
+	}
+	if delay <= 0 {
+		delay = DefaultConvertRetryDelay
 	}
 
-	// Handle progress protection if existing prd.json has progress
-	if hasProgress && existingPRD != nil {
-		choice := ChoiceOverwrite // Default to overwrite if no progress
-
-		if opts.Merge {
-			choice = ChoiceMerge
-		} else if opts.Force {
-			choice = ChoiceOverwrite
-		} else {
-			// Prompt user for choice
-			var promptErr error
-			choice, promptErr = promptProgressConflict(existingPRD, newPRD)
-			if promptErr != nil {
-				return fmt.Errorf("failed to prompt for choice: %w", promptErr)
+	for attempt := 0; ; attempt++ {
+		rawJSON, err := runClaudeConversion(absPRDDir, opts)
+		if err == nil || !isTransient(err) || attempt >= retries {
+			if err != nil && attempt > 0 {
+				return "", fmt.Errorf("conversion failed after %d attempts: %w", attempt+1, err)
 			}
+			return rawJSON, err
 		}
 
-		switch choice {
-		case ChoiceCancel:
-			return fmt.Errorf("conversion cancelled by user")
-		case ChoiceMerge:
-			// Merge progress from existing PRD into new PRD
-			MergeProgress(existingPRD, newPRD)
-			// Re-marshal with merged progress
-			mergedContent, err := json.MarshalIndent(newPRD, "", "  ")
-			if err != nil {
-				return fmt.Errorf("failed to marshal merged PRD: %w", err)
-			}
-			normalizedContent = mergedContent
-		case ChoiceOverwrite:
-			// Use the new PRD as-is (no progress)
+		fmt.Printf("Conversion failed (%v), retrying in %s (%d/%d)...\n", err, delay, attempt+1, retries)
+		sleep(delay)
+		delay *= 2
+		if delay > maxConvertRetryDelay {
+			delay = maxConvertRetryDelay
 		}
 	}
-
-	// Write the final normalized prd.json
-	if err := os.WriteFile(prdJsonPath, append(normalizedContent, '\n'), 0644); err != nil {
-		return fmt.Errorf("failed to write prd.json: %w", err)
-	}
-
-	fmt.Println(lipgloss.NewStyle().Foreground(cSuccess).Render("✓ PRD converted successfully"))
-	return nil
 }
 
 // runClaudeConversion reads prd.md, sends content inline to Claude, and returns the JSON output.
-func runClaudeConversion(absPRDDir string) (string, error) {
+func runClaudeConversion(absPRDDir string, opts ConvertOptions) (string, error) {
 	content, err := os.ReadFile(filepath.Join(absPRDDir, "prd.md"))
 	if err != nil {
 		return "", fmt.Errorf("failed to read prd.md: %w", err)
@@ -189,7 +329,8 @@ func runClaudeConversion(absPRDDir string) (string, error) {
 
 	prompt := embed.GetConvertPrompt(string(content))
 
-	cmd := exec.Command("claude", "-p", "--tools", "")
+	agent := opts.agent()
+	cmd := agent.OneShotCommand()
 	cmd.Dir = absPRDDir
 	cmd.Stdin = strings.NewReader(prompt)
 
@@ -198,18 +339,24 @@ func runClaudeConversion(absPRDDir string) (string, error) {
 	cmd.Stderr = &stderr
 
 	if err := cmd.Start(); err != nil {
-		return "", fmt.Errorf("failed to start Claude: %w", err)
+		return "", fmt.Errorf("failed to start %s: %w", agent.Name(), err)
 	}
 
 	if err := waitWithPanel(cmd, "Converting PRD", "Analyzing PRD...", &stderr); err != nil {
+		if looksTransient(stderr.String()) {
+			return "", &transientError{err}
+		}
 		return "", err
 	}
+	if strings.TrimSpace(stdout.String()) == "" {
+		return "", fmt.Errorf("%s returned no output", agent.Name())
+	}
 
 	return stdout.String(), nil
 }
 
 // runClaudeJSONFix asks Claude to fix invalid JSON inline and returns the corrected output.
-func runClaudeJSONFix(badJSON string, validationErr error) (string, error) {
+func runClaudeJSONFix(opts ConvertOptions, badJSON string, validationErr error) (string, error) {
 	fixPrompt := fmt.Sprintf(
 		"The following JSON is invalid. The error is: %s\n\n"+
 			"Fix the JSON (pay special attention to escaping double quotes inside string values with backslashes) "+
@@ -217,14 +364,16 @@ func runClaudeJSONFix(badJSON string, validationErr error) (string, error) {
 		validationErr.Error(), badJSON,
 	)
 
-	cmd := exec.Command("claude", "-p", fixPrompt)
+	agent := opts.agent()
+	cmd := agent.OneShotCommand()
+	cmd.Stdin = strings.NewReader(fixPrompt)
 
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
 	if err := cmd.Start(); err != nil {
-		return "", fmt.Errorf("failed to start Claude: %w", err)
+		return "", fmt.Errorf("failed to start %s: %w", agent.Name(), err)
 	}
 
 	if err := waitWithSpinner(cmd, "Fixing JSON", "Fixing prd.json...", &stderr); err != nil {
@@ -579,14 +728,15 @@ func NeedsConversion(prdDir string) (bool, error) {
 		return false, fmt.Errorf("failed to stat prd.md: %w", err)
 	}
 
-	// Check if prd.json exists
-	jsonInfo, err := os.Stat(prdJsonPath)
+	// Compare against whichever PRD file LoadPRD would read (prd.json, or prd.yaml/prd.yml)
+	prdDataPath := ResolvePath(prdJsonPath)
+	jsonInfo, err := os.Stat(prdDataPath)
 	if os.IsNotExist(err) {
-		// prd.md exists but prd.json doesn't - needs conversion
+		// prd.md exists but no prd.json/prd.yaml - needs conversion
 		return true, nil
 	}
 	if err != nil {
-		return false, fmt.Errorf("failed to stat prd.json: %w", err)
+		return false, fmt.Errorf("failed to stat %s: %w", filepath.Base(prdDataPath), err)
 	}
 
 	// Both exist - compare modification times
@@ -684,7 +834,8 @@ func HasProgress(prd *PRD) bool {
 }
 
 // MergeProgress merges progress from the old PRD into the new PRD.
-// For stories with matching IDs, it preserves the Passes and InProgress status.
+// For stories with matching IDs, it preserves the Passes and InProgress status,
+// and the TicketURL and EstimateMinutes when the new story doesn't have its own.
 // New stories (in newPRD but not in oldPRD) are added without progress.
 // Removed stories (in oldPRD but not in newPRD) are dropped.
 func MergeProgress(oldPRD, newPRD *PRD) {
@@ -696,14 +847,23 @@ func MergeProgress(oldPRD, newPRD *PRD) {
 	oldStatus := make(map[string]struct {
 		passes     bool
 		inProgress bool
+		skipped    bool
+		ticketURL  string
+		estimate   int
 	})
 	for _, story := range oldPRD.UserStories {
 		oldStatus[story.ID] = struct {
 			passes     bool
 			inProgress bool
+			skipped    bool
+			ticketURL  string
+			estimate   int
 		}{
 			passes:     story.Passes,
 			inProgress: story.InProgress,
+			skipped:    story.Skipped,
+			ticketURL:  story.TicketURL,
+			estimate:   story.EstimateMinutes,
 		}
 	}
 
@@ -712,10 +872,68 @@ func MergeProgress(oldPRD, newPRD *PRD) {
 		if status, exists := oldStatus[newPRD.UserStories[i].ID]; exists {
 			newPRD.UserStories[i].Passes = status.passes
 			newPRD.UserStories[i].InProgress = status.inProgress
+			newPRD.UserStories[i].Skipped = status.skipped
+			// A link added to prd.json by hand has nowhere to live in prd.md
+			if newPRD.UserStories[i].TicketURL == "" {
+				newPRD.UserStories[i].TicketURL = status.ticketURL
+			}
+			if newPRD.UserStories[i].EstimateMinutes == 0 {
+				newPRD.UserStories[i].EstimateMinutes = status.estimate
+			}
 		}
 	}
 }
 
+// ConflictEnvVar names the environment variable that decides, without
+// asking, how conversion treats existing progress: merge, overwrite or cancel.
+// --merge and --force take precedence over it.
+const ConflictEnvVar = "CHIEF_CONVERSION_CONFLICT"
+
+// stdinIsTerminal reports whether someone can answer a prompt on stdin.
+// Tests replace it.
+var stdinIsTerminal = func() bool {
+	return term.IsTerminal(os.Stdin.Fd())
+}
+
+// resolveProgressConflict decides how to treat the progress in an existing
+// prd.json: --merge or --force if given, then CHIEF_CONVERSION_CONFLICT, then
+// the user's answer. Without a terminal to ask on, it merges, which keeps
+// every status that still applies, rather than waiting on stdin forever.
+func resolveProgressConflict(opts ConvertOptions, oldPRD, newPRD *PRD) (ProgressConflictChoice, error) {
+	if opts.Merge {
+		return ChoiceMerge, nil
+	}
+	if opts.Force {
+		return ChoiceOverwrite, nil
+	}
+	if value := os.Getenv(ConflictEnvVar); value != "" {
+		return parseConflictChoice(value)
+	}
+	if !stdinIsTerminal() {
+		fmt.Printf("prd.json has progress and stdin is not a terminal; merging it (set %s or pass --merge/--force to choose)\n", ConflictEnvVar)
+		return ChoiceMerge, nil
+	}
+	choice, err := promptProgressConflict(oldPRD, newPRD)
+	if err != nil {
+		return ChoiceCancel, fmt.Errorf("failed to prompt for choice: %w", err)
+	}
+	return choice, nil
+}
+
+// parseConflictChoice parses a CHIEF_CONVERSION_CONFLICT value.
+func parseConflictChoice(value string) (ProgressConflictChoice, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "merge":
+		return ChoiceMerge, nil
+	case "overwrite":
+		return ChoiceOverwrite, nil
+	case "cancel":
+		return ChoiceCancel, nil
+	default:
+		return ChoiceCancel, fmt.Errorf("invalid %s %q: must be merge, overwrite or cancel", ConflictEnvVar, value)
+	}
+}
+
 // promptProgressConflict prompts the user to choose how to handle a progress conflict.
 func promptProgressConflict(oldPRD, newPRD *PRD) (ProgressConflictChoice, error) {
 	// Count stories with progress
@@ -1,6 +1,7 @@
 package prd
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -206,6 +207,30 @@ func TestNeedsConversion(t *testing.T) {
 			t.Error("NeedsConversion() = true, want false when prd.json is newer")
 		}
 	})
+
+	t.Run("prd.yaml is compared when there is no prd.json", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		prdMdPath := filepath.Join(tmpDir, "prd.md")
+		prdYamlPath := filepath.Join(tmpDir, "prd.yaml")
+		if err := os.WriteFile(prdMdPath, []byte("# Test PRD"), 0644); err != nil {
+			t.Fatalf("Failed to create prd.md: %v", err)
+		}
+		if err := os.WriteFile(prdYamlPath, []byte("project: test\n"), 0644); err != nil {
+			t.Fatalf("Failed to create prd.yaml: %v", err)
+		}
+
+		old := time.Now().Add(-time.Hour)
+		os.Chtimes(prdMdPath, old, old)
+		if needs, err := NeedsConversion(tmpDir); err != nil || needs {
+			t.Errorf("NeedsConversion() = %v, %v; want false when prd.yaml is newer", needs, err)
+		}
+
+		os.Chtimes(prdMdPath, time.Now(), time.Now())
+		os.Chtimes(prdYamlPath, old, old)
+		if needs, err := NeedsConversion(tmpDir); err != nil || !needs {
+			t.Errorf("NeedsConversion() = %v, %v; want true when prd.md is newer than prd.yaml", needs, err)
+		}
+	})
 }
 
 func TestConvertMissingPrdMd(t *testing.T) {
@@ -217,6 +242,92 @@ func TestConvertMissingPrdMd(t *testing.T) {
 	}
 }
 
+func TestConvertRetriesTransientFailures(t *testing.T) {
+	validJSON := `{"project": "Test", "userStories": [{"id": "US-001", "title": "First", "priority": 1}]}`
+
+	tests := []struct {
+		name      string
+		failures  int    // Times the fake claude fails before succeeding
+		stderr    string // What it prints when it fails
+		retries   int
+		wantErr   bool
+		wantCalls int
+		wantWaits []time.Duration
+	}{
+		{"succeeds after retries", 2, "overloaded", 3, false, 3, []time.Duration{time.Second, 2 * time.Second}},
+		{"gives up", 5, "API Error: 529", 2, true, 3, []time.Duration{time.Second, 2 * time.Second}},
+		{"no retries by default", 1, "overloaded", 0, true, 1, nil},
+		{"capped", 100, "rate limit exceeded", 50, true, MaxConvertRetries + 1, nil},
+		{"not transient", 1, "Invalid API key", 3, true, 1, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prdDir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(prdDir, "prd.md"), []byte("# Test\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			// The fake claude counts its calls and fails until the count passes failures
+			binDir := t.TempDir()
+			counter := filepath.Join(binDir, "calls")
+			script := fmt.Sprintf("#!/bin/sh\necho x >> %s\n"+
+				"if [ $(wc -l < %s) -le %d ]; then echo '%s' >&2; exit 1; fi\n"+
+				"echo '%s'\n", counter, counter, tt.failures, tt.stderr, validJSON)
+			if err := os.WriteFile(filepath.Join(binDir, "claude"), []byte(script), 0755); err != nil {
+				t.Fatal(err)
+			}
+			t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+			var waits []time.Duration
+			sleep = func(d time.Duration) { waits = append(waits, d) }
+			defer func() { sleep = time.Sleep }()
+
+			err := Convert(ConvertOptions{PRDDir: prdDir, Retries: tt.retries, RetryDelay: time.Second})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Convert() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			data, _ := os.ReadFile(counter)
+			if calls := strings.Count(string(data), "x"); calls != tt.wantCalls {
+				t.Errorf("claude ran %d times, want %d", calls, tt.wantCalls)
+			}
+			if tt.wantWaits != nil && fmt.Sprint(waits) != fmt.Sprint(tt.wantWaits) {
+				t.Errorf("waits = %v, want %v", waits, tt.wantWaits)
+			}
+			if !tt.wantErr {
+				if _, err := LoadPRD(filepath.Join(prdDir, "prd.json")); err != nil {
+					t.Errorf("prd.json not written: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestConvertDoesNotRetryMissingClaude(t *testing.T) {
+	prdDir := t.TempDir()
+	md := "# Test\n\n### US-001: First\n\n- Works\n"
+	if err := os.WriteFile(filepath.Join(prdDir, "prd.md"), []byte(md), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", t.TempDir())
+
+	retried := false
+	sleep = func(time.Duration) { retried = true }
+	defer func() { sleep = time.Sleep }()
+
+	if err := Convert(ConvertOptions{PRDDir: prdDir, Retries: 3}); err != nil {
+		t.Fatalf("Convert() error = %v, want the offline conversion", err)
+	}
+	if retried {
+		t.Error("A missing claude binary should not be retried")
+	}
+	p, err := LoadPRD(filepath.Join(prdDir, "prd.json"))
+	if err != nil || len(p.UserStories) != 1 || p.UserStories[0].ID != "US-001" {
+		t.Errorf("expected prd.json converted without claude, got %+v (%v)", p, err)
+	}
+}
+
 func TestHasProgress(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -325,6 +436,45 @@ func TestMergeProgress(t *testing.T) {
 		}
 	})
 
+	t.Run("ticket links survive reconversion", func(t *testing.T) {
+		oldPRD := &PRD{
+			UserStories: []UserStory{
+				{ID: "US-001", TicketURL: "https://linear.app/acme/issue/ENG-1"},
+				{ID: "US-002", TicketURL: "https://linear.app/acme/issue/ENG-2"},
+			},
+		}
+		newPRD := &PRD{
+			UserStories: []UserStory{
+				{ID: "US-001"},
+				{ID: "US-002", TicketURL: "https://linear.app/acme/issue/ENG-9"},
+			},
+		}
+
+		MergeProgress(oldPRD, newPRD)
+
+		if got := newPRD.UserStories[0].TicketURL; got != "https://linear.app/acme/issue/ENG-1" {
+			t.Errorf("US-001 should keep its ticket link, got %q", got)
+		}
+		// A link from prd.md wins over the old one
+		if got := newPRD.UserStories[1].TicketURL; got != "https://linear.app/acme/issue/ENG-9" {
+			t.Errorf("US-002 should take the new ticket link, got %q", got)
+		}
+	})
+
+	t.Run("estimates survive reconversion", func(t *testing.T) {
+		oldPRD := &PRD{UserStories: []UserStory{{ID: "US-001", EstimateMinutes: 30}, {ID: "US-002", EstimateMinutes: 30}}}
+		newPRD := &PRD{UserStories: []UserStory{{ID: "US-001"}, {ID: "US-002", EstimateMinutes: 60}}}
+
+		MergeProgress(oldPRD, newPRD)
+
+		if got := newPRD.UserStories[0].EstimateMinutes; got != 30 {
+			t.Errorf("US-001 should keep its estimate, got %d", got)
+		}
+		if got := newPRD.UserStories[1].EstimateMinutes; got != 60 {
+			t.Errorf("US-002 should take the new estimate, got %d", got)
+		}
+	})
+
 	t.Run("new stories added - no progress", func(t *testing.T) {
 		oldPRD := &PRD{
 			UserStories: []UserStory{
@@ -381,10 +531,10 @@ func TestMergeProgress(t *testing.T) {
 	t.Run("mixed scenario - add, remove, keep", func(t *testing.T) {
 		oldPRD := &PRD{
 			UserStories: []UserStory{
-				{ID: "US-001", Passes: true},         // Keep with progress
-				{ID: "US-002", Passes: true},         // Removed
-				{ID: "US-003", InProgress: true},     // Keep with progress
-				{ID: "US-004", Passes: false},        // Keep without progress
+				{ID: "US-001", Passes: true},     // Keep with progress
+				{ID: "US-002", Passes: true},     // Removed
+				{ID: "US-003", InProgress: true}, // Keep with progress
+				{ID: "US-004", Passes: false},    // Keep without progress
 			},
 		}
 		newPRD := &PRD{
@@ -632,3 +782,33 @@ As a user, I want a new feature.
 		t.Error("Sample prd.md should trigger conversion need")
 	}
 }
+
+func TestResolveProgressConflict(t *testing.T) {
+	restore := stdinIsTerminal
+	defer func() { stdinIsTerminal = restore }()
+	stdinIsTerminal = func() bool { return false }
+
+	tests := []struct {
+		name    string
+		opts    ConvertOptions
+		env     string
+		want    ProgressConflictChoice
+		wantErr bool
+	}{
+		{"merge flag", ConvertOptions{Merge: true}, "overwrite", ChoiceMerge, false},
+		{"force flag", ConvertOptions{Force: true}, "cancel", ChoiceOverwrite, false},
+		{"env overwrite", ConvertOptions{}, "overwrite", ChoiceOverwrite, false},
+		{"env cancel", ConvertOptions{}, "Cancel", ChoiceCancel, false},
+		{"env invalid", ConvertOptions{}, "keep", ChoiceCancel, true},
+		{"no terminal", ConvertOptions{}, "", ChoiceMerge, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(ConflictEnvVar, tt.env)
+			got, err := resolveProgressConflict(tt.opts, &PRD{}, &PRD{})
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("resolveProgressConflict() = %v, %v; want %v (error %v)", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
@@ -4,26 +4,88 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Format identifies the on-disk encoding of a PRD file.
+type Format int
+
+const (
+	FormatJSON Format = iota
+	FormatYAML
 )
 
-// LoadPRD reads and parses a PRD JSON file from the given path.
+// FormatForPath returns the PRD format implied by a file's extension.
+func FormatForPath(path string) Format {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return FormatYAML
+	default:
+		return FormatJSON
+	}
+}
+
+// ResolvePath returns the PRD file that should be read for path. Callers
+// usually pass the canonical prd.json path; if that file doesn't exist but a
+// prd.yaml or prd.yml sits next to it, the YAML file is returned instead.
+// prd.json wins when both exist, since conversion from prd.md writes JSON.
+func ResolvePath(path string) string {
+	if filepath.Base(path) != "prd.json" {
+		return path
+	}
+	if _, err := os.Stat(path); err == nil {
+		return path
+	}
+	dir := filepath.Dir(path)
+	for _, name := range []string{"prd.yaml", "prd.yml"} {
+		candidate := filepath.Join(dir, name)
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate
+		}
+	}
+	return path
+}
+
+// LoadPRD reads and parses a PRD file from the given path. JSON and YAML are
+// both supported; see ResolvePath for how a YAML PRD is found.
 func LoadPRD(path string) (*PRD, error) {
+	path = ResolvePath(path)
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read PRD file: %w", err)
 	}
 
 	var p PRD
-	if err := json.Unmarshal(data, &p); err != nil {
+	if FormatForPath(path) == FormatYAML {
+		if err := yaml.Unmarshal(data, &p); err != nil {
+			return nil, fmt.Errorf("failed to parse PRD YAML: %w", err)
+		}
+	} else if err := json.Unmarshal(data, &p); err != nil {
 		return nil, fmt.Errorf("failed to parse PRD JSON: %w", err)
 	}
 
+	if cycle := p.DependencyCycle(); cycle != nil {
+		return nil, fmt.Errorf("story dependency cycle: %s", strings.Join(cycle, " → "))
+	}
+
 	return &p, nil
 }
 
-// Save writes the PRD back to a JSON file at the given path.
+// Save writes the PRD back to the given path, in the same format the PRD was
+// loaded from (YAML if path resolves to a prd.yaml/prd.yml, JSON otherwise).
 func (p *PRD) Save(path string) error {
-	data, err := json.MarshalIndent(p, "", "  ")
+	path = ResolvePath(path)
+
+	var data []byte
+	var err error
+	if FormatForPath(path) == FormatYAML {
+		data, err = yaml.Marshal(p)
+	} else {
+		data, err = json.MarshalIndent(p, "", "  ")
+	}
 	if err != nil {
 		return fmt.Errorf("failed to marshal PRD: %w", err)
 	}
@@ -0,0 +1,212 @@
+package prd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// mdHeading matches a Markdown heading, capturing its level marks and text.
+	mdHeading = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	// mdStoryHeading matches a story heading's text, e.g. "US-001: Title" or
+	// "CCS-12 - Title", capturing the ID and title.
+	mdStoryHeading = regexp.MustCompile(`^\[?([A-Za-z][A-Za-z0-9]*-\d+)\]?\s*(?:[:.\-–—]\s*)?(.+)$`)
+	// mdLabel matches a bold label line, e.g. "**Priority:** 1", capturing the
+	// label and the rest of the line.
+	mdLabel = regexp.MustCompile(`^\*\*([^*:]+):?\*\*:?\s*(.*)$`)
+	// mdListItem matches a bullet or numbered list item with an optional
+	// checkbox, capturing its text.
+	mdListItem = regexp.MustCompile(`^(?:[-*+]|\d+[.)])\s+(?:\[[ xX]\]\s+)?(.*)$`)
+)
+
+// ConvertMarkdown converts a conventionally structured prd.md to a PRD
+// without Claude. It understands the layout chief's PRD prompt asks for:
+//
+//   - The project name comes from the first "# " heading, without a "PRD:"
+//     prefix, and the description from the first paragraph after it.
+//   - Each heading of the form "US-001: Title" starts a story. Its plain
+//     paragraphs (or a "**Description:**" line) form the description, and
+//     list items under "**Steps:**" or "**Acceptance Criteria:**" its steps;
+//     without such a label every list item in the story is a step.
+//   - "**Priority:** N", "**Ticket:** <url>", "**Estimate:** 30m" and
+//     "**Tags:** backend, ui" lines set those fields.
+//     Stories without a priority are numbered in the order they appear,
+//     after the highest priority given.
+//
+// A story ends at the next heading of the same or a higher level. Anything
+// else is ignored.
+func ConvertMarkdown(md []byte) (*PRD, error) {
+	p := &PRD{}
+	var (
+		story       *UserStory
+		storyLevel  int
+		hasPriority bool
+		unnumbered  []int // Indexes of the stories without a priority
+		inSteps     bool  // After a steps label
+		sawSteps    bool  // The story has a steps label
+		otherSteps  []string
+		description []string // Paragraph lines of the project or current story
+		inIntro     bool     // Between the title and the first story or section
+	)
+
+	finishStory := func() {
+		if story == nil {
+			return
+		}
+		if !sawSteps {
+			story.Steps = otherSteps
+		}
+		if story.Steps == nil {
+			story.Steps = []string{}
+		}
+		if story.Description == "" {
+			story.Description = strings.Join(description, " ")
+		}
+		if !hasPriority {
+			unnumbered = append(unnumbered, len(p.UserStories))
+		}
+		p.UserStories = append(p.UserStories, *story)
+		story = nil
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(md))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	inFence := false
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "```") || strings.HasPrefix(line, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+
+		if m := mdHeading.FindStringSubmatch(line); m != nil {
+			level, text := len(m[1]), m[2]
+			if story == nil && inIntro && p.Description == "" && len(description) > 0 {
+				p.Description = strings.Join(description, " ")
+			}
+			if story != nil && level <= storyLevel {
+				finishStory()
+			}
+			if level == 1 && p.Project == "" {
+				p.Project = strings.TrimSpace(strings.TrimPrefix(text, "PRD:"))
+				inIntro = true
+				continue
+			}
+			if s := mdStoryHeading.FindStringSubmatch(text); s != nil && story == nil {
+				story = &UserStory{ID: s[1], Title: strings.TrimSpace(s[2])}
+				storyLevel = level
+				hasPriority, inSteps, sawSteps = false, false, false
+				otherSteps, description = nil, nil
+				inIntro = false
+				continue
+			}
+			// Until it has a paragraph, sections such as "Introduction" still
+			// describe the project
+			inIntro = inIntro && p.Description == ""
+			continue
+		}
+
+		if story == nil {
+			// The project description is the first paragraph of the intro
+			if !inIntro || p.Description != "" {
+				continue
+			}
+			switch {
+			case line == "" && len(description) > 0:
+				p.Description = strings.Join(description, " ")
+			case line != "" && !mdListItem.MatchString(line) && !mdLabel.MatchString(line):
+				description = append(description, line)
+			}
+			continue
+		}
+
+		if m := mdLabel.FindStringSubmatch(line); m != nil {
+			label, value := strings.ToLower(strings.TrimSpace(m[1])), strings.TrimSpace(m[2])
+			inSteps = false
+			switch label {
+			case "priority":
+				if n, err := strconv.Atoi(value); err == nil {
+					story.Priority = n
+					hasPriority = true
+				}
+			case "description":
+				story.Description = value
+			case "ticket":
+				story.TicketURL = strings.Trim(value, "<>")
+			case "estimate":
+				if minutes, ok := parseEstimateMinutes(value); ok {
+					story.EstimateMinutes = minutes
+				}
+			case "tags", "tag":
+				for _, tag := range strings.Split(value, ",") {
+					if tag = strings.Trim(strings.TrimSpace(tag), "`"); tag != "" {
+						story.Tags = append(story.Tags, tag)
+					}
+				}
+			case "steps", "acceptance criteria":
+				inSteps, sawSteps = true, true
+			}
+			continue
+		}
+
+		if m := mdListItem.FindStringSubmatch(line); m != nil {
+			if inSteps {
+				story.Steps = append(story.Steps, m[1])
+			} else {
+				otherSteps = append(otherSteps, m[1])
+			}
+			continue
+		}
+		if line != "" && !inSteps {
+			description = append(description, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read prd.md: %w", err)
+	}
+	finishStory()
+
+	highest := 0
+	for _, s := range p.UserStories {
+		highest = max(highest, s.Priority)
+	}
+	for _, i := range unnumbered {
+		highest++
+		p.UserStories[i].Priority = highest
+	}
+
+	if p.Project == "" {
+		return nil, fmt.Errorf("prd.md has no \"# Project name\" heading")
+	}
+	if len(p.UserStories) == 0 {
+		return nil, fmt.Errorf("prd.md has no user stories; expected headings like \"### US-001: Title\"")
+	}
+	return p, nil
+}
+
+// parseEstimateMinutes parses a story estimate such as "45", "30m", "2h",
+// "1h30m" or "90 minutes" into whole minutes.
+func parseEstimateMinutes(value string) (int, bool) {
+	value = strings.ToLower(strings.ReplaceAll(value, " ", ""))
+	for _, suffix := range []string{"minutes", "minute", "mins", "min"} {
+		if strings.HasSuffix(value, suffix) {
+			value = strings.TrimSuffix(value, suffix) + "m"
+			break
+		}
+	}
+	if n, err := strconv.Atoi(value); err == nil && n > 0 {
+		return n, true
+	}
+	if d, err := time.ParseDuration(value); err == nil && d >= time.Minute {
+		return int(d.Round(time.Minute) / time.Minute), true
+	}
+	return 0, false
+}
@@ -0,0 +1,169 @@
+package prd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestConvertMarkdown(t *testing.T) {
+	md := `# PRD: Task Priority System
+
+## Introduction
+
+Add priority levels to tasks
+so users can focus.
+
+## Goals
+
+- Allow assigning priority
+
+## User Stories
+
+### US-001: Add priority field
+**Priority:** 2
+**Estimate:** 1h30m
+**Tags:** backend, db
+**Ticket:** <https://example.com/T-1>
+**Description:** As a developer, I need to store task priority.
+
+**Acceptance Criteria:**
+- [ ] Add priority column
+- [x] Typecheck passes
+
+### US-002 - Show badge
+As a user, I want to see priority at a glance.
+
+` + "```" + `
+### US-999: Not a story
+` + "```" + `
+
+- Badge is colored
+- Works in dark mode
+
+## Non-Goals
+
+- Notifications
+`
+	p, err := ConvertMarkdown([]byte(md))
+	if err != nil {
+		t.Fatalf("ConvertMarkdown() error = %v", err)
+	}
+
+	want := &PRD{
+		Project:     "Task Priority System",
+		Description: "Add priority levels to tasks so users can focus.",
+		UserStories: []UserStory{
+			{
+				ID:              "US-001",
+				Title:           "Add priority field",
+				Description:     "As a developer, I need to store task priority.",
+				Steps:           []string{"Add priority column", "Typecheck passes"},
+				Priority:        2,
+				TicketURL:       "https://example.com/T-1",
+				EstimateMinutes: 90,
+				Tags:            []string{"backend", "db"},
+			},
+			{
+				ID:          "US-002",
+				Title:       "Show badge",
+				Description: "As a user, I want to see priority at a glance.",
+				Steps:       []string{"Badge is colored", "Works in dark mode"},
+				Priority:    3, // After the highest given priority
+			},
+		},
+	}
+	if !reflect.DeepEqual(p, want) {
+		t.Errorf("ConvertMarkdown() =\n%+v\nwant\n%+v", p, want)
+	}
+}
+
+func TestConvertMarkdownErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		md   string
+		want string
+	}{
+		{"no title", "### US-001: Story\n", "heading"},
+		{"no stories", "# Project\n\nJust prose.\n", "no user stories"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ConvertMarkdown([]byte(tt.md))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ConvertMarkdown() error = %v, want one mentioning %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertOffline(t *testing.T) {
+	prdDir := t.TempDir()
+	md := "# Test\n\nA test.\n\n### US-001: First\n**Steps:**\n- Do it\n"
+	if err := os.WriteFile(filepath.Join(prdDir, "prd.md"), []byte(md), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// No claude on PATH, so this falls back even without Offline
+	t.Setenv("PATH", t.TempDir())
+
+	if err := Convert(ConvertOptions{PRDDir: prdDir}); err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	p, err := LoadPRD(filepath.Join(prdDir, "prd.json"))
+	if err != nil {
+		t.Fatalf("LoadPRD() error = %v", err)
+	}
+	if p.Project != "Test" || len(p.UserStories) != 1 || p.UserStories[0].Steps[0] != "Do it" {
+		t.Errorf("unexpected converted PRD %+v", p)
+	}
+}
+
+func TestConvertKeepsYAMLPRD(t *testing.T) {
+	prdDir := t.TempDir()
+	md := "# Test\n\nA test.\n\n### US-001: First\n**Steps:**\n- Do it\n"
+	if err := os.WriteFile(filepath.Join(prdDir, "prd.md"), []byte(md), 0644); err != nil {
+		t.Fatal(err)
+	}
+	yamlPath := filepath.Join(prdDir, "prd.yaml")
+	if err := os.WriteFile(yamlPath, []byte("project: Old\nuserStories:\n  - id: US-001\n    title: First\n    passes: true\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Convert(ConvertOptions{PRDDir: prdDir, Offline: true, Merge: true}); err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(prdDir, "prd.json")); !os.IsNotExist(err) {
+		t.Error("expected no prd.json to shadow prd.yaml")
+	}
+	p, err := LoadPRD(yamlPath)
+	if err != nil {
+		t.Fatalf("LoadPRD() error = %v", err)
+	}
+	if p.Project != "Test" || len(p.UserStories) != 1 || !p.UserStories[0].Passes || p.UserStories[0].Steps[0] != "Do it" {
+		t.Errorf("expected prd.yaml updated with progress kept, got %+v", p)
+	}
+}
+
+func TestParseEstimateMinutes(t *testing.T) {
+	tests := []struct {
+		value string
+		want  int
+		ok    bool
+	}{
+		{"45", 45, true},
+		{"30m", 30, true},
+		{"2h", 120, true},
+		{"1h 30m", 90, true},
+		{"90 minutes", 90, true},
+		{"soon", 0, false},
+		{"0", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseEstimateMinutes(tt.value)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseEstimateMinutes(%q) = %d, %v, want %d, %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
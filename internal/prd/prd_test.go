@@ -1,8 +1,10 @@
 package prd
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -79,12 +81,12 @@ func TestPRD_Save(t *testing.T) {
 		Description: "A saved PRD",
 		UserStories: []UserStory{
 			{
-				ID:                 "US-001",
-				Title:              "Test Story",
-				Description:        "Test",
-				Steps: []string{"AC1"},
-				Priority:           1,
-				Passes:             true,
+				ID:          "US-001",
+				Title:       "Test Story",
+				Description: "Test",
+				Steps:       []string{"AC1"},
+				Priority:    1,
+				Passes:      true,
 			},
 		},
 	}
@@ -110,6 +112,74 @@ func TestPRD_Save(t *testing.T) {
 	}
 }
 
+func TestLoadPRD_YAML(t *testing.T) {
+	tmpDir := t.TempDir()
+	yamlPath := filepath.Join(tmpDir, "prd.yaml")
+
+	validYAML := `project: YAML Project
+description: Hand-written spec
+userStories:
+  - id: US-001
+    title: "First: Story"
+    steps:
+      - Run "make test"
+    priority: 1
+    passes: false
+    phase: foundation
+`
+	if err := os.WriteFile(yamlPath, []byte(validYAML), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	// Callers pass the canonical prd.json path; the YAML file is found next to it
+	p, err := LoadPRD(filepath.Join(tmpDir, "prd.json"))
+	if err != nil {
+		t.Fatalf("LoadPRD failed: %v", err)
+	}
+	if p.Project != "YAML Project" || len(p.UserStories) != 1 {
+		t.Fatalf("unexpected PRD: %+v", p)
+	}
+	story := p.UserStories[0]
+	if story.Title != "First: Story" || story.Phase != "foundation" || len(story.Steps) != 1 {
+		t.Errorf("unexpected story: %+v", story)
+	}
+
+	// Saving writes back to the YAML file rather than creating prd.json
+	p.UserStories[0].Passes = true
+	if err := p.Save(filepath.Join(tmpDir, "prd.json")); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "prd.json")); !os.IsNotExist(err) {
+		t.Error("expected Save not to create prd.json for a YAML PRD")
+	}
+	loaded, err := LoadPRD(yamlPath)
+	if err != nil {
+		t.Fatalf("LoadPRD after Save failed: %v", err)
+	}
+	if !loaded.UserStories[0].Passes {
+		t.Error("expected passes: true to be saved to prd.yaml")
+	}
+}
+
+func TestResolvePath_PrefersJSON(t *testing.T) {
+	tmpDir := t.TempDir()
+	jsonPath := filepath.Join(tmpDir, "prd.json")
+
+	if got := ResolvePath(jsonPath); got != jsonPath {
+		t.Errorf("expected missing PRD to resolve to prd.json, got %s", got)
+	}
+
+	os.WriteFile(filepath.Join(tmpDir, "prd.yml"), []byte("project: x\n"), 0644)
+	if got := ResolvePath(jsonPath); got != filepath.Join(tmpDir, "prd.yml") {
+		t.Errorf("expected prd.yml, got %s", got)
+	}
+
+	os.WriteFile(jsonPath, []byte(`{"project":"x"}`), 0644)
+	if got := ResolvePath(jsonPath); got != jsonPath {
+		t.Errorf("expected prd.json to take precedence, got %s", got)
+	}
+}
+
 func TestPRD_AllComplete_EmptyPRD(t *testing.T) {
 	p := &PRD{
 		Project:     "Empty",
@@ -151,6 +221,94 @@ func TestPRD_AllComplete_SomePending(t *testing.T) {
 	}
 }
 
+func TestPRD_AllComplete_Skipped(t *testing.T) {
+	p := &PRD{
+		Project: "Test",
+		UserStories: []UserStory{
+			{ID: "US-001", Passes: true},
+			{ID: "US-002", Skipped: true},
+		},
+	}
+
+	if !p.AllComplete() {
+		t.Error("expected AllComplete() to return true when the only unfinished story was skipped")
+	}
+}
+
+func TestPRD_CompletionPercentage(t *testing.T) {
+	tests := []struct {
+		name    string
+		stories []UserStory
+		want    float64
+	}{
+		{"empty", nil, 100},
+		{"unweighted", []UserStory{{Passes: true}, {}, {}, {}}, 25},
+		{"weighted", []UserStory{{Passes: true, Weight: 3}, {Weight: 1}}, 75},
+		{"mixed defaults to 1", []UserStory{{Passes: true}, {Weight: 3}}, 25},
+		{"negative weight counts as 1", []UserStory{{Passes: true, Weight: -2}, {}}, 50},
+		{"skipped doesn't count", []UserStory{{Passes: true}, {Skipped: true}, {}}, 50},
+		{"all skipped", []UserStory{{Skipped: true}}, 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &PRD{UserStories: tt.stories}
+			if got := p.CompletionPercentage(); got != tt.want {
+				t.Errorf("CompletionPercentage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPRD_StoryCounts(t *testing.T) {
+	p := &PRD{UserStories: []UserStory{
+		{Passes: true},
+		{Passes: true, Skipped: true},
+		{Skipped: true},
+		{InProgress: true},
+		{},
+	}}
+	completed, counted, skipped := p.StoryCounts()
+	if completed != 2 || counted != 4 || skipped != 1 {
+		t.Errorf("StoryCounts() = %d, %d, %d; want 2, 4, 1", completed, counted, skipped)
+	}
+}
+
+func TestPRD_IterationAllowance(t *testing.T) {
+	p := &PRD{UserStories: []UserStory{
+		{ID: "US-001", Priority: 1, Passes: true},
+		{ID: "US-002", Priority: 2},
+		{ID: "US-003", Priority: 3, Weight: 3},
+		{ID: "US-004", Priority: 4},
+	}}
+
+	// Most important gets double, least important its weight, between scales linearly
+	allowances := p.iterationAllowances()
+	want := map[string]int{"US-002": 2, "US-003": 5, "US-004": 1}
+	if len(allowances) != len(want) {
+		t.Fatalf("expected allowances %v, got %v", want, allowances)
+	}
+	for id, n := range want {
+		if allowances[id] != n {
+			t.Errorf("%s: expected allowance %d, got %d", id, n, allowances[id])
+		}
+	}
+	if got := p.IterationAllowance(); got != 8 {
+		t.Errorf("expected allowance 8, got %d", got)
+	}
+
+	// A lone story is the most important one
+	single := &PRD{UserStories: []UserStory{{ID: "US-001", Priority: 5}}}
+	if got := single.IterationAllowance(); got != 2 {
+		t.Errorf("expected allowance 2 for one story, got %d", got)
+	}
+
+	done := &PRD{UserStories: []UserStory{{ID: "US-001", Passes: true}}}
+	if got := done.IterationAllowance(); got != 0 {
+		t.Errorf("expected allowance 0 when complete, got %d", got)
+	}
+}
+
 func TestPRD_NextStory_EmptyPRD(t *testing.T) {
 	p := &PRD{
 		Project:     "Empty",
@@ -235,6 +393,148 @@ func TestPRD_NextStory_SkipsCompleted(t *testing.T) {
 	}
 }
 
+func TestPRD_NextStory_SkipsSkipped(t *testing.T) {
+	p := &PRD{
+		Project: "Test",
+		UserStories: []UserStory{
+			{ID: "US-001", Priority: 1, Skipped: true, InProgress: true},
+			{ID: "US-002", Priority: 2},
+		},
+	}
+
+	next := p.NextStory()
+	if next == nil || next.ID != "US-002" {
+		t.Errorf("expected US-002 after skipping US-001, got %+v", next)
+	}
+}
+
+func TestPRD_Phases(t *testing.T) {
+	p := &PRD{
+		UserStories: []UserStory{
+			{ID: "US-001", Phase: "foundation", Passes: true},
+			{ID: "US-002", Phase: "features"},
+			{ID: "US-003"},
+			{ID: "US-004", Phase: "foundation", Passes: true},
+			{ID: "US-005", Phase: "polish"},
+		},
+	}
+
+	phases := p.Phases()
+	if len(phases) != 3 || phases[0] != "foundation" || phases[1] != "features" || phases[2] != "polish" {
+		t.Errorf("expected [foundation features polish], got %v", phases)
+	}
+	if got := p.CurrentPhase(); got != "features" {
+		t.Errorf("expected current phase features, got %q", got)
+	}
+}
+
+func TestPRD_CurrentPhase_NoPhases(t *testing.T) {
+	p := &PRD{UserStories: []UserStory{{ID: "US-001"}}}
+	if got := p.CurrentPhase(); got != "" {
+		t.Errorf("expected empty current phase, got %q", got)
+	}
+}
+
+func TestPRD_NextStory_StaysInCurrentPhase(t *testing.T) {
+	// A lower priority story in a later phase must wait for the current phase
+	p := &PRD{
+		Project: "Test",
+		UserStories: []UserStory{
+			{ID: "US-001", Priority: 2, Phase: "foundation"},
+			{ID: "US-002", Priority: 1, Phase: "features"},
+		},
+	}
+
+	next := p.NextStory()
+	if next == nil {
+		t.Fatal("expected non-nil story")
+	}
+	if next.ID != "US-001" {
+		t.Errorf("expected US-001 from the current phase, got %s", next.ID)
+	}
+}
+
+func TestPRD_NextStory_SkipsBlocked(t *testing.T) {
+	p := &PRD{
+		Project: "Test",
+		UserStories: []UserStory{
+			{ID: "US-001", Priority: 1, DependsOn: []string{"US-002"}},
+			{ID: "US-002", Priority: 2},
+		},
+	}
+
+	next := p.NextStory()
+	if next == nil || next.ID != "US-002" {
+		t.Fatalf("expected US-002 while US-001 is blocked, got %v", next)
+	}
+	if got := p.BlockedBy(&p.UserStories[0]); len(got) != 1 || got[0] != "US-002" {
+		t.Errorf("expected US-001 blocked by [US-002], got %v", got)
+	}
+
+	p.UserStories[1].Passes = true
+	if next := p.NextStory(); next == nil || next.ID != "US-001" {
+		t.Errorf("expected US-001 once its dependency passes, got %v", next)
+	}
+}
+
+func TestPRD_BlockedBy_SkippedDependency(t *testing.T) {
+	p := &PRD{UserStories: []UserStory{
+		{ID: "US-001", Priority: 1, Skipped: true},
+		{ID: "US-002", Priority: 2, DependsOn: []string{"US-001"}},
+	}}
+
+	if p.IsBlocked(&p.UserStories[1]) {
+		t.Error("expected a skipped dependency to count as met")
+	}
+	if next := p.NextStory(); next == nil || next.ID != "US-002" {
+		t.Errorf("expected US-002 to be next, got %v", next)
+	}
+}
+
+func TestPRD_BlockedBy_UnknownDependency(t *testing.T) {
+	p := &PRD{UserStories: []UserStory{{ID: "US-001", DependsOn: []string{"US-999"}}}}
+
+	if !p.IsBlocked(&p.UserStories[0]) {
+		t.Error("expected a dependency on an unknown story to block")
+	}
+}
+
+func TestLoadPRD_DependencyCycle(t *testing.T) {
+	tmpDir := t.TempDir()
+	prdPath := filepath.Join(tmpDir, "prd.json")
+	content := `{
+  "project": "Test",
+  "userStories": [
+    {"id": "US-001", "title": "A", "dependsOn": ["US-003"]},
+    {"id": "US-002", "title": "B", "dependsOn": ["US-001"]},
+    {"id": "US-003", "title": "C", "dependsOn": ["US-002"]}
+  ]
+}`
+	if err := os.WriteFile(prdPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	_, err := LoadPRD(prdPath)
+	if err == nil {
+		t.Fatal("expected error for dependency cycle, got nil")
+	}
+	if !strings.Contains(err.Error(), "US-001 → US-003 → US-002 → US-001") {
+		t.Errorf("expected error to name the cycle, got %v", err)
+	}
+}
+
+func TestPRD_DependencyCycle_Acyclic(t *testing.T) {
+	p := &PRD{UserStories: []UserStory{
+		{ID: "US-001"},
+		{ID: "US-002", DependsOn: []string{"US-001"}},
+		{ID: "US-003", DependsOn: []string{"US-001", "US-002"}},
+	}}
+
+	if cycle := p.DependencyCycle(); cycle != nil {
+		t.Errorf("expected no cycle, got %v", cycle)
+	}
+}
+
 func TestPRD_NextStory_InterruptedTakesPrecedence(t *testing.T) {
 	// Even if there's a lower priority story, in-progress takes precedence
 	p := &PRD{
@@ -256,13 +556,13 @@ func TestPRD_NextStory_InterruptedTakesPrecedence(t *testing.T) {
 
 func TestUserStory_Fields(t *testing.T) {
 	story := UserStory{
-		ID:                 "US-TEST",
-		Title:              "Test Title",
-		Description:        "Test Description",
-		Steps: []string{"AC1", "AC2", "AC3"},
-		Priority:           5,
-		Passes:             true,
-		InProgress:         false,
+		ID:          "US-TEST",
+		Title:       "Test Title",
+		Description: "Test Description",
+		Steps:       []string{"AC1", "AC2", "AC3"},
+		Priority:    5,
+		Passes:      true,
+		InProgress:  false,
 	}
 
 	if story.ID != "US-TEST" {
@@ -303,3 +603,31 @@ func TestPRD_Save_PreservesInProgress(t *testing.T) {
 		t.Error("expected InProgress to be preserved as true")
 	}
 }
+
+func TestPRD_Warnings(t *testing.T) {
+	p := &PRD{
+		UserStories: []UserStory{
+			{ID: "US-001", Title: "Fine", Steps: []string{"Works"}},
+			{ID: "US-002", Title: "No criteria", Steps: []string{"  "}},
+			{ID: "US-003", Steps: []string{"Works"}},
+			{Title: "No ID"},
+		},
+	}
+	want := []string{
+		"US-002 has no acceptance criteria",
+		"US-003 has no title",
+		"story 4 has no acceptance criteria",
+	}
+	got := p.Warnings()
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Warnings() = %q, want %q", got, want)
+	}
+
+	err := p.Strict()
+	if !errors.Is(err, ErrPRDWarnings) || !strings.Contains(err.Error(), "US-003 has no title") {
+		t.Errorf("Strict() = %v, want ErrPRDWarnings listing the warnings", err)
+	}
+	if err := (&PRD{UserStories: p.UserStories[:1]}).Strict(); err != nil {
+		t.Errorf("Strict() on a valid PRD = %v", err)
+	}
+}
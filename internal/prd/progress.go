@@ -2,11 +2,13 @@ package prd
 
 import (
 	"bufio"
+	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/fsnotify/fsnotify"
 )
@@ -85,6 +87,61 @@ func ParseProgress(path string) (map[string][]ProgressEntry, error) {
 	return result, nil
 }
 
+// AppendProgressNote adds a user's note as a bullet at the end of the story's
+// latest section in progress.md, so it shows with the agent's own progress
+// notes and the next iteration reads it. When the story has no section yet,
+// a new one dated today is appended.
+func AppendProgressNote(path, storyID, note string) error {
+	note = strings.Join(strings.Fields(note), " ")
+	if note == "" {
+		return fmt.Errorf("note is empty")
+	}
+	bullet := "- Note: " + note
+
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read progress.md: %w", err)
+	}
+	content := strings.TrimRight(string(data), "\n")
+	var lines []string
+	if content != "" {
+		lines = strings.Split(content, "\n")
+	}
+
+	// Find the story's last section
+	header := -1
+	for i, line := range lines {
+		if m := storyHeaderRegex.FindStringSubmatch(line); m != nil && m[2] == storyID {
+			header = i
+		}
+	}
+
+	if header < 0 {
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, fmt.Sprintf("## %s - %s", time.Now().Format("2006-01-02"), storyID), bullet, "---")
+	} else {
+		// The section runs until a separator or the next heading; the note
+		// goes after its last non-blank line
+		insert := header + 1
+		for i := header + 1; i < len(lines); i++ {
+			if strings.TrimSpace(lines[i]) == "---" || strings.HasPrefix(lines[i], "## ") {
+				break
+			}
+			if strings.TrimSpace(lines[i]) != "" {
+				insert = i + 1
+			}
+		}
+		lines = append(lines[:insert], append([]string{bullet}, lines[insert:]...)...)
+	}
+
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to write progress.md: %w", err)
+	}
+	return nil
+}
+
 // ProgressWatcher watches progress.md for changes and sends parsed entries.
 type ProgressWatcher struct {
 	dir     string
@@ -263,3 +263,58 @@ func TestProgressPath(t *testing.T) {
 		t.Errorf("ProgressPath() = %q, want %q", got, want)
 	}
 }
+
+func TestAppendProgressNote_ExistingSection(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "progress.md")
+	content := `## 2026-02-20 - US-001
+- First session
+---
+## 2026-02-20 - US-002
+- Other story
+---
+## 2026-02-21 - US-001
+- Second session
+
+---
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	if err := AppendProgressNote(path, "US-001", "flaky test here,\nverify manually"); err != nil {
+		t.Fatalf("AppendProgressNote failed: %v", err)
+	}
+
+	entries, err := ParseProgress(path)
+	if err != nil {
+		t.Fatalf("ParseProgress failed: %v", err)
+	}
+	if len(entries["US-001"]) != 2 {
+		t.Fatalf("expected 2 entries for US-001, got %d", len(entries["US-001"]))
+	}
+	if got := strings.TrimSpace(entries["US-001"][1].Content); got != "- Second session\n- Note: flaky test here, verify manually" {
+		t.Errorf("expected the note at the end of the latest section, got %q", got)
+	}
+	if strings.Contains(entries["US-001"][0].Content, "Note") || strings.Contains(entries["US-002"][0].Content, "Note") {
+		t.Error("expected other sections to be left alone")
+	}
+}
+
+func TestAppendProgressNote_NewSection(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "progress.md")
+
+	if err := AppendProgressNote(path, "US-003", "check the migration"); err != nil {
+		t.Fatalf("AppendProgressNote failed: %v", err)
+	}
+	if err := AppendProgressNote(path, "US-003", "   "); err == nil {
+		t.Error("expected an error for an empty note")
+	}
+
+	entries, err := ParseProgress(path)
+	if err != nil {
+		t.Fatalf("ParseProgress failed: %v", err)
+	}
+	if len(entries["US-003"]) != 1 || entries["US-003"][0].Content != "- Note: check the migration" {
+		t.Errorf("expected a new section holding the note, got %+v", entries["US-003"])
+	}
+}
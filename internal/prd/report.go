@@ -0,0 +1,79 @@
+package prd
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ExportMarkdown renders a PRD and its progress.md notes, as returned by
+// ParseProgress, as a standalone Markdown report: the project description, a
+// table of stories with their status, and each story's notes in PRD order.
+func ExportMarkdown(p *PRD, progress map[string][]ProgressEntry) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s\n\n", p.Project)
+	if p.Description != "" {
+		fmt.Fprintf(&b, "%s\n\n", p.Description)
+	}
+
+	// Progress is reported as the TUI and chief status show it: the weighted
+	// percentage, with a count that leaves out the same skipped stories
+	completed, counted, skipped := p.StoryCounts()
+	fmt.Fprintf(&b, "**Progress:** %d/%d stories complete (%.0f%%)", completed, counted, p.CompletionPercentage())
+	if skipped > 0 {
+		fmt.Fprintf(&b, ", %d skipped", skipped)
+	}
+	b.WriteString("\n\n")
+
+	b.WriteString("## Stories\n\n")
+	if len(p.UserStories) == 0 {
+		b.WriteString("No stories defined.\n")
+	} else {
+		b.WriteString("| ID | Story | Status |\n")
+		b.WriteString("|----|-------|--------|\n")
+		for i := range p.UserStories {
+			story := &p.UserStories[i]
+			fmt.Fprintf(&b, "| %s | %s | %s |\n", markdownCell(story.ID), markdownCell(story.Title), reportStatus(p, story))
+		}
+	}
+
+	wroteNotes := false
+	for _, story := range p.UserStories {
+		entries := progress[story.ID]
+		if len(entries) == 0 {
+			continue
+		}
+		if !wroteNotes {
+			b.WriteString("\n## Progress notes\n")
+			wroteNotes = true
+		}
+		fmt.Fprintf(&b, "\n### %s: %s\n", story.ID, story.Title)
+		for _, entry := range entries {
+			fmt.Fprintf(&b, "\n_%s_\n\n%s\n", entry.Date, strings.TrimSpace(entry.Content))
+		}
+	}
+
+	return b.String()
+}
+
+// reportStatus describes a story's status for the report's story table.
+func reportStatus(p *PRD, story *UserStory) string {
+	switch {
+	case story.Passes:
+		return "✅ Passed"
+	case story.Skipped:
+		return "⏭️ Skipped"
+	case story.InProgress:
+		return "🔄 In progress"
+	case p.IsBlocked(story):
+		return "⛔ Blocked by " + strings.Join(p.BlockedBy(story), ", ")
+	default:
+		return "⬜ Pending"
+	}
+}
+
+// markdownCell makes text safe to put in a Markdown table cell.
+func markdownCell(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	return strings.ReplaceAll(text, "|", `\|`)
+}
@@ -0,0 +1,45 @@
+package prd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExportMarkdown(t *testing.T) {
+	p := &PRD{
+		Project:     "Auth",
+		Description: "Sign-in for the app.",
+		UserStories: []UserStory{
+			{ID: "US-001", Title: "Login | logout", Passes: true, Weight: 3},
+			{ID: "US-002", Title: "Reset", InProgress: true},
+			{ID: "US-003", Title: "SSO", DependsOn: []string{"US-002"}},
+			{ID: "US-004", Title: "Audit", Skipped: true},
+		},
+	}
+	progress := map[string][]ProgressEntry{
+		"US-001": {{StoryID: "US-001", Date: "2025-01-01", Content: "- Added the form\n"}},
+		"US-OLD": {{StoryID: "US-OLD", Date: "2025-01-01", Content: "- Removed story"}},
+	}
+
+	report := ExportMarkdown(p, progress)
+	for _, want := range []string{
+		"# Auth\n\nSign-in for the app.\n",
+		"**Progress:** 1/3 stories complete (60%), 1 skipped\n",
+		"| US-001 | Login \\| logout | ✅ Passed |",
+		"| US-002 | Reset | 🔄 In progress |",
+		"| US-003 | SSO | ⛔ Blocked by US-002 |",
+		"| US-004 | Audit | ⏭️ Skipped |",
+		"### US-001: Login | logout\n\n_2025-01-01_\n\n- Added the form\n",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report missing %q:\n%s", want, report)
+		}
+	}
+	if strings.Contains(report, "US-OLD") {
+		t.Error("expected notes for stories no longer in the PRD to be left out")
+	}
+
+	if report := ExportMarkdown(&PRD{Project: "Empty"}, nil); strings.Contains(report, "Progress notes") || !strings.Contains(report, "No stories defined.") {
+		t.Errorf("unexpected report for an empty PRD:\n%s", report)
+	}
+}
@@ -0,0 +1,93 @@
+package prd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// StoryTime records how long one loop iteration spent on a story.
+type StoryTime struct {
+	StoryID  string        `json:"storyId"`
+	Duration time.Duration `json:"duration"` // nanoseconds
+	Passed   bool          `json:"passed"`   // Whether the story passed when the iteration ended
+	At       time.Time     `json:"at"`       // When the iteration ended
+}
+
+// TimingsPath returns the timings.jsonl path for a given prd.json path.
+func TimingsPath(prdPath string) string {
+	return filepath.Join(filepath.Dir(prdPath), "timings.jsonl")
+}
+
+// LoadTimings reads every recorded StoryTime for a PRD, oldest first.
+// Returns nil (no error) when nothing has been recorded yet.
+func LoadTimings(prdPath string) ([]StoryTime, error) {
+	f, err := os.Open(TimingsPath(prdPath))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var timings []StoryTime
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var t StoryTime
+		// Skip lines that didn't finish writing rather than losing the whole history
+		if err := json.Unmarshal(scanner.Bytes(), &t); err == nil && t.StoryID != "" {
+			timings = append(timings, t)
+		}
+	}
+	return timings, scanner.Err()
+}
+
+// AppendTiming adds a StoryTime to the PRD's timings file.
+func AppendTiming(prdPath string, t StoryTime) error {
+	data, err := json.Marshal(t)
+	if err != nil {
+		return fmt.Errorf("failed to encode timing: %w", err)
+	}
+	f, err := os.OpenFile(TimingsPath(prdPath), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open timings file: %w", err)
+	}
+	defer f.Close()
+	_, err = f.Write(append(data, '\n'))
+	return err
+}
+
+// EstimateRemaining estimates how long the stories that don't pass yet will
+// take, from the average time spent on stories that already pass. Time already
+// spent on a pending story counts against its share. ok is false when no
+// passing story has recorded timings to base an estimate on.
+func (p *PRD) EstimateRemaining(timings []StoryTime) (remaining time.Duration, ok bool) {
+	spent := make(map[string]time.Duration)
+	for _, t := range timings {
+		spent[t.StoryID] += t.Duration
+	}
+
+	var doneTotal time.Duration
+	doneCount := 0
+	for _, story := range p.UserStories {
+		if story.Passes && spent[story.ID] > 0 {
+			doneTotal += spent[story.ID]
+			doneCount++
+		}
+	}
+	if doneCount == 0 {
+		return 0, false
+	}
+
+	average := doneTotal / time.Duration(doneCount)
+	for _, story := range p.UserStories {
+		if !story.Settled() && spent[story.ID] < average {
+			remaining += average - spent[story.ID]
+		}
+	}
+	return remaining, true
+}
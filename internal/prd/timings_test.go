@@ -0,0 +1,62 @@
+package prd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAppendAndLoadTimings(t *testing.T) {
+	prdPath := filepath.Join(t.TempDir(), "prd.json")
+
+	if timings, err := LoadTimings(prdPath); err != nil || timings != nil {
+		t.Fatalf("expected no timings before any are recorded, got %v, %v", timings, err)
+	}
+
+	for _, st := range []StoryTime{
+		{StoryID: "US-001", Duration: 10 * time.Minute, Passed: true},
+		{StoryID: "US-002", Duration: 5 * time.Minute},
+	} {
+		if err := AppendTiming(prdPath, st); err != nil {
+			t.Fatalf("AppendTiming failed: %v", err)
+		}
+	}
+	// A torn final line shouldn't lose the history before it
+	f, _ := os.OpenFile(TimingsPath(prdPath), os.O_APPEND|os.O_WRONLY, 0644)
+	f.WriteString(`{"storyId":"US-0`)
+	f.Close()
+
+	timings, err := LoadTimings(prdPath)
+	if err != nil {
+		t.Fatalf("LoadTimings failed: %v", err)
+	}
+	if len(timings) != 2 || timings[0].StoryID != "US-001" || timings[1].Duration != 5*time.Minute {
+		t.Errorf("unexpected timings: %+v", timings)
+	}
+}
+
+func TestEstimateRemaining(t *testing.T) {
+	p := &PRD{UserStories: []UserStory{
+		{ID: "US-001", Passes: true},
+		{ID: "US-002", Passes: true},
+		{ID: "US-003"},
+		{ID: "US-004"},
+	}}
+
+	if _, ok := p.EstimateRemaining(nil); ok {
+		t.Error("expected no estimate without timings")
+	}
+
+	timings := []StoryTime{
+		{StoryID: "US-001", Duration: 8 * time.Minute},
+		{StoryID: "US-001", Duration: 4 * time.Minute}, // retried: 12m total
+		{StoryID: "US-002", Duration: 8 * time.Minute},
+		{StoryID: "US-003", Duration: 4 * time.Minute}, // partly done
+	}
+	// Average of 10m per story: 6m left on US-003 plus 10m for US-004
+	remaining, ok := p.EstimateRemaining(timings)
+	if !ok || remaining != 16*time.Minute {
+		t.Errorf("expected 16m remaining, got %s (ok=%v)", remaining, ok)
+	}
+}
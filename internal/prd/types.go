@@ -1,57 +1,304 @@
 // Package prd provides types and utilities for working with Product
 // Requirements Documents (PRDs). It includes loading, saving, watching
-// for changes, and converting between prd.md and prd.json formats.
+// for changes, and converting between prd.md and prd.json formats. PRDs may
+// also be hand-written as prd.yaml.
 package prd
 
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
 // UserStory represents a single user story in a PRD.
 type UserStory struct {
-	ID                 string   `json:"id"`
-	Title              string   `json:"title"`
-	Description        string   `json:"description"`
-	Steps              []string `json:"steps"`
-	Priority           int      `json:"priority"`
-	Passes             bool     `json:"passes"`
-	InProgress         bool     `json:"inProgress,omitempty"`
+	ID              string   `json:"id" yaml:"id"`
+	Title           string   `json:"title" yaml:"title"`
+	Description     string   `json:"description" yaml:"description"`
+	Steps           []string `json:"steps" yaml:"steps"`
+	Priority        int      `json:"priority" yaml:"priority"`
+	Passes          bool     `json:"passes" yaml:"passes"`
+	InProgress      bool     `json:"inProgress,omitempty" yaml:"inProgress,omitempty"`
+	Skipped         bool     `json:"skipped,omitempty" yaml:"skipped,omitempty"`                 // Set aside by the user; the loop moves past it and it doesn't hold up completion
+	Phase           string   `json:"phase,omitempty" yaml:"phase,omitempty"`                     // Optional phase name; phases run in order of first appearance
+	DependsOn       []string `json:"dependsOn,omitempty" yaml:"dependsOn,omitempty"`             // IDs of stories that must pass first
+	Weight          float64  `json:"weight,omitempty" yaml:"weight,omitempty"`                   // Relative size, for completion percentage and iteration budget; 0 means 1
+	TicketURL       string   `json:"ticketURL,omitempty" yaml:"ticketURL,omitempty"`             // Link to the story's tracking ticket (Jira, Linear, GitHub issue, ...)
+	EstimateMinutes int      `json:"estimateMinutes,omitempty" yaml:"estimateMinutes,omitempty"` // Rough time estimate, compared with the actual time on completion; 0 means none
+	Tags            []string `json:"tags,omitempty" yaml:"tags,omitempty"`                       // Labels grouping stories by area, e.g. "backend" or "ui"
+}
+
+// Settled reports whether the loop is done with the story: it passes or was
+// skipped.
+func (s *UserStory) Settled() bool {
+	return s.Passes || s.Skipped
+}
+
+// HasTag reports whether the story is tagged with tag, ignoring case.
+func (s *UserStory) HasTag(tag string) bool {
+	for _, t := range s.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// Estimate returns the story's time estimate, or 0 if it has none.
+func (s *UserStory) Estimate() time.Duration {
+	if s.EstimateMinutes <= 0 {
+		return 0
+	}
+	return time.Duration(s.EstimateMinutes) * time.Minute
+}
+
+// weight returns the story's share of the completion percentage, treating an
+// unset or non-positive Weight as 1.
+func (s *UserStory) weight() float64 {
+	if s.Weight <= 0 {
+		return 1
+	}
+	return s.Weight
 }
 
 // PRD represents a Product Requirements Document.
 type PRD struct {
-	Project     string      `json:"project"`
-	Description string      `json:"description"`
-	UserStories []UserStory `json:"userStories"`
+	Project     string      `json:"project" yaml:"project"`
+	Description string      `json:"description" yaml:"description"`
+	UserStories []UserStory `json:"userStories" yaml:"userStories"`
 }
 
-// AllComplete returns true when all stories have passes: true.
+// AllComplete returns true when every story passes or was skipped.
 func (p *PRD) AllComplete() bool {
 	if len(p.UserStories) == 0 {
 		return true
 	}
 	for _, story := range p.UserStories {
-		if !story.Passes {
+		if !story.Settled() {
 			return false
 		}
 	}
 	return true
 }
 
+// CompletionPercentage returns how much of the PRD is done, from 0 to 100.
+// Each passing story counts by its Weight, so with no weights set this is
+// the share of stories that pass. Skipped stories don't count either way. A
+// PRD with no stories is 100% complete.
+func (p *PRD) CompletionPercentage() float64 {
+	var done, total float64
+	for i := range p.UserStories {
+		if p.UserStories[i].Skipped && !p.UserStories[i].Passes {
+			continue
+		}
+		w := p.UserStories[i].weight()
+		total += w
+		if p.UserStories[i].Passes {
+			done += w
+		}
+	}
+	if total == 0 {
+		return 100.0
+	}
+	return done / total * 100.0
+}
+
+// StoryCounts returns how many stories pass and how many count towards
+// CompletionPercentage, along with how many were skipped without passing and
+// so are left out of both.
+func (p *PRD) StoryCounts() (completed, counted, skipped int) {
+	for i := range p.UserStories {
+		switch {
+		case p.UserStories[i].Passes:
+			completed++
+			counted++
+		case p.UserStories[i].Skipped:
+			skipped++
+		default:
+			counted++
+		}
+	}
+	return completed, counted, skipped
+}
+
+// IterationAllowance returns how many iterations the remaining work is
+// allowed before any configured multiplier or buffer: the sum of each
+// unfinished story's allowance. A story's allowance is its Weight, scaled up
+// to double for the most important (lowest priority number) remaining story,
+// so hard high-priority stories get room to finish.
+func (p *PRD) IterationAllowance() int {
+	allowance := 0
+	for _, n := range p.iterationAllowances() {
+		allowance += n
+	}
+	return allowance
+}
+
+// iterationAllowances returns how many iterations each unfinished story is
+// budgeted, keyed by story ID.
+func (p *PRD) iterationAllowances() map[string]int {
+	// Rank the distinct priorities of the remaining stories, most important first
+	var priorities []int
+	seen := make(map[int]bool)
+	for _, story := range p.UserStories {
+		if !story.Settled() && !seen[story.Priority] {
+			seen[story.Priority] = true
+			priorities = append(priorities, story.Priority)
+		}
+	}
+	sort.Ints(priorities)
+	rank := make(map[int]int, len(priorities))
+	for i, priority := range priorities {
+		rank[priority] = i
+	}
+
+	allowances := make(map[string]int)
+	for i := range p.UserStories {
+		story := &p.UserStories[i]
+		if story.Settled() {
+			continue
+		}
+		// 1 for the least important priority, rising linearly to 2 for the most
+		scale := 2.0
+		if len(priorities) > 1 {
+			scale = 2 - float64(rank[story.Priority])/float64(len(priorities)-1)
+		}
+		allowances[story.ID] = int(math.Ceil(story.weight() * scale))
+	}
+	return allowances
+}
+
+// Phases returns the distinct phase names in order of first appearance.
+// Stories without a phase are not included.
+func (p *PRD) Phases() []string {
+	var phases []string
+	seen := make(map[string]bool)
+	for _, story := range p.UserStories {
+		if story.Phase != "" && !seen[story.Phase] {
+			seen[story.Phase] = true
+			phases = append(phases, story.Phase)
+		}
+	}
+	return phases
+}
+
+// CurrentPhase returns the first phase that still has unsettled stories,
+// or an empty string if the PRD has no phases or all phases are complete.
+func (p *PRD) CurrentPhase() string {
+	for _, phase := range p.Phases() {
+		for _, story := range p.UserStories {
+			if story.Phase == phase && !story.Settled() {
+				return phase
+			}
+		}
+	}
+	return ""
+}
+
+// BlockedBy returns the IDs of the story's dependencies that aren't settled
+// yet. A skipped dependency counts as met, so its dependents aren't stuck
+// behind it; dependencies on unknown story IDs count as unmet.
+func (p *PRD) BlockedBy(story *UserStory) []string {
+	var blocking []string
+	for _, dep := range story.DependsOn {
+		met := false
+		for _, other := range p.UserStories {
+			if other.ID == dep {
+				met = other.Settled()
+				break
+			}
+		}
+		if !met {
+			blocking = append(blocking, dep)
+		}
+	}
+	return blocking
+}
+
+// IsBlocked returns true if the story has dependencies that aren't settled yet.
+func (p *PRD) IsBlocked(story *UserStory) bool {
+	return len(p.BlockedBy(story)) > 0
+}
+
+// DependencyCycle returns the story IDs forming a dependency cycle, with the
+// first ID repeated at the end (e.g. [US-001 US-002 US-001]), or nil if the
+// dependency graph is acyclic.
+func (p *PRD) DependencyCycle() []string {
+	deps := make(map[string][]string, len(p.UserStories))
+	for _, story := range p.UserStories {
+		deps[story.ID] = story.DependsOn
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int, len(deps))
+	var stack []string
+
+	var visit func(id string) []string
+	visit = func(id string) []string {
+		state[id] = visiting
+		stack = append(stack, id)
+		for _, dep := range deps[id] {
+			switch state[dep] {
+			case visiting:
+				// Found a back edge: the cycle is the stack from dep onwards
+				for i, sid := range stack {
+					if sid == dep {
+						cycle := append([]string{}, stack[i:]...)
+						return append(cycle, dep)
+					}
+				}
+			case unvisited:
+				if _, known := deps[dep]; known {
+					if cycle := visit(dep); cycle != nil {
+						return cycle
+					}
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[id] = done
+		return nil
+	}
+
+	for _, story := range p.UserStories {
+		if state[story.ID] == unvisited {
+			if cycle := visit(story.ID); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
 // NextStory returns the next story to work on.
 // It returns:
 //   - First story with inProgress: true (interrupted story), or
-//   - Lowest priority story with passes: false, or
-//   - nil if all stories are complete
+//   - Lowest priority unblocked story with passes: false in the current phase, or
+//   - nil if all stories are complete, skipped or blocked
 func (p *PRD) NextStory() *UserStory {
 	// First, check for any in-progress story (interrupted)
 	for i := range p.UserStories {
-		if p.UserStories[i].InProgress {
+		if p.UserStories[i].InProgress && !p.UserStories[i].Skipped {
 			return &p.UserStories[i]
 		}
 	}
 
-	// Find the lowest priority story that hasn't passed
+	// Find the lowest priority story that hasn't passed, staying within the current phase
+	phase := p.CurrentPhase()
 	var next *UserStory
 	for i := range p.UserStories {
 		story := &p.UserStories[i]
-		if !story.Passes {
+		if phase != "" && story.Phase != phase {
+			continue
+		}
+		if !story.Settled() && !p.IsBlocked(story) {
 			if next == nil || story.Priority < next.Priority {
 				next = story
 			}
@@ -59,3 +306,43 @@ func (p *PRD) NextStory() *UserStory {
 	}
 	return next
 }
+
+// Warnings returns problems that leave the loop unable to tell when a story
+// is done: a story without a title, or without any acceptance criteria. The
+// PRD still loads; callers surface them or, when strict, refuse to run.
+func (p *PRD) Warnings() []string {
+	var warnings []string
+	for i, story := range p.UserStories {
+		name := story.ID
+		if name == "" {
+			name = fmt.Sprintf("story %d", i+1)
+		}
+		if strings.TrimSpace(story.Title) == "" {
+			warnings = append(warnings, name+" has no title")
+		}
+		hasCriteria := false
+		for _, step := range story.Steps {
+			if strings.TrimSpace(step) != "" {
+				hasCriteria = true
+				break
+			}
+		}
+		if !hasCriteria {
+			warnings = append(warnings, name+" has no acceptance criteria")
+		}
+	}
+	return warnings
+}
+
+// ErrPRDWarnings is returned by Strict when a PRD has warnings.
+var ErrPRDWarnings = errors.New("PRD has problems")
+
+// Strict returns an ErrPRDWarnings error listing the PRD's warnings, or nil
+// if it has none.
+func (p *PRD) Strict() error {
+	warnings := p.Warnings()
+	if len(warnings) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrPRDWarnings, strings.Join(warnings, "; "))
+}
@@ -32,7 +32,7 @@ func NewWatcher(path string) (*Watcher, error) {
 	}
 
 	w := &Watcher{
-		path:    path,
+		path:    ResolvePath(path),
 		watcher: fsWatcher,
 		events:  make(chan WatcherEvent, 10),
 		done:    make(chan struct{}),
@@ -167,7 +167,7 @@ func (w *Watcher) hasStatusChanged(newPRD *PRD) bool {
 		}
 
 		// Check if status fields changed
-		if oldStory.Passes != newStory.Passes || oldStory.InProgress != newStory.InProgress {
+		if oldStory.Passes != newStory.Passes || oldStory.InProgress != newStory.InProgress || oldStory.Skipped != newStory.Skipped {
 			return true
 		}
 	}
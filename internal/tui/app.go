@@ -2,6 +2,7 @@ package tui
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -12,6 +13,7 @@ import (
 	"github.com/minicodemonkey/chief/internal/config"
 	"github.com/minicodemonkey/chief/internal/git"
 	"github.com/minicodemonkey/chief/internal/loop"
+	"github.com/minicodemonkey/chief/internal/notify"
 	"github.com/minicodemonkey/chief/internal/paths"
 	"github.com/minicodemonkey/chief/internal/prd"
 )
@@ -37,6 +39,7 @@ const (
 	StateStopped
 	StateComplete
 	StateError
+	StateQueued // Waiting for a free slot under loop.maxConcurrent
 )
 
 func (s AppState) String() string {
@@ -53,6 +56,8 @@ func (s AppState) String() string {
 		return "Complete"
 	case StateError:
 		return "Error"
+	case StateQueued:
+		return "Queued"
 	default:
 		return "Unknown"
 	}
@@ -78,6 +83,7 @@ type PRDCompletedMsg struct {
 // mergeResultMsg is sent when a merge operation completes.
 type mergeResultMsg struct {
 	branch    string
+	strategy  string
 	conflicts []string
 	output    string
 	err       error
@@ -85,18 +91,20 @@ type mergeResultMsg struct {
 
 // cleanResultMsg is sent when a clean operation completes.
 type cleanResultMsg struct {
-	prdName      string
-	success      bool
-	message      string
-	clearBranch  bool
+	prdName     string
+	success     bool
+	message     string
+	clearBranch bool
+	sharedWith  []string // Other PRDs that used the removed worktree
 }
 
 // autoActionResultMsg is sent when a post-completion auto-action (push/PR) completes.
 type autoActionResultMsg struct {
 	action  string // "push" or "pr"
 	err     error
-	prURL   string // Only set for successful PR creation
-	prTitle string // Only set for successful PR creation
+	prURL   string        // Only set for successful PR creation
+	prTitle string        // Only set for successful PR creation
+	prOpts  git.PROptions // Draft and reviewers the PR was created with
 }
 
 // completionSpinnerTickMsg is sent to animate the completion screen spinner.
@@ -134,6 +142,9 @@ type LaunchEditMsg struct {
 	Name string
 }
 
+// resumeStartMsg starts the loop of a resumed session once the TUI is up.
+type resumeStartMsg struct{}
+
 // ViewMode represents which view is currently active.
 type ViewMode int
 
@@ -148,6 +159,9 @@ const (
 	ViewCompletion
 	ViewSettings
 	ViewQuitConfirm
+	ViewOverview
+	ViewPromptReview
+	ViewDecisions
 )
 
 // App is the main Bubble Tea model for the Chief TUI.
@@ -158,10 +172,28 @@ type App struct {
 	state         AppState
 	iteration     int
 	startTime     time.Time
+	lastElapsed   time.Duration // Elapsed time of the last run, restored from a previous session while no loop runs
 	selectedIndex int
-	width         int
-	height        int
-	err           error
+
+	// Stories panel filter
+	storyFilter      string    // Case-insensitive ID/title substring; empty shows every story
+	storyFilterInput bool      // Whether keystrokes are going to the filter
+	storySort        storySort // Display order of the stories panel, kept for the session
+
+	startOnLaunch bool // Start the loop once the TUI is running, when resuming a session
+	readOnly      bool // Another chief instance holds the project lock; see SetReadOnly
+
+	// Note being typed for a story's section in progress.md
+	noteEditing bool
+	noteStory   string
+	noteInput   string
+
+	// Story ID being typed after ":" to jump to a story
+	jumpInput bool
+	jumpQuery string
+	width     int
+	height    int
+	err       error
 
 	// Loop manager for parallel PRD execution
 	manager *loop.Manager
@@ -169,6 +201,7 @@ type App struct {
 
 	// Activity tracking
 	lastActivity string
+	currentFile  string // Most recent file the agent touched (cleared between stories)
 
 	// File watching
 	watcher         *prd.Watcher
@@ -187,43 +220,63 @@ type App struct {
 	baseDir string // Base directory for .chief/prds/
 
 	// Project config
-	config *config.Config
+	config  *config.Config
+	keys    KeyMap               // Keys bound to remappable actions, from config.Keybindings
+	layout  config.UIConfig      // Dashboard layout preferences, validated in NewApp
+	pricing config.PricingConfig // The current PRD's prices, refreshed on switch and settings save
 
 	// Diff viewer
-	diffViewer *DiffViewer
+	diffViewer     *DiffViewer
+	storyDiffStats map[string]*git.DiffStat // Size of each passed story's commit, keyed by "<prd>/<story ID>"
+	diffStatEpoch  int                      // Bumped when storyDiffStats is cleared, so stale loads are dropped
 
 	// Help overlay
 	helpOverlay      *HelpOverlay
 	previousViewMode ViewMode // View to return to when closing help
 
 	// Branch warning dialog
-	branchWarning      *BranchWarning
-	pendingStartPRD    string // PRD name waiting to start after branch decision
-	pendingWorktreePath string // Absolute worktree path for pending PRD
+	branchWarning       *BranchWarning
+	pendingStartPRD     string   // PRD name waiting to start after branch decision
+	startQueue          []string // PRDs from a start-all waiting their turn for a start dialog
+	pendingStartStory   string   // Story to start at for the pending start (empty = usual order)
+	pendingWorktreePath string   // Absolute worktree path for pending PRD
 
 	// Worktree setup spinner
 	worktreeSpinner *WorktreeSpinner
 
 	// Completion screen
 	completionScreen *CompletionScreen
+	completionFacts  completionFacts // What the completion screen's auto-actions are guarded on
 
 	// Story timing tracking
-	storyTimings     []StoryTiming
-	currentStoryID   string
+	storyTimings      []StoryTiming
+	currentStoryID    string
 	currentStoryStart time.Time
 
 	// Settings overlay
 	settingsOverlay *SettingsOverlay
 
 	// Quit confirmation dialog
-	quitConfirm *QuitConfirmation
+	quitConfirm     *QuitConfirmation
+	quitRequestedAt time.Time // When q last opened or was pressed in the dialog, for the double-press bypass
+
+	// First-iteration prompt review before a loop starts
+	promptReview *PromptReview
+	reviewPrompt bool // Review even when the config doesn't ask for it (--review-prompt)
 
-	// Completion notification callback
+	// Completion and failure notification callbacks
 	onCompletion func(prdName string)
+	onError      func(prdName string, err error)
 
 	// Verbose mode - show raw Claude output
 	verbose bool
 
+	// Plain-text narration of loop transitions (--accessible)
+	narrator *narrator
+
+	// Choices made in dialogs this run, for the decisions view
+	decisions []Decision
+
 	// Post-exit action - what to do after TUI exits
 	PostExitAction PostExitAction
 	PostExitPRD    string // PRD name for post-exit action
@@ -236,6 +289,7 @@ const (
 	PostExitNone PostExitAction = iota
 	PostExitInit
 	PostExitEdit
+	PostExitDetach // Keep running loops going after the UI closes (see RunDetached)
 )
 
 // NewApp creates a new App with the given PRD.
@@ -244,27 +298,15 @@ func NewApp(prdPath string) (*App, error) {
 }
 
 // NewAppWithOptions creates a new App with the given PRD and options.
-// If maxIter <= 0, it will be calculated dynamically based on remaining stories.
+// If maxIter <= 0, it will be calculated dynamically from the remaining stories'
+// priorities and weights, using the loop.iterationsPerStory and
+// loop.iterationBuffer settings.
 func NewAppWithOptions(prdPath string, maxIter int) (*App, error) {
 	p, err := prd.LoadPRD(prdPath)
 	if err != nil {
 		return nil, err
 	}
 
-	// Calculate dynamic default if maxIter <= 0
-	if maxIter <= 0 {
-		remaining := 0
-		for _, story := range p.UserStories {
-			if !story.Passes {
-				remaining++
-			}
-		}
-		maxIter = remaining + 5
-		if maxIter < 5 {
-			maxIter = 5
-		}
-	}
-
 	// Extract PRD name from path (directory name or filename without extension)
 	prdName := filepath.Base(filepath.Dir(prdPath))
 	if prdName == "." || prdName == "/" {
@@ -287,6 +329,16 @@ func NewAppWithOptions(prdPath string, maxIter int) (*App, error) {
 		cfg = config.Default()
 	}
 
+	// Calculate dynamic default if maxIter <= 0
+	budgetActivity := ""
+	prdCfg, err := cfg.ForPRD(baseDir, prdName)
+	if err != nil {
+		prdCfg = cfg
+	}
+	if maxIter <= 0 {
+		maxIter, budgetActivity = iterationBudget(prdCfg, p)
+	}
+
 	// Prune stale worktrees on startup (clean git's internal tracking)
 	if git.IsGitRepo(baseDir) {
 		_ = git.PruneWorktrees(baseDir)
@@ -300,6 +352,8 @@ func NewAppWithOptions(prdPath string, maxIter int) (*App, error) {
 	manager := loop.NewManager(maxIter)
 	manager.SetBaseDir(baseDir)
 	manager.SetConfig(cfg)
+	manager.SetMaxConcurrent(cfg.Loop.MaxConcurrent)
+	manager.SetRetryPolicy(cfg.Loop.RetryAttempts, cfg.Loop.RetryBackoff)
 
 	// Register the initial PRD with the manager
 	manager.Register(prdName, prdPath)
@@ -307,42 +361,154 @@ func NewAppWithOptions(prdPath string, maxIter int) (*App, error) {
 	// Create tab bar for always-visible PRD tabs
 	tabBar := NewTabBar(baseDir, prdName, manager)
 
+	// Apply the color scheme before anything renders
+	themeErr := LoadTheme(cfg)
+
+	// Resolve remapped keys once; the config is not reloaded while running
+	keys := NewKeyMap(cfg.Keybindings)
+
+	// Fall back to the default layout rather than render with a bad setting
+	layout := cfg.UI
+	layoutErr := layout.Validate()
+	if layoutErr != nil {
+		layout = config.UIConfig{}
+	}
+
 	// Create picker with manager reference (for creating new PRDs)
 	picker := NewPRDPicker(baseDir, prdName, manager)
-
-	return &App{
-		prd:           p,
-		prdPath:       prdPath,
-		prdName:       prdName,
-		state:         StateReady,
-		iteration:     0,
-		selectedIndex: 0,
-		maxIter:       maxIter,
-		manager:       manager,
-		watcher:         watcher,
-		progressWatcher: progressWatcher,
-		progress:        progress,
-		viewMode:        ViewDashboard,
-		logViewer:     NewLogViewer(),
-		diffViewer:    NewDiffViewer(baseDir),
-		tabBar:        tabBar,
-		picker:        picker,
-		baseDir:       baseDir,
-		config:        cfg,
-		helpOverlay:      NewHelpOverlay(),
+	picker.SetKeyMap(keys)
+	picker.SetWorktreesBase(cfg.Worktree.BaseDir)
+	helpOverlay := NewHelpOverlay()
+	helpOverlay.SetKeyMap(keys)
+
+	// Pick up the iteration and state a previous session left the loop in
+	loopState, iteration, _ := manager.GetState(prdName)
+
+	app := &App{
+		prd:              p,
+		prdPath:          prdPath,
+		prdName:          prdName,
+		state:            appStateFor(loopState),
+		iteration:        iteration,
+		selectedIndex:    0,
+		maxIter:          maxIter,
+		manager:          manager,
+		watcher:          watcher,
+		progressWatcher:  progressWatcher,
+		progress:         progress,
+		viewMode:         ViewDashboard,
+		logViewer:        NewLogViewer(),
+		diffViewer:       NewDiffViewer(baseDir),
+		storyDiffStats:   make(map[string]*git.DiffStat),
+		tabBar:           tabBar,
+		picker:           picker,
+		baseDir:          baseDir,
+		config:           cfg,
+		keys:             keys,
+		layout:           layout,
+		pricing:          prdCfg.Pricing,
+		helpOverlay:      helpOverlay,
 		branchWarning:    NewBranchWarning(),
 		worktreeSpinner:  NewWorktreeSpinner(),
 		completionScreen: NewCompletionScreen(),
 		settingsOverlay:  NewSettingsOverlay(),
-		quitConfirm:     NewQuitConfirmation(),
-	}, nil
+		quitConfirm:      NewQuitConfirmation(),
+		promptReview:     NewPromptReview(),
+	}
+	app.diffViewer.SetContext(diffContextFromConfig(cfg))
+	app.restoreLastRun()
+	app.restoreInProgress()
+	app.reconcileBranches()
+	if budgetActivity != "" {
+		app.lastActivity = budgetActivity
+	}
+	if warning := prdWarningActivity(p); warning != "" {
+		app.lastActivity = warning
+	}
+	if warnings := keys.Warnings(); len(warnings) > 0 {
+		app.lastActivity = "Keybindings: " + strings.Join(warnings, "; ")
+	}
+	if themeErr != nil {
+		app.lastActivity = "Theme: " + strings.ReplaceAll(themeErr.Error(), "\n", "; ") + " (using defaults)"
+	}
+	if layoutErr != nil {
+		app.lastActivity = "Layout: " + layoutErr.Error() + " (using defaults)"
+	}
+	return app, nil
 }
 
-// SetCompletionCallback sets a callback that is called when any PRD completes.
+// resizeActiveView passes the window size to the open modal or the diff
+// viewer, which are otherwise only sized when they open.
+func (a *App) resizeActiveView() {
+	switch a.viewMode {
+	case ViewDiff:
+		a.diffViewer.SetSize(a.width-4, a.height-headerHeight-footerHeight-2)
+	case ViewPicker:
+		a.picker.SetSize(a.width, a.height)
+	case ViewHelp:
+		a.helpOverlay.SetSize(a.width, a.height)
+	case ViewBranchWarning:
+		a.branchWarning.SetSize(a.width, a.height)
+	case ViewWorktreeSpinner:
+		a.worktreeSpinner.SetSize(a.width, a.height)
+	case ViewCompletion:
+		a.completionScreen.SetSize(a.width, a.height)
+	case ViewSettings:
+		a.settingsOverlay.SetSize(a.width, a.height)
+	case ViewQuitConfirm:
+		a.quitConfirm.SetSize(a.width, a.height)
+	case ViewPromptReview:
+		a.promptReview.SetSize(a.width, a.height)
+	}
+}
+
+// reconcileBranches forgets the branches of PRDs whose branch was deleted
+// outside chief, noting it on the activity line.
+func (a *App) reconcileBranches() {
+	if a.manager == nil {
+		return
+	}
+	if cleared := a.manager.ReconcileBranches(); len(cleared) > 0 {
+		a.lastActivity = "Branch deleted outside chief; cleared branch and worktree for " + strings.Join(cleared, ", ")
+	}
+}
+
+// SetCompletionCallback sets a callback that is called when a PRD completes.
+// Which completions trigger it is controlled by the notifications.scope config.
 func (a *App) SetCompletionCallback(fn func(prdName string)) {
 	a.onCompletion = fn
-	if a.manager != nil {
-		a.manager.SetCompletionCallback(fn)
+}
+
+// SetErrorCallback sets a callback that is called when a PRD's loop fails.
+func (a *App) SetErrorCallback(fn func(prdName string, err error)) {
+	a.onError = fn
+}
+
+// shouldNotifyCompletion reports whether a PRD's completion should trigger the
+// completion callback under the configured notification scope.
+func (a *App) shouldNotifyCompletion(prdName string) bool {
+	scope := config.NotifyEach
+	if a.config != nil && a.config.Notifications.Scope != "" {
+		scope = a.config.Notifications.Scope
+	}
+
+	switch scope {
+	case config.NotifyActiveOnly:
+		return prdName == a.prdName
+	case config.NotifyAllComplete:
+		if a.manager == nil {
+			return true
+		}
+		// The completing PRD may still report as running until its loop exits
+		for _, name := range a.manager.GetRunningPRDs() {
+			if name != prdName {
+				return false
+			}
+		}
+		// Queued loops have yet to run, so the batch isn't done
+		return len(a.manager.Queued()) == 0
+	default:
+		return true
 	}
 }
 
@@ -351,6 +517,12 @@ func (a *App) SetVerbose(v bool) {
 	a.verbose = v
 }
 
+// SetAccessible narrates story starts, completions, run completion and errors
+// to w as plain text lines, alongside the visual TUI.
+func (a *App) SetAccessible(w io.Writer) {
+	a.narrator = newNarrator(w)
+}
+
 // DisableRetry disables automatic retry on Claude crashes.
 func (a *App) DisableRetry() {
 	if a.manager != nil {
@@ -358,6 +530,80 @@ func (a *App) DisableRetry() {
 	}
 }
 
+// SetIterationTimeout kills and retries an iteration after this long without Claude output.
+func (a *App) SetIterationTimeout(d time.Duration) {
+	if a.manager != nil {
+		a.manager.SetIterationTimeout(d)
+	}
+}
+
+// EnableEventSocket opens the Unix socket set by loop.eventSocket, if any, so
+// other tools can follow the loops' events. A socket that can't be opened is
+// reported on the activity line.
+func (a *App) EnableEventSocket() {
+	if a.manager == nil || a.config == nil {
+		return
+	}
+	path := a.config.Loop.EventSocketPath(a.baseDir)
+	if path == "" {
+		return
+	}
+	if err := a.manager.EnableEventSocket(path); err != nil {
+		a.lastActivity = "Event socket: " + err.Error()
+	}
+}
+
+// CloseEventSocket closes the event socket and removes its file.
+func (a *App) CloseEventSocket() {
+	if a.manager != nil {
+		a.manager.CloseEventSocket()
+	}
+}
+
+// SetReviewPrompt makes every loop start wait for the first-iteration prompt
+// to be reviewed, in addition to the loop.reviewPrompt config setting.
+func (a *App) SetReviewPrompt(review bool) {
+	a.reviewPrompt = review
+}
+
+// ResumeSession restores the selection of a previous session and, with start
+// set, starts the PRD's loop as soon as the TUI is running.
+func (a *App) ResumeSession(storyID string, start bool) {
+	a.selectStoryByID(storyID)
+	a.startOnLaunch = start
+	a.lastActivity = "Resumed where you left off"
+}
+
+// saveSession records the PRD and story being viewed, and whether its loop is
+// running, for OfferResume on the next launch.
+func (a *App) saveSession() {
+	// The instance holding the lock owns the session file
+	if a.baseDir == "" || a.prdName == "" || a.readOnly {
+		return
+	}
+	session := loop.Session{PRD: a.prdName, UpdatedAt: time.Now()}
+	if a.prd != nil {
+		if story := a.GetSelectedStory(); story != nil {
+			session.Story = story.ID
+		}
+	}
+	if a.manager != nil {
+		if state, _, err := a.manager.GetState(a.prdName); err == nil {
+			session.Running = state == loop.LoopStateRunning
+		}
+	}
+	_ = loop.SaveSession(paths.SessionPath(a.baseDir), session)
+}
+
+// shouldReviewPrompt reports whether a PRD's loop starts wait for prompt review.
+func (a *App) shouldReviewPrompt(prdName string) bool {
+	if a.reviewPrompt {
+		return true
+	}
+	cfg := a.configFor(prdName)
+	return cfg != nil && cfg.Loop.ReviewPrompt
+}
+
 // Init initializes the App.
 func (a App) Init() tea.Cmd {
 	// Start the file watcher
@@ -373,12 +619,16 @@ func (a App) Init() tea.Cmd {
 		_ = a.progressWatcher.Start()
 	}
 
-	return tea.Batch(
+	cmds := []tea.Cmd{
 		tea.EnterAltScreen,
 		a.listenForPRDChanges(),
 		a.listenForManagerEvents(),
 		a.listenForProgressChanges(),
-	)
+	}
+	if a.startOnLaunch {
+		cmds = append(cmds, func() tea.Msg { return resumeStartMsg{} })
+	}
+	return tea.Batch(cmds...)
 }
 
 // listenForManagerEvents listens for events from all managed loops.
@@ -397,6 +647,25 @@ func (a *App) listenForManagerEvents() tea.Cmd {
 
 // Update handles messages and updates the model.
 func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	model, cmd := a.update(msg)
+	// The selected story's diff stat is read in the background, not while rendering
+	if next, ok := model.(App); ok {
+		if statCmd := next.loadStoryDiffStat(); statCmd != nil {
+			model, cmd = next, tea.Batch(cmd, statCmd)
+		}
+	}
+	// Once a start dialog is answered, the next queued PRD from a start-all gets its turn
+	if next, ok := model.(App); ok && len(next.startQueue) > 0 {
+		model, queuedCmd := next.continueStartAll()
+		return model, tea.Batch(cmd, queuedCmd)
+	}
+	return model, cmd
+}
+
+// update handles a message for Update.
+func (a App) update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	defer a.recoverPanic()
+
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		a.width = msg.Width
@@ -404,11 +673,21 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Log viewer size is set authoritatively in renderLogView (with correct -4 width).
 		// Only update height here for scroll calculations; width will match on next render.
 		a.logViewer.SetSize(a.width-4, a.height-headerHeight-footerHeight-2)
+		a.resizeActiveView()
 		return a, nil
 
+	case tea.MouseMsg:
+		return a.handleMouse(msg)
+
 	case LoopEventMsg:
 		return a.handleLoopEvent(msg.PRDName, msg.Event)
 
+	case storyDiffStatMsg:
+		if msg.epoch == a.diffStatEpoch {
+			a.storyDiffStats[msg.key] = msg.stat
+		}
+		return a, nil
+
 	case LoopFinishedMsg:
 		return a.handleLoopFinished(msg.PRDName, msg.Err)
 
@@ -433,6 +712,15 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case autoActionResultMsg:
 		return a.handleAutoActionResult(msg)
 
+	case webhookResultMsg:
+		return a.handleWebhookResult(msg)
+
+	case clipboardResultMsg:
+		return a.handleClipboardResult(msg)
+
+	case browserResultMsg:
+		return a.handleBrowserResult(msg)
+
 	case backgroundAutoActionResultMsg:
 		return a.handleBackgroundAutoAction(msg)
 
@@ -476,6 +764,12 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case PRDUpdateMsg:
 		return a.handlePRDUpdate(msg)
 
+	case resumeStartMsg:
+		if a.state == StateReady || a.state == StatePaused || a.state == StateStopped || a.state == StateError {
+			return a.startLoop()
+		}
+		return a, nil
+
 	case LaunchInitMsg:
 		a.PostExitAction = PostExitInit
 		a.PostExitPRD = msg.Name
@@ -486,9 +780,28 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.PostExitPRD = msg.Name
 		return a, tea.Quit
 
+	case promptEditedMsg:
+		return a.handlePromptEdited(msg)
+
 	case tea.KeyMsg:
+		// While typing a stories filter, keys go to the filter
+		if a.storyFilterInput && a.viewMode == ViewDashboard {
+			return a.handleStoryFilterKeys(msg)
+		}
+		if a.noteEditing && a.viewMode == ViewDashboard {
+			return a.handleProgressNoteKeys(msg)
+		}
+		if a.jumpInput && (a.viewMode == ViewDashboard || a.viewMode == ViewDiff) {
+			return a.handleStoryJumpKeys(msg)
+		}
+
+		// The prompt review dialog takes every key until it's answered
+		if a.viewMode == ViewPromptReview {
+			return a.handlePromptReviewKeys(msg)
+		}
+
 		// Handle help overlay first (can be opened/closed from any view)
-		if msg.String() == "?" {
+		if a.keys.Resolve(msg.String()) == ActionHelp {
 			if a.viewMode == ViewHelp {
 				// Close help, return to previous view
 				a.viewMode = a.previousViewMode
@@ -502,6 +815,13 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return a, nil
 		}
 
+		// Keys that would write to the project are refused while another
+		// instance holds the lock
+		if a.readOnlyBlocks(a.keys.Resolve(msg.String())) {
+			a.refuseReadOnly()
+			return a, nil
+		}
+
 		// Handle settings overlay (can be opened/closed from any view)
 		if msg.String() == "," {
 			if a.viewMode == ViewSettings {
@@ -509,10 +829,16 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				a.viewMode = a.previousViewMode
 				return a, nil
 			}
-			if a.viewMode == ViewDashboard || a.viewMode == ViewLog || a.viewMode == ViewPicker || a.viewMode == ViewCompletion {
+			if a.viewMode == ViewDashboard || a.viewMode == ViewLog || a.viewMode == ViewPicker || a.viewMode == ViewCompletion || a.viewMode == ViewOverview {
 				a.previousViewMode = a.viewMode
 				a.settingsOverlay.SetSize(a.width, a.height)
-				a.settingsOverlay.LoadFromConfig(a.config)
+				// A PRD with its own config is edited in place of the project config
+				if config.PRDConfigExists(a.baseDir, a.prdName) {
+					a.settingsOverlay.LoadFromConfig(a.configFor(a.prdName))
+					a.settingsOverlay.SetPRD(a.prdName)
+				} else {
+					a.settingsOverlay.LoadFromConfig(a.config)
+				}
 				a.viewMode = ViewSettings
 				return a, nil
 			}
@@ -542,7 +868,7 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return a.handleBranchWarningKeys(msg)
 		}
 
-		// Handle worktree spinner view - only Esc is active
+		// Handle worktree spinner view - Esc cancels, and a failed setup can be retried or skipped
 		if a.viewMode == ViewWorktreeSpinner {
 			return a.handleWorktreeSpinnerKeys(msg)
 		}
@@ -557,13 +883,13 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return a.handleQuitConfirmKeys(msg)
 		}
 
-		switch msg.String() {
+		switch a.keys.Resolve(msg.String()) {
 		case "q", "ctrl+c":
 			return a.tryQuit()
 
 		// View switching
-		case "t":
-			if a.viewMode == ViewDashboard || a.viewMode == ViewDiff {
+		case ActionLog:
+			if a.viewMode == ViewDashboard || a.viewMode == ViewDiff || a.viewMode == ViewOverview {
 				a.viewMode = ViewLog
 				// SetSize is handled by renderLogView with correct dimensions
 			} else {
@@ -571,23 +897,33 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return a, nil
 
+		// PRD overview
+		case "o":
+			if a.viewMode == ViewDashboard || a.viewMode == ViewLog || a.viewMode == ViewDiff {
+				a.viewMode = ViewOverview
+			} else if a.viewMode == ViewOverview {
+				a.viewMode = ViewDashboard
+			}
+			return a, nil
+
+		// Decisions made in dialogs this run
+		case "D":
+			if a.viewMode == ViewDashboard || a.viewMode == ViewLog || a.viewMode == ViewDiff || a.viewMode == ViewOverview {
+				a.viewMode = ViewDecisions
+			} else if a.viewMode == ViewDecisions {
+				a.viewMode = ViewDashboard
+			}
+			return a, nil
+
 		// Diff view
-		case "d":
-			if a.viewMode == ViewDashboard || a.viewMode == ViewLog {
+		case ActionDiff:
+			if a.viewMode == ViewDashboard || a.viewMode == ViewLog || a.viewMode == ViewOverview {
 				// Use the current PRD's worktree directory if available, otherwise base dir
-				diffDir := a.baseDir
-				if instance := a.manager.GetInstance(a.prdName); instance != nil && instance.WorktreeDir != "" {
-					diffDir = instance.WorktreeDir
-				}
+				diffDir := a.storyDiffDir(a.prdName)
 				a.diffViewer.SetBaseDir(diffDir)
+				a.diffViewer.SetBaseBranch(a.configuredBaseBranch(a.prdName))
 				if instance := a.manager.GetInstance(a.prdName); instance != nil {
-					branch := instance.Branch
-					if branch == "" {
-						if detected, err := git.GetCurrentBranch(diffDir); err == nil {
-							branch = detected
-						}
-					}
-					a.diffViewer.SetTicketPrefix(git.ExtractTicketFromBranch(branch))
+					a.diffViewer.SetTicketPrefix(a.storyTicketPrefix(a.prdName, diffDir))
 				}
 				a.diffViewer.SetSize(a.width-4, a.height-headerHeight-footerHeight-2)
 				// Load diff for the selected story's commit
@@ -603,8 +939,9 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return a, nil
 
 		// New PRD (opens picker in input mode)
-		case "n":
-			if a.viewMode == ViewDashboard || a.viewMode == ViewLog || a.viewMode == ViewDiff {
+		case ActionNew:
+			if a.viewMode == ViewDashboard || a.viewMode == ViewLog || a.viewMode == ViewDiff || a.viewMode == ViewOverview {
+				a.reconcileBranches()
 				a.picker.Refresh()
 				a.picker.SetSize(a.width, a.height)
 				a.picker.StartInputMode()
@@ -614,7 +951,8 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// List PRDs (opens picker in selection mode)
 		case "l":
-			if a.viewMode == ViewDashboard || a.viewMode == ViewLog || a.viewMode == ViewDiff {
+			if a.viewMode == ViewDashboard || a.viewMode == ViewLog || a.viewMode == ViewDiff || a.viewMode == ViewOverview {
+				a.reconcileBranches()
 				a.picker.Refresh()
 				a.picker.SetSize(a.width, a.height)
 				a.viewMode = ViewPicker
@@ -622,8 +960,8 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return a, nil
 
 		// Edit current PRD
-		case "e":
-			if a.viewMode == ViewDashboard || a.viewMode == ViewLog || a.viewMode == ViewDiff {
+		case ActionEdit:
+			if a.viewMode == ViewDashboard || a.viewMode == ViewLog || a.viewMode == ViewDiff || a.viewMode == ViewOverview {
 				a.stopAllLoops()
 				a.stopWatcher()
 				return a, func() tea.Msg {
@@ -634,7 +972,7 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// Number keys 1-9 to switch PRDs
 		case "1", "2", "3", "4", "5", "6", "7", "8", "9":
-			if a.viewMode == ViewDashboard || a.viewMode == ViewLog || a.viewMode == ViewDiff {
+			if a.viewMode == ViewDashboard || a.viewMode == ViewLog || a.viewMode == ViewDiff || a.viewMode == ViewOverview {
 				index := int(msg.String()[0] - '1') // Convert "1" to 0, "2" to 1, etc.
 				if entry := a.tabBar.GetEntry(index); entry != nil {
 					return a.switchToPRD(entry.Name, entry.Path)
@@ -642,19 +980,93 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return a, nil
 
+		// Change the stories panel's order
+		case ActionSort:
+			if a.viewMode == ViewDashboard {
+				a.cycleStorySort()
+			}
+			return a, nil
+
+		// Jot a note against the selected story in progress.md
+		case "N":
+			if a.viewMode == ViewDashboard {
+				a.startProgressNote()
+			}
+			return a, nil
+
+		// Filter the stories panel
+		case "/":
+			if a.viewMode == ViewDashboard {
+				a.storyFilterInput = true
+			}
+			return a, nil
+
+		// Jump to a story by ID
+		case ":":
+			if a.viewMode == ViewDashboard || a.viewMode == ViewDiff {
+				a.startStoryJump()
+			}
+			return a, nil
+		case "esc":
+			if a.viewMode == ViewDashboard && a.storyFilter != "" {
+				a.clearStoryFilter()
+			}
+			if a.viewMode == ViewDecisions {
+				a.viewMode = ViewDashboard
+			}
+			return a, nil
+
+		// Reopen the selected story so the loop picks it up again
+		case "r":
+			if a.viewMode == ViewDashboard && a.canReopenSelectedStory() {
+				a.reopenSelectedStory()
+			}
+			return a, nil
+
+		// Open the selected story's tracking ticket
+		case "O":
+			if story := a.GetSelectedStory(); a.viewMode == ViewDashboard && story != nil && story.TicketURL != "" {
+				return a, openInBrowser(story.TicketURL)
+			}
+			return a, nil
+
+		// Start the loop at the selected story, ahead of the usual order
+		case "S":
+			if a.viewMode == ViewDashboard && a.canStartAtSelectedStory() {
+				return a.startAtSelectedStory()
+			}
+			return a, nil
+
+		// Loop controls across every PRD
+		case ActionStartAll:
+			if a.viewMode == ViewDashboard || a.viewMode == ViewLog || a.viewMode == ViewDiff || a.viewMode == ViewOverview {
+				return a.startAll()
+			}
+			return a, nil
+		case ActionPauseAll:
+			if a.viewMode == ViewDashboard || a.viewMode == ViewLog || a.viewMode == ViewDiff || a.viewMode == ViewOverview {
+				return a.pauseAll()
+			}
+			return a, nil
+
 		// Loop controls (work in both views)
-		case "s":
+		case ActionStart:
 			if a.state == StateReady || a.state == StatePaused || a.state == StateError || a.state == StateStopped {
+				a.pendingStartStory = ""
 				return a.startLoop()
 			}
-		case "p":
-			if a.state == StateRunning {
+		case ActionPause:
+			if a.state == StateRunning || a.state == StateQueued {
 				return a.pauseLoop()
 			}
-		case "x":
-			if a.state == StateRunning || a.state == StatePaused {
+		case ActionStop:
+			if a.state == StateRunning || a.state == StatePaused || a.state == StateQueued {
 				return a.stopLoopAndUpdate()
 			}
+		case ActionSkip:
+			if (a.viewMode == ViewDashboard || a.viewMode == ViewLog) && a.canSkipCurrentStory() {
+				a.skipCurrentStory()
+			}
 
 		// Navigation - different behavior based on view
 		case "up", "k":
@@ -663,9 +1075,7 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			} else if a.viewMode == ViewDiff {
 				a.diffViewer.ScrollUp()
 			} else {
-				if a.selectedIndex > 0 {
-					a.selectedIndex--
-				}
+				a.moveStorySelection(-1)
 			}
 		case "down", "j":
 			if a.viewMode == ViewLog {
@@ -673,9 +1083,7 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			} else if a.viewMode == ViewDiff {
 				a.diffViewer.ScrollDown()
 			} else {
-				if a.selectedIndex < len(a.prd.UserStories)-1 {
-					a.selectedIndex++
-				}
+				a.moveStorySelection(1)
 			}
 
 		// Log/diff scrolling
@@ -703,6 +1111,41 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			} else if a.viewMode == ViewDiff {
 				a.diffViewer.ScrollToBottom()
 			}
+		case "w":
+			if a.viewMode == ViewLog {
+				if path, err := a.exportLog(time.Now()); err != nil {
+					a.lastActivity = "Failed to export log: " + err.Error()
+				} else {
+					a.lastActivity = "Log written to " + path
+				}
+			}
+		case "u":
+			if a.viewMode == ViewDiff {
+				a.diffViewer.ToggleWorkingTree()
+			}
+		case "[", "]":
+			if a.viewMode == ViewDiff {
+				a.diffViewer.AdjustContext(msg.String() == "]")
+			}
+		case "v":
+			if a.viewMode == ViewDiff {
+				a.diffViewer.ToggleSideBySide()
+			}
+		case "T", "C", "R", "E":
+			if a.viewMode == ViewLog {
+				category, _ := logFilterCategoryForKey(msg.String())
+				a.logViewer.ToggleFilter(category)
+			}
+		case "F":
+			if a.viewMode == ViewLog {
+				a.logViewer.ClearFilter()
+			}
+		case "y":
+			if a.viewMode == ViewLog {
+				return a, copyToClipboard(a.logViewer.PlainText())
+			} else if a.viewMode == ViewDiff {
+				return a, copyToClipboard(a.diffViewer.PlainText())
+			}
 
 		// Max iterations control
 		case "+", "=":
@@ -734,8 +1177,17 @@ func (a App) startLoopForPRD(prdName string) (tea.Model, tea.Cmd) {
 		return a.doStartLoop(prdName, prdDir)
 	}
 
-	worktreePath := paths.WorktreeDir(a.baseDir, prdName)
-	relWorktreePath := paths.WorktreeDir(a.baseDir, prdName)
+	// A PRD configured to share another's branch runs in its worktree
+	if instance := a.manager.GetInstance(prdName); instance == nil || instance.WorktreeDir == "" {
+		if cfg := a.configFor(prdName); cfg != nil && cfg.Worktree.SharedBranch != "" {
+			if dir, owner := a.manager.WorktreeForBranch(cfg.Worktree.SharedBranch, prdName); dir != "" {
+				return a.joinWorktree(prdName, dir, cfg.Worktree.SharedBranch, owner, "")
+			}
+		}
+	}
+
+	worktreePath := a.worktreePathFor(prdName)
+	relWorktreePath := displayWorktreePath(a.baseDir, worktreePath)
 
 	// Determine dialog context
 	isProtected := git.IsProtectedBranch(branch)
@@ -756,7 +1208,19 @@ func (a App) startLoopForPRD(prdName string) (tea.Model, tea.Cmd) {
 	// Show the dialog only for protected branch or another PRD running
 	a.branchWarning.SetSize(a.width, a.height)
 	a.branchWarning.SetContext(branch, prdName, relWorktreePath)
+	a.branchWarning.SetSuggestedBranch(a.suggestedBranch(prdName))
+	joinBranch := a.suggestedBranch(prdName)
+	if cfg := a.configFor(prdName); cfg != nil && cfg.Worktree.SharedBranch != "" {
+		joinBranch = cfg.Worktree.SharedBranch
+	}
+	if dir, owner := a.manager.WorktreeForBranch(joinBranch, prdName); dir != "" {
+		a.branchWarning.SetJoinable(joinBranch, owner, displayWorktreePath(a.baseDir, dir))
+	} else {
+		a.branchWarning.SetJoinable("", "", "")
+	}
 	a.branchWarning.SetDialogContext(dialogCtx)
+	dirty, _ := git.IsDirty(a.baseDir)
+	a.branchWarning.SetDirty(dirty)
 	a.branchWarning.Reset()
 	a.pendingStartPRD = prdName
 	a.pendingWorktreePath = worktreePath
@@ -764,11 +1228,33 @@ func (a App) startLoopForPRD(prdName string) (tea.Model, tea.Cmd) {
 	return a, nil
 }
 
-// isAnotherPRDRunningInSameDir checks if another PRD is running in the project root (no worktree).
+// joinWorktree points a PRD at another PRD's worktree and branch and starts
+// it there. dialog names the start dialog the choice was made in, if any.
+func (a App) joinWorktree(prdName, worktreeDir, branch, owner, dialog string) (tea.Model, tea.Cmd) {
+	prdDir := paths.PRDDir(a.baseDir, prdName)
+	if a.manager.GetInstance(prdName) == nil {
+		a.manager.Register(prdName, filepath.Join(prdDir, "prd.json"))
+	}
+	a.manager.JoinWorktree(prdName, worktreeDir, branch)
+	if dialog != "" {
+		a.recordDecision(prdName, dialog, fmt.Sprintf("joined %s's worktree on %s", owner, branch))
+	}
+	if a.tabBar != nil {
+		a.tabBar.Refresh()
+	}
+	a.lastActivity = fmt.Sprintf("Joined %s's worktree on %s", owner, branch)
+	return a.doStartLoop(prdName, prdDir)
+}
+
+// isAnotherPRDRunningInSameDir checks if another PRD is running in the project root (no worktree),
+// or is running in the same worktree or on the same branch as this PRD.
 func (a *App) isAnotherPRDRunningInSameDir(prdName string) bool {
 	if a.manager == nil {
 		return false
 	}
+	if a.manager.RunningConflict(prdName) != "" {
+		return true
+	}
 	for _, inst := range a.manager.GetAllInstances() {
 		if inst.Name != prdName && inst.State == loop.LoopStateRunning && inst.WorktreeDir == "" {
 			return true
@@ -786,17 +1272,67 @@ func (a App) doStartLoop(prdName, prdDir string) (tea.Model, tea.Cmd) {
 		a.manager.Register(prdName, prdPath)
 	}
 
-	// Start the loop via manager
-	if err := a.manager.Start(prdName); err != nil {
+	// A targeted start only applies to the PRD it was requested for
+	startStory := ""
+	if prdName == a.prdName {
+		startStory = a.pendingStartStory
+	}
+	a.pendingStartStory = ""
+
+	// Show the first iteration's prompt for approval before anything runs
+	if a.shouldReviewPrompt(prdName) {
+		prompt, err := a.manager.Prompt(prdName, startStory)
+		if err != nil {
+			a.lastActivity = "Error starting loop: " + err.Error()
+			return a, nil
+		}
+		a.promptReview.Open(prdName, startStory, prompt)
+		a.viewMode = ViewPromptReview
+		return a, nil
+	}
+
+	return a.launchLoop(prdName, startStory, "")
+}
+
+// launchLoop starts a registered PRD's loop, optionally targeting a story
+// first and replacing the first iteration's prompt.
+func (a App) launchLoop(prdName, startStory, firstPrompt string) (tea.Model, tea.Cmd) {
+	var err error
+	switch {
+	case firstPrompt != "":
+		err = a.manager.StartWithPrompt(prdName, startStory, firstPrompt)
+	case startStory != "":
+		err = a.manager.StartAt(prdName, startStory)
+	default:
+		err = a.manager.Start(prdName)
+	}
+	if err != nil {
 		a.lastActivity = "Error starting loop: " + err.Error()
 		return a, nil
 	}
 
+	queued := false
+	if state, _, err := a.manager.GetState(prdName); err == nil && state == loop.LoopStateQueued {
+		queued = true
+	}
+
 	// Update state if this is the current PRD
+	if prdName == a.prdName && queued {
+		a.state = StateQueued
+		a.lastActivity = "Queued until a running loop finishes"
+		a.storyTimings = nil
+		a.currentStoryID = ""
+		a.currentStoryStart = time.Time{}
+		return a, nil
+	}
 	if prdName == a.prdName {
 		a.state = StateRunning
 		a.startTime = time.Now()
+		a.lastElapsed = 0
 		a.lastActivity = "Starting loop..."
+		if startStory != "" {
+			a.lastActivity = a.startStoryMessage(startStory)
+		}
 		// Reset story timing state
 		a.storyTimings = nil
 		a.currentStoryID = ""
@@ -804,6 +1340,10 @@ func (a App) doStartLoop(prdName, prdDir string) (tea.Model, tea.Cmd) {
 		return a, tickElapsed()
 	}
 
+	if queued {
+		a.lastActivity = "Queued loop for: " + prdName
+		return a, nil
+	}
 	a.lastActivity = "Started loop for: " + prdName
 	return a, nil
 }
@@ -815,9 +1355,22 @@ func (a App) pauseLoop() (tea.Model, tea.Cmd) {
 
 // pauseLoopForPRD pauses the loop for a specific PRD.
 func (a App) pauseLoopForPRD(prdName string) (tea.Model, tea.Cmd) {
+	queued := false
 	if a.manager != nil {
+		state, _, _ := a.manager.GetState(prdName)
+		queued = state == loop.LoopStateQueued
 		a.manager.Pause(prdName)
 	}
+	if queued {
+		// It never started, so it is paused straight away
+		if prdName == a.prdName {
+			a.state = StatePaused
+			a.lastActivity = "Paused"
+		} else {
+			a.lastActivity = "Paused " + prdName
+		}
+		return a, nil
+	}
 	if prdName == a.prdName {
 		a.lastActivity = "Pausing after current iteration..."
 	} else {
@@ -855,8 +1408,10 @@ func (a App) stopLoopAndUpdateForPRD(prdName string) (tea.Model, tea.Cmd) {
 	return a, nil
 }
 
-// stopAllLoops stops all running loops.
+// stopAllLoops stops all running loops, first saving the session so the next
+// launch can offer to resume it.
 func (a *App) stopAllLoops() {
+	a.saveSession()
 	if a.manager != nil {
 		a.manager.StopAll()
 	}
@@ -870,6 +1425,7 @@ func (a App) tryQuit() (tea.Model, tea.Cmd) {
 		a.viewMode = ViewQuitConfirm
 		a.quitConfirm.Reset()
 		a.quitConfirm.SetSize(a.width, a.height)
+		a.quitRequestedAt = time.Now()
 		return a, nil
 	}
 	a.stopAllLoops()
@@ -877,6 +1433,10 @@ func (a App) tryQuit() (tea.Model, tea.Cmd) {
 	return a, tea.Quit
 }
 
+// quitBypassWindow is how soon a second q must follow the first to skip the
+// quit confirmation and stop the loops.
+const quitBypassWindow = 2 * time.Second
+
 // handleQuitConfirmKeys handles keyboard input for the quit confirmation dialog.
 func (a App) handleQuitConfirmKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
@@ -889,19 +1449,91 @@ func (a App) handleQuitConfirmKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "down", "j":
 		a.quitConfirm.MoveDown()
 		return a, nil
+	case "q", "ctrl+c":
+		if time.Since(a.quitRequestedAt) < quitBypassWindow {
+			a.recordDecision("", "Quit with loops running", "quit and stopped all loops")
+			a.stopAllLoops()
+			a.stopWatcher()
+			return a, tea.Quit
+		}
+		a.quitRequestedAt = time.Now()
+		return a, nil
 	case "enter":
-		if a.quitConfirm.GetSelected() == QuitOptionQuit {
+		switch a.quitConfirm.GetSelected() {
+		case QuitOptionQuit:
+			a.recordDecision("", "Quit with loops running", "quit and stopped all loops")
 			a.stopAllLoops()
 			a.stopWatcher()
 			return a, tea.Quit
+		case QuitOptionBackground:
+			a.recordDecision("", "Quit with loops running", "quit and kept the loops running in the background")
+			a.saveSession()
+			a.stopWatcher()
+			a.PostExitAction = PostExitDetach
+			return a, tea.Quit
 		}
 		// Cancel
+		a.recordDecision("", "Quit with loops running", "kept running")
 		a.viewMode = a.previousViewMode
 		return a, nil
 	}
 	return a, nil
 }
 
+// handlePromptReviewKeys handles keyboard input for the prompt review dialog.
+func (a App) handlePromptReviewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter", "y":
+		a.viewMode = ViewDashboard
+		// Only pin the prompt when it was edited, so an unchanged review runs the usual prompt
+		firstPrompt := ""
+		if a.promptReview.Edited() {
+			firstPrompt = a.promptReview.Prompt()
+			a.recordDecision(a.promptReview.PRDName(), "Prompt review", "started with an edited prompt")
+		} else {
+			a.recordDecision(a.promptReview.PRDName(), "Prompt review", "started with the generated prompt")
+		}
+		return a.launchLoop(a.promptReview.PRDName(), a.promptReview.StartStory(), firstPrompt)
+	case "esc", "n":
+		a.recordDecision(a.promptReview.PRDName(), "Prompt review", "cancelled the start")
+		a.viewMode = ViewDashboard
+		a.lastActivity = "Start cancelled"
+		return a, nil
+	case "e":
+		return a, editPrompt(a.promptReview.Prompt())
+	case "up", "k":
+		a.promptReview.ScrollUp()
+	case "down", "j":
+		a.promptReview.ScrollDown()
+	case "ctrl+u", "pgup":
+		a.promptReview.PageUp()
+	case "ctrl+d", "pgdown":
+		a.promptReview.PageDown()
+	case "ctrl+c":
+		return a.tryQuit()
+	}
+	return a, nil
+}
+
+// handlePromptEdited takes the prompt back from the editor.
+func (a App) handlePromptEdited(msg promptEditedMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case msg.err != nil:
+		a.lastActivity = "Editor failed, prompt unchanged: " + msg.err.Error()
+	case strings.TrimSpace(msg.prompt) == "":
+		a.lastActivity = "Edited prompt was empty, keeping the original"
+	default:
+		a.promptReview.SetPrompt(msg.prompt)
+	}
+	return a, nil
+}
+
+// renderPromptReviewView renders the prompt review dialog.
+func (a *App) renderPromptReviewView() string {
+	a.promptReview.SetSize(a.width, a.height)
+	return a.promptReview.Render()
+}
+
 // renderQuitConfirmView renders the quit confirmation dialog.
 func (a *App) renderQuitConfirmView() string {
 	a.quitConfirm.SetSize(a.width, a.height)
@@ -919,7 +1551,15 @@ func (a App) handleLoopEvent(prdName string, event loop.Event) (tea.Model, tea.C
 		a.logViewer.AddEvent(event)
 	}
 
-	var autoActionCmd tea.Cmd
+	var autoActionCmd, webhookCmd, tickCmd tea.Cmd
+
+	// A queued loop got its slot
+	if isCurrentPRD && a.state == StateQueued && event.Type != loop.EventError {
+		a.state = StateRunning
+		a.startTime = time.Now()
+		a.lastElapsed = 0
+		tickCmd = tickElapsed()
+	}
 
 	switch event.Type {
 	case loop.EventIterationStart:
@@ -938,6 +1578,9 @@ func (a App) handleLoopEvent(prdName string, event loop.Event) (tea.Model, tea.C
 	case loop.EventToolStart:
 		if isCurrentPRD {
 			a.lastActivity = "Running tool: " + event.Tool
+			if file := toolFilePath(event); file != "" {
+				a.currentFile = file
+			}
 		}
 	case loop.EventToolResult:
 		if isCurrentPRD {
@@ -946,6 +1589,7 @@ func (a App) handleLoopEvent(prdName string, event loop.Event) (tea.Model, tea.C
 	case loop.EventStoryStarted:
 		if isCurrentPRD {
 			a.lastActivity = "Working on: " + event.StoryID
+			a.currentFile = ""
 			// Finalize previous story timing
 			a.finalizeStoryTiming()
 			// Start tracking the new story
@@ -956,15 +1600,23 @@ func (a App) handleLoopEvent(prdName string, event loop.Event) (tea.Model, tea.C
 		if isCurrentPRD {
 			a.state = StateComplete
 			a.lastActivity = "All stories complete!"
+			a.currentFile = ""
 			// Finalize the last story's timing
 			a.finalizeStoryTiming()
 			autoActionCmd = a.showCompletionScreen(prdName)
+			// With auto-actions pending, the webhook fires once they finish so it can include the PR
+			if !a.completionScreen.IsAutoActionRunning() {
+				webhookCmd = a.notifyWebhook(prdName, notify.StateComplete, "", nil)
+			}
 		} else {
 			// For background PRDs, trigger auto-push/PR without showing completion screen
 			autoActionCmd = a.runBackgroundAutoActions(prdName)
+			if autoActionCmd == nil {
+				webhookCmd = a.notifyWebhook(prdName, notify.StateComplete, "", nil)
+			}
 		}
-		// Trigger completion callback for any PRD
-		if a.onCompletion != nil {
+		// Trigger completion callback, subject to the configured notification scope
+		if a.onCompletion != nil && a.shouldNotifyCompletion(prdName) {
 			a.onCompletion(prdName)
 		}
 	case loop.EventMaxIterationsReached:
@@ -972,6 +1624,19 @@ func (a App) handleLoopEvent(prdName string, event loop.Event) (tea.Model, tea.C
 			a.state = StatePaused
 			a.lastActivity = "Max iterations reached"
 		}
+	case loop.EventPhaseComplete:
+		if isCurrentPRD {
+			a.lastActivity = "Phase complete: " + event.Text
+		}
+	case loop.EventBlocked:
+		if isCurrentPRD {
+			a.lastActivity = "Blocked: " + event.Text
+		}
+	case loop.EventAttention:
+		if isCurrentPRD {
+			a.state = StatePaused
+			a.lastActivity = event.Text + " — review, then press " + a.keys.Key(ActionStart) + " to continue"
+		}
 	case loop.EventError:
 		if isCurrentPRD {
 			a.state = StateError
@@ -980,18 +1645,27 @@ func (a App) handleLoopEvent(prdName string, event loop.Event) (tea.Model, tea.C
 				a.lastActivity = "Error: " + event.Err.Error()
 			}
 		}
-	case loop.EventRetrying:
+		if a.onError != nil {
+			a.onError(prdName, event.Err)
+		}
+		webhookCmd = a.notifyWebhook(prdName, notify.StateError, "", event.Err)
+	case loop.EventRetrying, loop.EventTimeout, loop.EventOutOfScope, loop.EventWarning:
 		if isCurrentPRD {
 			a.lastActivity = event.Text
 		}
+	case loop.EventHook, loop.EventStopCommit:
+		if isCurrentPRD {
+			a.lastActivity, _, _ = strings.Cut(event.Text, "\n")
+		}
 	}
 
 	// Reload PRD from disk only on meaningful state changes (not every event)
 	if isCurrentPRD {
 		switch event.Type {
-		case loop.EventStoryStarted, loop.EventComplete, loop.EventError, loop.EventMaxIterationsReached:
+		case loop.EventStoryStarted, loop.EventComplete, loop.EventError, loop.EventMaxIterationsReached, loop.EventPhaseComplete, loop.EventAttention:
 			if p, err := prd.LoadPRD(a.prdPath); err == nil {
 				a.prd = p
+				a.restoreInProgress()
 			}
 		}
 
@@ -1007,16 +1681,78 @@ func (a App) handleLoopEvent(prdName string, event loop.Event) (tea.Model, tea.C
 		}
 	}
 
+	if a.narrator != nil {
+		a.narrator.Narrate(prdName, event, a.narrationPRD(prdName, event))
+	}
+
 	// Refresh tab bar to show updated state
 	if a.tabBar != nil {
+		a.tabBar.Advance(prdName)
 		a.tabBar.Refresh()
 	}
-
-	// Continue listening for manager events, plus any auto-action commands
-	if autoActionCmd != nil {
-		return a, tea.Batch(a.listenForManagerEvents(), autoActionCmd)
+
+	// Continue listening for manager events, plus any auto-action or webhook commands
+	return a, tea.Batch(a.listenForManagerEvents(), autoActionCmd, webhookCmd, tickCmd)
+}
+
+// narrationPRD returns the PRD to narrate an event against, reloading it for
+// events that can follow a story completing.
+func (a *App) narrationPRD(prdName string, event loop.Event) *prd.PRD {
+	switch event.Type {
+	case loop.EventStoryStarted, loop.EventComplete, loop.EventError, loop.EventMaxIterationsReached, loop.EventAttention:
+	default:
+		return nil
+	}
+	if prdName == a.prdName {
+		return a.prd
+	}
+	if a.manager == nil {
+		return nil
+	}
+	instance := a.manager.GetInstance(prdName)
+	if instance == nil {
+		return nil
+	}
+	p, err := prd.LoadPRD(instance.PRDPath)
+	if err != nil {
+		return nil
+	}
+	return p
+}
+
+// toolFilePath returns the file path a tool event operates on, or empty if the
+// tool doesn't target a single file.
+func toolFilePath(event loop.Event) string {
+	switch event.Tool {
+	case "Read", "Edit", "MultiEdit", "Write", "NotebookEdit":
+		if path, ok := event.ToolInput["file_path"].(string); ok {
+			return path
+		}
+		if path, ok := event.ToolInput["notebook_path"].(string); ok {
+			return path
+		}
+	}
+	return ""
+}
+
+// displayCurrentFile returns the current file relative to the PRD's working
+// directory when possible, so the footer shows a short, recognizable path.
+func (a *App) displayCurrentFile() string {
+	if a.currentFile == "" {
+		return ""
+	}
+	workDir := a.baseDir
+	if a.manager != nil {
+		if instance := a.manager.GetInstance(a.prdName); instance != nil && instance.WorktreeDir != "" {
+			workDir = instance.WorktreeDir
+		}
+	}
+	if workDir != "" {
+		if rel, err := filepath.Rel(workDir, a.currentFile); err == nil && !strings.HasPrefix(rel, "..") {
+			return rel
+		}
 	}
-	return a, a.listenForManagerEvents()
+	return a.currentFile
 }
 
 // handleLoopFinished handles when a loop finishes.
@@ -1047,6 +1783,7 @@ func (a App) handleLoopFinished(prdName string, err error) (tea.Model, tea.Cmd)
 		// Reload PRD to reflect any changes
 		if p, err := prd.LoadPRD(a.prdPath); err == nil {
 			a.prd = p
+			a.restoreInProgress()
 		}
 	}
 
@@ -1055,6 +1792,8 @@ func (a App) handleLoopFinished(prdName string, err error) (tea.Model, tea.Cmd)
 
 // View renders the TUI.
 func (a App) View() string {
+	defer a.recoverPanic()
+
 	switch a.viewMode {
 	case ViewLog:
 		return a.renderLogView()
@@ -1074,6 +1813,12 @@ func (a App) View() string {
 		return a.renderSettingsView()
 	case ViewQuitConfirm:
 		return a.renderQuitConfirmView()
+	case ViewOverview:
+		return a.renderOverviewView()
+	case ViewDecisions:
+		return a.renderDecisionsView()
+	case ViewPromptReview:
+		return a.renderPromptReviewView()
 	default:
 		return a.renderDashboard()
 	}
@@ -1112,8 +1857,10 @@ func (a App) handleBranchWarningKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	switch msg.String() {
 	case "esc":
+		a.recordDecision(a.pendingStartPRD, branchWarningDialog(a.branchWarning), "cancelled")
 		a.viewMode = ViewDashboard
 		a.pendingStartPRD = ""
+		a.pendingStartStory = ""
 		a.pendingWorktreePath = ""
 		a.lastActivity = "Cancelled"
 		return a, nil
@@ -1134,27 +1881,60 @@ func (a App) handleBranchWarningKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return a, nil
 
+	case "s":
+		a.branchWarning.ToggleStash()
+		return a, nil
+
 	case "enter":
+		// Refuse branch names git would reject, leaving the dialog open to fix it
+		if opt := a.branchWarning.GetSelectedOption(); opt == BranchOptionCreateWorktree || opt == BranchOptionCreateBranch {
+			if err := git.ValidateBranchName(a.branchWarning.GetSuggestedBranch()); err != nil {
+				a.branchWarning.SetBranchError(err.Error() + " (press e to edit)")
+				return a, nil
+			}
+		}
+
 		prdName := a.pendingStartPRD
 		prdDir := paths.PRDDir(a.baseDir, prdName)
+		dialog := branchWarningDialog(a.branchWarning)
 		a.pendingStartPRD = ""
 		a.pendingWorktreePath = ""
 		a.viewMode = ViewDashboard
 
+		// Put uncommitted changes aside so they don't go missing behind a
+		// worktree or get committed on the new branch by the agent
+		if a.branchWarning.ShouldStash() {
+			if err := git.Stash(a.baseDir, "chief: before starting "+prdName); err != nil {
+				a.lastActivity = "Error: " + err.Error()
+				return a, nil
+			}
+			a.recordDecision(prdName, dialog, "stashed uncommitted changes")
+		}
+
 		switch a.branchWarning.GetSelectedOption() {
+		case BranchOptionJoinWorktree:
+			branch := a.branchWarning.JoinBranch()
+			dir, owner := a.manager.WorktreeForBranch(branch, prdName)
+			if dir == "" {
+				a.lastActivity = "No worktree on " + branch + " to join any more"
+				return a, nil
+			}
+			return a.joinWorktree(prdName, dir, branch, owner, dialog)
+
 		case BranchOptionCreateWorktree:
 			branchName := a.branchWarning.GetSuggestedBranch()
-			worktreePath := paths.WorktreeDir(a.baseDir, prdName)
-			relWorktreePath := paths.WorktreeDir(a.baseDir, prdName)
+			worktreePath := a.worktreePathFor(prdName)
+			relWorktreePath := displayWorktreePath(a.baseDir, worktreePath)
 
-			// Detect default branch for display
+			// Detect the branch it starts from for display
 			defaultBranch := "main"
-			if db, err := git.GetDefaultBranch(a.baseDir); err == nil {
+			if db, err := git.BaseRef(a.baseDir, a.configuredBaseBranch(prdName)); err == nil {
 				defaultBranch = db
 			}
+			a.recordDecision(prdName, dialog, fmt.Sprintf("created worktree %s off %s", branchName, defaultBranch))
 
 			// Configure and show the spinner
-			a.worktreeSpinner.Configure(prdName, branchName, defaultBranch, relWorktreePath, a.config.Worktree.Setup)
+			a.worktreeSpinner.Configure(prdName, branchName, defaultBranch, relWorktreePath, a.configFor(prdName).Worktree.Setup)
 			a.worktreeSpinner.SetSize(a.width, a.height)
 			a.pendingStartPRD = prdName
 			a.pendingWorktreePath = worktreePath
@@ -1169,6 +1949,13 @@ func (a App) handleBranchWarningKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		case BranchOptionCreateBranch:
 			// Create the branch with (possibly edited) name
 			branchName := a.branchWarning.GetSuggestedBranch()
+			a.recordDecision(prdName, dialog, "created branch "+branchName)
+			if a.signCommits(prdName) {
+				if err := git.CheckSigning(a.baseDir); err != nil {
+					a.lastActivity = "Error: " + err.Error()
+					return a, nil
+				}
+			}
 			if err := git.CreateBranch(a.baseDir, branchName); err != nil {
 				a.lastActivity = "Error creating branch: " + err.Error()
 				return a, nil
@@ -1183,9 +1970,16 @@ func (a App) handleBranchWarningKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 		case BranchOptionContinue:
 			// Continue on current branch / run in same directory
+			if a.branchWarning.GetDialogContext() == DialogAnotherPRDRunning {
+				a.recordDecision(prdName, dialog, "ran in the same directory anyway")
+			} else {
+				a.recordDecision(prdName, dialog, "continued on "+a.branchWarning.currentBranch)
+			}
 			return a.doStartLoop(prdName, prdDir)
 
 		case BranchOptionCancel:
+			a.recordDecision(prdName, dialog, "cancelled")
+			a.pendingStartStory = ""
 			a.lastActivity = "Cancelled"
 			return a, nil
 		}
@@ -1203,13 +1997,31 @@ func (a *App) renderWorktreeSpinnerView() string {
 // handleWorktreeSpinnerKeys handles keyboard input for the worktree spinner.
 func (a App) handleWorktreeSpinnerKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
+	case "r":
+		// Run a failed setup command again in the worktree already created
+		if a.worktreeSpinner.SetupFailed() {
+			a.recordDecision(a.pendingStartPRD, "Worktree setup failed", "retried setup")
+			a.worktreeSpinner.RetryStep()
+			return a, a.runWorktreeStep(SpinnerStepRunSetup, a.baseDir, a.pendingWorktreePath, "")
+		}
+	case "c":
+		// Keep the worktree and start the loop without the setup command
+		if a.worktreeSpinner.SetupFailed() {
+			a.recordDecision(a.pendingStartPRD, "Worktree setup failed", "continued without setup")
+			a.worktreeSpinner.SkipSetup()
+			return a.finishWorktreeSetup()
+		}
 	case "esc":
+		if a.worktreeSpinner.SetupFailed() {
+			a.recordDecision(a.pendingStartPRD, "Worktree setup failed", "cancelled and removed the worktree")
+		}
 		// Cancel setup and clean up
 		a.worktreeSpinner.Cancel()
 		a.cleanupWorktreeSetup()
 		a.viewMode = ViewDashboard
 		a.lastActivity = "Worktree setup cancelled"
 		a.pendingStartPRD = ""
+		a.pendingStartStory = ""
 		a.pendingWorktreePath = ""
 		return a, nil
 	}
@@ -1234,10 +2046,12 @@ func (a *App) finalizeStoryTiming() {
 	}
 	duration := time.Since(a.currentStoryStart)
 	title := a.currentStoryID
-	// Look up the story title from the PRD
+	var estimate time.Duration
+	// Look up the story title and estimate from the PRD
 	for _, story := range a.prd.UserStories {
 		if story.ID == a.currentStoryID {
 			title = story.Title
+			estimate = story.Estimate()
 			break
 		}
 	}
@@ -1245,6 +2059,7 @@ func (a *App) finalizeStoryTiming() {
 		StoryID:  a.currentStoryID,
 		Title:    title,
 		Duration: duration,
+		Estimate: estimate,
 	})
 	a.currentStoryID = ""
 	a.currentStoryStart = time.Time{}
@@ -1256,9 +2071,12 @@ func (a *App) showCompletionScreen(prdName string) tea.Cmd {
 	// Count completed stories
 	completed := 0
 	total := len(a.prd.UserStories)
+	var skipped []string
 	for _, story := range a.prd.UserStories {
 		if story.Passes {
 			completed++
+		} else if story.Skipped {
+			skipped = append(skipped, story.ID)
 		}
 	}
 
@@ -1269,26 +2087,41 @@ func (a *App) showCompletionScreen(prdName string) tea.Cmd {
 	}
 
 	// Count commits on the branch
-	commitCount := 0
-	if branch != "" {
-		commitCount = git.CommitCount(a.baseDir, branch)
-	}
+	a.completionFacts = completionFactsFor(a.baseDir, a.configuredBaseBranch(prdName), a.prd, branch)
+	commitCount := a.completionFacts.commits
 
 	// Check if auto-actions are configured
-	hasAutoActions := a.config != nil && (a.config.OnComplete.Push || a.config.OnComplete.CreatePR)
+	cfg := a.configFor(prdName)
+	hasAutoActions := cfg != nil && (cfg.OnComplete.Push || cfg.OnComplete.CreatePR)
 
 	totalDuration := a.GetElapsedTime()
+	if a.config != nil {
+		a.completionScreen.SetConfettiTheme(ConfettiThemeFromConfig(a.config.Confetti))
+	}
 	a.completionScreen.Configure(prdName, completed, total, branch, commitCount, hasAutoActions, totalDuration, a.storyTimings)
+	a.completionScreen.SetUsage(a.usageSummary(prdName))
+	a.completionScreen.SetSkipped(skipped)
+	if cfg != nil && (cfg.OnComplete.Push || cfg.OnComplete.CreatePR) {
+		a.completionScreen.SetRemote(a.gitRemote(prdName), a.prBaseBranch(prdName))
+	}
 	a.completionScreen.SetSize(a.width, a.height)
 	a.viewMode = ViewCompletion
 
 	// Always start confetti tick
 	cmds := []tea.Cmd{tickConfetti()}
 
-	// Trigger auto-push if configured and branch is set
-	if a.config != nil && a.config.OnComplete.Push && branch != "" {
-		a.completionScreen.SetPushInProgress()
-		cmds = append(cmds, tickCompletionSpinner(), a.runAutoPush())
+	// Trigger auto-push if configured and branch is set, unless there is
+	// nothing worth pushing
+	if cfg != nil && cfg.OnComplete.Push && branch != "" {
+		if reason := pushSkipReason(a.completionFacts); reason != "" {
+			a.completionScreen.SetPushSkipped(reason)
+			if cfg.OnComplete.CreatePR {
+				a.completionScreen.SetPRSkipped("the branch was not pushed")
+			}
+		} else {
+			a.completionScreen.SetPushInProgress()
+			cmds = append(cmds, tickCompletionSpinner(), a.runAutoPush())
+		}
 	}
 
 	// If only PR is configured (no push), we can't create a PR without pushing first
@@ -1301,11 +2134,12 @@ type backgroundAutoActionResultMsg struct {
 	prdName string
 	action  string // "push" or "pr"
 	err     error
+	prURL   string // Only set for successful PR creation
 }
 
 // runBackgroundAutoActions triggers auto-push/PR for a background PRD that just completed.
 func (a *App) runBackgroundAutoActions(prdName string) tea.Cmd {
-	if a.config == nil || !a.config.OnComplete.Push {
+	if cfg := a.configFor(prdName); cfg == nil || !cfg.OnComplete.Push {
 		return nil
 	}
 
@@ -1313,6 +2147,10 @@ func (a *App) runBackgroundAutoActions(prdName string) tea.Cmd {
 	if instance == nil || instance.Branch == "" {
 		return nil
 	}
+	p, _ := prd.LoadPRD(instance.PRDPath)
+	if pushSkipReason(completionFactsFor(a.baseDir, a.configuredBaseBranch(prdName), p, instance.Branch)) != "" {
+		return nil
+	}
 
 	branch := instance.Branch
 	dir := a.baseDir
@@ -1320,8 +2158,9 @@ func (a *App) runBackgroundAutoActions(prdName string) tea.Cmd {
 		dir = instance.WorktreeDir
 	}
 
+	remote := a.gitRemote(prdName)
 	return func() tea.Msg {
-		if err := git.PushBranch(dir, branch); err != nil {
+		if err := git.PushBranch(dir, remote, branch); err != nil {
 			return backgroundAutoActionResultMsg{prdName: prdName, action: "push", err: err}
 		}
 		return backgroundAutoActionResultMsg{prdName: prdName, action: "push"}
@@ -1334,27 +2173,31 @@ func (a App) handleAutoActionResult(msg autoActionResultMsg) (tea.Model, tea.Cmd
 	case "push":
 		if msg.err != nil {
 			a.completionScreen.SetPushError(msg.err.Error())
-			return a, nil
+			return a, a.notifyWebhook(a.completionScreen.PRDName(), notify.StateComplete, "", msg.err)
 		}
 		a.completionScreen.SetPushSuccess()
 
 		// If PR creation is configured, start it now
-		if a.config != nil && a.config.OnComplete.CreatePR && a.completionScreen.HasBranch() {
+		if cfg := a.configFor(a.completionScreen.PRDName()); cfg != nil && cfg.OnComplete.CreatePR && a.completionScreen.HasBranch() {
+			if reason := prSkipReason(cfg.OnComplete, a.completionFacts); reason != "" {
+				a.completionScreen.SetPRSkipped(reason)
+				return a, a.notifyWebhook(a.completionScreen.PRDName(), notify.StateComplete, "", nil)
+			}
 			a.completionScreen.SetPRInProgress()
 			return a, tea.Batch(
 				tickCompletionSpinner(),
 				a.runAutoCreatePR(),
 			)
 		}
-		return a, nil
+		return a, a.notifyWebhook(a.completionScreen.PRDName(), notify.StateComplete, "", nil)
 
 	case "pr":
 		if msg.err != nil {
 			a.completionScreen.SetPRError(msg.err.Error())
-			return a, nil
+			return a, a.notifyWebhook(a.completionScreen.PRDName(), notify.StateComplete, "", msg.err)
 		}
-		a.completionScreen.SetPRSuccess(msg.prURL, msg.prTitle)
-		return a, nil
+		a.completionScreen.SetPRSuccess(msg.prURL, msg.prTitle, msg.prOpts.Draft, msg.prOpts.Reviewers)
+		return a, a.notifyWebhook(a.completionScreen.PRDName(), notify.StateComplete, msg.prURL, nil)
 	}
 	return a, nil
 }
@@ -1362,32 +2205,93 @@ func (a App) handleAutoActionResult(msg autoActionResultMsg) (tea.Model, tea.Cmd
 // handleBackgroundAutoAction handles auto-action results for background PRDs.
 func (a App) handleBackgroundAutoAction(msg backgroundAutoActionResultMsg) (tea.Model, tea.Cmd) {
 	if msg.err != nil {
-		// Log error but don't block - background action failed silently
-		return a, nil
+		// Background action failed silently; only the webhook hears about it
+		return a, a.notifyWebhook(msg.prdName, notify.StateComplete, "", msg.err)
 	}
 
-	if msg.action == "push" && a.config != nil && a.config.OnComplete.CreatePR {
+	if cfg := a.configFor(msg.prdName); msg.action == "push" && cfg != nil && cfg.OnComplete.CreatePR {
 		// Chain PR creation after successful push
 		instance := a.manager.GetInstance(msg.prdName)
-		if instance != nil && instance.Branch != "" {
+		var facts completionFacts
+		if instance != nil {
+			p, _ := prd.LoadPRD(instance.PRDPath)
+			facts = completionFactsFor(a.baseDir, cfg.Git.BaseBranch, p, instance.Branch)
+		}
+		if instance != nil && instance.Branch != "" && prSkipReason(cfg.OnComplete, facts) == "" {
 			prdName := msg.prdName
 			branch := instance.Branch
 			dir := a.baseDir
+			provider := a.gitProvider()
+			opts := a.prOptions(prdName)
 			prdPath := paths.PRDPath(a.baseDir, prdName)
+			templatePath := paths.PRTemplatePath(a.baseDir)
 			return a, func() tea.Msg {
 				p, err := prd.LoadPRD(prdPath)
 				if err != nil {
 					return backgroundAutoActionResultMsg{prdName: prdName, action: "pr", err: err}
 				}
 				title := git.PRTitleFromPRD(prdName, p)
-				body := git.PRBodyFromPRD(p)
-				_, err = git.CreatePR(dir, branch, title, body)
-				return backgroundAutoActionResultMsg{prdName: prdName, action: "pr", err: err}
+				body, err := git.PRBody(templatePath, p, prdPath)
+				if err != nil {
+					return backgroundAutoActionResultMsg{prdName: prdName, action: "pr", err: err}
+				}
+				url, err := git.CreatePR(provider, dir, branch, title, body, opts)
+				return backgroundAutoActionResultMsg{prdName: prdName, action: "pr", err: err, prURL: url}
 			}
 		}
 	}
 
-	return a, nil
+	// The chain is done: a push without PR creation, or the PR itself
+	return a, a.notifyWebhook(msg.prdName, notify.StateComplete, msg.prURL, nil)
+}
+
+// completionFacts is what the completion auto-actions are guarded on.
+type completionFacts struct {
+	completed    int  // Stories that pass
+	total        int  // Stories in the PRD
+	commits      int  // Commits on the branch that are not on the base branch
+	commitsKnown bool // Whether commits could be counted; if not, the actions run anyway
+}
+
+// completionFactsFor gathers the completion facts for a PRD and its branch,
+// counting commits against base (empty means the default branch). p may be
+// nil if the PRD couldn't be loaded.
+func completionFactsFor(baseDir, base string, p *prd.PRD, branch string) completionFacts {
+	var facts completionFacts
+	if p != nil {
+		facts.total = len(p.UserStories)
+		for _, story := range p.UserStories {
+			if story.Passes {
+				facts.completed++
+			}
+		}
+	}
+	if branch != "" {
+		commits, err := git.CommitsAhead(baseDir, base, branch)
+		facts.commits, facts.commitsKnown = commits, err == nil
+	}
+	return facts
+}
+
+// pushSkipReason returns why the completion push should be skipped, or ""
+// to push.
+func pushSkipReason(facts completionFacts) string {
+	if facts.commitsKnown && facts.commits == 0 {
+		return "no new commits on the branch"
+	}
+	return ""
+}
+
+// prSkipReason returns why the completion pull request should be skipped,
+// or "" to open it.
+func prSkipReason(onComplete config.OnCompleteConfig, facts completionFacts) string {
+	if reason := pushSkipReason(facts); reason != "" {
+		return reason
+	}
+	if onComplete.PRRequiresAllPass && facts.completed < facts.total {
+		return fmt.Sprintf("only %d/%d stories pass", facts.completed, facts.total)
+	}
+	return ""
 }
 
 // runAutoPush returns a tea.Cmd that pushes the branch in the background.
@@ -1398,8 +2302,9 @@ func (a *App) runAutoPush() tea.Cmd {
 	if instance := a.manager.GetInstance(a.completionScreen.PRDName()); instance != nil && instance.WorktreeDir != "" {
 		dir = instance.WorktreeDir
 	}
+	remote := a.gitRemote(a.completionScreen.PRDName())
 	return func() tea.Msg {
-		err := git.PushBranch(dir, branch)
+		err := git.PushBranch(dir, remote, branch)
 		return autoActionResultMsg{action: "push", err: err}
 	}
 }
@@ -1409,21 +2314,27 @@ func (a *App) runAutoCreatePR() tea.Cmd {
 	prdName := a.completionScreen.PRDName()
 	branch := a.completionScreen.Branch()
 	dir := a.baseDir
+	provider := a.gitProvider()
+	opts := a.prOptions(prdName)
 
 	// Load the PRD to generate PR content
 	prdPath := paths.PRDPath(a.baseDir, prdName)
+	templatePath := paths.PRTemplatePath(a.baseDir)
 	return func() tea.Msg {
 		p, err := prd.LoadPRD(prdPath)
 		if err != nil {
 			return autoActionResultMsg{action: "pr", err: fmt.Errorf("failed to load PRD: %s", err.Error())}
 		}
 		title := git.PRTitleFromPRD(prdName, p)
-		body := git.PRBodyFromPRD(p)
-		url, err := git.CreatePR(dir, branch, title, body)
+		body, err := git.PRBody(templatePath, p, prdPath)
+		if err != nil {
+			return autoActionResultMsg{action: "pr", err: err}
+		}
+		url, err := git.CreatePR(provider, dir, branch, title, body, opts)
 		if err != nil {
 			return autoActionResultMsg{action: "pr", err: err}
 		}
-		return autoActionResultMsg{action: "pr", prURL: url, prTitle: title}
+		return autoActionResultMsg{action: "pr", prURL: url, prTitle: title, prOpts: opts}
 	}
 }
 
@@ -1451,9 +2362,11 @@ func (a App) handleSettingsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	if a.settingsOverlay.IsEditing() {
 		switch msg.String() {
 		case "enter":
-			a.settingsOverlay.ConfirmEdit()
-			a.settingsOverlay.ApplyToConfig(a.config)
-			_ = config.Save(a.baseDir, a.config)
+			if err := a.settingsOverlay.ConfirmEdit(); err != nil {
+				// The error is shown in the overlay until the command is fixed
+				return a, nil
+			}
+			a.saveSettings()
 			return a, nil
 		case "esc":
 			a.settingsOverlay.CancelEdit()
@@ -1490,14 +2403,14 @@ func (a App) handleSettingsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		case SettingsItemBool:
 			key, newVal := a.settingsOverlay.ToggleBool()
 			if key == "onComplete.createPR" && newVal {
-				// Validate GH CLI asynchronously
+				// Validate the provider's CLI asynchronously
+				provider := a.gitProvider()
 				return a, func() tea.Msg {
-					installed, authenticated, err := git.CheckGHCLI()
+					installed, authenticated, err := git.CheckProviderCLI(provider)
 					return settingsGHCheckResultMsg{installed: installed, authenticated: authenticated, err: err}
 				}
 			}
-			a.settingsOverlay.ApplyToConfig(a.config)
-			_ = config.Save(a.baseDir, a.config)
+			a.saveSettings()
 			return a, nil
 		case SettingsItemString:
 			a.settingsOverlay.StartEditing()
@@ -1517,20 +2430,21 @@ func (a App) handleSettingsGHCheck(msg settingsGHCheckResultMsg) (tea.Model, tea
 	if msg.err != nil || !msg.installed || !msg.authenticated {
 		// Validation failed - revert toggle and show error
 		a.settingsOverlay.RevertToggle()
-		errMsg := "GitHub CLI (gh) is not installed"
+		cli := git.CLIFor(a.gitProvider())
+		errMsg := fmt.Sprintf("%s (%s) is not installed", cli.Name, cli.Binary)
 		if msg.installed && !msg.authenticated {
-			errMsg = "GitHub CLI (gh) is not authenticated. Run: gh auth login"
+			errMsg = fmt.Sprintf("%s (%s) is not authenticated. Run: %s auth login", cli.Name, cli.Binary, cli.Binary)
 		}
 		if msg.err != nil {
 			errMsg = msg.err.Error()
 		}
 		a.settingsOverlay.SetGHError(errMsg)
+		a.recordDecision("", cli.Name+" check", "pull request creation left off: "+errMsg)
 		return a, nil
 	}
 
 	// Validation passed - save the config
-	a.settingsOverlay.ApplyToConfig(a.config)
-	_ = config.Save(a.baseDir, a.config)
+	a.saveSettings()
 	return a, nil
 }
 
@@ -1552,14 +2466,16 @@ func (a App) handleCompletionKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if a.completionScreen.HasBranch() {
 			branch := a.completionScreen.Branch()
 			baseDir := a.baseDir
+			strategy := a.integrationStrategy()
+			sign := a.signCommits(a.completionScreen.PRDName())
 			a.viewMode = ViewDashboard
 			return a, func() tea.Msg {
-				conflicts, err := git.MergeBranch(baseDir, branch)
+				conflicts, err := git.MergeBranch(baseDir, branch, strategy, sign)
 				if err != nil {
-					return mergeResultMsg{branch: branch, conflicts: conflicts, err: err}
+					return mergeResultMsg{branch: branch, strategy: strategy, conflicts: conflicts, err: err}
 				}
-				output := parseMergeSuccessMessage(baseDir, branch)
-				return mergeResultMsg{branch: branch, output: output}
+				output := parseMergeSuccessMessage(baseDir, branch, strategy)
+				return mergeResultMsg{branch: branch, strategy: strategy, output: output}
 			}
 		}
 		return a, nil
@@ -1624,16 +2540,26 @@ func tickElapsed() tea.Cmd {
 func (a *App) runWorktreeStep(step WorktreeSpinnerStep, baseDir, worktreePath, branchName string) tea.Cmd {
 	switch step {
 	case SpinnerStepCreateBranch:
+		cfg := a.configFor(a.pendingStartPRD)
+		shallow := cfg != nil && cfg.Worktree.Shallow
+		base := a.configuredBaseBranch(a.pendingStartPRD)
+		sign := a.signCommits(a.pendingStartPRD)
 		return func() tea.Msg {
+			// Catch a broken signing setup before the agent's first commit
+			if sign {
+				if err := git.CheckSigning(baseDir); err != nil {
+					return worktreeStepResultMsg{step: SpinnerStepCreateBranch, err: err}
+				}
+			}
 			// CreateWorktree handles both branch creation and worktree addition
-			if err := git.CreateWorktree(baseDir, worktreePath, branchName); err != nil {
+			if err := git.CreateWorktree(baseDir, worktreePath, branchName, base, shallow); err != nil {
 				return worktreeStepResultMsg{step: SpinnerStepCreateBranch, err: err}
 			}
 			return worktreeStepResultMsg{step: SpinnerStepCreateBranch}
 		}
 
 	case SpinnerStepRunSetup:
-		setupCmd := a.config.Worktree.Setup
+		setupCmd := a.configFor(a.pendingStartPRD).Worktree.Setup
 		return func() tea.Msg {
 			cmd := exec.Command("sh", "-c", setupCmd)
 			cmd.Dir = worktreePath
@@ -1715,14 +2641,22 @@ func (a App) handleMergeResult(msg mergeResultMsg) (tea.Model, tea.Cmd) {
 			Message:   fmt.Sprintf("Failed to merge %s into current branch", msg.branch),
 			Conflicts: msg.conflicts,
 			Branch:    msg.branch,
+			Strategy:  msg.strategy,
 		})
+		choice := "failed"
+		if len(msg.conflicts) > 0 {
+			choice = "stopped on conflicts in " + strings.Join(msg.conflicts, ", ")
+		}
+		a.recordDecision("", "Merge "+msg.branch, choice)
 	} else {
 		a.picker.SetMergeResult(&MergeResult{
-			Success: true,
-			Message: msg.output,
-			Branch:  msg.branch,
+			Success:  true,
+			Message:  msg.output,
+			Branch:   msg.branch,
+			Strategy: msg.strategy,
 		})
-		a.lastActivity = fmt.Sprintf("Merged %s", msg.branch)
+		a.lastActivity = msg.output
+		a.recordDecision("", "Merge", msg.output)
 	}
 	// Switch to picker to show the merge result if not already there
 	if a.viewMode != ViewPicker {
@@ -1753,15 +2687,28 @@ func (a App) handleCleanConfirmationKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 		option := a.picker.GetCleanOption()
 		if option == CleanOptionCancel {
+			a.recordDecision(cc.EntryName, "Clean worktree", "cancelled")
 			a.picker.CancelCleanConfirmation()
 			return a, nil
 		}
+		if option == CleanOptionRemoveAll {
+			a.recordDecision(cc.EntryName, "Clean worktree", "removed worktree and branch "+cc.Branch)
+		} else {
+			a.recordDecision(cc.EntryName, "Clean worktree", "removed worktree, kept branch")
+		}
 
 		prdName := cc.EntryName
 		branch := cc.Branch
 		clearBranch := option == CleanOptionRemoveAll
+		sharedWith := cc.SharedWith
 		baseDir := a.baseDir
-		worktreePath := git.WorktreePathForPRD(baseDir, prdName)
+		worktreePath := a.worktreePathFor(prdName)
+		if a.manager != nil {
+			if instance := a.manager.GetInstance(prdName); instance != nil && instance.WorktreeDir != "" {
+				// A PRD that joined another's worktree has none at its own path
+				worktreePath = instance.WorktreeDir
+			}
+		}
 
 		return a, func() tea.Msg {
 			// Remove the worktree
@@ -1794,6 +2741,7 @@ func (a App) handleCleanConfirmationKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				success:     true,
 				message:     msg,
 				clearBranch: clearBranch,
+				sharedWith:  sharedWith,
 			}
 		}
 	}
@@ -1813,6 +2761,9 @@ func (a App) handleCleanResult(msg cleanResultMsg) (tea.Model, tea.Cmd) {
 		// Clear worktree info from manager
 		if a.manager != nil {
 			a.manager.ClearWorktreeInfo(msg.prdName, msg.clearBranch)
+			for _, name := range msg.sharedWith {
+				a.manager.ClearWorktreeInfo(name, msg.clearBranch)
+			}
 		}
 		a.picker.Refresh()
 		a.lastActivity = fmt.Sprintf("Cleaned worktree for %s", msg.prdName)
@@ -1838,7 +2789,7 @@ func (a App) handlePickerKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		case "enter":
 			name := a.picker.GetInputValue()
 			if name != "" {
-				// Launch interactive Claude session to create the PRD
+				// Launch an interactive agent session to create the PRD
 				a.picker.CancelInputMode()
 				a.stopAllLoops()
 				a.stopWatcher()
@@ -1880,7 +2831,13 @@ func (a App) handlePickerKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	}
 
 	// Normal picker mode
-	switch msg.String() {
+	switch a.keys.Resolve(msg.String()) {
+	case ActionSort:
+		// The picker has nothing to sort, so the sort key archives there
+		if a.picker.CanArchive() {
+			return a.archiveSelectedPRD()
+		}
+		return a, nil
 	case "esc", "l":
 		a.viewMode = ViewDashboard
 		return a, nil
@@ -1900,11 +2857,11 @@ func (a App) handlePickerKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return a.switchToPRD(entry.Name, entry.Path)
 		}
 		return a, nil
-	case "n":
+	case ActionNew:
 		a.picker.StartInputMode()
 		return a, nil
-	case "e":
-		// Edit the selected PRD - launch interactive Claude session
+	case ActionEdit:
+		// Edit the selected PRD - launch an interactive agent session
 		entry := a.picker.GetSelectedEntry()
 		if entry != nil && entry.LoadError == nil {
 			a.stopAllLoops()
@@ -1916,7 +2873,7 @@ func (a App) handlePickerKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return a, nil
 
 	// Loop controls for the SELECTED PRD (not current)
-	case "s":
+	case ActionStart:
 		entry := a.picker.GetSelectedEntry()
 		if entry != nil && entry.LoadError == nil {
 			state := entry.LoopState
@@ -1928,19 +2885,19 @@ func (a App) handlePickerKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 		}
 		return a, nil
-	case "p":
+	case ActionPause:
 		entry := a.picker.GetSelectedEntry()
-		if entry != nil && entry.LoopState == loop.LoopStateRunning {
+		if entry != nil && (entry.LoopState == loop.LoopStateRunning || entry.LoopState == loop.LoopStateQueued) {
 			model, cmd := a.pauseLoopForPRD(entry.Name)
 			a.picker.Refresh()
 			return model, cmd
 		}
 		return a, nil
-	case "x":
+	case ActionStop:
 		entry := a.picker.GetSelectedEntry()
 		if entry != nil {
 			state := entry.LoopState
-			if state == loop.LoopStateRunning || state == loop.LoopStatePaused {
+			if state == loop.LoopStateRunning || state == loop.LoopStatePaused || state == loop.LoopStateQueued {
 				model, cmd := a.stopLoopAndUpdateForPRD(entry.Name)
 				a.picker.Refresh()
 				return model, cmd
@@ -1952,16 +2909,25 @@ func (a App) handlePickerKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		// Merge completed PRD's branch
 		if a.picker.CanMerge() {
 			entry := a.picker.GetSelectedEntry()
+			if active := a.activeSharers(entry.Name); len(active) > 0 {
+				a.picker.SetMergeResult(&MergeResult{
+					Message: fmt.Sprintf("%s still working on %s; wait for it to finish before merging", strings.Join(active, ", "), entry.Branch),
+					Branch:  entry.Branch,
+				})
+				return a, nil
+			}
 			branch := entry.Branch
 			baseDir := a.baseDir
+			strategy := a.integrationStrategy()
+			sign := a.signCommits(entry.Name)
 			return a, func() tea.Msg {
-				conflicts, err := git.MergeBranch(baseDir, branch)
+				conflicts, err := git.MergeBranch(baseDir, branch, strategy, sign)
 				if err != nil {
-					return mergeResultMsg{branch: branch, conflicts: conflicts, err: err}
+					return mergeResultMsg{branch: branch, strategy: strategy, conflicts: conflicts, err: err}
 				}
 				// Build success message with merge details
-				output := parseMergeSuccessMessage(baseDir, branch)
-				return mergeResultMsg{branch: branch, output: output}
+				output := parseMergeSuccessMessage(baseDir, branch, strategy)
+				return mergeResultMsg{branch: branch, strategy: strategy, output: output}
 			}
 		}
 		return a, nil
@@ -1969,6 +2935,13 @@ func (a App) handlePickerKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "c":
 		// Clean worktree for non-running PRD
 		if a.picker.CanClean() {
+			entry := a.picker.GetSelectedEntry()
+			if active := a.activeSharers(entry.Name); len(active) > 0 {
+				a.picker.SetCleanResult(&CleanResult{
+					Message: fmt.Sprintf("%s still using this worktree; stop it first", strings.Join(active, ", ")),
+				})
+				return a, nil
+			}
 			a.picker.StartCleanConfirmation()
 		}
 		return a, nil
@@ -1977,14 +2950,217 @@ func (a App) handlePickerKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return a, nil
 }
 
-// parseMergeSuccessMessage constructs a success message after a merge.
-func parseMergeSuccessMessage(repoDir, branch string) string {
+// activeSharers returns the sorted names of the other PRDs sharing prdName's
+// worktree or branch whose loops are running, queued or paused, which a clean
+// or merge would pull the ground from under.
+func (a *App) activeSharers(prdName string) []string {
+	if a.manager == nil {
+		return nil
+	}
+	var active []string
+	for _, name := range a.manager.Conflicts(prdName) {
+		state, _, _ := a.manager.GetState(name)
+		if state == loop.LoopStateRunning || state == loop.LoopStateQueued || state == loop.LoopStatePaused {
+			active = append(active, name)
+		}
+	}
+	return active
+}
+
+// archiveSelectedPRD moves the PRD selected in the picker into the archive.
+// One with a worktree is refused until it has been cleaned, so the worktree
+// isn't left behind without a PRD in the list to clean it from.
+func (a App) archiveSelectedPRD() (tea.Model, tea.Cmd) {
+	entry := a.picker.GetSelectedEntry()
+	name := entry.Name
+	worktree := a.worktreePathFor(name)
+	if _, err := os.Stat(worktree); err == nil {
+		a.picker.SetCleanResult(&CleanResult{
+			Action:  "Archive",
+			Message: fmt.Sprintf("%s still has a worktree at %s. Clean it first (c), then archive.", name, worktree),
+		})
+		return a, nil
+	}
+
+	if err := loop.CheckNotRunning(paths.PRDPath(a.baseDir, name), name, "archiving"); err != nil {
+		a.picker.SetCleanResult(&CleanResult{Action: "Archive", Message: err.Error()})
+		return a, nil
+	}
+	dst, err := prd.Archive(a.baseDir, name)
+	if err != nil {
+		a.picker.SetCleanResult(&CleanResult{Action: "Archive", Message: err.Error()})
+		return a, nil
+	}
+	if a.manager != nil {
+		_ = a.manager.Unregister(name)
+	}
+	a.picker.SetCleanResult(&CleanResult{
+		Action:  "Archive",
+		Success: true,
+		Message: fmt.Sprintf("Archived %s to %s", name, dst),
+	})
+	a.picker.Refresh()
+	return a, nil
+}
+
+// parseMergeSuccessMessage constructs a success message after a merge,
+// naming the integration strategy that was used.
+func parseMergeSuccessMessage(repoDir, branch, strategy string) string {
 	// Try to get the default branch for display
 	defaultBranch := "current branch"
 	if db, err := git.GetDefaultBranch(repoDir); err == nil {
 		defaultBranch = db
 	}
-	return fmt.Sprintf("Merged %s into %s", branch, defaultBranch)
+	switch strategy {
+	case git.StrategyRebase:
+		return fmt.Sprintf("Rebased %s onto %s and fast-forwarded", branch, defaultBranch)
+	case git.StrategySquash:
+		return fmt.Sprintf("Squashed %s into one commit on %s", branch, defaultBranch)
+	default:
+		return fmt.Sprintf("Merged %s into %s", branch, defaultBranch)
+	}
+}
+
+// suggestedBranch returns the branch name to offer for a PRD, from the
+// configured git.branchPattern.
+func (a *App) suggestedBranch(prdName string) string {
+	pattern := ""
+	if a.config != nil {
+		pattern = a.config.Git.BranchPattern
+	}
+	return git.BranchName(pattern, prdName, time.Now())
+}
+
+// gitProvider returns where pull requests are opened (git.ProviderGitHub or
+// git.ProviderGitLab; empty means GitHub).
+func (a *App) gitProvider() string {
+	if a.config == nil {
+		return ""
+	}
+	return a.config.Git.Provider
+}
+
+// prOptions returns the draft, reviewer, base and head settings for a PRD's
+// auto-created pull requests. The base is always resolved, so the pull
+// request targets the branch the completion screen shows.
+func (a *App) prOptions(prdName string) git.PROptions {
+	cfg := a.configFor(prdName)
+	if cfg == nil {
+		return git.PROptions{}
+	}
+	return git.PROptions{
+		Draft:      cfg.OnComplete.Draft,
+		Reviewers:  cfg.OnComplete.Reviewers,
+		Base:       a.prBaseBranch(prdName),
+		HeadRemote: cfg.Git.Remote,
+	}
+}
+
+// gitRemote returns the remote a PRD's branch is pushed to.
+func (a *App) gitRemote(prdName string) string {
+	cfg := a.configFor(prdName)
+	if cfg == nil {
+		return git.DefaultRemote
+	}
+	return git.RemoteOrDefault(cfg.Git.Remote)
+}
+
+// prBaseBranch returns the branch a PRD's pull request targets, as passed
+// to gh or glab and shown on the completion screen.
+func (a *App) prBaseBranch(prdName string) string {
+	base, _ := git.BaseBranch(a.baseDir, a.configuredBaseBranch(prdName))
+	return base
+}
+
+// configuredBaseBranch returns a PRD's git.baseBranch, which its branch
+// starts from and is compared with (empty means the default branch).
+func (a *App) configuredBaseBranch(prdName string) string {
+	if cfg := a.configFor(prdName); cfg != nil {
+		return cfg.Git.BaseBranch
+	}
+	return ""
+}
+
+// configFor returns the config for a PRD: the project config with the PRD's
+// own overrides, if it has any, laid over it.
+func (a *App) configFor(prdName string) *config.Config {
+	if a.config == nil {
+		return nil
+	}
+	cfg, err := a.config.ForPRD(a.baseDir, prdName)
+	if err != nil {
+		return a.config
+	}
+	return cfg
+}
+
+// worktreePathFor returns where the named PRD's worktree lives, honouring a
+// configured worktree.baseDir.
+func (a *App) worktreePathFor(prdName string) string {
+	configured := ""
+	if cfg := a.configFor(prdName); cfg != nil {
+		configured = cfg.Worktree.BaseDir
+	}
+	return git.WorktreePathForPRD(a.baseDir, configured, prdName)
+}
+
+// saveSettings applies the settings overlay to the config it edits, either a
+// PRD's overrides or the project config, and saves it.
+func (a *App) saveSettings() {
+	if prdName := a.settingsOverlay.PRD(); prdName != "" {
+		cfg := a.configFor(prdName)
+		a.settingsOverlay.ApplyToConfig(cfg)
+		_ = config.SaveForPRD(a.baseDir, prdName, cfg)
+		a.refreshPricing()
+		return
+	}
+	a.settingsOverlay.ApplyToConfig(a.config)
+	_ = config.Save(a.baseDir, a.config)
+	a.refreshPricing()
+}
+
+// refreshPricing reloads the current PRD's prices, which usage summaries read
+// on every render.
+func (a *App) refreshPricing() {
+	if cfg := a.configFor(a.prdName); cfg != nil {
+		a.pricing = cfg.Pricing
+	}
+}
+
+// integrationStrategy returns how the m action integrates a completed branch.
+func (a *App) integrationStrategy() string {
+	if a.config == nil {
+		return ""
+	}
+	return a.config.OnComplete.IntegrationStrategy
+}
+
+// signCommits reports whether commits made for the named PRD must be signed.
+func (a *App) signCommits(prdName string) bool {
+	cfg := a.configFor(prdName)
+	return cfg != nil && cfg.Git.SignCommits
+}
+
+// prdWarningActivity returns an activity line naming the PRD's first warning,
+// such as a story without acceptance criteria, or "" if it has none.
+func prdWarningActivity(p *prd.PRD) string {
+	warnings := p.Warnings()
+	switch len(warnings) {
+	case 0:
+		return ""
+	case 1:
+		return "Warning: " + warnings[0]
+	default:
+		return fmt.Sprintf("Warning: %s (+%d more, see chief status)", warnings[0], len(warnings)-1)
+	}
+}
+
+// iterationBudget returns the default max iterations for p's remaining work
+// under cfg's loop settings, with an activity line showing how it was worked
+// out.
+func iterationBudget(cfg *config.Config, p *prd.PRD) (int, string) {
+	allowance := p.IterationAllowance()
+	return cfg.Loop.IterationBudget(allowance), "Max iterations: " + cfg.Loop.IterationFormula(allowance)
 }
 
 // switchToPRD switches to a different PRD (view only - does not stop other loops).
@@ -2026,39 +3202,27 @@ func (a App) switchToPRD(name, prdPath string) (tea.Model, tea.Cmd) {
 
 	// Get the state from the manager for this PRD
 	loopState, iteration, loopErr := a.manager.GetState(name)
-	appState := StateReady
-	switch loopState {
-	case loop.LoopStateRunning:
-		appState = StateRunning
-	case loop.LoopStatePaused:
-		appState = StatePaused
-	case loop.LoopStateStopped:
-		appState = StateStopped
-	case loop.LoopStateComplete:
-		appState = StateComplete
-	case loop.LoopStateError:
-		appState = StateError
-	}
+	appState := appStateFor(loopState)
 
 	// Only recalculate max iterations if no loop is currently running for this PRD
+	budgetActivity := ""
 	if instance := a.manager.GetInstance(name); instance == nil || instance.State != loop.LoopStateRunning {
-		remaining := 0
-		for _, story := range newPRD.UserStories {
-			if !story.Passes {
-				remaining++
-			}
-		}
-		a.maxIter = remaining + 5
-		if a.maxIter < 5 {
-			a.maxIter = 5
+		cfg := a.configFor(name)
+		if cfg == nil {
+			cfg = config.Default()
 		}
+		a.maxIter, budgetActivity = iterationBudget(cfg, newPRD)
 	}
 
 	// Update app state
 	a.prd = newPRD
 	a.prdPath = prdPath
 	a.prdName = name
+	a.restoreInProgress()
 	a.selectedIndex = 0
+	a.clearStoryFilter()
+	a.noteEditing = false
+	a.jumpInput = false
 	a.state = appState
 	a.iteration = iteration
 	a.err = loopErr
@@ -2070,7 +3234,12 @@ func (a App) switchToPRD(name, prdPath string) (tea.Model, tea.Cmd) {
 	} else {
 		a.startTime = time.Time{}
 	}
+	a.refreshPricing()
 	a.lastActivity = "Switched to PRD: " + name
+	if budgetActivity != "" {
+		a.lastActivity += " (" + budgetActivity + ")"
+	}
+	a.currentFile = ""
 	a.viewMode = ViewDashboard
 	a.picker.SetCurrentPRD(name)
 	a.tabBar.SetActiveByName(name)
@@ -2081,6 +3250,12 @@ func (a App) switchToPRD(name, prdPath string) (tea.Model, tea.Cmd) {
 	a.storyTimings = nil
 	a.currentStoryID = ""
 	a.currentStoryStart = time.Time{}
+	if appState != StateRunning {
+		a.restoreLastRun()
+	}
+	if warning := prdWarningActivity(newPRD); warning != "" {
+		a.lastActivity = warning
+	}
 
 	// Return with new watcher listeners (and elapsed tick if running)
 	cmds := []tea.Cmd{a.listenForPRDChanges(), a.listenForProgressChanges()}
@@ -2119,12 +3294,128 @@ func (a *App) GetSelectedStory() *prd.UserStory {
 }
 
 // markStoryInProgress clears any existing in-progress flags and marks the
-// given story as in-progress, then saves the PRD to disk.
+// given story as in-progress, then saves the PRD to disk unless in-progress
+// state is kept out of prd.json.
 func (a *App) markStoryInProgress(storyID string) {
 	for i := range a.prd.UserStories {
 		a.prd.UserStories[i].InProgress = a.prd.UserStories[i].ID == storyID
 	}
+	if a.inProgressOnDisk() {
+		_ = a.prd.Save(a.prdPath)
+	}
+}
+
+// inProgressOnDisk reports whether in-progress flags are written to prd.json.
+// When they aren't, the loop manager tracks the story and saves it to state.json.
+func (a *App) inProgressOnDisk() bool {
+	cfg := a.configFor(a.prdName)
+	return cfg == nil || !cfg.Loop.InProgressInState
+}
+
+// restoreInProgress marks the story the loop is working on as in progress in
+// a freshly loaded PRD, when that state isn't kept in prd.json itself.
+func (a *App) restoreInProgress() {
+	if a.inProgressOnDisk() || a.manager == nil || a.prd == nil {
+		return
+	}
+	instance := a.manager.GetInstance(a.prdName)
+	if instance == nil || instance.Story == "" {
+		return
+	}
+	// Copy before marking: the PRD may be the one the file watcher compares against
+	p := *a.prd
+	p.UserStories = append([]prd.UserStory(nil), a.prd.UserStories...)
+	for i := range p.UserStories {
+		p.UserStories[i].InProgress = p.UserStories[i].ID == instance.Story && !p.UserStories[i].Settled()
+	}
+	a.prd = &p
+}
+
+// canReopenSelectedStory returns true if the selected story has passed and
+// no loop is currently working on this PRD.
+func (a *App) canReopenSelectedStory() bool {
+	story := a.GetSelectedStory()
+	if story == nil || !story.Settled() {
+		return false
+	}
+	return a.state != StateRunning
+}
+
+// canStartAtSelectedStory returns true if the loop can be started at the selected story.
+func (a *App) canStartAtSelectedStory() bool {
+	story := a.GetSelectedStory()
+	if story == nil || story.Settled() {
+		return false
+	}
+	return a.state == StateReady || a.state == StatePaused || a.state == StateError || a.state == StateStopped
+}
+
+// startAtSelectedStory starts the loop with the selected story first,
+// overriding the usual priority order for this run.
+func (a App) startAtSelectedStory() (tea.Model, tea.Cmd) {
+	story := a.GetSelectedStory()
+	if blockers := a.prd.BlockedBy(story); len(blockers) > 0 {
+		a.lastActivity = fmt.Sprintf("Can't start at %s: blocked by %s", story.ID, strings.Join(blockers, ", "))
+		return a, nil
+	}
+	a.pendingStartStory = story.ID
+	return a.startLoop()
+}
+
+// startStoryMessage describes a targeted start, naming how many pending
+// stories are being skipped for this run.
+func (a *App) startStoryMessage(storyID string) string {
+	skipped := 0
+	for _, story := range a.prd.UserStories {
+		if !story.Settled() && story.ID != storyID {
+			skipped++
+		}
+	}
+	if skipped == 0 {
+		return fmt.Sprintf("Starting at %s...", storyID)
+	}
+	noun := "stories"
+	if skipped == 1 {
+		noun = "story"
+	}
+	return fmt.Sprintf("Starting at %s, skipping %d other pending %s for now...", storyID, skipped, noun)
+}
+
+// reopenSelectedStory marks the selected story as not passing and saves the PRD to disk.
+func (a *App) reopenSelectedStory() {
+	story := a.GetSelectedStory()
+	if story == nil {
+		return
+	}
+	story.Passes = false
+	story.Skipped = false
+	story.InProgress = false
 	_ = a.prd.Save(a.prdPath)
+	a.lastActivity = fmt.Sprintf("Reopened %s", story.ID)
+	if a.state == StateComplete {
+		a.state = StateReady
+	}
+}
+
+// canSkipCurrentStory returns true if the loop is working on a story that can
+// be skipped.
+func (a *App) canSkipCurrentStory() bool {
+	return a.state == StateRunning && a.currentStoryID != "" && a.manager != nil
+}
+
+// skipCurrentStory marks the story the loop is working on as skipped and
+// abandons its iteration, so the loop moves on to the next story.
+func (a *App) skipCurrentStory() {
+	storyID := a.currentStoryID
+	if err := a.manager.SkipStory(a.prdName, storyID); err != nil {
+		a.lastActivity = "Can't skip " + storyID + ": " + err.Error()
+		return
+	}
+	if p, err := prd.LoadPRD(a.prdPath); err == nil {
+		a.prd = p
+	}
+	a.finalizeStoryTiming()
+	a.lastActivity = fmt.Sprintf("Skipped %s; moving on to the next story", storyID)
 }
 
 // clearInProgress clears all in-progress flags and saves the PRD to disk.
@@ -2136,31 +3427,72 @@ func (a *App) clearInProgress() {
 			dirty = true
 		}
 	}
-	if dirty {
+	if dirty && a.inProgressOnDisk() {
 		_ = a.prd.Save(a.prdPath)
 	}
 }
 
-// selectStoryByID sets the selected index to the story with the given ID.
+// selectStoryByID sets the selected index to the story with the given ID,
+// unless the stories filter hides it.
 func (a *App) selectStoryByID(storyID string) {
 	for i, story := range a.prd.UserStories {
-		if story.ID == storyID {
+		if story.ID == storyID && a.storyMatchesFilter(&story) {
 			a.selectedIndex = i
 			return
 		}
 	}
 }
 
-// selectInProgressStory sets the selected index to the first in-progress story.
+// selectInProgressStory sets the selected index to the first in-progress story
+// the stories filter shows.
 func (a *App) selectInProgressStory() {
 	for i, story := range a.prd.UserStories {
-		if story.InProgress {
+		if story.InProgress && a.storyMatchesFilter(&story) {
 			a.selectedIndex = i
 			return
 		}
 	}
 }
 
+// inProgressStoryID returns the ID of the first in-progress story, or empty.
+func (a *App) inProgressStoryID() string {
+	for _, story := range a.prd.UserStories {
+		if story.InProgress {
+			return story.ID
+		}
+	}
+	return ""
+}
+
+// exportLog writes the log viewer's entries to a session-<timestamp>.log file
+// in the current PRD's directory and returns its path.
+func (a *App) exportLog(now time.Time) (string, error) {
+	path := filepath.Join(paths.PRDDir(a.baseDir, a.prdName), "session-"+now.Format("20060102-150405")+".log")
+	f, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	if _, err := a.logViewer.WriteTo(f); err != nil {
+		f.Close()
+		return "", err
+	}
+	return path, f.Close()
+}
+
+// storyIndexByID returns the index of the story with the given ID, or -1 if
+// the PRD has no such story.
+func (a *App) storyIndexByID(id string) int {
+	if id == "" || a.prd == nil {
+		return -1
+	}
+	for i, story := range a.prd.UserStories {
+		if story.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
 // GetState returns the current app state.
 func (a *App) GetState() AppState {
 	return a.state
@@ -2171,26 +3503,54 @@ func (a *App) GetIteration() int {
 	return a.iteration
 }
 
-// GetElapsedTime returns the elapsed time since the loop started.
+// GetElapsedTime returns the elapsed time since the loop started, or how long
+// the last run took when it was restored from a previous session.
 func (a *App) GetElapsedTime() time.Duration {
 	if a.startTime.IsZero() {
-		return 0
+		return a.lastElapsed
 	}
 	return time.Since(a.startTime)
 }
 
-// GetCompletionPercentage returns the percentage of completed stories.
-func (a *App) GetCompletionPercentage() float64 {
-	if len(a.prd.UserStories) == 0 {
-		return 100.0
+// restoreLastRun loads the elapsed time and story timings of the current
+// PRD's last run from the state the loop manager saved, so they survive a
+// restart of chief.
+func (a *App) restoreLastRun() {
+	a.lastElapsed = 0
+	snap, err := loop.LoadSnapshot(a.prdPath)
+	if err != nil || snap == nil {
+		return
 	}
-	var completed int
-	for _, s := range a.prd.UserStories {
-		if s.Passes {
-			completed++
-		}
+	a.lastElapsed = snap.Elapsed()
+	if timings, err := prd.LoadTimings(a.prdPath); err == nil {
+		a.storyTimings = storyTimingsSince(a.prd, timings, snap.StartTime)
+	}
+}
+
+// appStateFor maps a loop state to the app state shown for it.
+func appStateFor(state loop.LoopState) AppState {
+	switch state {
+	case loop.LoopStateRunning:
+		return StateRunning
+	case loop.LoopStatePaused:
+		return StatePaused
+	case loop.LoopStateStopped:
+		return StateStopped
+	case loop.LoopStateComplete:
+		return StateComplete
+	case loop.LoopStateError:
+		return StateError
+	case loop.LoopStateQueued:
+		return StateQueued
+	default:
+		return StateReady
 	}
-	return float64(completed) / float64(len(a.prd.UserStories)) * 100.0
+}
+
+// GetCompletionPercentage returns the percentage of completed stories,
+// weighted by each story's Weight.
+func (a *App) GetCompletionPercentage() float64 {
+	return a.prd.CompletionPercentage()
 }
 
 // GetLastActivity returns the last activity message.
@@ -2250,19 +3610,39 @@ func (a App) handlePRDUpdate(msg PRDUpdateMsg) (tea.Model, tea.Cmd) {
 		// File error - could be temporary, keep watching
 		a.lastActivity = "PRD file error: " + msg.Error.Error()
 	} else if msg.PRD != nil {
+		// Remember which story was selected so the selection follows it when
+		// the agent adds or removes stories around it
+		selectedID, inProgressID := "", ""
+		if a.prd != nil {
+			if story := a.GetSelectedStory(); story != nil {
+				selectedID = story.ID
+			}
+			inProgressID = a.inProgressStoryID()
+		}
+
 		// Update the PRD
 		a.prd = msg.PRD
+		// Stories may have passed or been retitled since their stats were read
+		clear(a.storyDiffStats)
+		a.diffStatEpoch++
+		a.restoreInProgress()
 
-		// Adjust selected index if it's now out of bounds
-		if a.selectedIndex >= len(a.prd.UserStories) {
+		if i := a.storyIndexByID(selectedID); i >= 0 {
+			a.selectedIndex = i
+		} else if a.selectedIndex >= len(a.prd.UserStories) {
+			// The selected story was removed; clamp to the end of the list
 			a.selectedIndex = len(a.prd.UserStories) - 1
 			if a.selectedIndex < 0 {
 				a.selectedIndex = 0
 			}
 		}
 
-		// Auto-select the in-progress story so the user sees its details
-		a.selectInProgressStory()
+		// Auto-select a newly started story so the user sees its details, but
+		// leave the selection alone while the same story stays in progress
+		if id := a.inProgressStoryID(); id != "" && id != inProgressID {
+			a.selectInProgressStory()
+		}
+		a.clampSelectionToFilter()
 	}
 
 	// Continue listening for changes
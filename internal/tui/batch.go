@@ -0,0 +1,166 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/minicodemonkey/chief/internal/git"
+	"github.com/minicodemonkey/chief/internal/loop"
+	"github.com/minicodemonkey/chief/internal/paths"
+)
+
+// startAll starts every PRD that is ready, paused or stopped and still has
+// stories to do. PRDs that need a decision before they start (a protected
+// branch, another PRD in the same directory, or prompt review) are queued
+// rather than started as they are, and their dialogs shown one at a time.
+func (a App) startAll() (tea.Model, tea.Cmd) {
+	if a.tabBar == nil || a.manager == nil {
+		return a, nil
+	}
+	a.tabBar.Refresh()
+	a.pendingStartStory = ""
+	a.startQueue = nil
+
+	var cmds []tea.Cmd
+	started := 0
+	for i := 0; i < a.tabBar.Count(); i++ {
+		entry := *a.tabBar.GetEntry(i)
+		if !batchStartable(entry) {
+			continue
+		}
+		if a.needsStartDecision(entry.Name) {
+			a.startQueue = append(a.startQueue, entry.Name)
+			continue
+		}
+		model, cmd := a.doStartLoop(entry.Name, paths.PRDDir(a.baseDir, entry.Name))
+		a = model.(App)
+		cmds = append(cmds, cmd)
+		started++
+	}
+	a.tabBar.Refresh()
+
+	switch {
+	case started == 0 && len(a.startQueue) == 0:
+		a.lastActivity = "No PRDs to start"
+	case len(a.startQueue) == 0:
+		a.lastActivity = fmt.Sprintf("Started %d %s", started, pluralPRDs(started))
+	default:
+		a.lastActivity = fmt.Sprintf("Started %d %s; %d waiting for a decision", started, pluralPRDs(started), len(a.startQueue))
+	}
+	return a, tea.Batch(cmds...)
+}
+
+// continueStartAll starts the queued PRDs from a start-all, stopping at the
+// first that opens a dialog. It waits while any dialog is open.
+func (a App) continueStartAll() (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+	for len(a.startQueue) > 0 && a.isBrowsingView() {
+		name := a.startQueue[0]
+		a.startQueue = a.startQueue[1:]
+
+		// Skip anything started, finished or removed since it was queued
+		if a.tabBar != nil {
+			a.tabBar.Refresh()
+		}
+		entry := a.tabEntry(name)
+		if entry == nil || !batchStartable(*entry) {
+			continue
+		}
+		model, cmd := a.startLoopForPRD(name)
+		a = model.(App)
+		cmds = append(cmds, cmd)
+	}
+	if a.tabBar != nil {
+		a.tabBar.Refresh()
+	}
+	return a, tea.Batch(cmds...)
+}
+
+// pauseAll pauses every running PRD after its current iteration and drops
+// any PRDs still queued by a start-all.
+func (a App) pauseAll() (tea.Model, tea.Cmd) {
+	if a.manager == nil {
+		return a, nil
+	}
+	a.startQueue = nil
+	// Queued loops go first, or they would take the slots the running ones free
+	for _, name := range a.manager.Queued() {
+		a.manager.Pause(name)
+		if name == a.prdName {
+			a.state = StatePaused
+		}
+	}
+	paused := 0
+	for _, instance := range a.manager.GetAllInstances() {
+		if instance.State == loop.LoopStateRunning {
+			a.manager.Pause(instance.Name)
+			paused++
+		}
+	}
+	if a.tabBar != nil {
+		a.tabBar.Refresh()
+	}
+	if paused == 0 {
+		a.lastActivity = "No PRDs are running"
+	} else {
+		a.lastActivity = fmt.Sprintf("Pausing %d %s after the current iteration...", paused, pluralPRDs(paused))
+	}
+	return a, nil
+}
+
+// batchStartable reports whether a start-all should start a PRD.
+func batchStartable(entry TabEntry) bool {
+	switch entry.LoopState {
+	case loop.LoopStateReady, loop.LoopStatePaused, loop.LoopStateStopped:
+	default:
+		return false
+	}
+	return entry.Total == 0 || entry.Completed < entry.Total
+}
+
+// needsStartDecision reports whether starting a PRD would open a dialog: the
+// branch warning or prompt review.
+func (a *App) needsStartDecision(prdName string) bool {
+	if a.shouldReviewPrompt(prdName) {
+		return true
+	}
+	if !git.IsGitRepo(a.baseDir) {
+		return false
+	}
+	branch, err := git.GetCurrentBranch(a.baseDir)
+	if err != nil {
+		return false
+	}
+	return git.IsProtectedBranch(branch) || a.isAnotherPRDRunningInSameDir(prdName)
+}
+
+// isBrowsingView reports whether the TUI shows one of the main views rather
+// than a dialog or overlay.
+func (a *App) isBrowsingView() bool {
+	switch a.viewMode {
+	case ViewDashboard, ViewLog, ViewDiff, ViewOverview, ViewDecisions:
+		return true
+	}
+	return false
+}
+
+// tabEntry returns the tab bar entry for a PRD, or nil.
+func (a *App) tabEntry(name string) *TabEntry {
+	if a.tabBar == nil {
+		return nil
+	}
+	for i := 0; i < a.tabBar.Count(); i++ {
+		if entry := a.tabBar.GetEntry(i); entry.Name == name {
+			return entry
+		}
+	}
+	return nil
+}
+
+// pluralPRDs returns "PRD" or "PRDs" for n.
+func pluralPRDs(n int) string {
+	if n == 1 {
+		return "PRD"
+	}
+	return "PRDs"
+}
@@ -15,6 +15,7 @@ const (
 	BranchOptionCreateBranch                                // Create branch only (no worktree)
 	BranchOptionContinue                                    // Continue on current branch / run in same directory
 	BranchOptionCancel                                      // Cancel
+	BranchOptionJoinWorktree                                // Run in another PRD's worktree on the same branch
 )
 
 // DialogContext determines which set of options to show.
@@ -47,6 +48,13 @@ type BranchWarning struct {
 	selectedIndex int
 	editMode      bool   // Whether we're editing the branch name
 	branchName    string // The current branch name (editable)
+	suggested     string // The branch name the dialog starts with
+	branchError   string // Why the branch name was rejected, shown under it
+	dirty         bool   // The working tree has uncommitted changes
+	stash         bool   // Stash those changes before creating the branch
+	joinBranch    string // Branch of another PRD's worktree this PRD can join; empty if none
+	joinOwner     string // PRD whose worktree that is
+	joinPath      string // Relative path of that worktree
 	context       DialogContext
 	options       []dialogOption
 }
@@ -68,10 +76,51 @@ func (b *BranchWarning) SetSize(width, height int) {
 func (b *BranchWarning) SetContext(currentBranch, prdName, worktreePath string) {
 	b.currentBranch = currentBranch
 	b.prdName = prdName
-	b.branchName = fmt.Sprintf("chief/%s", prdName)
+	b.suggested = fmt.Sprintf("chief/%s", prdName)
+	b.branchName = b.suggested
 	b.worktreePath = worktreePath
 }
 
+// SetSuggestedBranch replaces the branch name the dialog suggests, e.g. with
+// the configured branch pattern expanded for the PRD.
+func (b *BranchWarning) SetSuggestedBranch(name string) {
+	b.suggested = name
+	b.branchName = name
+}
+
+// SetDirty records whether the working tree has uncommitted changes, which
+// shows a warning and lets the user stash them first.
+func (b *BranchWarning) SetDirty(dirty bool) {
+	b.dirty = dirty
+}
+
+// ToggleStash turns stashing uncommitted changes before proceeding on or off.
+// It does nothing when the tree is clean.
+func (b *BranchWarning) ToggleStash() {
+	if b.dirty {
+		b.stash = !b.stash
+	}
+}
+
+// ShouldStash returns true if uncommitted changes should be stashed before the
+// selected option creates a branch or worktree.
+func (b *BranchWarning) ShouldStash() bool {
+	return b.dirty && b.stash && b.selectedOptionHasBranch()
+}
+
+// SetJoinable offers joining another PRD's worktree on branch, or withdraws
+// the offer when branch is empty. Call it before SetDialogContext.
+func (b *BranchWarning) SetJoinable(branch, owner, worktreePath string) {
+	b.joinBranch = branch
+	b.joinOwner = owner
+	b.joinPath = worktreePath
+}
+
+// JoinBranch returns the branch of the worktree the dialog offers to join.
+func (b *BranchWarning) JoinBranch() string {
+	return b.joinBranch
+}
+
 // SetDialogContext sets which context mode the dialog should display.
 func (b *BranchWarning) SetDialogContext(ctx DialogContext) {
 	b.context = ctx
@@ -141,6 +190,20 @@ func (b *BranchWarning) buildOptions() {
 			},
 		}
 	}
+
+	// Joining a related PRD's worktree is what the user set the branch up for
+	if b.joinBranch != "" {
+		for i := range b.options {
+			b.options[i].recommended = false
+		}
+		join := dialogOption{
+			label:       fmt.Sprintf("Join existing worktree for %s", b.joinBranch),
+			hint:        fmt.Sprintf("%s (shared with %s)", b.joinPath, b.joinOwner),
+			recommended: true,
+			option:      BranchOptionJoinWorktree,
+		}
+		b.options = append([]dialogOption{join}, b.options...)
+	}
 }
 
 // GetSuggestedBranch returns the branch name (may be edited by user).
@@ -179,7 +242,14 @@ func (b *BranchWarning) GetDialogContext() DialogContext {
 func (b *BranchWarning) Reset() {
 	b.selectedIndex = 0
 	b.editMode = false
-	b.branchName = fmt.Sprintf("chief/%s", b.prdName)
+	b.branchName = b.suggested
+	b.branchError = ""
+	b.stash = false
+}
+
+// SetBranchError shows why the branch name can't be used. Editing the name clears it.
+func (b *BranchWarning) SetBranchError(msg string) {
+	b.branchError = msg
 }
 
 // IsEditMode returns true if the branch name is being edited.
@@ -203,6 +273,7 @@ func (b *BranchWarning) AddInputChar(ch rune) {
 	if (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z') ||
 		(ch >= '0' && ch <= '9') || ch == '-' || ch == '_' || ch == '/' {
 		b.branchName += string(ch)
+		b.branchError = ""
 	}
 }
 
@@ -210,6 +281,7 @@ func (b *BranchWarning) AddInputChar(ch rune) {
 func (b *BranchWarning) DeleteInputChar() {
 	if len(b.branchName) > 0 {
 		b.branchName = b.branchName[:len(b.branchName)-1]
+		b.branchError = ""
 	}
 }
 
@@ -241,6 +313,9 @@ func (b *BranchWarning) Render() string {
 	// Branch name (shown when any option involves a branch)
 	b.renderBranchName(&content)
 
+	// Uncommitted changes warning
+	b.renderDirtyWarning(&content)
+
 	// Options
 	b.renderOptions(&content)
 
@@ -252,6 +327,8 @@ func (b *BranchWarning) Render() string {
 	footerStyle := lipgloss.NewStyle().Foreground(MutedColor)
 	if b.editMode {
 		content.WriteString(footerStyle.Render("Enter: confirm  Esc: cancel edit"))
+	} else if b.dirty {
+		content.WriteString(footerStyle.Render("↑/↓: Navigate  Enter: Select  e: Edit branch  s: Stash  Esc: Cancel"))
 	} else {
 		content.WriteString(footerStyle.Render("↑/↓: Navigate  Enter: Select  e: Edit branch  Esc: Cancel"))
 	}
@@ -328,11 +405,35 @@ func (b *BranchWarning) renderBranchName(content *strings.Builder) {
 		cursorStyle := lipgloss.NewStyle().Foreground(PrimaryColor).Blink(true)
 		content.WriteString(inputStyle.Render(b.branchName))
 		content.WriteString(cursorStyle.Render("▌"))
-		content.WriteString("\n\n")
+		content.WriteString("\n")
 	} else {
 		content.WriteString(branchLabelStyle.Render(fmt.Sprintf("Branch: %s", b.branchName)))
-		content.WriteString("\n\n")
+		content.WriteString("\n")
+	}
+	if b.branchError != "" {
+		content.WriteString(lipgloss.NewStyle().Foreground(ErrorColor).Render(b.branchError))
+		content.WriteString("\n")
+	}
+	content.WriteString("\n")
+}
+
+// renderDirtyWarning warns that uncommitted changes won't follow a new
+// worktree and shows whether they'll be stashed first.
+func (b *BranchWarning) renderDirtyWarning(content *strings.Builder) {
+	if !b.dirty {
+		return
+	}
+	warningStyle := lipgloss.NewStyle().Foreground(WarningColor)
+	content.WriteString(warningStyle.Render("⚠ You have uncommitted changes. A new worktree"))
+	content.WriteString("\n")
+	content.WriteString(warningStyle.Render("  starts from the last commit without them."))
+	content.WriteString("\n")
+	check := "[ ]"
+	if b.stash {
+		check = "[x]"
 	}
+	content.WriteString(lipgloss.NewStyle().Foreground(MutedColor).Render(check + " Stash them first (s)"))
+	content.WriteString("\n\n")
 }
 
 // renderOptions renders the selectable options list.
@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -202,6 +203,35 @@ func TestBranchWarningBranchEdit(t *testing.T) {
 	}
 }
 
+func TestBranchWarningSuggestedBranch(t *testing.T) {
+	bw := NewBranchWarning()
+	bw.SetSize(80, 24)
+	bw.SetContext("main", "auth", ".chief/worktrees/auth/")
+	bw.SetSuggestedBranch("feature/2026-03-09-auth")
+	bw.SetDialogContext(DialogProtectedBranch)
+
+	if bw.GetSuggestedBranch() != "feature/2026-03-09-auth" {
+		t.Errorf("expected pattern branch, got %q", bw.GetSuggestedBranch())
+	}
+
+	// Edits are discarded by Reset, which goes back to the suggestion
+	bw.StartEditMode()
+	bw.DeleteInputChar()
+	bw.Reset()
+	if bw.GetSuggestedBranch() != "feature/2026-03-09-auth" {
+		t.Errorf("expected Reset to restore suggestion, got %q", bw.GetSuggestedBranch())
+	}
+
+	bw.SetBranchError(`"x" is not a valid branch name`)
+	if !strings.Contains(bw.Render(), "not a valid branch name") {
+		t.Error("expected render to include the branch error")
+	}
+	bw.AddInputChar('x')
+	if bw.branchError != "" {
+		t.Errorf("expected editing to clear the error, got %q", bw.branchError)
+	}
+}
+
 func TestBranchWarningPathHints(t *testing.T) {
 	bw := NewBranchWarning()
 	bw.SetSize(80, 24)
@@ -237,6 +267,46 @@ func TestBranchWarningRender(t *testing.T) {
 	}
 }
 
+func TestBranchWarningDirtyTree(t *testing.T) {
+	bw := NewBranchWarning()
+	bw.SetSize(80, 30)
+	bw.SetContext("main", "auth", ".chief/worktrees/auth/")
+	bw.SetDialogContext(DialogProtectedBranch)
+	bw.Reset()
+
+	// A clean tree shows no warning and can't be stashed
+	bw.ToggleStash()
+	if bw.ShouldStash() || strings.Contains(bw.Render(), "uncommitted changes") {
+		t.Error("expected no stash option or warning for a clean tree")
+	}
+
+	bw.SetDirty(true)
+	output := bw.Render()
+	if !strings.Contains(output, "uncommitted changes") || !strings.Contains(output, "[ ] Stash them first") {
+		t.Errorf("expected a dirty tree warning, got:\n%s", output)
+	}
+
+	bw.ToggleStash()
+	if !bw.ShouldStash() {
+		t.Error("expected ShouldStash after toggling on the create branch option")
+	}
+	if !strings.Contains(bw.Render(), "[x] Stash them first") {
+		t.Error("expected the stash box to be checked")
+	}
+
+	// Continuing on the current branch leaves the changes where they are
+	bw.MoveDown()
+	bw.MoveDown()
+	if bw.GetSelectedOption() != BranchOptionContinue || bw.ShouldStash() {
+		t.Error("expected no stash when continuing on the current branch")
+	}
+
+	bw.Reset()
+	if bw.ShouldStash() {
+		t.Error("expected Reset to clear the stash choice")
+	}
+}
+
 func TestBranchWarningGetDialogContext(t *testing.T) {
 	bw := NewBranchWarning()
 	bw.SetContext("main", "auth", ".chief/worktrees/auth/")
@@ -256,3 +326,29 @@ func TestBranchWarningGetDialogContext(t *testing.T) {
 		t.Error("expected DialogNoConflicts")
 	}
 }
+
+func TestBranchWarningJoinWorktree(t *testing.T) {
+	bw := NewBranchWarning()
+	bw.SetSize(80, 30)
+	bw.SetContext("chief/api", "api-tests", ".chief/worktrees/api-tests/")
+	bw.SetJoinable("chief/api", "api", ".chief/worktrees/api/")
+	bw.SetDialogContext(DialogAnotherPRDRunning)
+	bw.Reset()
+
+	if bw.GetSelectedOption() != BranchOptionJoinWorktree || bw.JoinBranch() != "chief/api" {
+		t.Fatalf("expected joining chief/api to be the first option, got %v", bw.GetSelectedOption())
+	}
+	output := bw.Render()
+	if !strings.Contains(output, "Join existing worktree for chief/api") || !strings.Contains(output, "shared with api") {
+		t.Errorf("expected the join option, got:\n%s", output)
+	}
+	if strings.Count(output, "(Recommended)") != 1 {
+		t.Errorf("expected only the join option to be recommended, got:\n%s", output)
+	}
+
+	bw.SetJoinable("", "", "")
+	bw.SetDialogContext(DialogAnotherPRDRunning)
+	if bw.GetSelectedOption() != BranchOptionCreateWorktree {
+		t.Errorf("expected no join option once withdrawn, got %v", bw.GetSelectedOption())
+	}
+}
@@ -0,0 +1,35 @@
+package tui
+
+import (
+	"errors"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/minicodemonkey/chief/internal/browser"
+)
+
+// browserResultMsg is sent when opening a URL in the browser finishes.
+type browserResultMsg struct {
+	url string
+	err error
+}
+
+// openInBrowser returns a tea.Cmd that opens url in the default browser in
+// the background.
+func openInBrowser(url string) tea.Cmd {
+	return func() tea.Msg {
+		return browserResultMsg{url: url, err: browser.Open(url)}
+	}
+}
+
+// handleBrowserResult reports the outcome of opening a URL in the activity line.
+func (a App) handleBrowserResult(msg browserResultMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case errors.Is(msg.err, browser.ErrUnavailable):
+		a.lastActivity = "⚠ Couldn't open " + msg.url + ": " + msg.err.Error()
+	case msg.err != nil:
+		a.lastActivity = "⚠ " + msg.err.Error()
+	default:
+		a.lastActivity = "Opened " + msg.url
+	}
+	return a, nil
+}
@@ -0,0 +1,41 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/minicodemonkey/chief/internal/clipboard"
+)
+
+// clipboardResultMsg is sent when copying a view's content finishes.
+type clipboardResultMsg struct {
+	lines int
+	err   error
+}
+
+// copyToClipboard returns a tea.Cmd that copies text to the system clipboard
+// in the background.
+func copyToClipboard(text string) tea.Cmd {
+	return func() tea.Msg {
+		lines := 0
+		if text != "" {
+			lines = strings.Count(text, "\n") + 1
+		}
+		return clipboardResultMsg{lines: lines, err: clipboard.Copy(text)}
+	}
+}
+
+// handleClipboardResult reports the outcome of a copy in the activity line.
+func (a App) handleClipboardResult(msg clipboardResultMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case errors.Is(msg.err, clipboard.ErrUnavailable):
+		a.lastActivity = "⚠ Nothing copied: " + msg.err.Error()
+	case msg.err != nil:
+		a.lastActivity = "⚠ " + msg.err.Error()
+	default:
+		a.lastActivity = fmt.Sprintf("Copied %d lines", msg.lines)
+	}
+	return a, nil
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/minicodemonkey/chief/internal/prd"
 )
 
 // AutoActionState represents the progress of an auto-action (push or PR).
@@ -16,6 +17,7 @@ const (
 	AutoActionInProgress                        // Currently running
 	AutoActionSuccess                           // Completed successfully
 	AutoActionError                             // Failed with error
+	AutoActionSkipped                           // Not attempted, e.g. with nothing to push
 )
 
 // StoryTiming records the duration of a completed story.
@@ -23,6 +25,79 @@ type StoryTiming struct {
 	StoryID  string
 	Title    string
 	Duration time.Duration
+	Estimate time.Duration // The story's estimate, 0 if it has none
+}
+
+// storyTimingsSince totals the recorded iteration timings per story from
+// since onwards, in the order stories were first worked on.
+func storyTimingsSince(p *prd.PRD, timings []prd.StoryTime, since time.Time) []StoryTiming {
+	var result []StoryTiming
+	index := make(map[string]int)
+	for _, t := range timings {
+		if t.At.Before(since) {
+			continue
+		}
+		i, ok := index[t.StoryID]
+		if !ok {
+			timing := StoryTiming{StoryID: t.StoryID, Title: t.StoryID}
+			for _, story := range p.UserStories {
+				if story.ID == t.StoryID {
+					timing.Title = story.Title
+					timing.Estimate = story.Estimate()
+					break
+				}
+			}
+			i = len(result)
+			index[t.StoryID] = i
+			result = append(result, timing)
+		}
+		result[i].Duration += t.Duration
+	}
+	return result
+}
+
+// estimateTotals sums the estimates of the timed stories that have one, and
+// the time actually spent on those stories.
+func estimateTotals(timings []StoryTiming) (estimated, actual time.Duration, stories int) {
+	for _, st := range timings {
+		if st.Estimate > 0 {
+			estimated += st.Estimate
+			actual += st.Duration
+			stories++
+		}
+	}
+	return estimated, actual, stories
+}
+
+// formatMinutes formats a duration to the minute, e.g. 45m, 2h or 1h30m.
+func formatMinutes(d time.Duration) string {
+	minutes := int(d.Round(time.Minute) / time.Minute)
+	switch {
+	case minutes < 60:
+		return fmt.Sprintf("%dm", minutes)
+	case minutes%60 == 0:
+		return fmt.Sprintf("%dh", minutes/60)
+	default:
+		return fmt.Sprintf("%dh%02dm", minutes/60, minutes%60)
+	}
+}
+
+// formatEstimateDelta formats how far actual ran over (+) or under (-) the
+// estimate, to the minute.
+func formatEstimateDelta(actual, estimate time.Duration) string {
+	delta := actual - estimate
+	if delta < 0 {
+		return "-" + formatMinutes(-delta)
+	}
+	return "+" + formatMinutes(delta)
+}
+
+// estimateDeltaStyle colors a delta by whether the story ran over its estimate.
+func estimateDeltaStyle(actual, estimate time.Duration) lipgloss.Style {
+	if actual-estimate >= time.Minute/2 {
+		return lipgloss.NewStyle().Foreground(WarningColor)
+	}
+	return lipgloss.NewStyle().Foreground(SuccessColor)
 }
 
 // CompletionScreen manages the completion screen state shown when a PRD finishes.
@@ -30,33 +105,47 @@ type CompletionScreen struct {
 	width  int
 	height int
 
-	prdName    string
-	completed  int
-	total      int
-	branch     string
-	commitCount int
+	prdName        string
+	completed      int
+	total          int
+	branch         string
+	commitCount    int
 	hasAutoActions bool // Whether push/PR auto-actions are configured
 
 	// Duration data
 	totalDuration time.Duration
 	storyTimings  []StoryTiming
+	skipped       []string // IDs of stories skipped instead of completed
+	usageTokens   string   // Tokens used, e.g. "1.2M" (empty = unknown)
+	usageCost     string   // Estimated cost of those tokens, e.g. "$4.10" (empty = no pricing)
 
 	// Confetti animation
-	confetti *Confetti
+	confetti      *Confetti
+	confettiTheme ConfettiTheme
 
 	// Auto-action state
 	pushState    AutoActionState
-	pushError    string
+	pushError    string // Why the push failed or was skipped
 	prState      AutoActionState
-	prError      string
+	prError      string // Why the PR failed or was skipped
 	prURL        string
 	prTitle      string
+	prDraft      bool
+	prReviewers  []string
+	remote       string // Remote the branch is pushed to (empty = not shown)
+	prBase       string // Branch the pull request targets (empty = not shown)
 	spinnerFrame int
 }
 
 // NewCompletionScreen creates a new completion screen.
 func NewCompletionScreen() *CompletionScreen {
-	return &CompletionScreen{}
+	return &CompletionScreen{confettiTheme: DefaultConfettiTheme()}
+}
+
+// SetConfettiTheme sets the glyphs and colors used for confetti, or disables it.
+// It takes effect the next time the screen is configured.
+func (c *CompletionScreen) SetConfettiTheme(theme ConfettiTheme) {
+	c.confettiTheme = theme
 }
 
 // Configure sets up the completion screen with PRD completion data.
@@ -69,6 +158,9 @@ func (c *CompletionScreen) Configure(prdName string, completed, total int, branc
 	c.hasAutoActions = hasAutoActions
 	c.totalDuration = totalDuration
 	c.storyTimings = storyTimings
+	c.usageTokens = ""
+	c.usageCost = ""
+	c.skipped = nil
 	// Reset auto-action state
 	c.pushState = AutoActionIdle
 	c.pushError = ""
@@ -76,24 +168,46 @@ func (c *CompletionScreen) Configure(prdName string, completed, total int, branc
 	c.prError = ""
 	c.prURL = ""
 	c.prTitle = ""
+	c.prDraft = false
+	c.prReviewers = nil
+	c.remote = ""
+	c.prBase = ""
 	c.spinnerFrame = 0
 	// Initialize confetti (deferred until SetSize if dimensions aren't known yet)
-	if c.width > 0 && c.height > 0 {
-		c.confetti = NewConfetti(c.width, c.height)
+	if c.width > 0 && c.height > 0 && !c.confettiTheme.Disabled {
+		c.confetti = NewConfetti(c.width, c.height, c.confettiTheme)
 	} else {
 		c.confetti = nil
 	}
 }
 
+// SetUsage sets the token count and estimated cost shown next to the duration.
+func (c *CompletionScreen) SetUsage(tokens, cost string) {
+	c.usageTokens = tokens
+	c.usageCost = cost
+}
+
+// SetRemote sets the remote the branch is pushed to and the branch its pull
+// request targets, named in the push and PR status lines.
+func (c *CompletionScreen) SetRemote(remote, base string) {
+	c.remote = remote
+	c.prBase = base
+}
+
+// SetSkipped sets the IDs of stories that were skipped rather than completed.
+func (c *CompletionScreen) SetSkipped(ids []string) {
+	c.skipped = ids
+}
+
 // SetSize sets the screen dimensions.
 func (c *CompletionScreen) SetSize(width, height int) {
 	c.width = width
 	c.height = height
 	if c.confetti != nil {
 		c.confetti.SetSize(width, height)
-	} else if c.prdName != "" && width > 0 && height > 0 {
+	} else if c.prdName != "" && width > 0 && height > 0 && !c.confettiTheme.Disabled {
 		// Initialize confetti now that we have real dimensions
-		c.confetti = NewConfetti(width, height)
+		c.confetti = NewConfetti(width, height, c.confettiTheme)
 	}
 }
 
@@ -128,16 +242,25 @@ func (c *CompletionScreen) SetPushError(errMsg string) {
 	c.pushError = errMsg
 }
 
+// SetPushSkipped marks the push as skipped for reason.
+func (c *CompletionScreen) SetPushSkipped(reason string) {
+	c.pushState = AutoActionSkipped
+	c.pushError = reason
+}
+
 // SetPRInProgress marks the PR creation as in progress.
 func (c *CompletionScreen) SetPRInProgress() {
 	c.prState = AutoActionInProgress
 }
 
-// SetPRSuccess marks the PR creation as successful.
-func (c *CompletionScreen) SetPRSuccess(url, title string) {
+// SetPRSuccess marks the PR creation as successful, noting whether it was
+// opened as a draft and who was asked to review it.
+func (c *CompletionScreen) SetPRSuccess(url, title string, draft bool, reviewers []string) {
 	c.prState = AutoActionSuccess
 	c.prURL = url
 	c.prTitle = title
+	c.prDraft = draft
+	c.prReviewers = reviewers
 }
 
 // SetPRError marks the PR creation as failed with an error message.
@@ -146,6 +269,12 @@ func (c *CompletionScreen) SetPRError(errMsg string) {
 	c.prError = errMsg
 }
 
+// SetPRSkipped marks the PR creation as skipped for reason.
+func (c *CompletionScreen) SetPRSkipped(reason string) {
+	c.prState = AutoActionSkipped
+	c.prError = reason
+}
+
 // Tick advances the spinner animation frame.
 func (c *CompletionScreen) Tick() {
 	c.spinnerFrame++
@@ -192,6 +321,11 @@ func (c *CompletionScreen) Render() string {
 	prdTitle := formatPRDTitle(c.prdName)
 	content.WriteString(subtitleStyle.Render(fmt.Sprintf("%s — %d/%d stories", prdTitle, c.completed, c.total)))
 	content.WriteString("\n")
+	if len(c.skipped) > 0 {
+		skipped := truncateWithEllipsis("Skipped: "+strings.Join(c.skipped, ", "), innerWidth)
+		content.WriteString(statusSkippedStyle.Render(skipped))
+		content.WriteString("\n")
+	}
 	content.WriteString(DividerStyle.Render(strings.Repeat("─", innerWidth)))
 	content.WriteString("\n")
 
@@ -199,7 +333,29 @@ func (c *CompletionScreen) Render() string {
 	if c.totalDuration > 0 {
 		content.WriteString("\n")
 		durationStyle := lipgloss.NewStyle().Foreground(SuccessColor)
-		content.WriteString(durationStyle.Render(fmt.Sprintf("Completed in %s", formatDuration(c.totalDuration))))
+		duration := fmt.Sprintf("Completed in %s", formatDuration(c.totalDuration))
+		if c.usageTokens != "" {
+			duration += " · " + c.usageTokens + " tokens"
+			if c.usageCost != "" {
+				duration += " (~" + c.usageCost + ")"
+			}
+		}
+		content.WriteString(durationStyle.Render(duration))
+		content.WriteString("\n")
+	}
+
+	// Estimated vs actual, for the stories that had an estimate
+	if estimated, actual, stories := estimateTotals(c.storyTimings); stories > 0 {
+		if c.totalDuration <= 0 {
+			content.WriteString("\n")
+		}
+		storyLabel := "story"
+		if stories != 1 {
+			storyLabel = "stories"
+		}
+		summary := fmt.Sprintf("Estimated %s for %d %s, took %s ", formatMinutes(estimated), stories, storyLabel, formatMinutes(actual))
+		content.WriteString(lipgloss.NewStyle().Foreground(TextColor).Render(summary))
+		content.WriteString(estimateDeltaStyle(actual, estimated).Render("(" + formatEstimateDelta(actual, estimated) + ")"))
 		content.WriteString("\n")
 	}
 
@@ -305,8 +461,19 @@ func (c *CompletionScreen) calculateModalHeight() int {
 	if c.totalDuration > 0 {
 		durationLine = 2 // blank + duration text
 	}
+	if _, _, stories := estimateTotals(c.storyTimings); stories > 0 {
+		durationLine++ // estimate summary
+		if c.totalDuration <= 0 {
+			durationLine++ // blank before it
+		}
+	}
+
+	skippedLine := 0
+	if len(c.skipped) > 0 {
+		skippedLine = 1
+	}
 
-	calculated := base + storyLines + autoLines + durationLine
+	calculated := base + storyLines + autoLines + durationLine + skippedLine
 	maxHeight := c.height - 4
 	if maxHeight < 10 {
 		maxHeight = 10
@@ -338,10 +505,17 @@ func (c *CompletionScreen) renderStoryTimings(innerWidth int) string {
 		}
 	}
 
+	// With any estimates, a column after the duration shows how each story
+	// did against its own
+	deltaWidth := 0
+	if _, _, stories := estimateTotals(c.storyTimings); stories > 0 {
+		deltaWidth = 7 // space + e.g. "+1h05m"
+	}
+
 	maxBarWidth := 10
-	// Layout: "✓ " + title + " " + dots + " " + duration + "  " + bar
-	// Reserve: 2 (check+space) + 1 (space before dots) + 1 (space after dots) + 8 (duration) + 2 (gap) + bar
-	fixedWidth := 2 + 1 + 1 + 8 + 2 + maxBarWidth
+	// Layout: "✓ " + title + " " + dots + " " + duration + delta + "  " + bar
+	// Reserve: 2 (check+space) + 1 (space before dots) + 1 (space after dots) + 8 (duration) + delta + 2 (gap) + bar
+	fixedWidth := 2 + 1 + 1 + 8 + deltaWidth + 2 + maxBarWidth
 	maxTitleWidth := innerWidth - fixedWidth
 	if maxTitleWidth < 10 {
 		maxTitleWidth = 10
@@ -375,7 +549,7 @@ func (c *CompletionScreen) renderStoryTimings(innerWidth int) string {
 		}
 
 		// Dot leaders
-		dotCount := innerWidth - 2 - titleLen - 1 - len(durStr) - 2 - maxBarWidth - 1
+		dotCount := innerWidth - 2 - titleLen - 1 - len(durStr) - deltaWidth - 2 - maxBarWidth - 1
 		if dotCount < 2 {
 			dotCount = 2
 		}
@@ -398,6 +572,14 @@ func (c *CompletionScreen) renderStoryTimings(innerWidth int) string {
 		b.WriteString(dotStyle.Render(dots))
 		b.WriteString(" ")
 		b.WriteString(durStyle.Render(durStr))
+		if deltaWidth > 0 {
+			delta := ""
+			if st.Estimate > 0 {
+				delta = formatEstimateDelta(st.Duration, st.Estimate)
+			}
+			b.WriteString(" ")
+			b.WriteString(estimateDeltaStyle(st.Duration, st.Estimate).Render(fmt.Sprintf("%-*s", deltaWidth-1, delta)))
+		}
 		b.WriteString("  ")
 		b.WriteString(barStyle.Render(bar))
 		b.WriteString("\n")
@@ -423,17 +605,29 @@ func (c *CompletionScreen) renderAutoActions(innerWidth int) string {
 	successStyle := lipgloss.NewStyle().Foreground(SuccessColor)
 	errorStyle := lipgloss.NewStyle().Foreground(ErrorColor)
 	spinnerStyle := lipgloss.NewStyle().Foreground(PrimaryColor)
+	skippedStyle := lipgloss.NewStyle().Foreground(MutedColor)
+
+	remote := "remote"
+	if c.remote != "" {
+		remote = c.remote
+	}
+	into := ""
+	if c.prBase != "" {
+		into = " into " + c.prBase
+	}
 
 	// Push status
 	if c.pushState != AutoActionIdle {
 		switch c.pushState {
 		case AutoActionInProgress:
 			frame := spinnerChars[c.spinnerFrame%len(spinnerChars)]
-			lines.WriteString(spinnerStyle.Render(fmt.Sprintf("%s Pushing branch to remote...", frame)))
+			lines.WriteString(spinnerStyle.Render(fmt.Sprintf("%s Pushing branch to %s...", frame, remote)))
 		case AutoActionSuccess:
-			lines.WriteString(successStyle.Render("✓ Pushed branch to remote"))
+			lines.WriteString(successStyle.Render("✓ Pushed branch to " + remote))
 		case AutoActionError:
 			lines.WriteString(errorStyle.Render(fmt.Sprintf("✗ Push failed: %s", c.pushError)))
+		case AutoActionSkipped:
+			lines.WriteString(skippedStyle.Render(fmt.Sprintf("– Skipped push: %s", c.pushError)))
 		}
 		lines.WriteString("\n")
 	}
@@ -443,13 +637,24 @@ func (c *CompletionScreen) renderAutoActions(innerWidth int) string {
 		switch c.prState {
 		case AutoActionInProgress:
 			frame := spinnerChars[c.spinnerFrame%len(spinnerChars)]
-			lines.WriteString(spinnerStyle.Render(fmt.Sprintf("%s Creating pull request...", frame)))
+			lines.WriteString(spinnerStyle.Render(fmt.Sprintf("%s Creating pull request%s...", frame, into)))
 		case AutoActionSuccess:
-			lines.WriteString(successStyle.Render(fmt.Sprintf("✓ Created PR: %s", c.prTitle)))
+			label := "PR"
+			if c.prDraft {
+				label = "PR (draft)"
+			}
+			label += into
+			lines.WriteString(successStyle.Render(fmt.Sprintf("✓ Created %s: %s", label, c.prTitle)))
 			lines.WriteString("\n")
 			lines.WriteString(infoStyle.Render(fmt.Sprintf("  %s", c.prURL)))
+			if len(c.prReviewers) > 0 {
+				lines.WriteString("\n")
+				lines.WriteString(infoStyle.Render(fmt.Sprintf("  Reviewers: %s", strings.Join(c.prReviewers, ", "))))
+			}
 		case AutoActionError:
 			lines.WriteString(errorStyle.Render(fmt.Sprintf("✗ PR creation failed: %s", c.prError)))
+		case AutoActionSkipped:
+			lines.WriteString(skippedStyle.Render(fmt.Sprintf("– Skipped PR: %s", c.prError)))
 		}
 		lines.WriteString("\n")
 	}
@@ -3,6 +3,11 @@ package tui
 import (
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/minicodemonkey/chief/internal/config"
+	"github.com/minicodemonkey/chief/internal/prd"
 )
 
 func TestCompletionScreen_Configure(t *testing.T) {
@@ -165,6 +170,30 @@ func TestCompletionScreen_PushInProgress(t *testing.T) {
 	}
 }
 
+func TestCompletionScreen_NamesRemoteAndBase(t *testing.T) {
+	cs := NewCompletionScreen()
+	cs.Configure("auth", 8, 8, "chief/auth", 5, true, 0, nil)
+	cs.SetRemote("fork", "develop")
+	cs.SetPushSuccess()
+	cs.SetPRSuccess("https://github.com/org/repo/pull/42", "feat(auth): Authentication", false, nil)
+	cs.SetSize(80, 40)
+
+	rendered := cs.Render()
+	if !strings.Contains(rendered, "Pushed branch to fork") {
+		t.Error("expected the push line to name the remote")
+	}
+	if !strings.Contains(rendered, "Created PR into develop") {
+		t.Error("expected the PR line to name the base branch")
+	}
+
+	// Configuring the screen for another PRD forgets them
+	cs.Configure("auth", 8, 8, "chief/auth", 5, true, 0, nil)
+	cs.SetPushSuccess()
+	if rendered := cs.Render(); !strings.Contains(rendered, "Pushed branch to remote") {
+		t.Error("expected the generic push line after reconfiguring")
+	}
+}
+
 func TestCompletionScreen_PushSuccess(t *testing.T) {
 	cs := NewCompletionScreen()
 	cs.Configure("auth", 8, 8, "chief/auth", 5, true, 0, nil)
@@ -196,6 +225,51 @@ func TestCompletionScreen_PushError(t *testing.T) {
 	}
 }
 
+func TestCompletionScreen_Skipped(t *testing.T) {
+	cs := NewCompletionScreen()
+	cs.Configure("auth", 8, 8, "chief/auth", 0, true, 0, nil)
+	cs.SetPushSkipped("no new commits on the branch")
+	cs.SetPRSkipped("the branch was not pushed")
+	cs.SetSize(80, 40)
+
+	rendered := cs.Render()
+	for _, want := range []string{"Skipped push: no new commits on the branch", "Skipped PR: the branch was not pushed"} {
+		if !strings.Contains(rendered, want) {
+			t.Errorf("expected %q, got:\n%s", want, rendered)
+		}
+	}
+	if cs.IsAutoActionRunning() {
+		t.Error("expected skipped actions not to count as running")
+	}
+}
+
+func TestAutoActionSkipReasons(t *testing.T) {
+	requireAll := config.OnCompleteConfig{PRRequiresAllPass: true}
+	tests := []struct {
+		name       string
+		onComplete config.OnCompleteConfig
+		facts      completionFacts
+		wantPush   string
+		wantPR     string
+	}{
+		{"all pass with commits", requireAll, completionFacts{completed: 3, total: 3, commits: 2, commitsKnown: true}, "", ""},
+		{"no commits", config.OnCompleteConfig{}, completionFacts{completed: 3, total: 3, commitsKnown: true}, "no new commits on the branch", "no new commits on the branch"},
+		{"commits unknown", config.OnCompleteConfig{}, completionFacts{completed: 3, total: 3}, "", ""},
+		{"partial, PR allowed", config.OnCompleteConfig{}, completionFacts{completed: 2, total: 3, commits: 1, commitsKnown: true}, "", ""},
+		{"partial, PR requires all", requireAll, completionFacts{completed: 2, total: 3, commits: 1, commitsKnown: true}, "", "only 2/3 stories pass"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pushSkipReason(tt.facts); got != tt.wantPush {
+				t.Errorf("pushSkipReason() = %q, want %q", got, tt.wantPush)
+			}
+			if got := prSkipReason(tt.onComplete, tt.facts); got != tt.wantPR {
+				t.Errorf("prSkipReason() = %q, want %q", got, tt.wantPR)
+			}
+		})
+	}
+}
+
 func TestCompletionScreen_PRInProgress(t *testing.T) {
 	cs := NewCompletionScreen()
 	cs.Configure("auth", 8, 8, "chief/auth", 5, true, 0, nil)
@@ -216,7 +290,7 @@ func TestCompletionScreen_PRSuccess(t *testing.T) {
 	cs := NewCompletionScreen()
 	cs.Configure("auth", 8, 8, "chief/auth", 5, true, 0, nil)
 	cs.SetPushSuccess()
-	cs.SetPRSuccess("https://github.com/org/repo/pull/42", "feat(auth): Authentication")
+	cs.SetPRSuccess("https://github.com/org/repo/pull/42", "feat(auth): Authentication", false, nil)
 	cs.SetSize(80, 40)
 
 	rendered := cs.Render()
@@ -234,6 +308,38 @@ func TestCompletionScreen_PRSuccess(t *testing.T) {
 	}
 }
 
+func TestCompletionScreen_PRSuccessDraftWithReviewers(t *testing.T) {
+	cs := NewCompletionScreen()
+	cs.Configure("auth", 8, 8, "chief/auth", 5, true, 0, nil)
+	cs.SetPushSuccess()
+	cs.SetPRSuccess("https://github.com/org/repo/pull/42", "feat(auth): Authentication", true, []string{"alice", "bob"})
+	cs.SetSize(80, 40)
+
+	rendered := cs.Render()
+	if !strings.Contains(rendered, "Created PR (draft)") {
+		t.Error("expected draft marker on the PR line")
+	}
+	if !strings.Contains(rendered, "Reviewers: alice, bob") {
+		t.Error("expected reviewers in render output")
+	}
+}
+
+func TestCompletionScreen_Usage(t *testing.T) {
+	cs := NewCompletionScreen()
+	cs.Configure("auth", 8, 8, "chief/auth", 5, false, 90*time.Second, nil)
+	cs.SetUsage("1.2M", "$4.10")
+	cs.SetSize(80, 40)
+
+	if rendered := cs.Render(); !strings.Contains(rendered, "Completed in 1m30s · 1.2M tokens (~$4.10)") {
+		t.Errorf("expected usage next to the duration, got:\n%s", rendered)
+	}
+
+	cs.Configure("auth", 8, 8, "chief/auth", 5, false, 90*time.Second, nil)
+	if cs.usageTokens != "" || cs.usageCost != "" {
+		t.Errorf("expected Configure to reset usage, got %q %q", cs.usageTokens, cs.usageCost)
+	}
+}
+
 func TestCompletionScreen_PRError(t *testing.T) {
 	cs := NewCompletionScreen()
 	cs.Configure("auth", 8, 8, "chief/auth", 5, true, 0, nil)
@@ -254,7 +360,7 @@ func TestCompletionScreen_ConfigureResetsAutoActions(t *testing.T) {
 	cs := NewCompletionScreen()
 	cs.Configure("auth", 8, 8, "chief/auth", 5, true, 0, nil)
 	cs.SetPushSuccess()
-	cs.SetPRSuccess("https://example.com", "title")
+	cs.SetPRSuccess("https://example.com", "title", true, []string{"alice"})
 
 	// Reconfigure should reset
 	cs.Configure("payments", 3, 5, "chief/payments", 2, false, 0, nil)
@@ -268,6 +374,9 @@ func TestCompletionScreen_ConfigureResetsAutoActions(t *testing.T) {
 	if cs.prURL != "" {
 		t.Error("expected prURL to be empty after Configure")
 	}
+	if cs.prDraft || cs.prReviewers != nil {
+		t.Error("expected draft and reviewers to be reset after Configure")
+	}
 }
 
 func TestCompletionScreen_Tick(t *testing.T) {
@@ -320,3 +429,101 @@ func TestCenterModal(t *testing.T) {
 		t.Error("expected top padding in centered modal")
 	}
 }
+
+func TestCompletionScreen_ConfettiDisabled(t *testing.T) {
+	cs := NewCompletionScreen()
+	cs.SetConfettiTheme(ConfettiTheme{Disabled: true})
+	cs.Configure("auth", 8, 8, "", 0, false, 0, nil)
+	cs.SetSize(80, 40)
+
+	if cs.HasConfetti() {
+		t.Error("expected no confetti when disabled")
+	}
+	if !strings.Contains(cs.Render(), "PRD Complete!") {
+		t.Error("expected completion screen to render without confetti")
+	}
+}
+
+func TestConfettiThemeFromConfig(t *testing.T) {
+	theme := ConfettiThemeFromConfig(config.ConfettiConfig{
+		Chars:  []string{"*", "", "🎉", "+"},
+		Colors: []string{"#FF0000", ""},
+	})
+	if len(theme.Chars) != 2 || theme.Chars[0] != "*" || theme.Chars[1] != "+" {
+		t.Errorf("expected only single-cell glyphs [* +], got %v", theme.Chars)
+	}
+	if len(theme.Colors) != 1 || theme.Colors[0] != lipgloss.Color("#FF0000") {
+		t.Errorf("expected colors [#FF0000], got %v", theme.Colors)
+	}
+
+	theme = ConfettiThemeFromConfig(config.ConfettiConfig{})
+	if len(theme.Chars) != len(confettiChars) || len(theme.Colors) != len(confettiColors) {
+		t.Error("expected default glyphs and colors when none are configured")
+	}
+
+	c := NewConfetti(20, 10, ConfettiThemeFromConfig(config.ConfettiConfig{Chars: []string{"*"}}))
+	for _, p := range c.particles {
+		if p.char != "*" {
+			t.Fatalf("expected every particle to use the configured glyph, got %q", p.char)
+		}
+	}
+}
+
+func TestStoryTimingsSince(t *testing.T) {
+	start := time.Date(2026, 3, 4, 9, 0, 0, 0, time.UTC)
+	p := &prd.PRD{UserStories: []prd.UserStory{{ID: "US-001", Title: "Setup"}, {ID: "US-002", Title: "Login", EstimateMinutes: 5}}}
+	timings := []prd.StoryTime{
+		{StoryID: "US-001", Duration: time.Hour, At: start.Add(-time.Minute)}, // an earlier run
+		{StoryID: "US-002", Duration: 2 * time.Minute, At: start.Add(2 * time.Minute)},
+		{StoryID: "US-001", Duration: 3 * time.Minute, At: start.Add(5 * time.Minute)},
+		{StoryID: "US-002", Duration: time.Minute, At: start.Add(6 * time.Minute)},
+	}
+
+	got := storyTimingsSince(p, timings, start)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 stories, got %+v", got)
+	}
+	if got[0].StoryID != "US-002" || got[0].Title != "Login" || got[0].Duration != 3*time.Minute || got[0].Estimate != 5*time.Minute {
+		t.Errorf("unexpected first timing: %+v", got[0])
+	}
+	if got[1].StoryID != "US-001" || got[1].Duration != 3*time.Minute || got[1].Estimate != 0 {
+		t.Errorf("unexpected second timing: %+v", got[1])
+	}
+}
+
+func TestCompletionScreen_Estimates(t *testing.T) {
+	cs := NewCompletionScreen()
+	cs.Configure("auth", 3, 3, "chief/auth", 3, false, 50*time.Minute, []StoryTiming{
+		{StoryID: "US-001", Title: "Setup", Duration: 20 * time.Minute, Estimate: 15 * time.Minute},
+		{StoryID: "US-002", Title: "Login", Duration: 10 * time.Minute, Estimate: 30 * time.Minute},
+		{StoryID: "US-003", Title: "Logout", Duration: 20 * time.Minute},
+	})
+	cs.SetSize(80, 40)
+	rendered := stripANSI(cs.Render())
+
+	if !strings.Contains(rendered, "Estimated 45m for 2 stories, took 30m (-15m)") {
+		t.Errorf("expected an estimated vs actual summary, got:\n%s", rendered)
+	}
+	for _, want := range []string{"20m00s +5m", "10m00s -20m"} {
+		if !strings.Contains(rendered, want) {
+			t.Errorf("expected %q against the story's estimate, got:\n%s", want, rendered)
+		}
+	}
+}
+
+func TestFormatMinutes(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "0m"},
+		{45 * time.Minute, "45m"},
+		{2 * time.Hour, "2h"},
+		{90*time.Minute + 20*time.Second, "1h30m"},
+	}
+	for _, tt := range tests {
+		if got := formatMinutes(tt.d); got != tt.want {
+			t.Errorf("formatMinutes(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
@@ -5,20 +5,69 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/minicodemonkey/chief/internal/config"
 )
 
-// confettiChars are the characters used for confetti particles.
+// confettiChars are the default characters used for confetti particles.
 var confettiChars = []string{"✦", "★", "●", "◆", "♦", "▲", "■", "♥", "✧", "⬥"}
 
-// confettiColors are the colors used for confetti particles.
-var confettiColors = []lipgloss.Color{
-	SuccessColor,
-	PrimaryColor,
-	WarningColor,
-	ErrorColor,
-	lipgloss.Color("#FF6AC1"), // Pink
-	lipgloss.Color("#FFD700"), // Gold
-	lipgloss.Color("#FF8C00"), // Dark orange
+// confettiColors are the default colors used for confetti particles.
+var confettiColors = defaultConfettiColors()
+
+// defaultConfettiColors returns the theme's status colors plus a few festive
+// extras.
+func defaultConfettiColors() []lipgloss.Color {
+	return []lipgloss.Color{
+		SuccessColor,
+		PrimaryColor,
+		WarningColor,
+		ErrorColor,
+		lipgloss.Color("#FF6AC1"), // Pink
+		lipgloss.Color("#FFD700"), // Gold
+		lipgloss.Color("#FF8C00"), // Dark orange
+	}
+}
+
+// ConfettiTheme controls how the completion screen confetti looks.
+type ConfettiTheme struct {
+	Disabled bool
+	Chars    []string
+	Colors   []lipgloss.Color
+}
+
+// DefaultConfettiTheme returns the built-in confetti glyphs and colors.
+func DefaultConfettiTheme() ConfettiTheme {
+	return ConfettiTheme{Chars: confettiChars, Colors: confettiColors}
+}
+
+// ConfettiThemeFromConfig builds a confetti theme from config, keeping the
+// default glyphs or colors when none are configured. Glyphs that aren't
+// exactly one cell wide are dropped since each particle occupies one cell.
+func ConfettiThemeFromConfig(cfg config.ConfettiConfig) ConfettiTheme {
+	theme := DefaultConfettiTheme()
+	theme.Disabled = cfg.Disabled
+
+	var chars []string
+	for _, ch := range cfg.Chars {
+		if lipgloss.Width(ch) == 1 {
+			chars = append(chars, ch)
+		}
+	}
+	if len(chars) > 0 {
+		theme.Chars = chars
+	}
+
+	var colors []lipgloss.Color
+	for _, color := range cfg.Colors {
+		if color != "" {
+			colors = append(colors, lipgloss.Color(color))
+		}
+	}
+	if len(colors) > 0 {
+		theme.Colors = colors
+	}
+
+	return theme
 }
 
 // Particle represents a single confetti particle.
@@ -35,6 +84,7 @@ type Confetti struct {
 	particles []Particle
 	width     int
 	height    int
+	theme     ConfettiTheme
 }
 
 // SetSize updates the confetti bounds to match the current screen size.
@@ -44,10 +94,17 @@ func (c *Confetti) SetSize(width, height int) {
 }
 
 // NewConfetti creates a new confetti system with particles spread across the screen.
-func NewConfetti(width, height int) *Confetti {
+func NewConfetti(width, height int, theme ConfettiTheme) *Confetti {
+	if len(theme.Chars) == 0 {
+		theme.Chars = confettiChars
+	}
+	if len(theme.Colors) == 0 {
+		theme.Colors = confettiColors
+	}
 	c := &Confetti{
 		width:  width,
 		height: height,
+		theme:  theme,
 	}
 
 	count := 80 + rand.Intn(40) // 80-120 particles
@@ -55,13 +112,13 @@ func NewConfetti(width, height int) *Confetti {
 
 	for i := range c.particles {
 		c.particles[i] = Particle{
-			x:    rand.Float64() * float64(width),
-			y:    rand.Float64()*float64(height+10) - float64(height/2), // stagger: some above screen, some mid
-			vx:   (rand.Float64() - 0.5) * 0.6,                         // lateral drift -0.3 to 0.3
-			vy:   0.2 + rand.Float64()*0.4,                              // falling 0.2-0.6
-			char: confettiChars[rand.Intn(len(confettiChars))],
-			color: confettiColors[rand.Intn(len(confettiColors))],
-			life: 80 + rand.Intn(120), // 80-200 ticks
+			x:     rand.Float64() * float64(width),
+			y:     rand.Float64()*float64(height+10) - float64(height/2), // stagger: some above screen, some mid
+			vx:    (rand.Float64() - 0.5) * 0.6,                          // lateral drift -0.3 to 0.3
+			vy:    0.2 + rand.Float64()*0.4,                              // falling 0.2-0.6
+			char:  c.theme.Chars[rand.Intn(len(c.theme.Chars))],
+			color: c.theme.Colors[rand.Intn(len(c.theme.Colors))],
+			life:  80 + rand.Intn(120), // 80-200 ticks
 		}
 	}
 
@@ -83,8 +140,8 @@ func (c *Confetti) Tick() {
 				y:     -rand.Float64() * float64(c.height/3),
 				vx:    (rand.Float64() - 0.5) * 0.6,
 				vy:    0.2 + rand.Float64()*0.4,
-				char:  confettiChars[rand.Intn(len(confettiChars))],
-				color: confettiColors[rand.Intn(len(confettiColors))],
+				char:  c.theme.Chars[rand.Intn(len(c.theme.Chars))],
+				color: c.theme.Colors[rand.Intn(len(c.theme.Colors))],
 				life:  80 + rand.Intn(120),
 			}
 		}
@@ -0,0 +1,101 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/minicodemonkey/chief/internal/paths"
+	"github.com/minicodemonkey/chief/internal/prd"
+)
+
+// crashLog records the crash report written for the first panic, so the
+// caller can point the user at it once the terminal has been restored.
+var crashLog struct {
+	mu   sync.Mutex
+	path string
+}
+
+// recoverPanic captures a panic in Update/View, writes a crash report, and
+// re-panics so Bubble Tea can restore the terminal and end the program.
+// Must be called directly via defer.
+func (a *App) recoverPanic() {
+	r := recover()
+	if r == nil {
+		return
+	}
+	a.writeCrashReport(r, debug.Stack())
+	panic(r)
+}
+
+// writeCrashReport appends the panic value and stack trace to the project's
+// crash log, or to chief-crash.log in the temp directory when there is no
+// project yet. Only the first panic is recorded.
+func (a *App) writeCrashReport(r any, stack []byte) {
+	crashLog.mu.Lock()
+	defer crashLog.mu.Unlock()
+	if crashLog.path != "" {
+		return
+	}
+
+	path := filepath.Join(os.TempDir(), "chief-crash.log")
+	if a.baseDir != "" {
+		path = paths.CrashLogPath(a.baseDir)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	fmt.Fprintf(f, "=== chief crash at %s ===\n", time.Now().Format(time.RFC3339))
+	fmt.Fprintf(f, "PRD: %s\nView: %d\nState: %s\n", a.prdName, a.viewMode, a.state)
+	fmt.Fprintf(f, "panic: %v\n\n%s\n", r, stack)
+	crashLog.path = path
+}
+
+// CleanupAfterCrash stops all running loops and clears in-progress flags on
+// disk after the program panicked, so agents don't keep running unattended
+// and the next launch doesn't see phantom interrupted stories. Returns the
+// crash log path, or empty if no report was written.
+func (a *App) CleanupAfterCrash() string {
+	prdPaths := []string{a.prdPath}
+	if a.manager != nil {
+		for _, inst := range a.manager.GetAllInstances() {
+			prdPaths = append(prdPaths, inst.PRDPath)
+		}
+		a.manager.StopAll()
+		a.manager.CloseEventSocket()
+	}
+	for _, path := range prdPaths {
+		clearInProgressOnDisk(path)
+	}
+
+	crashLog.mu.Lock()
+	defer crashLog.mu.Unlock()
+	return crashLog.path
+}
+
+// clearInProgressOnDisk clears in-progress flags in the PRD file at path.
+func clearInProgressOnDisk(path string) {
+	p, err := prd.LoadPRD(path)
+	if err != nil {
+		return
+	}
+	dirty := false
+	for i := range p.UserStories {
+		if p.UserStories[i].InProgress {
+			p.UserStories[i].InProgress = false
+			dirty = true
+		}
+	}
+	if dirty {
+		_ = p.Save(path)
+	}
+}
@@ -0,0 +1,80 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/minicodemonkey/chief/internal/loop"
+	"github.com/minicodemonkey/chief/internal/paths"
+	"github.com/minicodemonkey/chief/internal/prd"
+)
+
+func TestRecoverPanicWritesCrashReportAndCleansUp(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+	crashLog.path = ""
+	defer func() { crashLog.path = "" }()
+
+	baseDir := t.TempDir()
+	prdPath := filepath.Join(t.TempDir(), "prd.json")
+	p := &prd.PRD{UserStories: []prd.UserStory{{ID: "US-001", InProgress: true}}}
+	if err := p.Save(prdPath); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	app := &App{baseDir: baseDir, prdName: "auth", prdPath: prdPath, prd: p, manager: loop.NewManager(5)}
+
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("expected the panic to be re-raised for Bubble Tea")
+			}
+		}()
+		defer app.recoverPanic()
+		var stories []prd.UserStory
+		_ = stories[3]
+	}()
+
+	logPath := app.CleanupAfterCrash()
+	if logPath != paths.CrashLogPath(baseDir) {
+		t.Fatalf("expected crash log at %s, got %q", paths.CrashLogPath(baseDir), logPath)
+	}
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("failed to read crash log: %v", err)
+	}
+	if !strings.Contains(string(data), "index out of range") || !strings.Contains(string(data), "PRD: auth") {
+		t.Errorf("expected panic details in crash log, got:\n%s", data)
+	}
+
+	loaded, err := prd.LoadPRD(prdPath)
+	if err != nil {
+		t.Fatalf("LoadPRD failed: %v", err)
+	}
+	if loaded.UserStories[0].InProgress {
+		t.Error("expected in-progress flag to be cleared after crash")
+	}
+}
+
+func TestCrashReportWithoutProject(t *testing.T) {
+	home := t.TempDir()
+	restore := paths.SetHomeDir(home)
+	defer restore()
+	tmp := t.TempDir()
+	t.Setenv("TMPDIR", tmp)
+	crashLog.path = ""
+	defer func() { crashLog.path = "" }()
+
+	app := &App{}
+	app.writeCrashReport("boom", []byte("stack"))
+
+	want := filepath.Join(tmp, "chief-crash.log")
+	if crashLog.path != want {
+		t.Fatalf("expected crash log at %s, got %q", want, crashLog.path)
+	}
+	if _, err := os.Stat(filepath.Join(home, ".chief")); !os.IsNotExist(err) {
+		t.Error("expected nothing written under ~/.chief without a project")
+	}
+}
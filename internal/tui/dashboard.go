@@ -6,7 +6,7 @@ import (
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
-	"github.com/minicodemonkey/chief/internal/paths"
+	"github.com/minicodemonkey/chief/internal/config"
 	"github.com/minicodemonkey/chief/internal/prd"
 )
 
@@ -14,7 +14,7 @@ const (
 	// Layout constants
 	minWidth             = 80
 	narrowWidthThreshold = 100 // Below this, switch to stacked layout
-	storiesPanelPct      = 35  // Stories panel takes 35% of width
+	storiesPanelPct      = 35  // Stories panel takes 35% of width unless ui.storiesPanelPct says otherwise
 	detailsPanelPct      = 65  // Details panel takes 65% of width
 	headerHeight         = 5   // Increased to accommodate tab bar (brand line + tab bar + border)
 	footerHeight         = 3   // Increased to accommodate activity line
@@ -22,11 +22,28 @@ const (
 	progressBarWidth     = 20
 )
 
-// isNarrowMode returns true if the terminal width is below the threshold for stacked layout.
+// isNarrowMode returns true if the dashboard uses the stacked layout: when
+// ui.forceLayout asks for it, or when the terminal width is below the
+// threshold and no layout is forced.
 func (a *App) isNarrowMode() bool {
+	switch a.layout.ForceLayout {
+	case config.LayoutStacked:
+		return true
+	case config.LayoutWide:
+		return false
+	}
 	return a.width < narrowWidthThreshold
 }
 
+// storiesPanelPercent returns the share of the width the stories panel
+// takes in the side-by-side layout.
+func (a *App) storiesPanelPercent() int {
+	if a.layout.StoriesPanelPct != 0 {
+		return a.layout.StoriesPanelPct
+	}
+	return storiesPanelPct
+}
+
 // renderDashboard renders the full dashboard view.
 func (a *App) renderDashboard() string {
 	if a.width == 0 || a.height == 0 {
@@ -45,7 +62,7 @@ func (a *App) renderDashboard() string {
 	contentHeight := a.height - a.effectiveHeaderHeight() - footerHeight - 2 // -2 for panel borders
 
 	// Render panels
-	storiesWidth := (a.width * storiesPanelPct / 100) - 2
+	storiesWidth, _ := a.storiesPanelSize()
 	detailsWidth := a.width - storiesWidth - 4 // -4 for borders and gap
 
 	storiesPanel := a.renderStoriesPanel(storiesWidth, contentHeight)
@@ -58,6 +75,17 @@ func (a *App) renderDashboard() string {
 	return lipgloss.JoinVertical(lipgloss.Left, header, content, footer)
 }
 
+// storiesPanelSize returns the width and height the stories panel is
+// rendered at, excluding its border, in either layout.
+func (a *App) storiesPanelSize() (width, height int) {
+	contentHeight := a.height - a.effectiveHeaderHeight() - footerHeight - 2 // -2 for panel borders
+	if a.isNarrowMode() {
+		// Stacked: full width, 40% of the height
+		return a.width - 2, max((contentHeight*40)/100, 5)
+	}
+	return (a.width * a.storiesPanelPercent() / 100) - 2, contentHeight
+}
+
 // renderStackedDashboard renders the dashboard with stacked layout for narrow terminals.
 func (a *App) renderStackedDashboard() string {
 	header := a.renderNarrowHeader()
@@ -67,11 +95,9 @@ func (a *App) renderStackedDashboard() string {
 	contentHeight := a.height - a.effectiveHeaderHeight() - footerHeight - 2 // -2 for panel borders
 
 	// Split height between stories (40%) and details (60%)
-	storiesHeight := max((contentHeight*40)/100, 5)
+	panelWidth, storiesHeight := a.storiesPanelSize()
 	detailsHeight := contentHeight - storiesHeight - 1 // -1 for gap between panels
 
-	panelWidth := a.width - 2 // Account for borders
-
 	storiesPanel := a.renderStoriesPanel(panelWidth, storiesHeight)
 	detailsPanel := a.renderDetailsPanel(panelWidth, detailsHeight)
 
@@ -94,8 +120,7 @@ func (a *App) getWorktreeInfo() (branch, dir string) {
 	}
 	branch = instance.Branch
 	if instance.WorktreeDir != "" {
-		// Convert absolute worktree path to relative for display
-		dir = paths.WorktreeDir(a.baseDir, a.prdName)
+		dir = displayWorktreePath(a.baseDir, instance.WorktreeDir)
 	} else {
 		dir = "./ (current directory)"
 	}
@@ -149,7 +174,17 @@ func (a *App) renderHeader() string {
 
 	// Combine elements
 	leftPart := lipgloss.JoinHorizontal(lipgloss.Center, brand, "  ", state)
+	if badge := a.renderReadOnlyBadge(); badge != "" {
+		leftPart = lipgloss.JoinHorizontal(lipgloss.Center, leftPart, "  ", badge)
+	}
 	rightPart := lipgloss.JoinHorizontal(lipgloss.Center, iteration, "  ", elapsedStr)
+	if tokens, cost := a.usageSummary(a.prdName); tokens != "" {
+		usage := "tok: " + tokens
+		if cost != "" {
+			usage += " ~" + cost
+		}
+		rightPart = lipgloss.JoinHorizontal(lipgloss.Center, rightPart, "  ", SubtitleStyle.Render(usage))
+	}
 
 	// Create the full header line with proper spacing
 	spacing := strings.Repeat(" ", max(0, a.width-lipgloss.Width(leftPart)-lipgloss.Width(rightPart)-2))
@@ -197,6 +232,9 @@ func (a *App) renderNarrowHeader() string {
 
 	// Combine elements
 	leftPart := lipgloss.JoinHorizontal(lipgloss.Center, brand, " ", state)
+	if badge := a.renderReadOnlyBadge(); badge != "" {
+		leftPart = lipgloss.JoinHorizontal(lipgloss.Center, leftPart, " ", badge)
+	}
 	rightPart := iterTime
 
 	// Create the full header line with proper spacing
@@ -223,29 +261,54 @@ func (a *App) renderFooter() string {
 	// Keyboard shortcuts (context-sensitive based on view and state)
 	var shortcuts []string
 
-	if a.viewMode == ViewLog {
+	if a.jumpInput {
+		shortcuts = []string{"type a story ID or number", "tab: complete", "enter: go", "esc: cancel"}
+	} else if a.viewMode == ViewLog {
 		// Log view shortcuts
-		shortcuts = []string{"t: dashboard", "d: diff", "e: edit", "n: new", "l: list", "1-9: switch", "?: help", "j/k: scroll", "q: quit"}
+		shortcuts = []string{a.keys.Hint(ActionLog, "dashboard"), a.keys.Hint(ActionDiff, "diff"), a.keys.Hint(ActionEdit, "edit"), a.keys.Hint(ActionNew, "new"), "l: list", "1-9: switch", a.keys.Hint(ActionHelp, "help"), "j/k: scroll", "T/C/R/E: filter", "w: save", "y: copy", "q: quit"}
 	} else if a.viewMode == ViewDiff {
 		// Diff view shortcuts
-		shortcuts = []string{"d: dashboard", "t: log", "e: edit", "n: new", "l: list", "?: help", "j/k: scroll", "q: quit"}
+		shortcuts = []string{a.keys.Hint(ActionDiff, "dashboard"), a.keys.Hint(ActionLog, "log"), a.keys.Hint(ActionEdit, "edit"), a.keys.Hint(ActionNew, "new"), "l: list", a.keys.Hint(ActionHelp, "help"), "j/k: scroll", "u: uncommitted", "[/]: context", "v: split", "y: copy", "q: quit"}
+	} else if a.viewMode == ViewOverview {
+		// Overview shortcuts
+		shortcuts = []string{"o: dashboard", a.keys.Hint(ActionLog, "log"), a.keys.Hint(ActionDiff, "diff"), "D: decisions", a.keys.Hint(ActionEdit, "edit"), a.keys.Hint(ActionNew, "new"), "l: list", "1-9: switch", a.keys.Hint(ActionHelp, "help"), "q: quit"}
+	} else if a.viewMode == ViewDecisions {
+		shortcuts = []string{"D/esc: dashboard", a.keys.Hint(ActionHelp, "help"), "q: quit"}
+	} else if a.storyFilterInput {
+		shortcuts = []string{"type to filter stories", "↑/↓: select", "enter: done", "esc: clear"}
+	} else if a.noteEditing {
+		shortcuts = []string{"type a note for progress.md", "enter: save", "esc: cancel"}
 	} else {
-		// Dashboard view shortcuts
+		// Dashboard view shortcuts, with per-story actions for the selected entry
+		story := a.buildStoryShortcuts()
 		switch a.state {
 		case StateReady, StatePaused:
-			shortcuts = []string{"s: start", "d: diff", "e: edit", "t: log", "n: new", "l: list", "1-9: switch", "?: help", "q: quit"}
-		case StateRunning:
-			shortcuts = []string{"p: pause", "x: stop", "d: diff", "t: log", "n: new", "l: list", "1-9: switch", "?: help", "q: quit"}
+			shortcuts = append([]string{a.keys.Hint(ActionStart, "start")}, story...)
+			shortcuts = append(shortcuts, a.keys.Hint(ActionEdit, "edit"), "/: filter", a.keys.Hint(ActionLog, "log"), "o: overview", a.keys.Hint(ActionNew, "new"), "l: list", "1-9: switch", a.keys.Hint(ActionHelp, "help"), "q: quit")
+		case StateRunning, StateQueued:
+			shortcuts = append([]string{a.keys.Hint(ActionPause, "pause"), a.keys.Hint(ActionStop, "stop")}, story...)
+			if a.canSkipCurrentStory() {
+				shortcuts = append(shortcuts, a.keys.Hint(ActionSkip, "skip story"))
+			}
+			shortcuts = append(shortcuts, "/: filter", a.keys.Hint(ActionLog, "log"), "o: overview", a.keys.Hint(ActionNew, "new"), "l: list", "1-9: switch", a.keys.Hint(ActionHelp, "help"), "q: quit")
 		case StateStopped, StateError:
-			shortcuts = []string{"s: retry", "d: diff", "e: edit", "t: log", "n: new", "l: list", "1-9: switch", "?: help", "q: quit"}
+			shortcuts = append([]string{a.keys.Hint(ActionStart, "retry")}, story...)
+			shortcuts = append(shortcuts, a.keys.Hint(ActionEdit, "edit"), "/: filter", a.keys.Hint(ActionLog, "log"), "o: overview", a.keys.Hint(ActionNew, "new"), "l: list", "1-9: switch", a.keys.Hint(ActionHelp, "help"), "q: quit")
 		default:
-			shortcuts = []string{"d: diff", "e: edit", "t: log", "n: new", "l: list", "1-9: switch", "?: help", "q: quit"}
+			shortcuts = append(story, a.keys.Hint(ActionEdit, "edit"), "/: filter", a.keys.Hint(ActionLog, "log"), "o: overview", a.keys.Hint(ActionNew, "new"), "l: list", "1-9: switch", a.keys.Hint(ActionHelp, "help"), "q: quit")
 		}
 	}
 	shortcutsStr := footerStyle.Render(strings.Join(shortcuts, "  │  "))
 
-	// PRD name
-	prdInfo := footerStyle.Render(fmt.Sprintf("PRD: %s", a.prdName))
+	// PRD name, prefixed by the file the agent is working in
+	prdInfoText := fmt.Sprintf("PRD: %s", a.prdName)
+	if file := a.displayCurrentFile(); file != "" {
+		maxFileLen := a.width - lipgloss.Width(shortcutsStr) - len(prdInfoText) - 12
+		if maxFileLen >= 10 {
+			prdInfoText = fmt.Sprintf("✏ %s  │  %s", truncatePathLeft(file, maxFileLen), prdInfoText)
+		}
+	}
+	prdInfo := footerStyle.Render(prdInfoText)
 
 	// Create footer line with proper spacing
 	spacing := strings.Repeat(" ", max(0, a.width-lipgloss.Width(shortcutsStr)-lipgloss.Width(prdInfo)-2))
@@ -260,6 +323,39 @@ func (a *App) renderFooter() string {
 	return lipgloss.JoinVertical(lipgloss.Left, border, activityLine, footerLine)
 }
 
+// buildStoryShortcuts builds context-sensitive shortcuts based on the selected story's state.
+func (a *App) buildStoryShortcuts() []string {
+	story := a.GetSelectedStory()
+	if story == nil {
+		return []string{a.keys.Hint(ActionDiff, "diff")}
+	}
+
+	var shortcuts []string
+	switch {
+	case story.Passes:
+		shortcuts = []string{a.keys.Hint(ActionDiff, "commit diff")}
+		if a.canReopenSelectedStory() {
+			shortcuts = append(shortcuts, "r: reopen")
+		}
+	case story.Skipped:
+		shortcuts = []string{a.keys.Hint(ActionDiff, "diff")}
+		if a.canReopenSelectedStory() {
+			shortcuts = append(shortcuts, "r: reopen")
+		}
+	case story.InProgress:
+		shortcuts = []string{a.keys.Hint(ActionDiff, "diff so far")}
+	default:
+		shortcuts = []string{a.keys.Hint(ActionDiff, "diff")}
+		if a.canStartAtSelectedStory() {
+			shortcuts = append(shortcuts, "S: start here")
+		}
+	}
+	if story.TicketURL != "" {
+		shortcuts = append(shortcuts, "O: ticket")
+	}
+	return shortcuts
+}
+
 // renderNarrowFooter renders a condensed footer for narrow terminals.
 func (a *App) renderNarrowFooter() string {
 	// Condensed keyboard shortcuts for narrow mode
@@ -267,18 +363,28 @@ func (a *App) renderNarrowFooter() string {
 
 	if a.viewMode == ViewLog {
 		// Log view shortcuts - condensed
-		shortcuts = []string{"t", "e", "n", "1-9", "?", "q"}
+		shortcuts = []string{a.keys.Key(ActionLog), a.keys.Key(ActionEdit), a.keys.Key(ActionNew), "1-9", a.keys.Key(ActionHelp), "q"}
+	} else if a.viewMode == ViewOverview {
+		shortcuts = []string{"o", a.keys.Key(ActionLog), a.keys.Key(ActionEdit), a.keys.Key(ActionNew), "1-9", a.keys.Key(ActionHelp), "q"}
+	} else if a.viewMode == ViewDecisions {
+		shortcuts = []string{"D", a.keys.Key(ActionHelp), "q"}
 	} else {
 		// Dashboard view shortcuts - condensed
 		switch a.state {
 		case StateReady, StatePaused:
-			shortcuts = []string{"s", "e", "t", "n", "1-9", "?", "q"}
-		case StateRunning:
-			shortcuts = []string{"p", "x", "t", "n", "1-9", "?", "q"}
+			shortcuts = []string{a.keys.Key(ActionStart), a.keys.Key(ActionEdit), a.keys.Key(ActionLog), a.keys.Key(ActionNew), "1-9", a.keys.Key(ActionHelp), "q"}
+		case StateRunning, StateQueued:
+			shortcuts = []string{a.keys.Key(ActionPause), a.keys.Key(ActionStop), a.keys.Key(ActionLog), a.keys.Key(ActionNew), "1-9", a.keys.Key(ActionHelp), "q"}
+			if a.canSkipCurrentStory() {
+				shortcuts = append(shortcuts[:2:2], append([]string{a.keys.Key(ActionSkip)}, shortcuts[2:]...)...)
+			}
 		case StateStopped, StateError:
-			shortcuts = []string{"s", "e", "t", "n", "1-9", "?", "q"}
+			shortcuts = []string{a.keys.Key(ActionStart), a.keys.Key(ActionEdit), a.keys.Key(ActionLog), a.keys.Key(ActionNew), "1-9", a.keys.Key(ActionHelp), "q"}
 		default:
-			shortcuts = []string{"e", "t", "n", "1-9", "?", "q"}
+			shortcuts = []string{a.keys.Key(ActionEdit), a.keys.Key(ActionLog), a.keys.Key(ActionNew), "1-9", a.keys.Key(ActionHelp), "q"}
+		}
+		if a.canReopenSelectedStory() {
+			shortcuts = append([]string{"r"}, shortcuts...)
 		}
 	}
 	shortcutsStr := footerStyle.Render(strings.Join(shortcuts, " "))
@@ -325,6 +431,26 @@ func (a *App) renderNarrowActivityLine() string {
 
 // renderActivityLine renders the current activity status line.
 func (a *App) renderActivityLine() string {
+	if a.noteEditing {
+		// Keep the end of a long note, where the cursor is, in view
+		prefix := "Note for " + a.noteStory + ": "
+		input := []rune(a.noteInput)
+		if avail := a.width - 5 - len(prefix); avail > 1 && len(input) > avail {
+			input = append([]rune("…"), input[len(input)-avail+1:]...)
+		}
+		return lipgloss.NewStyle().Foreground(PrimaryColor).Render(prefix + string(input) + "▌")
+	}
+	if a.jumpInput {
+		line := "Go to story: " + a.jumpQuery + "▌"
+		if ids := a.storyJumpSuggestions(); len(ids) > 0 {
+			if len(ids) > maxJumpSuggestions {
+				ids = append(ids[:maxJumpSuggestions:maxJumpSuggestions], "…")
+			}
+			line += "  " + lipgloss.NewStyle().Foreground(MutedColor).Render(strings.Join(ids, " "))
+		}
+		return lipgloss.NewStyle().Foreground(PrimaryColor).Render(line)
+	}
+
 	activity := a.lastActivity
 	if activity == "" {
 		activity = "Ready to start"
@@ -348,48 +474,62 @@ func (a *App) renderStoriesPanel(width, height int) string {
 
 	// Panel title using centralized style
 	title := PanelTitleStyle.Render("Stories")
+	if a.storySort != sortOriginal {
+		title += lipgloss.NewStyle().Foreground(mutedColor).Render(" ↕ " + a.storySort.String())
+	}
 	content.WriteString(title)
 	content.WriteString("\n")
 	content.WriteString(DividerStyle.Render(strings.Repeat("─", width-2)))
 	content.WriteString("\n")
 
-	// Story list
-	listHeight := height - 5 // Account for title, border, and progress bar
-	for i, story := range a.prd.UserStories {
-		if i >= listHeight {
+	// Story list, grouped under phase headers when the PRD defines phases
+	rows := a.storyListRows(storyListHeight(height))
+	phaseStyle := lipgloss.NewStyle().Foreground(mutedColor).Bold(true)
+	for _, row := range rows {
+		switch row.kind {
+		case storyRowFilter:
+			filterLine := "/ " + a.storyFilter
+			if a.storyFilterInput {
+				filterLine += "▌"
+			}
+			content.WriteString(lipgloss.NewStyle().Foreground(PrimaryColor).Render(filterLine))
+		case storyRowNoMatch:
+			content.WriteString(lipgloss.NewStyle().Foreground(mutedColor).Render("No stories match"))
+		case storyRowPhase:
+			content.WriteString(phaseStyle.Render("▸ " + row.phase))
+		case storyRowMore:
 			// Show indicator that there are more stories
 			moreStyle := lipgloss.NewStyle().Foreground(mutedColor)
-			content.WriteString(moreStyle.Render(fmt.Sprintf("... and %d more", len(a.prd.UserStories)-i)))
-			break
-		}
-
-		icon := GetStatusIcon(story.Passes, story.InProgress)
-
-		// Truncate title to fit
-		maxTitleLen := width - 12 // Account for icon, ID, and spacing
-		displayTitle := story.Title
-		if len(displayTitle) > maxTitleLen {
-			displayTitle = displayTitle[:maxTitleLen-3] + "..."
-		}
+			content.WriteString(moreStyle.Render(fmt.Sprintf("... and %d more", row.more)))
+		case storyRowStory:
+			story := a.prd.UserStories[row.story]
+			icon := GetStatusIcon(story.Passes, story.Skipped, story.InProgress, a.prd.IsBlocked(&a.prd.UserStories[row.story]))
+
+			// Truncate title to fit
+			maxTitleLen := width - 12 // Account for icon, ID, and spacing
+			displayTitle := story.Title
+			if len(displayTitle) > maxTitleLen {
+				displayTitle = displayTitle[:maxTitleLen-3] + "..."
+			}
 
-		line := fmt.Sprintf("%s %s %s", icon, story.ID, displayTitle)
+			line := fmt.Sprintf("%s %s %s", icon, story.ID, displayTitle)
 
-		if i == a.selectedIndex {
-			// Pad line to full width to ensure background fills the entire row
-			lineWidth := lipgloss.Width(line)
-			targetWidth := width - 2
-			if lineWidth < targetWidth {
-				line = line + strings.Repeat(" ", targetWidth-lineWidth)
+			if row.story == a.selectedIndex {
+				// Pad line to full width to ensure background fills the entire row
+				lineWidth := lipgloss.Width(line)
+				targetWidth := width - 2
+				if lineWidth < targetWidth {
+					line = line + strings.Repeat(" ", targetWidth-lineWidth)
+				}
+				line = selectedStyle.Render(line)
 			}
-			line = selectedStyle.Render(line)
+			content.WriteString(line)
 		}
-
-		content.WriteString(line)
 		content.WriteString("\n")
 	}
 
 	// Pad remaining space
-	linesWritten := min(len(a.prd.UserStories), listHeight) + 2 // +2 for title and divider
+	linesWritten := len(rows) + 2 // +2 for title and divider
 	for i := linesWritten; i < height-3; i++ {
 		content.WriteString("\n")
 	}
@@ -403,6 +543,64 @@ func (a *App) renderStoriesPanel(width, height int) string {
 	return panelStyle.Width(width).Height(height).Render(content.String())
 }
 
+// Kinds of row in the stories panel's list.
+const (
+	storyRowStory   = iota // A story
+	storyRowFilter         // The filter being typed or applied
+	storyRowNoMatch        // Placeholder when the filter matches nothing
+	storyRowPhase          // Phase header above the phase's stories
+	storyRowMore           // "... and N more" when the list doesn't fit
+)
+
+// storyListRow is one row of the stories panel's list.
+type storyListRow struct {
+	kind  int
+	story int    // Index into the PRD's stories (storyRowStory)
+	phase string // Phase name (storyRowPhase)
+	more  int    // Stories that didn't fit (storyRowMore)
+}
+
+// storyListHeight returns how many list rows fit in a stories panel of the
+// given height, after its title, divider and progress bar.
+func storyListHeight(panelHeight int) int {
+	return panelHeight - 5
+}
+
+// storyListRows lays out the stories panel's list in at most listHeight
+// rows. Rendering and mouse clicks both go through it so they agree on which
+// story is on which row.
+func (a *App) storyListRows(listHeight int) []storyListRow {
+	var rows []storyListRow
+
+	// Filter line, while typing or when a filter narrows the list
+	if a.storyFilterInput || a.storyFilter != "" {
+		rows = append(rows, storyListRow{kind: storyRowFilter})
+	}
+
+	visible := a.visibleStoryIndices()
+	if len(visible) == 0 && a.storyFilter != "" {
+		rows = append(rows, storyListRow{kind: storyRowNoMatch})
+	}
+
+	// Phase headers only make sense while stories are in file order
+	showPhases := a.prd != nil && len(a.prd.Phases()) > 0 && a.storySort == sortOriginal
+	lastPhase := ""
+	for pos, i := range visible {
+		story := a.prd.UserStories[i]
+		needsHeader := showPhases && story.Phase != "" && story.Phase != lastPhase
+		if len(rows) >= listHeight || (needsHeader && len(rows)+1 >= listHeight) {
+			rows = append(rows, storyListRow{kind: storyRowMore, more: len(visible) - pos})
+			break
+		}
+		if needsHeader {
+			rows = append(rows, storyListRow{kind: storyRowPhase, phase: story.Phase})
+		}
+		lastPhase = story.Phase
+		rows = append(rows, storyListRow{kind: storyRowStory, story: i})
+	}
+	return rows
+}
+
 // renderDetailsPanel renders the details panel for the selected story.
 func (a *App) renderDetailsPanel(width, height int) string {
 	// Check for empty PRD state first
@@ -433,20 +631,46 @@ func (a *App) renderDetailsPanel(width, height int) string {
 	content.WriteString("\n\n")
 
 	// Status and Priority with proper styling
-	statusIcon := GetStatusIcon(story.Passes, story.InProgress)
+	blockedBy := a.prd.BlockedBy(story)
+	statusIcon := GetStatusIcon(story.Passes, story.Skipped, story.InProgress, len(blockedBy) > 0)
 	var statusText string
 	var statusStyle lipgloss.Style
 	if story.Passes {
 		statusText = "Passed"
 		statusStyle = statusPassedStyle
+	} else if story.Skipped {
+		statusText = "Skipped"
+		statusStyle = statusSkippedStyle
 	} else if story.InProgress {
 		statusText = "In Progress"
 		statusStyle = statusInProgressStyle
+	} else if len(blockedBy) > 0 {
+		statusText = "Blocked by " + strings.Join(blockedBy, ", ")
+		statusStyle = statusBlockedStyle
 	} else {
 		statusText = "Pending"
 		statusStyle = statusPendingStyle
 	}
-	content.WriteString(fmt.Sprintf("%s %s  │  Priority: %d\n", statusIcon, statusStyle.Render(statusText), story.Priority))
+	content.WriteString(fmt.Sprintf("%s %s  │  Priority: %d", statusIcon, statusStyle.Render(statusText), story.Priority))
+	if estimate := story.Estimate(); estimate > 0 {
+		content.WriteString("  │  Estimate: " + formatMinutes(estimate))
+	}
+	content.WriteString("\n")
+	if story.TicketURL != "" {
+		content.WriteString(labelStyle.Render("Ticket: "))
+		content.WriteString(truncateWithEllipsis(story.TicketURL, max(0, width-4-len("Ticket: ")-len(" (O to open)"))))
+		content.WriteString(SubtitleStyle.Render(" (O to open)"))
+		content.WriteString("\n")
+	}
+	if len(story.Tags) > 0 {
+		content.WriteString(renderTagChips(story.Tags, width-4))
+		content.WriteString("\n")
+	}
+	if stat := a.storyDiffStat(story); stat != nil {
+		content.WriteString(labelStyle.Render("Changes: "))
+		content.WriteString(renderDiffStat(stat))
+		content.WriteString("\n")
+	}
 	content.WriteString(DividerStyle.Render(strings.Repeat("─", width-4)))
 	content.WriteString("\n\n")
 
@@ -508,7 +732,7 @@ func (a *App) renderErrorPanel(width, height int) string {
 	content.WriteString(DividerStyle.Render(strings.Repeat("─", width-4)))
 	content.WriteString("\n\n")
 	hintStyle := lipgloss.NewStyle().Foreground(WarningColor)
-	content.WriteString(hintStyle.Render("💡 Tip: Check claude.log in the PRD directory for full error details."))
+	content.WriteString(hintStyle.Render(fmt.Sprintf("💡 Tip: Run `chief logs %s` to see claude.log's full error details.", a.prdName)))
 	content.WriteString("\n\n")
 
 	// Retry instructions
@@ -601,7 +825,7 @@ func (a *App) renderInterruptedWarning(width int) string {
 	warningText := fmt.Sprintf("%s Interrupted Story: %s (%s)", warningIcon, story.ID, truncateWithEllipsis(story.Title, width-30))
 	content.WriteString(warningStyle.Width(width).Render(warningText))
 	content.WriteString("\n")
-	content.WriteString(lipgloss.NewStyle().Foreground(MutedColor).Render("A previous session was interrupted. Press 's' to resume."))
+	content.WriteString(lipgloss.NewStyle().Foreground(MutedColor).Render("A previous session was interrupted. Press '" + a.keys.Key(ActionStart) + "' to resume."))
 
 	return content.String()
 }
@@ -651,6 +875,60 @@ func formatDuration(d time.Duration) string {
 	return fmt.Sprintf("%ds", s)
 }
 
+// formatTokens formats a token count compactly, e.g. 950, 12.3k or 1.2M.
+func formatTokens(n int) string {
+	switch {
+	case n >= 1_000_000:
+		return fmt.Sprintf("%.1fM", float64(n)/1_000_000)
+	case n >= 1_000:
+		return fmt.Sprintf("%.1fk", float64(n)/1_000)
+	default:
+		return fmt.Sprintf("%d", n)
+	}
+}
+
+// usageSummary returns the tokens a PRD's loops have used, e.g. "1.2M", and
+// their estimated cost, e.g. "$4.10", when prices are configured. Both are
+// empty until Claude reports usage.
+func (a *App) usageSummary(prdName string) (tokens, cost string) {
+	if a.manager == nil {
+		return "", ""
+	}
+	usage := a.manager.GetUsage(prdName)
+	if usage.Total() == 0 {
+		return "", ""
+	}
+	pricing := a.pricing
+	if prdName != a.prdName {
+		if cfg := a.configFor(prdName); cfg != nil {
+			pricing = cfg.Pricing
+		}
+	}
+	if c, ok := pricing.Cost(usage.InputTokens, usage.CacheReadTokens, usage.OutputTokens); ok {
+		cost = fmt.Sprintf("$%.2f", c)
+	}
+	return formatTokens(usage.Total()), cost
+}
+
+// renderTagChips renders a story's tags as chips, wrapping onto further lines
+// when they don't fit in width.
+func renderTagChips(tags []string, width int) string {
+	var lines []string
+	line := ""
+	for _, tag := range tags {
+		chip := tagChipStyle.Render(truncateWithEllipsis(tag, max(1, width-2)))
+		if line != "" && lipgloss.Width(line)+1+lipgloss.Width(chip) > width {
+			lines = append(lines, line)
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += chip
+	}
+	return strings.Join(append(lines, line), "\n")
+}
+
 // wrapText wraps text to fit within a given width.
 func wrapText(text string, width int) string {
 	if width <= 0 {
@@ -703,6 +981,19 @@ func min(a, b int) int {
 	return b
 }
 
+// truncatePathLeft shortens a path to maxLen by dropping leading characters,
+// keeping the file name visible.
+func truncatePathLeft(path string, maxLen int) string {
+	runes := []rune(path)
+	if len(runes) <= maxLen {
+		return path
+	}
+	if maxLen <= 3 {
+		return string(runes[len(runes)-maxLen:])
+	}
+	return "..." + string(runes[len(runes)-(maxLen-3):])
+}
+
 // truncateWithEllipsis truncates text to maxLen characters, adding "..." if truncated.
 func truncateWithEllipsis(text string, maxLen int) string {
 	if maxLen <= 3 {
@@ -753,7 +1044,9 @@ func (a *App) renderDiffHeader() string {
 
 	// View indicator - show story ID if viewing a story-specific diff
 	viewLabel := "[Diff View]"
-	if a.diffViewer.storyID != "" {
+	if a.diffViewer.workingTree {
+		viewLabel = "[Diff: uncommitted]"
+	} else if a.diffViewer.storyID != "" {
 		viewLabel = fmt.Sprintf("[Diff: %s]", a.diffViewer.storyID)
 	}
 	viewIndicator := lipgloss.NewStyle().
@@ -774,6 +1067,16 @@ func (a *App) renderDiffHeader() string {
 		}
 		scrollInfo = SubtitleStyle.Render(fmt.Sprintf("%d lines  %d%%", len(a.diffViewer.lines), pct))
 	}
+	contextLabel := fmt.Sprintf("context: %d", a.diffViewer.context)
+	if a.diffViewer.showingSideBySide() {
+		contextLabel += "  split"
+	}
+	context := SubtitleStyle.Render(contextLabel)
+	if scrollInfo != "" {
+		scrollInfo = lipgloss.JoinHorizontal(lipgloss.Center, context, "  ", scrollInfo)
+	} else {
+		scrollInfo = context
+	}
 
 	// Combine elements
 	leftPart := lipgloss.JoinHorizontal(lipgloss.Center, brand, "  ", viewIndicator, "  ", state)
@@ -807,7 +1110,9 @@ func (a *App) renderNarrowDiffHeader() string {
 	brand := headerStyle.Render("chief")
 
 	viewLabel := "[Diff]"
-	if a.diffViewer.storyID != "" {
+	if a.diffViewer.workingTree {
+		viewLabel = "[Uncommitted]"
+	} else if a.diffViewer.storyID != "" {
 		viewLabel = fmt.Sprintf("[%s]", a.diffViewer.storyID)
 	}
 	viewIndicator := lipgloss.NewStyle().
@@ -824,6 +1129,12 @@ func (a *App) renderNarrowDiffHeader() string {
 	if len(a.diffViewer.lines) > 0 {
 		rightPart = SubtitleStyle.Render(fmt.Sprintf("%d lines", len(a.diffViewer.lines)))
 	}
+	context := SubtitleStyle.Render(fmt.Sprintf("-U%d", a.diffViewer.context))
+	if rightPart != "" {
+		rightPart = lipgloss.JoinHorizontal(lipgloss.Center, context, " ", rightPart)
+	} else {
+		rightPart = context
+	}
 
 	spacing := strings.Repeat(" ", max(0, a.width-lipgloss.Width(leftPart)-lipgloss.Width(rightPart)-2))
 	headerLine := lipgloss.JoinHorizontal(lipgloss.Center, leftPart, spacing, rightPart)
@@ -890,6 +1201,9 @@ func (a *App) renderLogHeader() string {
 
 	// Combine elements
 	leftPart := lipgloss.JoinHorizontal(lipgloss.Center, brand, "  ", viewIndicator, "  ", state)
+	if filter := a.logViewer.FilterLabel(); filter != "" {
+		leftPart = lipgloss.JoinHorizontal(lipgloss.Center, leftPart, "  ", lipgloss.NewStyle().Foreground(WarningColor).Render(filter))
+	}
 	rightPart := lipgloss.JoinHorizontal(lipgloss.Center, iteration, "  ", scrollIndicator)
 
 	// Create the full header line with proper spacing
@@ -925,6 +1239,9 @@ func (a *App) renderNarrowLogHeader() string {
 		scrollIcon = lipgloss.NewStyle().Foreground(MutedColor).Render("▽")
 	}
 	rightPart := SubtitleStyle.Render(fmt.Sprintf("#%d", a.iteration)) + " " + scrollIcon
+	if filter := a.logViewer.FilterLabel(); filter != "" {
+		rightPart = lipgloss.NewStyle().Foreground(WarningColor).Render(filter) + " " + rightPart
+	}
 
 	// Combine elements
 	leftPart := lipgloss.JoinHorizontal(lipgloss.Center, brand, " ", viewIndicator, " ", state)
@@ -0,0 +1,100 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Decision records the choice made in a dialog that shaped how a run was set
+// up, such as a branch warning or a quit confirmation.
+type Decision struct {
+	Time   time.Time
+	PRD    string // PRD the decision applied to; empty for app-wide decisions
+	Dialog string // What was asked, e.g. "On protected branch main"
+	Choice string // What was chosen, e.g. "created worktree chief/auth off main"
+}
+
+// String formats the decision as a single line.
+func (d Decision) String() string {
+	prefix := d.Time.Format("15:04:05")
+	if d.PRD != "" {
+		prefix += " [" + d.PRD + "]"
+	}
+	return fmt.Sprintf("%s %s: %s", prefix, d.Dialog, d.Choice)
+}
+
+// recordDecision remembers a dialog's outcome for the decisions view and
+// adds it to the log when it concerns the PRD being viewed.
+func (a *App) recordDecision(prdName, dialog, choice string) {
+	d := Decision{Time: time.Now(), PRD: prdName, Dialog: dialog, Choice: choice}
+	a.decisions = append(a.decisions, d)
+	if a.logViewer != nil && (prdName == "" || prdName == a.prdName) {
+		a.logViewer.AddDecision(dialog + ": " + choice)
+	}
+}
+
+// branchWarningDialog names the question the branch warning dialog asked.
+func branchWarningDialog(b *BranchWarning) string {
+	switch b.GetDialogContext() {
+	case DialogProtectedBranch:
+		return "On protected branch " + b.currentBranch
+	case DialogAnotherPRDRunning:
+		return "Another PRD running in the same directory"
+	default:
+		return "Where to run"
+	}
+}
+
+// renderDecisionsView renders the decisions made this run, oldest first, in
+// place of the story panels.
+func (a *App) renderDecisionsView() string {
+	if a.width == 0 || a.height == 0 {
+		return "Loading..."
+	}
+
+	var header, footer string
+	if a.isNarrowMode() {
+		header = a.renderNarrowHeader()
+		footer = a.renderNarrowFooter()
+	} else {
+		header = a.renderHeader()
+		footer = a.renderFooter()
+	}
+
+	contentHeight := a.height - a.effectiveHeaderHeight() - footerHeight - 2
+	panel := a.renderDecisionsPanel(a.width-2, contentHeight)
+
+	return lipgloss.JoinVertical(lipgloss.Left, header, panel, footer)
+}
+
+// renderDecisionsPanel renders the body of the decisions view. When there are
+// more decisions than fit, the most recent ones are shown.
+func (a *App) renderDecisionsPanel(width, height int) string {
+	var content strings.Builder
+
+	content.WriteString(titleStyle.Render("Decisions"))
+	content.WriteString("\n")
+	content.WriteString(SubtitleStyle.Render("Choices made in dialogs this run"))
+	content.WriteString("\n")
+	content.WriteString(DividerStyle.Render(strings.Repeat("─", max(0, width-4))))
+	content.WriteString("\n\n")
+
+	if len(a.decisions) == 0 {
+		content.WriteString(SubtitleStyle.Render("No decisions yet"))
+		return panelStyle.Width(width).Height(height).Render(content.String())
+	}
+
+	decisions := a.decisions
+	if visible := height - 6; visible > 0 && len(decisions) > visible {
+		decisions = decisions[len(decisions)-visible:]
+	}
+	for _, d := range decisions {
+		content.WriteString(truncateWithEllipsis(d.String(), width-4))
+		content.WriteString("\n")
+	}
+
+	return panelStyle.Width(width).Height(height).Render(content.String())
+}
@@ -0,0 +1,65 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestDecisionString(t *testing.T) {
+	d := Decision{
+		Time:   time.Date(2026, 3, 4, 9, 15, 0, 0, time.Local),
+		PRD:    "auth",
+		Dialog: "On protected branch main",
+		Choice: "created worktree chief/auth off main",
+	}
+	want := "09:15:00 [auth] On protected branch main: created worktree chief/auth off main"
+	if got := d.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestRecordDecision(t *testing.T) {
+	app := &App{prdName: "auth", logViewer: NewLogViewer()}
+	app.logViewer.SetSize(80, 20)
+
+	app.recordDecision("auth", "Prompt review", "started with an edited prompt")
+	app.recordDecision("api", "Clean worktree", "cancelled")
+
+	if len(app.decisions) != 2 {
+		t.Fatalf("expected 2 decisions, got %d", len(app.decisions))
+	}
+	// Only the viewed PRD's decisions go to its log
+	log := app.logViewer.PlainText()
+	if !strings.Contains(log, "Prompt review: started with an edited prompt") {
+		t.Errorf("expected the decision in the log, got %q", log)
+	}
+	if strings.Contains(log, "Clean worktree") {
+		t.Errorf("expected another PRD's decision to stay out of the log, got %q", log)
+	}
+
+	panel := app.renderDecisionsPanel(100, 20)
+	if !strings.Contains(panel, "[api] Clean worktree: cancelled") {
+		t.Errorf("expected the decisions view to list every PRD's decisions, got:\n%s", panel)
+	}
+}
+
+func TestBranchWarningCancelRecordsDecision(t *testing.T) {
+	app := App{prdName: "auth", logViewer: NewLogViewer(), branchWarning: NewBranchWarning()}
+	app.branchWarning.SetContext("main", "auth", ".chief/worktrees/auth/")
+	app.branchWarning.SetDialogContext(DialogProtectedBranch)
+	app.pendingStartPRD = "auth"
+	app.viewMode = ViewBranchWarning
+
+	model, _ := app.handleBranchWarningKeys(tea.KeyMsg{Type: tea.KeyEsc})
+	app = model.(App)
+
+	if len(app.decisions) != 1 {
+		t.Fatalf("expected 1 decision, got %d", len(app.decisions))
+	}
+	if d := app.decisions[0]; d.Dialog != "On protected branch main" || d.Choice != "cancelled" {
+		t.Errorf("unexpected decision: %+v", d)
+	}
+}
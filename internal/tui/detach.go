@@ -0,0 +1,97 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/minicodemonkey/chief/internal/notify"
+	"github.com/minicodemonkey/chief/internal/prd"
+)
+
+// RunDetached keeps the loops that were running when the UI closed going
+// until they finish, narrating their progress to w. A value on interrupt
+// stops them early. It is used after the quit dialog's "keep running in
+// background" choice (PostExitDetach).
+func (a *App) RunDetached(w io.Writer, interrupt <-chan os.Signal) {
+	if a.manager == nil {
+		return
+	}
+	running := a.manager.GetRunningPRDs()
+	if len(running) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "Chief UI closed; %d loop(s) still running: %v\n", len(running), running)
+	fmt.Fprintln(w, "Press Ctrl+C to stop them.")
+
+	n := newNarrator(w)
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case event := <-a.manager.Events():
+			n.Narrate(event.PRDName, event.Event, a.detachedPRD(event.PRDName))
+			if event.Completed {
+				a.runDetachedAutoActions(w, event.PRDName)
+				if a.onCompletion != nil {
+					a.onCompletion(event.PRDName)
+				}
+			}
+		case <-ticker.C:
+			if !a.manager.IsAnyRunning() {
+				fmt.Fprintln(w, "All loops finished.")
+				return
+			}
+		case <-interrupt:
+			fmt.Fprintln(w, "Stopping loops...")
+			// Keep draining so loops blocked on a full event channel can exit
+			go func() {
+				for range a.manager.Events() {
+				}
+			}()
+			a.manager.StopAll()
+			return
+		}
+	}
+}
+
+// runDetachedAutoActions pushes a PRD that finished after the UI closed and
+// opens its pull request, as onComplete asks and as runBackgroundAutoActions
+// does while the UI is open, then sends the webhook. It runs the same
+// commands in turn, narrating their results to w.
+func (a *App) runDetachedAutoActions(w io.Writer, prdName string) {
+	cmd := a.runBackgroundAutoActions(prdName)
+	if cmd == nil {
+		cmd = a.notifyWebhook(prdName, notify.StateComplete, "", nil)
+	}
+	for cmd != nil {
+		result, ok := cmd().(backgroundAutoActionResultMsg)
+		if !ok {
+			return
+		}
+		switch {
+		case result.err != nil:
+			fmt.Fprintf(w, "%s: %s failed: %v\n", prdName, result.action, result.err)
+		case result.action == "push":
+			fmt.Fprintf(w, "%s: pushed the branch\n", prdName)
+		case result.action == "pr":
+			fmt.Fprintf(w, "%s: opened pull request %s\n", prdName, result.prURL)
+		}
+		_, cmd = a.handleBackgroundAutoAction(result)
+	}
+}
+
+// detachedPRD loads a PRD's current state from disk for narration.
+func (a *App) detachedPRD(prdName string) *prd.PRD {
+	instance := a.manager.GetInstance(prdName)
+	if instance == nil {
+		return nil
+	}
+	p, err := prd.LoadPRD(instance.PRDPath)
+	if err != nil {
+		return nil
+	}
+	return p
+}
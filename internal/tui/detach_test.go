@@ -0,0 +1,50 @@
+package tui
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/minicodemonkey/chief/internal/config"
+	"github.com/minicodemonkey/chief/internal/loop"
+	"github.com/minicodemonkey/chief/internal/paths"
+)
+
+func TestRunDetachedAutoActionsPushes(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+	dir := t.TempDir()
+	remote := filepath.Join(t.TempDir(), "remote.git")
+	initGitRepo(t, dir,
+		[]string{"commit", "--allow-empty", "-m", "initial"},
+		[]string{"init", "--bare", remote},
+		[]string{"remote", "add", "origin", remote},
+		[]string{"checkout", "-b", "chief/auth"},
+		[]string{"commit", "--allow-empty", "-m", "US-001: Login"},
+		[]string{"checkout", "main"},
+	)
+	prdPath := paths.PRDPath(dir, "auth")
+	if err := os.MkdirAll(filepath.Dir(prdPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(prdPath, []byte(`{"project": "Auth", "userStories": [{"id": "US-001", "title": "Login", "passes": true}]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	mgr := loop.NewManager(5)
+	mgr.RegisterWithWorktree("auth", prdPath, "", "chief/auth")
+	app := &App{baseDir: dir, manager: mgr, config: &config.Config{OnComplete: config.OnCompleteConfig{Push: true}}}
+
+	var out bytes.Buffer
+	app.runDetachedAutoActions(&out, "auth")
+	if !strings.Contains(out.String(), "auth: pushed the branch") {
+		t.Errorf("expected the push to be narrated, got %q", out.String())
+	}
+	verify := exec.Command("git", "--git-dir", remote, "rev-parse", "--verify", "chief/auth")
+	if output, err := verify.CombinedOutput(); err != nil {
+		t.Errorf("expected chief/auth on the remote after finishing detached: %s", output)
+	}
+}
@@ -3,7 +3,9 @@ package tui
 import (
 	"strings"
 
+	"github.com/alecthomas/chroma/v2"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/minicodemonkey/chief/internal/config"
 	"github.com/minicodemonkey/chief/internal/git"
 )
 
@@ -16,16 +18,31 @@ type DiffViewer struct {
 	stats      string
 	baseDir    string
 	storyID      string // Story ID whose commit diff is being shown (empty = full branch diff)
+	storyTitle   string // Title of storyID, for finding its commit again
 	ticketPrefix string // Ticket prefix extracted from branch (e.g. CCS-1234)
+	baseBranch   string // Branch the full branch diff is taken against (empty = the default branch)
 	noCommit     bool   // True when no commit was found for the selected story
+	workingTree  bool   // True when showing uncommitted changes instead of commits
+	context      int    // Unchanged lines shown around each change (git diff -U)
+	sideBySide   bool   // Show old and new side by side when the viewport is wide enough
 	err          error
 	loaded       bool
+
+	parsed      []diffLine              // lines classified, rebuilt when lines change
+	rows        []diffRow               // parsed paired up for the side-by-side view
+	lexers      map[string]chroma.Lexer // Lexer per file in the diff (nil = not recognised)
+	highlighted map[string]string       // Highlighted code by file and text, so scrolling doesn't re-tokenise
 }
 
+// sideBySideMinWidth is the narrowest viewport the side-by-side view is used
+// in; below it the diff stays unified.
+const sideBySideMinWidth = 120
+
 // NewDiffViewer creates a new diff viewer.
 func NewDiffViewer(baseDir string) *DiffViewer {
 	return &DiffViewer{
 		baseDir: baseDir,
+		context: git.DefaultDiffContext,
 	}
 }
 
@@ -40,10 +57,77 @@ func (d *DiffViewer) SetBaseDir(dir string) {
 	d.baseDir = dir
 }
 
+// SetBaseBranch sets the branch the full branch diff is taken against.
+func (d *DiffViewer) SetBaseBranch(base string) {
+	d.baseBranch = base
+}
+
+// diffContextSteps are the context sizes the diff view steps through.
+var diffContextSteps = []int{0, 1, 3, 5, 10, 20, 50}
+
+// diffContextFromConfig returns the context lines configured by
+// diff.contextLines: 0 means git's default and a negative value none.
+func diffContextFromConfig(cfg *config.Config) int {
+	switch {
+	case cfg == nil || cfg.Diff.ContextLines == 0:
+		return git.DefaultDiffContext
+	case cfg.Diff.ContextLines < 0:
+		return 0
+	default:
+		return cfg.Diff.ContextLines
+	}
+}
+
+// SetContext sets the number of unchanged lines shown around each change.
+// It applies from the next load.
+func (d *DiffViewer) SetContext(lines int) {
+	d.context = max(0, lines)
+}
+
+// AdjustContext steps the context to the next larger (more) or smaller size
+// in diffContextSteps and reloads the diff being shown.
+func (d *DiffViewer) AdjustContext(more bool) {
+	next := d.context
+	if more {
+		for _, step := range diffContextSteps {
+			if step > d.context {
+				next = step
+				break
+			}
+		}
+	} else {
+		for i := len(diffContextSteps) - 1; i >= 0; i-- {
+			if diffContextSteps[i] < d.context {
+				next = diffContextSteps[i]
+				break
+			}
+		}
+	}
+	if next == d.context {
+		return
+	}
+	d.context = next
+	d.reload()
+}
+
+// reload fetches the diff being shown again, e.g. after the context changed.
+func (d *DiffViewer) reload() {
+	switch {
+	case d.workingTree:
+		d.LoadWorkingTree()
+	case d.storyID != "":
+		d.LoadForStory(d.storyID, d.storyTitle)
+	default:
+		d.Load()
+	}
+}
+
 // Load fetches the latest git diff for the full branch.
 func (d *DiffViewer) Load() {
 	d.storyID = ""
+	d.storyTitle = ""
 	d.noCommit = false
+	d.workingTree = false
 	d.loadDiff("", "")
 }
 
@@ -56,6 +140,8 @@ func (d *DiffViewer) SetTicketPrefix(prefix string) {
 // If no commit is found, it shows a "not committed yet" message.
 func (d *DiffViewer) LoadForStory(storyID, title string) {
 	d.storyID = storyID
+	d.storyTitle = title
+	d.workingTree = false
 
 	// Use ticket prefix from branch if available, otherwise fall back to story ID
 	prefix := d.ticketPrefix
@@ -68,7 +154,7 @@ func (d *DiffViewer) LoadForStory(storyID, title string) {
 		d.offset = 0
 		d.loaded = true
 		d.err = nil
-		d.lines = nil
+		d.setLines(nil)
 		d.stats = ""
 		return
 	}
@@ -77,6 +163,41 @@ func (d *DiffViewer) LoadForStory(storyID, title string) {
 	d.loadDiff(storyID, commitHash)
 }
 
+// ToggleWorkingTree switches between the commit diff and the uncommitted
+// changes in the worktree, reloading whichever is now shown.
+func (d *DiffViewer) ToggleWorkingTree() {
+	if d.workingTree {
+		d.workingTree = false
+		d.reload()
+		return
+	}
+	d.LoadWorkingTree()
+}
+
+// LoadWorkingTree fetches the uncommitted changes in the worktree, including
+// untracked files. The story being viewed is kept so toggling back returns
+// to its commit.
+func (d *DiffViewer) LoadWorkingTree() {
+	d.workingTree = true
+	d.noCommit = false
+	d.offset = 0
+	d.loaded = true
+	d.stats = ""
+
+	diff, err := git.WorkingTreeDiff(d.baseDir, d.context)
+	if err != nil {
+		d.err = err
+		d.setLines(nil)
+		return
+	}
+	d.err = nil
+	if strings.TrimSpace(diff) == "" {
+		d.setLines(nil)
+		return
+	}
+	d.setLines(strings.Split(strings.TrimRight(diff, "\n"), "\n"))
+}
+
 // loadDiff loads a diff, either for a specific commit or the full branch.
 func (d *DiffViewer) loadDiff(storyID, commitHash string) {
 	d.offset = 0
@@ -86,14 +207,14 @@ func (d *DiffViewer) loadDiff(storyID, commitHash string) {
 	var err error
 
 	if commitHash != "" {
-		diff, err = git.GetDiffForCommit(d.baseDir, commitHash)
+		diff, err = git.GetDiffForCommit(d.baseDir, commitHash, d.context)
 	} else {
-		diff, err = git.GetDiff(d.baseDir)
+		diff, err = git.GetDiff(d.baseDir, d.baseBranch, d.context)
 	}
 
 	if err != nil {
 		d.err = err
-		d.lines = nil
+		d.setLines(nil)
 		d.stats = ""
 		return
 	}
@@ -101,12 +222,12 @@ func (d *DiffViewer) loadDiff(storyID, commitHash string) {
 	d.err = nil
 
 	if strings.TrimSpace(diff) == "" {
-		d.lines = nil
+		d.setLines(nil)
 		d.stats = ""
 		return
 	}
 
-	d.lines = strings.Split(diff, "\n")
+	d.setLines(strings.Split(diff, "\n"))
 
 	if commitHash != "" {
 		stats, err := git.GetDiffStatsForCommit(d.baseDir, commitHash)
@@ -114,13 +235,55 @@ func (d *DiffViewer) loadDiff(storyID, commitHash string) {
 			d.stats = stats
 		}
 	} else {
-		stats, err := git.GetDiffStats(d.baseDir)
+		stats, err := git.GetDiffStats(d.baseDir, d.baseBranch)
 		if err == nil {
 			d.stats = stats
 		}
 	}
 }
 
+// setLines replaces the diff being shown.
+func (d *DiffViewer) setLines(lines []string) {
+	d.lines = lines
+	d.parsed = parseDiffLines(lines)
+	d.rows = pairDiffRows(d.parsed)
+	d.highlighted = make(map[string]string)
+}
+
+// parse classifies the lines for rendering if they were replaced without
+// setLines.
+func (d *DiffViewer) parse() {
+	if len(d.parsed) != len(d.lines) {
+		d.setLines(d.lines)
+	}
+}
+
+// ToggleSideBySide switches between the unified view and old and new side
+// by side. Side by side only takes effect in wide viewports.
+func (d *DiffViewer) ToggleSideBySide() {
+	d.sideBySide = !d.sideBySide
+	d.offset = min(d.offset, d.maxOffset())
+}
+
+// showingSideBySide reports whether the side-by-side view is in effect.
+func (d *DiffViewer) showingSideBySide() bool {
+	return d.sideBySide && d.width >= sideBySideMinWidth
+}
+
+// lineCount returns how many lines the current view has to scroll through.
+func (d *DiffViewer) lineCount() int {
+	if d.showingSideBySide() {
+		d.parse()
+		return len(d.rows)
+	}
+	return len(d.lines)
+}
+
+// PlainText returns the whole diff without styling, for copying.
+func (d *DiffViewer) PlainText() string {
+	return stripANSI(strings.Join(d.lines, "\n"))
+}
+
 // ScrollUp scrolls up one line.
 func (d *DiffViewer) ScrollUp() {
 	if d.offset > 0 {
@@ -164,10 +327,10 @@ func (d *DiffViewer) ScrollToBottom() {
 }
 
 func (d *DiffViewer) maxOffset() int {
-	if len(d.lines) <= d.height {
+	if d.lineCount() <= d.height {
 		return 0
 	}
-	return len(d.lines) - d.height
+	return d.lineCount() - d.height
 }
 
 // Render renders the diff view.
@@ -181,6 +344,9 @@ func (d *DiffViewer) Render() string {
 	}
 
 	if len(d.lines) == 0 {
+		if d.workingTree {
+			return lipgloss.NewStyle().Foreground(MutedColor).Render("No uncommitted changes")
+		}
 		if d.noCommit {
 			return lipgloss.NewStyle().Foreground(WarningColor).Render("⚠ Not committed yet — " + d.storyID + " is still in progress")
 		}
@@ -190,6 +356,11 @@ func (d *DiffViewer) Render() string {
 		return lipgloss.NewStyle().Foreground(MutedColor).Render("No changes detected")
 	}
 
+	d.parse()
+	if d.showingSideBySide() {
+		return d.renderSideBySide()
+	}
+
 	var content strings.Builder
 
 	// Render visible lines with syntax highlighting
@@ -199,16 +370,17 @@ func (d *DiffViewer) Render() string {
 	}
 
 	for i := d.offset; i < visibleEnd; i++ {
-		line := d.lines[i]
-		styled := d.styleLine(line)
-
-		// Truncate to width
-		if lipgloss.Width(styled) > d.width {
-			// Re-style the truncated raw line
-			if len(line) > d.width-3 {
-				line = line[:d.width-3] + "..."
+		line := d.parsed[i]
+		var styled string
+		if line.sign != 0 {
+			styled = d.styleCode(line.sign, line.text[1:], line.file, d.width)
+		} else {
+			styled = d.styleLine(line.text)
+			// Truncate to width
+			if lipgloss.Width(styled) > d.width {
+				// Re-style the truncated raw line
+				styled = d.styleLine(truncateWithEllipsis(line.text, d.width))
 			}
-			styled = d.styleLine(line)
 		}
 
 		content.WriteString(styled)
@@ -246,3 +418,54 @@ func (d *DiffViewer) styleLine(line string) string {
 	}
 }
 
+// styleCode renders a changed or context line of code: its sign in the
+// diff's colors and its text highlighted for the file's language, within
+// width columns. Files chroma doesn't recognise keep the plain diff colors.
+func (d *DiffViewer) styleCode(sign byte, text, file string, width int) string {
+	text = truncateWithEllipsis(strings.ReplaceAll(text, "\t", "    "), max(0, width-1))
+	signStyle := lipgloss.NewStyle().Foreground(MutedColor)
+	switch sign {
+	case '+':
+		signStyle = lipgloss.NewStyle().Foreground(SuccessColor)
+	case '-':
+		signStyle = lipgloss.NewStyle().Foreground(ErrorColor)
+	}
+
+	lexer := d.lexerFor(file)
+	if lexer == nil || text == "" {
+		if sign == ' ' {
+			return " " + text
+		}
+		return signStyle.Render(string(sign) + text)
+	}
+	key := file + "\x00" + text
+	code, ok := d.highlighted[key]
+	if !ok {
+		code = strings.TrimRight(highlightWith(lexer, text), "\n")
+		if code == "" {
+			code = text
+		}
+		if d.highlighted == nil {
+			d.highlighted = make(map[string]string)
+		}
+		d.highlighted[key] = code
+	}
+	return signStyle.Render(string(sign)) + code
+}
+
+// lexerFor returns the chroma lexer for a file in the diff, or nil if chroma
+// doesn't recognise it.
+func (d *DiffViewer) lexerFor(file string) chroma.Lexer {
+	if file == "" {
+		return nil
+	}
+	if lexer, ok := d.lexers[file]; ok {
+		return lexer
+	}
+	if d.lexers == nil {
+		d.lexers = make(map[string]chroma.Lexer)
+	}
+	lexer := matchLexer(file)
+	d.lexers[file] = lexer
+	return lexer
+}
@@ -0,0 +1,128 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// diffLine is a line of a unified diff, classified for rendering.
+type diffLine struct {
+	text string // The raw line
+	sign byte   // '+', '-' or ' ' for a line of code inside a hunk; 0 for headers and meta lines
+	file string // Path of the file the line belongs to (new side, or old side for deletions)
+}
+
+// diffRow is a row of the side-by-side view: either a header spanning both
+// columns or a pair of old and new lines, one of which may be missing.
+type diffRow struct {
+	header int // Index of a header or meta line in the parsed diff (-1 = a code row)
+	old    int // Index of the old side's line (-1 = none)
+	new    int // Index of the new side's line (-1 = none)
+}
+
+// parseDiffLines classifies the lines of a unified diff. Lines only count as
+// code between a hunk header and the next file header, so a removed line
+// starting with "--" isn't mistaken for a file header.
+func parseDiffLines(lines []string) []diffLine {
+	parsed := make([]diffLine, len(lines))
+	file := ""
+	inHunk := false
+	for i, line := range lines {
+		parsed[i].text = line
+		switch {
+		case strings.HasPrefix(line, "diff "):
+			inHunk = false
+			file = diffHeaderFile(line)
+		case strings.HasPrefix(line, "@@"):
+			inHunk = true
+		case !inHunk:
+			if path, ok := strings.CutPrefix(line, "+++ "); ok && path != "/dev/null" {
+				file = strings.TrimPrefix(path, "b/")
+			}
+		case line != "" && strings.ContainsRune("+- ", rune(line[0])):
+			parsed[i].sign = line[0]
+		}
+		parsed[i].file = file
+	}
+	return parsed
+}
+
+// diffHeaderFile returns the new path from a "diff --git a/x b/y" header.
+func diffHeaderFile(line string) string {
+	if i := strings.LastIndex(line, " b/"); i >= 0 {
+		return line[i+3:]
+	}
+	return ""
+}
+
+// pairDiffRows lays parsed diff lines out side by side. Context lines appear
+// in both columns, and each run of removed lines is paired with the added
+// lines that follow it.
+func pairDiffRows(parsed []diffLine) []diffRow {
+	var rows []diffRow
+	var removed, added []int
+	flush := func() {
+		for i := 0; i < max(len(removed), len(added)); i++ {
+			row := diffRow{header: -1, old: -1, new: -1}
+			if i < len(removed) {
+				row.old = removed[i]
+			}
+			if i < len(added) {
+				row.new = added[i]
+			}
+			rows = append(rows, row)
+		}
+		removed, added = removed[:0], added[:0]
+	}
+
+	for i, line := range parsed {
+		switch line.sign {
+		case '-':
+			if len(added) > 0 {
+				flush()
+			}
+			removed = append(removed, i)
+		case '+':
+			added = append(added, i)
+		case ' ':
+			flush()
+			rows = append(rows, diffRow{header: -1, old: i, new: i})
+		default:
+			flush()
+			rows = append(rows, diffRow{header: i, old: -1, new: -1})
+		}
+	}
+	flush()
+	return rows
+}
+
+// renderSideBySide renders the visible rows with the old side on the left
+// and the new side on the right.
+func (d *DiffViewer) renderSideBySide() string {
+	separator := lipgloss.NewStyle().Foreground(BorderColor).Render(" │ ")
+	colWidth := (d.width - lipgloss.Width(separator)) / 2
+
+	cell := func(index int) string {
+		if index < 0 {
+			return strings.Repeat(" ", colWidth)
+		}
+		line := d.parsed[index]
+		styled := d.styleCode(line.sign, line.text[1:], line.file, colWidth)
+		if pad := colWidth - lipgloss.Width(styled); pad > 0 {
+			styled += strings.Repeat(" ", pad)
+		}
+		return styled
+	}
+
+	visibleEnd := min(d.offset+d.height, len(d.rows))
+	lines := make([]string, 0, visibleEnd-d.offset)
+	for _, row := range d.rows[d.offset:visibleEnd] {
+		if row.header >= 0 {
+			lines = append(lines, d.styleLine(truncateWithEllipsis(d.parsed[row.header].text, d.width)))
+			continue
+		}
+		lines = append(lines, cell(row.old)+separator+cell(row.new))
+	}
+	return strings.Join(lines, "\n")
+}
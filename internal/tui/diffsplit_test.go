@@ -0,0 +1,71 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPairDiffRows(t *testing.T) {
+	lines := []string{
+		"diff --git a/main.go b/main.go",
+		"--- a/main.go",
+		"+++ b/main.go",
+		"@@ -1,4 +1,4 @@",
+		" package main",
+		"-func old() {}",
+		"--- not a header",
+		"+func new() {}",
+		" // end",
+		"+// added",
+	}
+	parsed := parseDiffLines(lines)
+	if parsed[1].sign != 0 || parsed[6].sign != '-' || parsed[4].file != "main.go" {
+		t.Fatalf("unexpected classification: %+v", parsed)
+	}
+
+	rows := pairDiffRows(parsed)
+	want := []diffRow{
+		{header: 0, old: -1, new: -1},
+		{header: 1, old: -1, new: -1},
+		{header: 2, old: -1, new: -1},
+		{header: 3, old: -1, new: -1},
+		{header: -1, old: 4, new: 4},
+		{header: -1, old: 5, new: 7},
+		{header: -1, old: 6, new: -1},
+		{header: -1, old: 8, new: 8},
+		{header: -1, old: -1, new: 9},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("pairDiffRows() = %+v, want %+v", rows, want)
+	}
+	for i := range want {
+		if rows[i] != want[i] {
+			t.Errorf("row %d = %+v, want %+v", i, rows[i], want[i])
+		}
+	}
+}
+
+func TestDiffViewer_SideBySide(t *testing.T) {
+	d := NewDiffViewer("")
+	d.loaded = true
+	d.setLines([]string{"diff --git a/x.go b/x.go", "@@ -1 +1 @@", "-old line", "+new line"})
+	d.ToggleSideBySide()
+
+	// Too narrow: stays unified
+	d.SetSize(80, 20)
+	if d.showingSideBySide() {
+		t.Fatal("expected the unified view below the side-by-side width")
+	}
+	if got := stripANSI(d.Render()); !strings.Contains(got, "-old line\n+new line") {
+		t.Errorf("expected a unified diff, got %q", got)
+	}
+
+	d.SetSize(140, 20)
+	lines := strings.Split(stripANSI(d.Render()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 rows side by side, got %q", lines)
+	}
+	if !strings.HasPrefix(lines[2], "-old line") || !strings.Contains(lines[2], "│ +new line") {
+		t.Errorf("expected old and new on one row, got %q", lines[2])
+	}
+}
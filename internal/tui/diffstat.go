@@ -0,0 +1,93 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/minicodemonkey/chief/internal/git"
+	"github.com/minicodemonkey/chief/internal/prd"
+)
+
+// storyDiffDir returns the directory a PRD's commits are in: its worktree if
+// it has one, otherwise the project directory.
+func (a *App) storyDiffDir(prdName string) string {
+	if instance := a.manager.GetInstance(prdName); instance != nil && instance.WorktreeDir != "" {
+		return instance.WorktreeDir
+	}
+	return a.baseDir
+}
+
+// storyTicketPrefix returns the ticket in the name of the branch a PRD runs
+// on, falling back to the branch checked out in dir.
+func (a *App) storyTicketPrefix(prdName, dir string) string {
+	branch := ""
+	if instance := a.manager.GetInstance(prdName); instance != nil {
+		branch = instance.Branch
+	}
+	if branch == "" {
+		if detected, err := git.GetCurrentBranch(dir); err == nil {
+			branch = detected
+		}
+	}
+	return git.ExtractTicketFromBranch(branch)
+}
+
+// storyDiffStatMsg carries a story's diff stat read by loadStoryDiffStat.
+type storyDiffStatMsg struct {
+	key   string
+	epoch int // diffStatEpoch when the load started
+	stat  *git.DiffStat
+}
+
+// storyDiffStat returns the cached size of a passed story's commit, or nil
+// if it has none or hasn't been read yet. It never runs git, so it's safe to
+// call while rendering.
+func (a *App) storyDiffStat(story *prd.UserStory) *git.DiffStat {
+	if a.storyDiffStats == nil || !story.Passes {
+		return nil
+	}
+	return a.storyDiffStats[a.prdName+"/"+story.ID]
+}
+
+// loadStoryDiffStat returns a command that reads the selected story's diff
+// stat with git, or nil if it isn't a passed story or is already cached.
+// Results, including misses, are cached until the PRD changes.
+func (a *App) loadStoryDiffStat() tea.Cmd {
+	if a.storyDiffStats == nil || a.prd == nil {
+		return nil
+	}
+	story := a.GetSelectedStory()
+	if story == nil || !story.Passes {
+		return nil
+	}
+	key := a.prdName + "/" + story.ID
+	if _, ok := a.storyDiffStats[key]; ok {
+		return nil
+	}
+	// Mark it as read so the selection doesn't start another load meanwhile
+	a.storyDiffStats[key] = nil
+
+	prdName, id, title, epoch := a.prdName, story.ID, story.Title, a.diffStatEpoch
+	return func() tea.Msg {
+		dir := a.storyDiffDir(prdName)
+		prefix := a.storyTicketPrefix(prdName, dir)
+		if prefix == "" {
+			prefix = id
+		}
+		stat, _ := git.StoryDiffStat(dir, prefix, title)
+		return storyDiffStatMsg{key: key, epoch: epoch, stat: stat}
+	}
+}
+
+// renderDiffStat formats a diff stat for the details panel, e.g.
+// "3 files, +42 −7".
+func renderDiffStat(stat *git.DiffStat) string {
+	files := "files"
+	if stat.Files == 1 {
+		files = "file"
+	}
+	added := lipgloss.NewStyle().Foreground(SuccessColor).Render(fmt.Sprintf("+%d", stat.Added))
+	removed := lipgloss.NewStyle().Foreground(ErrorColor).Render(fmt.Sprintf("−%d", stat.Removed))
+	return fmt.Sprintf("%d %s, %s %s", stat.Files, files, added, removed)
+}
@@ -0,0 +1,69 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/minicodemonkey/chief/internal/git"
+	"github.com/minicodemonkey/chief/internal/loop"
+	"github.com/minicodemonkey/chief/internal/prd"
+)
+
+func TestStoryDiffStatCached(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "login.go"), []byte("package login\n\nfunc Login() {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	initGitRepo(t, dir,
+		[]string{"add", "-A"},
+		[]string{"commit", "-m", "US-001: Add login"},
+	)
+
+	app := App{prdName: "auth", baseDir: dir, manager: loop.NewManager(5), storyDiffStats: make(map[string]*git.DiffStat)}
+	app.prd = &prd.PRD{UserStories: []prd.UserStory{
+		{ID: "US-001", Title: "Add login"},
+		{ID: "US-002", Title: "Add logout", Passes: true},
+	}}
+	if cmd := app.loadStoryDiffStat(); cmd != nil {
+		t.Error("loadStoryDiffStat() loaded a pending story")
+	}
+
+	// Passing the story makes Update load its stat in the background
+	app.prd.UserStories[0].Passes = true
+	model, cmd := app.Update(nil)
+	app = model.(App)
+	if cmd == nil {
+		t.Fatal("expected Update to load the selected story's diff stat")
+	}
+	if stat := app.storyDiffStat(&app.prd.UserStories[0]); stat != nil {
+		t.Errorf("storyDiffStat() = %+v before the load finished, want nil", stat)
+	}
+	if again := app.loadStoryDiffStat(); again != nil {
+		t.Error("loadStoryDiffStat() started a second load for the same story")
+	}
+
+	model, _ = app.Update(cmd())
+	app = model.(App)
+	stat := app.storyDiffStat(&app.prd.UserStories[0])
+	want := git.DiffStat{Files: 1, Added: 3}
+	if stat == nil || *stat != want {
+		t.Fatalf("storyDiffStat() = %+v, want %+v", stat, want)
+	}
+
+	// A story with no commit is cached as a miss
+	app.selectedIndex = 1
+	msg := app.loadStoryDiffStat()()
+	model, _ = app.Update(msg)
+	app = model.(App)
+	if stat, ok := app.storyDiffStats["auth/US-002"]; !ok || stat != nil {
+		t.Errorf("a story with no commit = %+v (cached %v), want a cached nil", stat, ok)
+	}
+
+	// A load that finishes after the PRD changed is dropped
+	app.diffStatEpoch++
+	model, _ = app.Update(storyDiffStatMsg{key: "auth/US-003", epoch: app.diffStatEpoch - 1, stat: &want})
+	if _, ok := model.(App).storyDiffStats["auth/US-003"]; ok {
+		t.Error("expected a stale load to be dropped")
+	}
+}
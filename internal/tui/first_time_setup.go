@@ -7,10 +7,11 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/minicodemonkey/chief/internal/config"
 	"github.com/minicodemonkey/chief/internal/git"
 )
 
-// ghCheckResultMsg is sent when the gh CLI check completes.
+// ghCheckResultMsg is sent when the PR provider's CLI check completes.
 type ghCheckResultMsg struct {
 	installed     bool
 	authenticated bool
@@ -24,6 +25,7 @@ type FirstTimeSetupResult struct {
 	Cancelled          bool
 	PushOnComplete     bool
 	CreatePROnComplete bool
+	Reviewers          []string // Default reviewers for created PRs
 }
 
 // FirstTimeSetupStep represents the current step in the setup flow.
@@ -33,6 +35,7 @@ const (
 	StepGitignore FirstTimeSetupStep = iota
 	StepPRDName
 	StepPostCompletion
+	StepReviewers
 	StepGHError
 )
 
@@ -56,9 +59,13 @@ type FirstTimeSetup struct {
 	createPRSelected int // 0 = Yes, 1 = No
 	postCompField    int // 0 = push toggle, 1 = PR toggle
 
+	// Reviewers step, shown when PR creation is enabled
+	reviewers string
+
 	// GH CLI error step
 	ghErrorMsg      string
-	ghErrorSelected int // 0 = Continue without PR, 1 = Try again
+	ghErrorSelected int    // 0 = Continue without PR, 1 = Try again
+	provider        string // Where PRs are opened (config git.provider), which picks the CLI to check
 
 	// Result
 	result FirstTimeSetupResult
@@ -72,7 +79,14 @@ func NewFirstTimeSetup(baseDir string, showGitignore bool) *FirstTimeSetup {
 	if showGitignore {
 		step = StepGitignore
 	}
+	provider, reviewers := "", ""
+	if cfg, err := config.Load(baseDir); err == nil {
+		provider = cfg.Git.Provider
+		reviewers = strings.Join(cfg.OnComplete.Reviewers, ", ")
+	}
 	return &FirstTimeSetup{
+		provider:          provider,
+		reviewers:         reviewers,
 		baseDir:           baseDir,
 		showGitignore:     showGitignore,
 		step:              step,
@@ -107,6 +121,8 @@ func (f FirstTimeSetup) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return f.handlePRDNameKeys(msg)
 		case StepPostCompletion:
 			return f.handlePostCompletionKeys(msg)
+		case StepReviewers:
+			return f.handleReviewersKeys(msg)
 		case StepGHError:
 			return f.handleGHErrorKeys(msg)
 		}
@@ -295,40 +311,87 @@ func (f FirstTimeSetup) confirmPostCompletion() (tea.Model, tea.Cmd) {
 	f.result.PushOnComplete = f.pushSelected == 0
 	f.result.CreatePROnComplete = f.createPRSelected == 0
 
-	// If PR creation is enabled, validate gh CLI
+	// If PR creation is enabled, ask for reviewers before validating the provider's CLI
 	if f.result.CreatePROnComplete {
-		return f, func() tea.Msg {
-			installed, authenticated, err := git.CheckGHCLI()
-			return ghCheckResultMsg{installed: installed, authenticated: authenticated, err: err}
-		}
+		f.step = StepReviewers
+		return f, nil
 	}
 
 	return f, tea.Quit
 }
 
+func (f FirstTimeSetup) handleReviewersKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		f.result.Cancelled = true
+		return f, tea.Quit
+
+	case "esc":
+		// Go back to post-completion step
+		f.step = StepPostCompletion
+		return f, nil
+
+	case "enter":
+		f.result.Reviewers = parseReviewers(f.reviewers)
+		return f, f.checkProviderCLI()
+
+	case "backspace":
+		if len(f.reviewers) > 0 {
+			f.reviewers = f.reviewers[:len(f.reviewers)-1]
+		}
+		return f, nil
+
+	default:
+		// Usernames, org/team slugs and the separators between them
+		if len(msg.String()) == 1 {
+			r := rune(msg.String()[0])
+			if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') ||
+				strings.ContainsRune("-_./@, ", r) {
+				f.reviewers += string(r)
+			}
+		}
+		return f, nil
+	}
+}
+
+// parseReviewers splits a comma- or space-separated list of reviewers.
+func parseReviewers(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ' ' })
+}
+
+// checkProviderCLI returns a command that checks the PR provider's CLI.
+func (f FirstTimeSetup) checkProviderCLI() tea.Cmd {
+	provider := f.provider
+	return func() tea.Msg {
+		installed, authenticated, err := git.CheckProviderCLI(provider)
+		return ghCheckResultMsg{installed: installed, authenticated: authenticated, err: err}
+	}
+}
+
 func (f FirstTimeSetup) handleGHCheckResult(msg ghCheckResultMsg) (tea.Model, tea.Cmd) {
+	cli := git.CLIFor(f.provider)
 	if msg.err != nil {
-		f.ghErrorMsg = fmt.Sprintf("Error checking gh CLI: %s", msg.err.Error())
+		f.ghErrorMsg = fmt.Sprintf("Error checking %s CLI: %s", cli.Binary, msg.err.Error())
 		f.ghErrorSelected = 0
 		f.step = StepGHError
 		return f, nil
 	}
 
 	if !msg.installed {
-		f.ghErrorMsg = "GitHub CLI (gh) is not installed.\nInstall it from: https://cli.github.com"
+		f.ghErrorMsg = fmt.Sprintf("%s (%s) is not installed.\nInstall it from: %s", cli.Name, cli.Binary, cli.InstallURL)
 		f.ghErrorSelected = 0
 		f.step = StepGHError
 		return f, nil
 	}
 
 	if !msg.authenticated {
-		f.ghErrorMsg = "GitHub CLI (gh) is not authenticated.\nRun: gh auth login"
+		f.ghErrorMsg = fmt.Sprintf("%s (%s) is not authenticated.\nRun: %s auth login", cli.Name, cli.Binary, cli.Binary)
 		f.ghErrorSelected = 0
 		f.step = StepGHError
 		return f, nil
 	}
 
-	// gh is installed and authenticated - done
+	// The CLI is installed and authenticated - done
 	return f, tea.Quit
 }
 
@@ -362,10 +425,7 @@ func (f FirstTimeSetup) handleGHErrorKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return f, tea.Quit
 		}
 		// Try again
-		return f, func() tea.Msg {
-			installed, authenticated, err := git.CheckGHCLI()
-			return ghCheckResultMsg{installed: installed, authenticated: authenticated, err: err}
-		}
+		return f, f.checkProviderCLI()
 	}
 	return f, nil
 }
@@ -379,6 +439,8 @@ func (f FirstTimeSetup) View() string {
 		return f.renderPRDNameStep()
 	case StepPostCompletion:
 		return f.renderPostCompletionStep()
+	case StepReviewers:
+		return f.renderReviewersStep()
 	case StepGHError:
 		return f.renderGHErrorStep()
 	default:
@@ -649,6 +711,65 @@ func (f FirstTimeSetup) renderPostCompletionStep() string {
 	return f.centerModal(modal)
 }
 
+func (f FirstTimeSetup) renderReviewersStep() string {
+	modalWidth := min(65, f.width-10)
+	if modalWidth < 45 {
+		modalWidth = 45
+	}
+
+	var content strings.Builder
+
+	// Title
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(PrimaryColor)
+	content.WriteString(titleStyle.Render("Pull Request Reviewers"))
+	content.WriteString("\n")
+	content.WriteString(DividerStyle.Render(strings.Repeat("─", modalWidth-4)))
+	content.WriteString("\n\n")
+
+	// Message
+	messageStyle := lipgloss.NewStyle().Foreground(TextColor)
+	content.WriteString(messageStyle.Render("Who should review the pull requests Chief creates?"))
+	content.WriteString("\n\n")
+
+	// Input field
+	inputStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(PrimaryColor).
+		Padding(0, 1).
+		Width(modalWidth - 8)
+
+	display := f.reviewers
+	if display == "" {
+		display = " " // Show cursor position
+	}
+	content.WriteString(inputStyle.Render(display + "█"))
+	content.WriteString("\n\n")
+
+	hintStyle := lipgloss.NewStyle().Foreground(MutedColor)
+	content.WriteString(hintStyle.Render("Separate usernames with commas. Leave empty for none."))
+
+	// Footer
+	content.WriteString("\n\n")
+	content.WriteString(DividerStyle.Render(strings.Repeat("─", modalWidth-4)))
+	content.WriteString("\n")
+
+	footerStyle := lipgloss.NewStyle().Foreground(MutedColor)
+	content.WriteString(footerStyle.Render("Enter: Continue  Esc: Back  Ctrl+C: Cancel"))
+
+	// Modal box
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(PrimaryColor).
+		Padding(1, 2).
+		Width(modalWidth)
+
+	modal := modalStyle.Render(content.String())
+
+	return f.centerModal(modal)
+}
+
 func (f FirstTimeSetup) renderGHErrorStep() string {
 	modalWidth := min(60, f.width-10)
 	if modalWidth < 45 {
@@ -661,7 +782,7 @@ func (f FirstTimeSetup) renderGHErrorStep() string {
 	titleStyle := lipgloss.NewStyle().
 		Bold(true).
 		Foreground(ErrorColor)
-	content.WriteString(titleStyle.Render("GitHub CLI Issue"))
+	content.WriteString(titleStyle.Render(git.CLIFor(f.provider).Name + " Issue"))
 	content.WriteString("\n")
 	content.WriteString(DividerStyle.Render(strings.Repeat("─", modalWidth-4)))
 	content.WriteString("\n\n")
@@ -0,0 +1,46 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/minicodemonkey/chief/internal/paths"
+)
+
+func TestFirstTimeSetupReviewersStep(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+
+	f := *NewFirstTimeSetup(t.TempDir(), false)
+	f.step = StepPostCompletion
+	f.result.PRDName = "main"
+
+	// Creating PRs (the default) leads to the reviewers step
+	model, _ := f.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	f = model.(FirstTimeSetup)
+	if f.step != StepReviewers {
+		t.Fatalf("expected reviewers step, got %d", f.step)
+	}
+
+	for _, r := range "alice, bob!" {
+		model, _ = f.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		f = model.(FirstTimeSetup)
+	}
+	model, cmd := f.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	f = model.(FirstTimeSetup)
+	if cmd == nil {
+		t.Error("expected the provider CLI check to run after reviewers")
+	}
+	if got := f.result.Reviewers; len(got) != 2 || got[0] != "alice" || got[1] != "bob" {
+		t.Errorf("expected reviewers [alice bob], got %v", got)
+	}
+}
+
+func TestParseReviewers(t *testing.T) {
+	if got := parseReviewers(" alice,bob  acme/platform ,"); len(got) != 3 || got[2] != "acme/platform" {
+		t.Errorf("unexpected reviewers: %v", got)
+	}
+	if got := parseReviewers("  "); len(got) != 0 {
+		t.Errorf("expected no reviewers, got %v", got)
+	}
+}
@@ -23,6 +23,7 @@ type HelpOverlay struct {
 	width    int
 	height   int
 	viewMode ViewMode
+	keys     KeyMap // Keys shown for remappable actions
 }
 
 // NewHelpOverlay creates a new help overlay.
@@ -41,15 +42,23 @@ func (h *HelpOverlay) SetViewMode(mode ViewMode) {
 	h.viewMode = mode
 }
 
+// SetKeyMap sets the keys shown for remappable actions.
+func (h *HelpOverlay) SetKeyMap(keys KeyMap) {
+	h.keys = keys
+}
+
 // GetCategories returns the shortcut categories for the current view.
 func (h *HelpOverlay) GetCategories() []ShortcutCategory {
 	// Common categories
 	loopControl := ShortcutCategory{
 		Name: "Loop Control",
 		Shortcuts: []Shortcut{
-			{Key: "s", Description: "Start loop"},
-			{Key: "p", Description: "Pause (after iteration)"},
-			{Key: "x", Description: "Stop immediately"},
+			{Key: h.keys.Key(ActionStart), Description: "Start loop"},
+			{Key: h.keys.Key(ActionPause), Description: "Pause (after iteration)"},
+			{Key: h.keys.Key(ActionStop), Description: "Stop immediately"},
+			{Key: h.keys.Key(ActionSkip), Description: "Skip current story"},
+			{Key: h.keys.Key(ActionStartAll), Description: "Start all PRDs"},
+			{Key: h.keys.Key(ActionPauseAll), Description: "Pause all running PRDs"},
 			{Key: "+/-", Description: "Adjust max iterations"},
 		},
 	}
@@ -57,9 +66,11 @@ func (h *HelpOverlay) GetCategories() []ShortcutCategory {
 	views := ShortcutCategory{
 		Name: "Views",
 		Shortcuts: []Shortcut{
-			{Key: "t", Description: "Toggle log view"},
-			{Key: "d", Description: "Toggle diff view"},
-			{Key: "?", Description: "Help overlay"},
+			{Key: h.keys.Key(ActionLog), Description: "Toggle log view"},
+			{Key: h.keys.Key(ActionDiff), Description: "Toggle diff view"},
+			{Key: "o", Description: "Toggle PRD overview"},
+			{Key: "D", Description: "Decisions made this run"},
+			{Key: h.keys.Key(ActionHelp), Description: "Help overlay"},
 		},
 	}
 
@@ -67,8 +78,8 @@ func (h *HelpOverlay) GetCategories() []ShortcutCategory {
 		Name: "PRD Control",
 		Shortcuts: []Shortcut{
 			{Key: "1-9", Description: "Switch to PRD"},
-			{Key: "e", Description: "Edit current PRD"},
-			{Key: "n", Description: "Create new PRD"},
+			{Key: h.keys.Key(ActionEdit), Description: "Edit current PRD"},
+			{Key: h.keys.Key(ActionNew), Description: "Create new PRD"},
 			{Key: "l", Description: "List/manage PRDs"},
 		},
 	}
@@ -79,6 +90,7 @@ func (h *HelpOverlay) GetCategories() []ShortcutCategory {
 			{Key: "q", Description: "Quit"},
 			{Key: "Ctrl+C", Description: "Quit"},
 			{Key: "Esc", Description: "Close overlay/modal"},
+			{Key: "Mouse", Description: "Click a tab or story, wheel to scroll"},
 		},
 	}
 
@@ -94,10 +106,29 @@ func (h *HelpOverlay) GetCategories() []ShortcutCategory {
 				{Key: "Ctrl+U / PgUp", Description: "Page up"},
 				{Key: "g", Description: "Go to top"},
 				{Key: "G", Description: "Go to bottom"},
+				{Key: "y", Description: "Copy to clipboard"},
 			},
 		}
+		if h.viewMode == ViewLog {
+			scrolling.Shortcuts = append(scrolling.Shortcuts,
+				Shortcut{Key: "w", Description: "Save log to file"},
+				Shortcut{Key: "T / C / R / E", Description: "Filter to text, tools, results, errors"},
+				Shortcut{Key: "F", Description: "Clear log filter"},
+			)
+		}
+		if h.viewMode == ViewDiff {
+			scrolling.Shortcuts = append(scrolling.Shortcuts,
+				Shortcut{Key: "u", Description: "Toggle uncommitted changes"},
+				Shortcut{Key: "[ / ]", Description: "Less / more context"},
+				Shortcut{Key: "v", Description: "Toggle side-by-side (wide terminals)"},
+				Shortcut{Key: ":", Description: "Jump to a story's diff by ID"},
+			)
+		}
 		return []ShortcutCategory{loopControl, prdControl, views, scrolling, general}
 
+	case ViewOverview, ViewDecisions:
+		return []ShortcutCategory{loopControl, prdControl, views, general}
+
 	case ViewPicker:
 		navigation := ShortcutCategory{
 			Name: "Navigation",
@@ -114,6 +145,14 @@ func (h *HelpOverlay) GetCategories() []ShortcutCategory {
 			Shortcuts: []Shortcut{
 				{Key: "j / ↓", Description: "Next story"},
 				{Key: "k / ↑", Description: "Previous story"},
+				{Key: "r", Description: "Reopen passed story"},
+				{Key: "S", Description: "Start loop at selected story"},
+				{Key: "O", Description: "Open story's ticket in browser"},
+				{Key: "N", Description: "Add a note to the story in progress.md"},
+				{Key: "/", Description: "Filter stories by ID, title or tag:name"},
+				{Key: ":", Description: "Jump to a story by ID or number"},
+				{Key: h.keys.Key(ActionSort), Description: "Sort by priority, status, ID or file order"},
+				{Key: "Esc", Description: "Clear story filter"},
 			},
 		}
 		return []ShortcutCategory{loopControl, prdControl, views, navigation, general}
@@ -0,0 +1,123 @@
+package tui
+
+import "fmt"
+
+// Actions whose keys can be remapped in the keybindings section of the config.
+const (
+	ActionStart = "start"
+	ActionPause = "pause"
+	ActionStop  = "stop"
+	ActionDiff  = "diff"
+	ActionLog   = "log"
+	ActionNew   = "new"
+	ActionEdit  = "edit"
+	ActionHelp  = "help"
+	ActionSort  = "sort"
+
+	ActionStartAll = "startAll" // Start every PRD that isn't running or complete
+	ActionPauseAll = "pauseAll" // Pause every running PRD
+	ActionSkip     = "skip"     // Abandon the current story and move on to the next
+)
+
+// keyActions lists the remappable actions in precedence order: when two
+// actions are bound to the same key, the earlier one gets it.
+var keyActions = []string{ActionHelp, ActionStart, ActionPause, ActionStop, ActionDiff, ActionLog, ActionNew, ActionEdit, ActionSort, ActionStartAll, ActionPauseAll, ActionSkip}
+
+// defaultKeys are the keys each action is bound to unless remapped.
+var defaultKeys = map[string]string{
+	ActionStart: "s",
+	ActionPause: "p",
+	ActionStop:  "x",
+	ActionDiff:  "d",
+	ActionLog:   "t",
+	ActionNew:   "n",
+	ActionEdit:  "e",
+	ActionHelp:  "?",
+	ActionSort:  "a", // "o" is the PRD overview
+
+	ActionStartAll: "A", // "S" starts at the selected story
+	ActionPauseAll: "P",
+	ActionSkip:     "K", // "k" moves up
+}
+
+// reservedKeys are the fixed keys for navigation, views and dialogs. They
+// can't be bound to an action, which keeps its default key instead.
+var reservedKeys = map[string]bool{
+	"q": true, "ctrl+c": true, "esc": true, "enter": true, ",": true,
+	"j": true, "k": true, "up": true, "down": true,
+	"ctrl+d": true, "ctrl+u": true, "pgdown": true, "pgup": true, "g": true, "G": true,
+	"l": true, "o": true, "v": true, "u": true, "y": true, "w": true, "r": true,
+	":": true, "/": true, "[": true, "]": true, "+": true, "=": true, "-": true, "_": true,
+	"D": true, "S": true, "T": true, "C": true, "R": true, "E": true, "F": true, "N": true, "O": true,
+	"m": true, "c": true, // Merge and clean in the picker
+	"1": true, "2": true, "3": true, "4": true, "5": true, "6": true, "7": true, "8": true, "9": true,
+}
+
+// KeyMap resolves the keys bound to remappable actions. The zero value uses
+// the default bindings.
+type KeyMap struct {
+	keys     map[string]string // action -> key
+	actions  map[string]string // key -> action
+	warnings []string          // Bindings that were refused or shadowed
+}
+
+// NewKeyMap builds a keymap from the defaults overridden by bindings, which
+// map action names to keys in Bubble Tea's notation (e.g. "z", "ctrl+p").
+// Unknown actions and empty keys are ignored, and reserved keys are refused;
+// both refusals and actions shadowed by an earlier one on the same key are
+// reported by Warnings.
+func NewKeyMap(bindings map[string]string) KeyMap {
+	k := KeyMap{
+		keys:    make(map[string]string, len(defaultKeys)),
+		actions: make(map[string]string, len(defaultKeys)),
+	}
+	for _, action := range keyActions {
+		key := defaultKeys[action]
+		if override := bindings[action]; override != "" {
+			if reservedKeys[override] {
+				k.warnings = append(k.warnings, fmt.Sprintf("%s can't use reserved key %q", action, override))
+			} else {
+				key = override
+			}
+		}
+		k.keys[action] = key
+		if other, taken := k.actions[key]; taken {
+			k.warnings = append(k.warnings, fmt.Sprintf("%s is shadowed by %s on %q", action, other, key))
+		} else {
+			k.actions[key] = action
+		}
+	}
+	return k
+}
+
+// Warnings describes the bindings NewKeyMap refused or that are shadowed by
+// another action, in precedence order.
+func (k KeyMap) Warnings() []string {
+	return k.warnings
+}
+
+// Key returns the key bound to action.
+func (k KeyMap) Key(action string) string {
+	if k.keys == nil {
+		return defaultKeys[action]
+	}
+	return k.keys[action]
+}
+
+// Resolve returns the action bound to a pressed key, or the key itself when
+// it isn't bound to a remappable action. A default key that has been remapped
+// away therefore no longer triggers its old action.
+func (k KeyMap) Resolve(pressed string) string {
+	if k.actions == nil {
+		k = NewKeyMap(nil)
+	}
+	if action, ok := k.actions[pressed]; ok {
+		return action
+	}
+	return pressed
+}
+
+// Hint formats a footer shortcut for action, e.g. "s: start".
+func (k KeyMap) Hint(action, label string) string {
+	return k.Key(action) + ": " + label
+}
@@ -0,0 +1,149 @@
+package tui
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/minicodemonkey/chief/internal/prd"
+)
+
+func TestKeyMap(t *testing.T) {
+	var zero KeyMap
+	if zero.Key(ActionPause) != "p" || zero.Resolve("p") != ActionPause || zero.Resolve("j") != "j" {
+		t.Errorf("expected the zero keymap to use the default bindings")
+	}
+
+	keys := NewKeyMap(map[string]string{ActionPause: "z", ActionStop: "z", "bogus": "b", ActionEdit: ""})
+	tests := []struct {
+		pressed string
+		want    string
+	}{
+		{"z", ActionPause}, // Earlier actions win a shared key
+		{"p", "p"},         // The old key no longer pauses
+		{"x", "x"},         // Stop moved to z too, so x is free
+		{"e", ActionEdit},  // An empty binding keeps the default
+		{"b", "b"},         // Unknown actions are ignored
+	}
+	for _, tt := range tests {
+		if got := keys.Resolve(tt.pressed); got != tt.want {
+			t.Errorf("Resolve(%q) = %q, want %q", tt.pressed, got, tt.want)
+		}
+	}
+	if got := keys.Hint(ActionPause, "pause"); got != "z: pause" {
+		t.Errorf("Hint() = %q, want %q", got, "z: pause")
+	}
+	if warnings := keys.Warnings(); len(warnings) != 1 || warnings[0] != `stop is shadowed by pause on "z"` {
+		t.Errorf("Warnings() = %q", warnings)
+	}
+}
+
+func TestKeyMapRefusesReservedKeys(t *testing.T) {
+	keys := NewKeyMap(map[string]string{ActionStart: "q", ActionDiff: "j", ActionLog: "3"})
+	for pressed, want := range map[string]string{"q": "q", "j": "j", "3": "3", "s": ActionStart, "d": ActionDiff, "t": ActionLog} {
+		if got := keys.Resolve(pressed); got != want {
+			t.Errorf("Resolve(%q) = %q, want %q", pressed, got, want)
+		}
+	}
+	if warnings := keys.Warnings(); len(warnings) != 3 || warnings[0] != `start can't use reserved key "q"` {
+		t.Errorf("Warnings() = %q", warnings)
+	}
+}
+
+// TestReservedKeysCoverFixedKeys checks that every fixed key the dashboard
+// and picker handle is reserved, so binding an action to it can't silently
+// shadow a built-in feature.
+func TestReservedKeysCoverFixedKeys(t *testing.T) {
+	file, err := parser.ParseFile(token.NewFileSet(), "app.go", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check := func(lit ast.Expr) {
+		if basic, ok := lit.(*ast.BasicLit); ok && basic.Kind == token.STRING {
+			key, _ := strconv.Unquote(basic.Value)
+			if !reservedKeys[key] {
+				t.Errorf("fixed key %q is handled in app.go but not reserved", key)
+			}
+		}
+	}
+	isMsgString := func(expr ast.Expr) bool {
+		call, ok := expr.(*ast.CallExpr)
+		if !ok {
+			return false
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		return ok && sel.Sel.Name == "String" && fmt.Sprint(sel.X) == "msg"
+	}
+
+	switches := 0
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+		ast.Inspect(fn, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.SwitchStmt:
+				call, ok := n.Tag.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+				if sel, ok := call.Fun.(*ast.SelectorExpr); !ok || sel.Sel.Name != "Resolve" {
+					return true
+				}
+				switches++
+				for _, stmt := range n.Body.List {
+					for _, expr := range stmt.(*ast.CaseClause).List {
+						check(expr)
+					}
+				}
+			case *ast.BinaryExpr:
+				// Keys checked before the switch, like "," for settings
+				if fn.Name.Name == "update" && n.Op == token.EQL && isMsgString(n.X) {
+					check(n.Y)
+				}
+			}
+			return true
+		})
+	}
+	if switches != 2 {
+		t.Errorf("found %d key switches on Resolve, want the dashboard's and the picker's", switches)
+	}
+}
+
+func TestRemappedKeys(t *testing.T) {
+	keys := NewKeyMap(map[string]string{ActionLog: "L", ActionHelp: "h"})
+	var model tea.Model = App{
+		viewMode:    ViewDashboard,
+		keys:        keys,
+		prd:         &prd.PRD{UserStories: []prd.UserStory{{ID: "US-001", Title: "Login"}}},
+		logViewer:   NewLogViewer(),
+		helpOverlay: NewHelpOverlay(),
+	}
+	press := func(key string) App {
+		model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		return model.(App)
+	}
+
+	if app := press("t"); app.viewMode != ViewDashboard {
+		t.Fatalf("expected the default log key to do nothing once remapped, got view %v", app.viewMode)
+	}
+	if app := press("L"); app.viewMode != ViewLog {
+		t.Fatalf("expected L to open the log, got view %v", app.viewMode)
+	}
+	if app := press("h"); app.viewMode != ViewHelp {
+		t.Fatalf("expected h to open help, got view %v", app.viewMode)
+	}
+
+	app := model.(App)
+	app.viewMode = ViewDashboard
+	app.width = 300
+	if footer := stripANSI(app.renderFooter()); !strings.Contains(footer, "L: log") || !strings.Contains(footer, "h: help") {
+		t.Errorf("expected the footer to show the configured keys, got %q", footer)
+	}
+}
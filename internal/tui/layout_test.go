@@ -1,11 +1,17 @@
 package tui
 
 import (
+	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/minicodemonkey/chief/internal/config"
 	"github.com/minicodemonkey/chief/internal/loop"
 	"github.com/minicodemonkey/chief/internal/paths"
+	"github.com/minicodemonkey/chief/internal/prd"
 )
 
 func TestIsNarrowMode(t *testing.T) {
@@ -32,6 +38,32 @@ func TestIsNarrowMode(t *testing.T) {
 	}
 }
 
+func TestForcedLayout(t *testing.T) {
+	stacked := &App{width: 200, layout: config.UIConfig{ForceLayout: config.LayoutStacked}}
+	if !stacked.isNarrowMode() {
+		t.Error("expected forceLayout stacked to stack a wide terminal")
+	}
+	wide := &App{width: 90, layout: config.UIConfig{ForceLayout: config.LayoutWide}}
+	if wide.isNarrowMode() {
+		t.Error("expected forceLayout wide to keep panels side by side on a narrow terminal")
+	}
+	auto := &App{width: 90, layout: config.UIConfig{ForceLayout: config.LayoutAuto}}
+	if !auto.isNarrowMode() {
+		t.Error("expected forceLayout auto to follow the terminal width")
+	}
+}
+
+func TestStoriesPanelPercent(t *testing.T) {
+	app := &App{width: 200, height: 40}
+	if width, _ := app.storiesPanelSize(); width != 200*storiesPanelPct/100-2 {
+		t.Errorf("expected the default split, got stories width %d", width)
+	}
+	app.layout.StoriesPanelPct = 25
+	if width, _ := app.storiesPanelSize(); width != 48 {
+		t.Errorf("expected a 25%% stories panel to be 48 wide, got %d", width)
+	}
+}
+
 func TestNarrowWidthThreshold(t *testing.T) {
 	// Verify the threshold constant is set correctly
 	if narrowWidthThreshold != 100 {
@@ -221,16 +253,18 @@ func TestGetWorktreeInfo_WithBranch(t *testing.T) {
 	tmpHome := t.TempDir()
 	restore := paths.SetHomeDir(tmpHome)
 	defer restore()
+	t.Setenv("HOME", tmpHome)
 
 	mgr := loop.NewManager(10)
-	mgr.RegisterWithWorktree("auth", "/tmp/prd.json", "/tmp/.chief/worktrees/auth", "chief/auth")
+	mgr.RegisterWithWorktree("auth", "/tmp/prd.json", paths.WorktreeDir("/tmp/project", "auth"), "chief/auth")
 
 	app := &App{prdName: "auth", manager: mgr, baseDir: "/tmp/project"}
 	branch, dir := app.getWorktreeInfo()
 	if branch != "chief/auth" {
 		t.Errorf("branch = %q, want %q", branch, "chief/auth")
 	}
-	expected := paths.WorktreeDir("/tmp/project", "auth")
+	// The worktree is outside the project, so it's shown from the home directory
+	expected := "~/.chief/projects/project/worktrees/auth/"
 	if dir != expected {
 		t.Errorf("dir = %q, want %q", dir, expected)
 	}
@@ -340,3 +374,544 @@ func TestRenderWorktreeInfoLine_BranchNoWorktree(t *testing.T) {
 		t.Errorf("renderWorktreeInfoLine() should contain 'current directory' for branch-only mode, got %q", got)
 	}
 }
+
+func TestBuildStoryShortcuts(t *testing.T) {
+	stories := []prd.UserStory{
+		{ID: "US-001", Passes: true},
+		{ID: "US-002", InProgress: true},
+		{ID: "US-003"},
+	}
+
+	tests := []struct {
+		desc     string
+		index    int
+		state    AppState
+		expected []string
+	}{
+		{"passed story offers reopen", 0, StateReady, []string{"d: commit diff", "r: reopen"}},
+		{"passed story while running hides reopen", 0, StateRunning, []string{"d: commit diff"}},
+		{"in-progress story", 1, StateRunning, []string{"d: diff so far"}},
+		{"pending story offers start here", 2, StateReady, []string{"d: diff", "S: start here"}},
+		{"pending story while running", 2, StateRunning, []string{"d: diff"}},
+		{"no selection", 5, StateReady, []string{"d: diff"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			app := &App{
+				prd:           &prd.PRD{UserStories: stories},
+				selectedIndex: tt.index,
+				state:         tt.state,
+			}
+			got := app.buildStoryShortcuts()
+			if strings.Join(got, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("buildStoryShortcuts() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestReopenSelectedStory(t *testing.T) {
+	prdPath := filepath.Join(t.TempDir(), "prd.json")
+	app := &App{
+		prd:     &prd.PRD{UserStories: []prd.UserStory{{ID: "US-001", Passes: true}}},
+		prdPath: prdPath,
+		state:   StateComplete,
+	}
+
+	app.reopenSelectedStory()
+
+	if app.prd.UserStories[0].Passes {
+		t.Error("expected story to no longer pass")
+	}
+	if app.state != StateReady {
+		t.Errorf("state = %v, want Ready", app.state)
+	}
+	saved, err := prd.LoadPRD(prdPath)
+	if err != nil {
+		t.Fatalf("failed to load saved PRD: %v", err)
+	}
+	if saved.UserStories[0].Passes {
+		t.Error("expected reopened story to be saved to disk")
+	}
+}
+
+func TestToolFilePath(t *testing.T) {
+	tests := []struct {
+		desc     string
+		event    loop.Event
+		expected string
+	}{
+		{"edit", loop.Event{Tool: "Edit", ToolInput: map[string]interface{}{"file_path": "/repo/main.go"}}, "/repo/main.go"},
+		{"write", loop.Event{Tool: "Write", ToolInput: map[string]interface{}{"file_path": "/repo/a.go"}}, "/repo/a.go"},
+		{"notebook", loop.Event{Tool: "NotebookEdit", ToolInput: map[string]interface{}{"notebook_path": "/repo/n.ipynb"}}, "/repo/n.ipynb"},
+		{"bash is ignored", loop.Event{Tool: "Bash", ToolInput: map[string]interface{}{"command": "go test"}}, ""},
+		{"missing input", loop.Event{Tool: "Edit"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if got := toolFilePath(tt.event); got != tt.expected {
+				t.Errorf("toolFilePath() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDisplayCurrentFile(t *testing.T) {
+	app := &App{baseDir: "/repo", currentFile: "/repo/internal/tui/app.go"}
+	if got := app.displayCurrentFile(); got != "internal/tui/app.go" {
+		t.Errorf("displayCurrentFile() = %q, want relative path", got)
+	}
+
+	app.currentFile = "/elsewhere/file.go"
+	if got := app.displayCurrentFile(); got != "/elsewhere/file.go" {
+		t.Errorf("displayCurrentFile() = %q, want absolute path outside base dir", got)
+	}
+}
+
+func TestTruncatePathLeft(t *testing.T) {
+	if got := truncatePathLeft("internal/tui/app.go", 30); got != "internal/tui/app.go" {
+		t.Errorf("short path changed: %q", got)
+	}
+	if got := truncatePathLeft("internal/tui/app.go", 10); got != ".../app.go" {
+		t.Errorf("truncatePathLeft() = %q, want %q", got, ".../app.go")
+	}
+}
+
+func TestFooterShowsCurrentFile(t *testing.T) {
+	app := &App{
+		width:       200,
+		prdName:     "main",
+		baseDir:     "/repo",
+		currentFile: "/repo/internal/tui/app.go",
+		prd:         &prd.PRD{},
+	}
+	footer := stripANSI(app.renderFooter())
+	if !strings.Contains(footer, "internal/tui/app.go") {
+		t.Errorf("expected footer to contain current file, got %q", footer)
+	}
+}
+
+func TestRenderStoriesPanel_PhaseHeaders(t *testing.T) {
+	app := &App{
+		prd: &prd.PRD{UserStories: []prd.UserStory{
+			{ID: "US-001", Title: "Schema", Phase: "foundation", Passes: true},
+			{ID: "US-002", Title: "API", Phase: "foundation"},
+			{ID: "US-003", Title: "UI", Phase: "features"},
+		}},
+	}
+
+	panel := stripANSI(app.renderStoriesPanel(60, 20))
+	if strings.Count(panel, "▸ foundation") != 1 || strings.Count(panel, "▸ features") != 1 {
+		t.Errorf("expected one header per phase, got:\n%s", panel)
+	}
+	if strings.Index(panel, "▸ features") > strings.Index(panel, "US-003") {
+		t.Errorf("expected features header before US-003, got:\n%s", panel)
+	}
+
+	app.prd = &prd.PRD{UserStories: []prd.UserStory{{ID: "US-001", Title: "Schema"}}}
+	if panel := stripANSI(app.renderStoriesPanel(60, 20)); strings.Contains(panel, "▸") {
+		t.Errorf("expected no phase headers without phases, got:\n%s", panel)
+	}
+}
+
+func TestRenderStoriesPanel_BlockedIcon(t *testing.T) {
+	app := &App{
+		prd: &prd.PRD{UserStories: []prd.UserStory{
+			{ID: "US-001", Title: "API"},
+			{ID: "US-002", Title: "UI", DependsOn: []string{"US-001"}},
+		}},
+	}
+
+	panel := stripANSI(app.renderStoriesPanel(60, 20))
+	if !strings.Contains(panel, IconBlocked+" US-002") {
+		t.Errorf("expected blocked icon for US-002, got:\n%s", panel)
+	}
+	if strings.Contains(panel, IconBlocked+" US-001") {
+		t.Errorf("expected no blocked icon for US-001, got:\n%s", panel)
+	}
+}
+
+func TestRenderOverviewPanel(t *testing.T) {
+	app := &App{
+		prdName: "auth",
+		prd: &prd.PRD{
+			Project:     "Auth Service",
+			Description: "JWT authentication for the REST API",
+			UserStories: []prd.UserStory{
+				{ID: "US-001", Title: "Schema", Phase: "foundation", Passes: true},
+				{ID: "US-002", Title: "API", Phase: "foundation", InProgress: true},
+				{ID: "US-003", Title: "UI", Phase: "features", DependsOn: []string{"US-002"}},
+			},
+		},
+		maxIter: 8,
+	}
+
+	panel := stripANSI(app.renderOverviewPanel(80, 30))
+	for _, want := range []string{
+		"Auth Service",
+		"PRD: auth",
+		"JWT authentication for the REST API",
+		"1/3",
+		"1 passed",
+		"1 in progress",
+		"0 pending",
+		"1 blocked",
+		"foundation 1/2",
+		"features 0/1",
+		"Iteration: 0/8",
+	} {
+		if !strings.Contains(panel, want) {
+			t.Errorf("expected overview to contain %q, got:\n%s", want, panel)
+		}
+	}
+}
+
+func TestStartStoryMessage(t *testing.T) {
+	app := &App{prd: &prd.PRD{UserStories: []prd.UserStory{
+		{ID: "US-001", Passes: true},
+		{ID: "US-002"},
+		{ID: "US-003"},
+		{ID: "US-004"},
+	}}}
+
+	if got := app.startStoryMessage("US-004"); got != "Starting at US-004, skipping 2 other pending stories for now..." {
+		t.Errorf("unexpected message: %q", got)
+	}
+
+	app.prd.UserStories = app.prd.UserStories[:2]
+	if got := app.startStoryMessage("US-002"); got != "Starting at US-002..." {
+		t.Errorf("unexpected message with nothing skipped: %q", got)
+	}
+}
+
+func TestStartAtSelectedStoryRefusesBlocked(t *testing.T) {
+	app := App{
+		prd: &prd.PRD{UserStories: []prd.UserStory{
+			{ID: "US-001"},
+			{ID: "US-002", DependsOn: []string{"US-001"}},
+		}},
+		selectedIndex: 1,
+	}
+
+	model, _ := app.startAtSelectedStory()
+	got := model.(App)
+	if got.pendingStartStory != "" {
+		t.Errorf("expected no targeted start for a blocked story, got %q", got.pendingStartStory)
+	}
+	if !strings.Contains(got.lastActivity, "blocked by US-001") {
+		t.Errorf("expected blocked message, got %q", got.lastActivity)
+	}
+}
+
+func TestStoryFilter(t *testing.T) {
+	var model tea.Model = App{
+		viewMode: ViewDashboard,
+		prd: &prd.PRD{UserStories: []prd.UserStory{
+			{ID: "US-001", Title: "Login form"},
+			{ID: "US-002", Title: "Signup form"},
+			{ID: "US-003", Title: "Password reset"},
+			{ID: "US-004", Title: "Login rate limiting"},
+		}},
+		selectedIndex: 1,
+	}
+	press := func(keys ...tea.KeyMsg) App {
+		for _, key := range keys {
+			model, _ = model.Update(key)
+		}
+		return model.(App)
+	}
+
+	app := press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")}, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("LOGIN")})
+	if got := app.visibleStoryIndices(); len(got) != 2 || got[0] != 0 || got[1] != 3 {
+		t.Fatalf("expected login stories to match case-insensitively, got %v", got)
+	}
+	if app.selectedIndex != 0 {
+		t.Errorf("expected selection to clamp to the first match, got %d", app.selectedIndex)
+	}
+
+	panel := stripANSI(app.renderStoriesPanel(40, 12))
+	if !strings.Contains(panel, "/ LOGIN") || strings.Contains(panel, "US-002") || !strings.Contains(panel, "US-004") {
+		t.Errorf("expected only filtered stories in the panel, got:\n%s", panel)
+	}
+
+	// Navigation skips stories the filter hides
+	app = press(tea.KeyMsg{Type: tea.KeyEnter}, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	if app.storyFilterInput || app.selectedIndex != 3 {
+		t.Errorf("expected j to move to the next match, got index %d (input=%v)", app.selectedIndex, app.storyFilterInput)
+	}
+
+	app = press(tea.KeyMsg{Type: tea.KeyEsc})
+	if app.storyFilter != "" || len(app.visibleStoryIndices()) != 4 {
+		t.Errorf("expected Esc to restore the full list, got filter %q", app.storyFilter)
+	}
+}
+
+func TestStoryFilterByTag(t *testing.T) {
+	app := App{prd: &prd.PRD{UserStories: []prd.UserStory{
+		{ID: "US-001", Title: "Login API", Tags: []string{"backend"}},
+		{ID: "US-002", Title: "Login form", Tags: []string{"UI"}},
+		{ID: "US-003", Title: "Deploy", Tags: []string{"infra", "backend"}},
+	}}}
+
+	tests := []struct {
+		filter string
+		want   []int
+	}{
+		{"tag:backend", []int{0, 2}},
+		{"TAG:ui", []int{1}},
+		{"tag:backend login", []int{0}},
+		{"tag:backend tag:infra", []int{2}},
+		{"tag:", []int{0, 1, 2}},
+		{"tag:mobile", nil},
+	}
+	for _, tt := range tests {
+		app.storyFilter = tt.filter
+		if got := app.visibleStoryIndices(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("filter %q = %v, want %v", tt.filter, got, tt.want)
+		}
+	}
+
+	chips := stripANSI(renderTagChips([]string{"backend", "infra", "ui"}, 20))
+	if lines := strings.Split(chips, "\n"); len(lines) != 2 || !strings.Contains(lines[0], "backend") || !strings.Contains(lines[1], "ui") {
+		t.Errorf("expected chips to wrap onto two lines, got %q", chips)
+	}
+}
+
+func TestProgressNote(t *testing.T) {
+	prdPath := filepath.Join(t.TempDir(), "prd.json")
+	var model tea.Model = App{
+		viewMode:      ViewDashboard,
+		prdPath:       prdPath,
+		width:         80,
+		prd:           &prd.PRD{UserStories: []prd.UserStory{{ID: "US-001"}, {ID: "US-002"}}},
+		selectedIndex: 1,
+	}
+	press := func(keys ...tea.KeyMsg) App {
+		for _, key := range keys {
+			model, _ = model.Update(key)
+		}
+		return model.(App)
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+	app := press(runes("N"), runes("flaky"), tea.KeyMsg{Type: tea.KeySpace}, runes("tests"))
+	if !app.noteEditing || app.noteInput != "flaky tests" {
+		t.Fatalf("expected note input %q, got %q (editing=%v)", "flaky tests", app.noteInput, app.noteEditing)
+	}
+	if line := stripANSI(app.renderActivityLine()); !strings.Contains(line, "Note for US-002: flaky tests") {
+		t.Errorf("expected the note being typed in the activity line, got %q", line)
+	}
+	// Keys that would normally act on the dashboard are typed into the note
+	if app.viewMode != ViewDashboard {
+		t.Errorf("expected to stay on the dashboard while typing, got %v", app.viewMode)
+	}
+
+	app = press(tea.KeyMsg{Type: tea.KeyEnter})
+	if app.noteEditing {
+		t.Error("expected Enter to close the note input")
+	}
+	entries := app.progress["US-002"]
+	if len(entries) != 1 || !strings.Contains(entries[0].Content, "Note: flaky tests") {
+		t.Errorf("expected the note in progress.md for US-002, got %+v", app.progress)
+	}
+
+	// Esc discards a note without touching the file
+	app = press(runes("N"), runes("never mind"), tea.KeyMsg{Type: tea.KeyEsc})
+	if progress, _ := prd.ParseProgress(prd.ProgressPath(prdPath)); strings.Contains(progress["US-002"][0].Content, "never mind") {
+		t.Error("expected Esc to discard the note")
+	}
+}
+
+func TestStoryJump(t *testing.T) {
+	var model tea.Model = App{
+		viewMode: ViewDashboard,
+		width:    80,
+		prd: &prd.PRD{UserStories: []prd.UserStory{
+			{ID: "US-001", Title: "Login"},
+			{ID: "US-016", Title: "Logout"},
+			{ID: "US-017", Title: "Profile"},
+		}},
+		storyFilter: "log",
+	}
+	press := func(keys ...tea.KeyMsg) App {
+		for _, key := range keys {
+			model, _ = model.Update(key)
+		}
+		return model.(App)
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+
+	app := press(runes(":"), runes("us-01"))
+	if line := stripANSI(app.renderActivityLine()); !strings.Contains(line, "Go to story: us-01") || !strings.Contains(line, "US-016 US-017") {
+		t.Errorf("expected the query and matching IDs in the activity line, got %q", line)
+	}
+	app = press(tea.KeyMsg{Type: tea.KeyTab})
+	if app.jumpQuery != "US-01" {
+		t.Errorf("expected Tab to complete the shared prefix, got %q", app.jumpQuery)
+	}
+
+	// A number finds the ID ending in it, clearing a filter that hides it
+	app = press(tea.KeyMsg{Type: tea.KeyBackspace}, tea.KeyMsg{Type: tea.KeyBackspace}, tea.KeyMsg{Type: tea.KeyBackspace},
+		tea.KeyMsg{Type: tea.KeyBackspace}, tea.KeyMsg{Type: tea.KeyBackspace}, runes("17"), enter)
+	if app.jumpInput || app.selectedIndex != 2 {
+		t.Errorf("expected US-017 selected, got index %d (input=%v)", app.selectedIndex, app.jumpInput)
+	}
+	if app.storyFilter != "" {
+		t.Errorf("expected the filter cleared to show US-017, got %q", app.storyFilter)
+	}
+
+	app = press(runes(":"), runes("US-999"), enter)
+	if app.selectedIndex != 2 || app.lastActivity != "No story matches US-999" {
+		t.Errorf("expected an error for an unknown ID, got index %d and %q", app.selectedIndex, app.lastActivity)
+	}
+}
+
+func TestStorySort(t *testing.T) {
+	var model tea.Model = App{
+		viewMode: ViewDashboard,
+		prd: &prd.PRD{UserStories: []prd.UserStory{
+			{ID: "US-10", Title: "Done", Priority: 3, Passes: true},
+			{ID: "US-2", Title: "Working", Priority: 2, InProgress: true},
+			{ID: "US-1", Title: "Waiting", Priority: 1, DependsOn: []string{"US-3"}},
+			{ID: "US-3", Title: "Ready", Priority: 4},
+		}},
+		selectedIndex: 2,
+	}
+	press := func() App {
+		model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+		return model.(App)
+	}
+
+	wantOrders := []struct {
+		sort  storySort
+		order []string
+	}{
+		{sortPriority, []string{"US-1", "US-2", "US-10", "US-3"}},
+		{sortStatus, []string{"US-2", "US-3", "US-1", "US-10"}},
+		{sortID, []string{"US-1", "US-2", "US-3", "US-10"}},
+		{sortOriginal, []string{"US-10", "US-2", "US-1", "US-3"}},
+	}
+	for _, want := range wantOrders {
+		app := press()
+		if app.storySort != want.sort {
+			t.Fatalf("expected sort %s, got %s", want.sort, app.storySort)
+		}
+		var got []string
+		for _, i := range app.visibleStoryIndices() {
+			got = append(got, app.prd.UserStories[i].ID)
+		}
+		if strings.Join(got, ",") != strings.Join(want.order, ",") {
+			t.Errorf("sort %s: got order %v, want %v", want.sort, got, want.order)
+		}
+		if story := app.GetSelectedStory(); story.ID != "US-1" {
+			t.Errorf("sort %s: expected the selection to stay on US-1, got %s", want.sort, story.ID)
+		}
+
+		header := stripANSI(app.renderStoriesPanel(40, 12))
+		if hasIndicator := strings.Contains(header, "↕ "+want.sort.String()); hasIndicator != (want.sort != sortOriginal) {
+			t.Errorf("sort %s: unexpected header indicator in:\n%s", want.sort, header)
+		}
+	}
+
+	if prdOrder := model.(App).prd.UserStories[0].ID; prdOrder != "US-10" {
+		t.Errorf("sorting must not reorder the PRD, first story is %s", prdOrder)
+	}
+}
+
+func TestHandlePRDUpdateKeepsSelectedStory(t *testing.T) {
+	app := App{
+		prd: &prd.PRD{UserStories: []prd.UserStory{
+			{ID: "US-001"}, {ID: "US-002"}, {ID: "US-003"},
+		}},
+		selectedIndex: 1,
+	}
+
+	// A story inserted ahead of the selection shifts its index
+	model, _ := app.handlePRDUpdate(PRDUpdateMsg{PRD: &prd.PRD{UserStories: []prd.UserStory{
+		{ID: "US-000"}, {ID: "US-001"}, {ID: "US-002"}, {ID: "US-003"},
+	}}})
+	app = model.(App)
+	if story := app.GetSelectedStory(); story == nil || story.ID != "US-002" {
+		t.Fatalf("expected selection to follow US-002, got index %d", app.selectedIndex)
+	}
+
+	// Removing the selected story clamps to the end of the list
+	app.selectedIndex = 3
+	model, _ = app.handlePRDUpdate(PRDUpdateMsg{PRD: &prd.PRD{UserStories: []prd.UserStory{
+		{ID: "US-000"}, {ID: "US-001"},
+	}}})
+	app = model.(App)
+	if app.selectedIndex != 1 {
+		t.Errorf("expected selection to clamp to the last story, got %d", app.selectedIndex)
+	}
+}
+
+func TestHandlePRDUpdateSelectsOnlyNewlyStartedStory(t *testing.T) {
+	app := App{
+		prd: &prd.PRD{UserStories: []prd.UserStory{
+			{ID: "US-001", InProgress: true}, {ID: "US-002"}, {ID: "US-003"},
+		}},
+		selectedIndex: 2,
+	}
+
+	// The same story still in progress leaves the user's selection alone
+	model, _ := app.handlePRDUpdate(PRDUpdateMsg{PRD: &prd.PRD{UserStories: []prd.UserStory{
+		{ID: "US-001", InProgress: true}, {ID: "US-002"}, {ID: "US-003"},
+	}}})
+	app = model.(App)
+	if story := app.GetSelectedStory(); story == nil || story.ID != "US-003" {
+		t.Fatalf("expected selection to stay on US-003, got index %d", app.selectedIndex)
+	}
+
+	// Moving on to another story selects it
+	model, _ = app.handlePRDUpdate(PRDUpdateMsg{PRD: &prd.PRD{UserStories: []prd.UserStory{
+		{ID: "US-001", Passes: true}, {ID: "US-002", InProgress: true}, {ID: "US-003"},
+	}}})
+	app = model.(App)
+	if story := app.GetSelectedStory(); story == nil || story.ID != "US-002" {
+		t.Errorf("expected the newly started US-002 to be selected, got index %d", app.selectedIndex)
+	}
+}
+
+func TestResizeWithPickerOpen(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+
+	app := App{
+		viewMode:  ViewPicker,
+		logViewer: NewLogViewer(),
+		picker:    NewPRDPicker(t.TempDir(), "", nil),
+	}
+	app.picker.SetSize(80, 24)
+	before := app.picker.Render()
+
+	model, _ := app.Update(tea.WindowSizeMsg{Width: 140, Height: 40})
+	after := model.(App).picker.Render()
+
+	// The modal is centered in the new width
+	modalWidth := widest(after)
+	for _, line := range strings.Split(after, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if pad := len(line) - len(strings.TrimLeft(line, " ")); pad != (140-modalWidth)/2 {
+			t.Fatalf("modal is indented %d columns, want %d to center it in 140:\n%s", pad, (140-modalWidth)/2, after)
+		}
+	}
+	if modalWidth <= widest(before) {
+		t.Errorf("modal didn't widen with the window: %d columns before, %d after", widest(before), widest(after))
+	}
+}
+
+// widest returns the width of the widest line of a centered modal, not
+// counting the padding that centers it.
+func widest(view string) int {
+	w := 0
+	for _, line := range strings.Split(view, "\n") {
+		w = max(w, lipgloss.Width(strings.TrimSpace(line)))
+	}
+	return w
+}
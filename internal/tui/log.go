@@ -3,6 +3,7 @@ package tui
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"path/filepath"
 	"strings"
 
@@ -22,6 +23,8 @@ type LogEntry struct {
 	ToolInput map[string]interface{}
 	StoryID   string
 	FilePath  string // For Read tool results, stores the file path for syntax highlighting
+	Decision  bool   // A dialog choice made in the TUI rather than a loop event
+	Failed    bool   // A hook that exited non-zero
 
 	highlightedCode string   // Pre-computed syntax highlighted code (computed once on add)
 	cachedLines     []string // Pre-rendered output lines (invalidated on width change)
@@ -30,12 +33,13 @@ type LogEntry struct {
 // LogViewer manages the log viewport state.
 type LogViewer struct {
 	entries          []LogEntry
-	scrollPos        int    // Current scroll position (top line index)
-	height           int    // Viewport height (lines)
-	width            int    // Viewport width
-	autoScroll       bool   // Auto-scroll to bottom when new content arrives
-	lastReadFilePath string // Track the last Read tool's file path for syntax highlighting
-	totalLineCount   int    // Running total of all rendered lines (O(1) lookup)
+	scrollPos        int                  // Current scroll position (top line index)
+	height           int                  // Viewport height (lines)
+	width            int                  // Viewport width
+	autoScroll       bool                 // Auto-scroll to bottom when new content arrives
+	lastReadFilePath string               // Track the last Read tool's file path for syntax highlighting
+	totalLineCount   int                  // Running total of the rendered lines the filter shows (O(1) lookup)
+	filter           map[LogCategory]bool // Categories shown; empty shows every entry
 }
 
 // NewLogViewer creates a new log viewer.
@@ -55,6 +59,7 @@ func (l *LogViewer) AddEvent(event loop.Event) {
 		Tool:      event.Tool,
 		ToolInput: event.ToolInput,
 		StoryID:   event.StoryID,
+		Failed:    (event.Type == loop.EventHook || event.Type == loop.EventStopCommit) && event.Err != nil,
 	}
 
 	// Track Read tool file paths for syntax highlighting
@@ -76,11 +81,15 @@ func (l *LogViewer) AddEvent(event loop.Event) {
 	// Filter out events we don't want to display
 	switch event.Type {
 	case loop.EventAssistantText, loop.EventToolStart, loop.EventToolResult,
-		loop.EventStoryStarted, loop.EventComplete, loop.EventError, loop.EventRetrying:
+		loop.EventStoryStarted, loop.EventComplete, loop.EventError, loop.EventRetrying,
+		loop.EventPhaseComplete, loop.EventTimeout, loop.EventBlocked, loop.EventAttention,
+		loop.EventOutOfScope, loop.EventWarning, loop.EventHook, loop.EventStopCommit:
 		// Pre-render and cache lines
 		if l.width > 0 {
 			entry.cachedLines = l.renderEntry(entry)
-			l.totalLineCount += len(entry.cachedLines)
+			if l.shows(entry) {
+				l.totalLineCount += len(entry.cachedLines)
+			}
 		}
 		l.entries = append(l.entries, entry)
 	default:
@@ -94,6 +103,23 @@ func (l *LogViewer) AddEvent(event loop.Event) {
 	}
 }
 
+// AddDecision adds a dialog choice to the log, e.g. "On protected branch main:
+// created worktree chief/auth off main".
+func (l *LogViewer) AddDecision(text string) {
+	entry := LogEntry{Text: text, Decision: true}
+	if l.width > 0 {
+		entry.cachedLines = l.renderEntry(entry)
+		if l.shows(entry) {
+			l.totalLineCount += len(entry.cachedLines)
+		}
+	}
+	l.entries = append(l.entries, entry)
+
+	if l.autoScroll && l.height > 0 {
+		l.scrollToBottom()
+	}
+}
+
 // SetSize sets the viewport dimensions. Rebuilds the line cache if width changed.
 func (l *LogViewer) SetSize(width, height int) {
 	widthChanged := l.width != width
@@ -112,7 +138,9 @@ func (l *LogViewer) rebuildCache() {
 	l.totalLineCount = 0
 	for i := range l.entries {
 		l.entries[i].cachedLines = l.renderEntry(l.entries[i])
-		l.totalLineCount += len(l.entries[i].cachedLines)
+		if l.shows(l.entries[i]) {
+			l.totalLineCount += len(l.entries[i].cachedLines)
+		}
 	}
 }
 
@@ -287,6 +315,12 @@ func (l *LogViewer) Render() string {
 			Padding(1, 2)
 		return emptyStyle.Render("No log entries yet. Start the loop to see Claude's activity.")
 	}
+	if l.totalLineCount == 0 && len(l.filter) > 0 {
+		emptyStyle := lipgloss.NewStyle().
+			Foreground(MutedColor).
+			Padding(1, 2)
+		return emptyStyle.Render("No log entries match " + l.FilterLabel() + ". Press F to show everything.")
+	}
 
 	// Calculate visible range
 	startLine := l.scrollPos
@@ -310,6 +344,9 @@ func (l *LogViewer) Render() string {
 	var visibleLines []string
 
 	for i := range l.entries {
+		if !l.shows(l.entries[i]) {
+			continue
+		}
 		lines := l.entries[i].cachedLines
 		entryEnd := currentLine + len(lines)
 
@@ -337,7 +374,7 @@ func (l *LogViewer) Render() string {
 	content := strings.Join(visibleLines, "\n")
 	if l.autoScroll && len(l.entries) > 0 {
 		lastEntry := l.entries[len(l.entries)-1]
-		if lastEntry.Type == loop.EventAssistantText || lastEntry.Type == loop.EventToolStart {
+		if l.shows(lastEntry) && (lastEntry.Type == loop.EventAssistantText || lastEntry.Type == loop.EventToolStart) {
 			cursorStyle := lipgloss.NewStyle().Foreground(PrimaryColor).Blink(true)
 			content += "\n" + cursorStyle.Render("▌")
 		}
@@ -346,8 +383,40 @@ func (l *LogViewer) Render() string {
 	return content
 }
 
+// PlainText returns every log entry the filter shows as rendered, without
+// styling, for copying.
+func (l *LogViewer) PlainText() string {
+	var lines []string
+	for _, entry := range l.entries {
+		if !l.shows(entry) {
+			continue
+		}
+		rendered := entry.cachedLines
+		if rendered == nil {
+			rendered = l.renderEntry(entry)
+		}
+		for _, line := range rendered {
+			lines = append(lines, strings.TrimRight(stripANSI(line), " "))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// WriteTo writes the log as plain text, formatted as the viewer shows it.
+func (l *LogViewer) WriteTo(w io.Writer) (int64, error) {
+	text := l.PlainText()
+	if text != "" {
+		text += "\n"
+	}
+	n, err := io.WriteString(w, text)
+	return int64(n), err
+}
+
 // renderEntry renders a single log entry as lines.
 func (l *LogViewer) renderEntry(entry LogEntry) []string {
+	if entry.Decision {
+		return l.renderDecision(entry)
+	}
 	switch entry.Type {
 	case loop.EventToolStart:
 		return l.renderToolCard(entry)
@@ -357,10 +426,22 @@ func (l *LogViewer) renderEntry(entry LogEntry) []string {
 		return l.renderStoryStarted(entry)
 	case loop.EventComplete:
 		return l.renderComplete(entry)
+	case loop.EventPhaseComplete:
+		return l.renderPhaseComplete(entry)
 	case loop.EventError:
 		return l.renderError(entry)
 	case loop.EventRetrying:
 		return l.renderRetrying(entry)
+	case loop.EventTimeout:
+		return l.renderTimeout(entry)
+	case loop.EventBlocked:
+		return l.renderBlocked(entry)
+	case loop.EventAttention:
+		return l.renderAttention(entry)
+	case loop.EventOutOfScope, loop.EventWarning:
+		return l.renderOutOfScope(entry)
+	case loop.EventHook, loop.EventStopCommit:
+		return l.renderHook(entry)
 	default:
 		return l.renderText(entry)
 	}
@@ -458,16 +539,29 @@ func (l *LogViewer) highlightCode(code, filePath string) string {
 	code = stripLineNumbers(code)
 
 	// Get lexer based on file extension
-	ext := filepath.Ext(filePath)
+	lexer := matchLexer(filePath)
+	if lexer == nil {
+		lexer = chroma.Coalesce(lexers.Fallback)
+	}
+	return highlightWith(lexer, code)
+}
+
+// matchLexer returns the chroma lexer for a file path, picked by its name or
+// extension, or nil if chroma doesn't recognise it.
+func matchLexer(filePath string) chroma.Lexer {
 	lexer := lexers.Match(filePath)
 	if lexer == nil {
-		lexer = lexers.Get(ext)
+		lexer = lexers.Get(filepath.Ext(filePath))
 	}
 	if lexer == nil {
-		lexer = lexers.Fallback
+		return nil
 	}
-	lexer = chroma.Coalesce(lexer)
+	return chroma.Coalesce(lexer)
+}
 
+// highlightWith formats code as ANSI-colored text with lexer, or returns ""
+// if it can't be highlighted.
+func highlightWith(lexer chroma.Lexer, code string) string {
 	// Use Tokyo Night theme for syntax highlighting
 	style := styles.Get("tokyonight-night")
 	if style == nil {
@@ -588,6 +682,24 @@ func (l *LogViewer) renderComplete(entry LogEntry) []string {
 	}
 }
 
+// renderPhaseComplete renders a phase boundary marker.
+func (l *LogViewer) renderPhaseComplete(entry LogEntry) []string {
+	phaseStyle := lipgloss.NewStyle().
+		Foreground(SuccessColor).
+		Bold(true).
+		Padding(0, 1)
+
+	dividerStyle := lipgloss.NewStyle().Foreground(SuccessColor)
+	divider := dividerStyle.Render(strings.Repeat("─", l.width-4))
+
+	return []string{
+		"",
+		divider,
+		phaseStyle.Render(fmt.Sprintf("✓ Phase complete: %s", entry.Text)),
+		divider,
+	}
+}
+
 // renderError renders an error message.
 func (l *LogViewer) renderError(entry LogEntry) []string {
 	errorStyle := lipgloss.NewStyle().
@@ -615,3 +727,93 @@ func (l *LogViewer) renderRetrying(entry LogEntry) []string {
 
 	return []string{retryStyle.Render("🔄 " + text)}
 }
+
+// renderTimeout renders a stalled iteration being killed.
+func (l *LogViewer) renderTimeout(entry LogEntry) []string {
+	timeoutStyle := lipgloss.NewStyle().
+		Foreground(WarningColor).
+		Bold(true)
+
+	return []string{timeoutStyle.Render("⏱ " + entry.Text)}
+}
+
+// renderOutOfScope renders a warning, about files changed outside the
+// worktree or from Claude's stderr.
+func (l *LogViewer) renderOutOfScope(entry LogEntry) []string {
+	scopeStyle := lipgloss.NewStyle().
+		Foreground(WarningColor).
+		Bold(true)
+
+	wrapped := wrapText("⚠ "+entry.Text, l.width-4)
+	var lines []string
+	for _, line := range strings.Split(wrapped, "\n") {
+		lines = append(lines, scopeStyle.Render(line))
+	}
+	return lines
+}
+
+// renderHook renders a hook's outcome, followed by the tail of its output, or
+// the commit made when a loop was stopped.
+func (l *LogViewer) renderHook(entry LogEntry) []string {
+	summary, output, _ := strings.Cut(entry.Text, "\n")
+	headerStyle := lipgloss.NewStyle().Foreground(SuccessColor).Bold(true)
+	icon := "✓ "
+	if entry.Failed {
+		headerStyle = lipgloss.NewStyle().Foreground(ErrorColor).Bold(true)
+		icon = "✗ "
+	}
+
+	var lines []string
+	for _, line := range strings.Split(wrapText(icon+summary, l.width-4), "\n") {
+		lines = append(lines, headerStyle.Render(line))
+	}
+	if output != "" {
+		outputStyle := lipgloss.NewStyle().Foreground(MutedColor)
+		for _, line := range strings.Split(wrapText(output, l.width-6), "\n") {
+			lines = append(lines, outputStyle.Render("  "+line))
+		}
+	}
+	return lines
+}
+
+// renderBlocked renders a blocker reported by Claude.
+func (l *LogViewer) renderBlocked(entry LogEntry) []string {
+	blockedStyle := lipgloss.NewStyle().
+		Foreground(ErrorColor).
+		Bold(true)
+
+	wrapped := wrapText("⛔ Blocked: "+entry.Text, l.width-4)
+	var lines []string
+	for _, line := range strings.Split(wrapped, "\n") {
+		lines = append(lines, blockedStyle.Render(line))
+	}
+	return lines
+}
+
+// renderDecision renders a choice made in a dialog.
+func (l *LogViewer) renderDecision(entry LogEntry) []string {
+	decisionStyle := lipgloss.NewStyle().
+		Foreground(PrimaryColor).
+		Bold(true)
+
+	wrapped := wrapText("➜ "+entry.Text, l.width-4)
+	var lines []string
+	for _, line := range strings.Split(wrapped, "\n") {
+		lines = append(lines, decisionStyle.Render(line))
+	}
+	return lines
+}
+
+// renderAttention renders the loop pausing for attention.
+func (l *LogViewer) renderAttention(entry LogEntry) []string {
+	pauseStyle := lipgloss.NewStyle().
+		Foreground(WarningColor).
+		Bold(true)
+
+	wrapped := wrapText("⏸ Paused: "+entry.Text, l.width-4)
+	var lines []string
+	for _, line := range strings.Split(wrapped, "\n") {
+		lines = append(lines, pauseStyle.Render(line))
+	}
+	return lines
+}
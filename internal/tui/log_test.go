@@ -1,6 +1,20 @@
 package tui
 
-import "testing"
+import (
+	"bytes"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minicodemonkey/chief/internal/clipboard"
+	"github.com/minicodemonkey/chief/internal/config"
+	"github.com/minicodemonkey/chief/internal/loop"
+	"github.com/minicodemonkey/chief/internal/paths"
+)
 
 func TestGetToolIcon(t *testing.T) {
 	tests := []struct {
@@ -172,6 +186,244 @@ func TestLogViewer_IsAutoScrolling(t *testing.T) {
 	}
 }
 
+func TestLogViewer_PlainText(t *testing.T) {
+	lv := NewLogViewer()
+	lv.SetSize(80, 20)
+	lv.AddEvent(loop.Event{Type: loop.EventStoryStarted, StoryID: "US-001"})
+	lv.AddEvent(loop.Event{Type: loop.EventAssistantText, Text: "Reading the config"})
+
+	text := lv.PlainText()
+	if strings.Contains(text, "\x1b[") {
+		t.Errorf("expected no ANSI codes, got %q", text)
+	}
+	if !strings.Contains(text, "US-001") || !strings.Contains(text, "Reading the config") {
+		t.Errorf("expected both entries in plain text, got %q", text)
+	}
+}
+
+func TestLogViewer_Filter(t *testing.T) {
+	lv := NewLogViewer()
+	lv.SetSize(80, 20)
+	lv.AddEvent(loop.Event{Type: loop.EventStoryStarted, StoryID: "US-001"})
+	lv.AddEvent(loop.Event{Type: loop.EventAssistantText, Text: "Reading the config"})
+	lv.AddEvent(loop.Event{Type: loop.EventToolStart, Tool: "Bash", ToolInput: map[string]interface{}{"command": "go test ./..."}})
+	lv.AddEvent(loop.Event{Type: loop.EventToolResult, Text: "ok"})
+	all := lv.totalLines()
+
+	lv.ToggleFilter(LogCategoryTools)
+	if got := lv.FilterLabel(); got != "[tools only]" {
+		t.Errorf("FilterLabel() = %q, want [tools only]", got)
+	}
+	text := lv.PlainText()
+	if !strings.Contains(text, "go test") || strings.Contains(text, "Reading the config") || strings.Contains(text, "US-001") {
+		t.Errorf("expected only the tool call, got %q", text)
+	}
+	if lv.totalLines() >= all || stripANSI(lv.Render()) == "" {
+		t.Errorf("expected fewer visible lines than %d, got %d", all, lv.totalLines())
+	}
+
+	// Entries added while filtered are counted only when shown
+	before := lv.totalLines()
+	lv.AddEvent(loop.Event{Type: loop.EventAssistantText, Text: "Done"})
+	if lv.totalLines() != before {
+		t.Errorf("expected hidden text not to add lines, got %d, want %d", lv.totalLines(), before)
+	}
+
+	lv.ToggleFilter(LogCategoryText)
+	if got := lv.FilterLabel(); got != "[text + tools only]" {
+		t.Errorf("FilterLabel() = %q, want [text + tools only]", got)
+	}
+
+	lv.ClearFilter()
+	if lv.FilterLabel() != "" || !strings.Contains(lv.PlainText(), "US-001") {
+		t.Error("expected clearing the filter to show every entry")
+	}
+}
+
+func TestLogViewer_FilterHidesCursorForHiddenEntry(t *testing.T) {
+	lv := NewLogViewer()
+	lv.SetSize(80, 20)
+	lv.AddEvent(loop.Event{Type: loop.EventAssistantText, Text: "Running the tests"})
+	lv.AddEvent(loop.Event{Type: loop.EventToolStart, Tool: "Bash", ToolInput: map[string]interface{}{"command": "go test ./..."}})
+	if !strings.Contains(lv.Render(), "▌") {
+		t.Fatal("expected the streaming cursor after a tool call")
+	}
+
+	lv.ToggleFilter(LogCategoryText)
+	if strings.Contains(lv.Render(), "▌") {
+		t.Error("expected no cursor when the last entry is filtered out")
+	}
+}
+
+func TestLogViewer_FilterNoMatches(t *testing.T) {
+	lv := NewLogViewer()
+	lv.SetSize(80, 20)
+	lv.AddEvent(loop.Event{Type: loop.EventAssistantText, Text: "All good"})
+	lv.ToggleFilter(LogCategoryErrors)
+	if got := stripANSI(lv.Render()); !strings.Contains(got, "No log entries match [errors only]") {
+		t.Errorf("expected a no-matches message, got %q", got)
+	}
+}
+
+func TestLogViewer_WriteTo(t *testing.T) {
+	lv := NewLogViewer()
+	lv.SetSize(80, 20)
+	lv.AddEvent(loop.Event{Type: loop.EventAssistantText, Text: "Done"})
+
+	var buf bytes.Buffer
+	n, err := lv.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("WriteTo failed: %v", err)
+	}
+	if n != int64(buf.Len()) || buf.String() != lv.PlainText()+"\n" {
+		t.Errorf("unexpected output (%d bytes): %q", n, buf.String())
+	}
+}
+
+func TestExportLog(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+	baseDir := t.TempDir()
+	if err := os.MkdirAll(paths.PRDDir(baseDir, "auth"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	app := App{baseDir: baseDir, prdName: "auth", logViewer: NewLogViewer()}
+	app.logViewer.SetSize(80, 20)
+	app.logViewer.AddEvent(loop.Event{Type: loop.EventAssistantText, Text: "Working on login"})
+
+	path, err := app.exportLog(time.Date(2026, 3, 4, 5, 6, 7, 0, time.Local))
+	if err != nil {
+		t.Fatalf("exportLog failed: %v", err)
+	}
+	if filepath.Base(path) != "session-20260304-050607.log" {
+		t.Errorf("unexpected path: %s", path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read exported log: %v", err)
+	}
+	if !strings.Contains(string(data), "Working on login") {
+		t.Errorf("expected log content in export, got %q", data)
+	}
+}
+
+func TestDiffViewer_PlainText(t *testing.T) {
+	d := NewDiffViewer("")
+	d.lines = []string{"diff --git a/x b/x", "+added", "-removed"}
+	if got := d.PlainText(); got != "diff --git a/x b/x\n+added\n-removed" {
+		t.Errorf("unexpected plain text: %q", got)
+	}
+}
+
+// initGitRepo makes dir a git repository on main and then runs each of the
+// given git commands in it.
+func initGitRepo(t *testing.T, dir string, commands ...[]string) {
+	t.Helper()
+	setup := [][]string{
+		{"init", "-b", "main"},
+		{"config", "user.email", "test@test.com"},
+		{"config", "user.name", "Test"},
+	}
+	for _, args := range append(setup, commands...) {
+		c := exec.Command("git", args...)
+		c.Dir = dir
+		if out, err := c.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %s", args, out)
+		}
+	}
+}
+
+func TestDiffViewer_ToggleWorkingTree(t *testing.T) {
+	dir := t.TempDir()
+	initGitRepo(t, dir, []string{"commit", "--allow-empty", "-m", "initial"})
+	if err := os.WriteFile(filepath.Join(dir, "wip.txt"), []byte("work in progress\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	d := NewDiffViewer(dir)
+	d.SetSize(80, 20)
+	d.LoadForStory("US-001", "Login")
+	if !d.noCommit {
+		t.Fatal("expected no commit for US-001")
+	}
+
+	d.ToggleWorkingTree()
+	if !d.workingTree || !strings.Contains(d.PlainText(), "+work in progress") {
+		t.Fatalf("expected the untracked file in the working tree diff, got %q", d.PlainText())
+	}
+	app := &App{diffViewer: d, width: 120}
+	if header := stripANSI(app.renderDiffHeader()); !strings.Contains(header, "[Diff: uncommitted]") {
+		t.Errorf("expected the header to show the uncommitted mode, got %q", header)
+	}
+
+	d.ToggleWorkingTree()
+	if d.workingTree || !d.noCommit || d.storyID != "US-001" {
+		t.Errorf("expected toggling back to return to US-001's commit diff")
+	}
+}
+
+func TestDiffViewer_AdjustContext(t *testing.T) {
+	d := NewDiffViewer(t.TempDir())
+	steps := []struct {
+		more bool
+		want int
+	}{
+		{true, 5}, {true, 10}, {false, 5}, {false, 3}, {false, 1}, {false, 0}, {false, 0},
+	}
+	for i, step := range steps {
+		d.AdjustContext(step.more)
+		if d.context != step.want {
+			t.Fatalf("step %d: context = %d, want %d", i, d.context, step.want)
+		}
+	}
+
+	d.SetContext(50)
+	d.AdjustContext(true)
+	if d.context != 50 {
+		t.Errorf("expected the context to stop at 50, got %d", d.context)
+	}
+	app := &App{diffViewer: d, width: 120}
+	if header := stripANSI(app.renderDiffHeader()); !strings.Contains(header, "context: 50") {
+		t.Errorf("expected the header to show the context, got %q", header)
+	}
+}
+
+func TestDiffContextFromConfig(t *testing.T) {
+	tests := []struct {
+		lines int
+		want  int
+	}{
+		{0, 3}, {-1, 0}, {8, 8},
+	}
+	for _, tt := range tests {
+		cfg := &config.Config{Diff: config.DiffConfig{ContextLines: tt.lines}}
+		if got := diffContextFromConfig(cfg); got != tt.want {
+			t.Errorf("diffContextFromConfig(%d) = %d, want %d", tt.lines, got, tt.want)
+		}
+	}
+	if got := diffContextFromConfig(nil); got != 3 {
+		t.Errorf("diffContextFromConfig(nil) = %d, want 3", got)
+	}
+}
+
+func TestHandleClipboardResult(t *testing.T) {
+	app := App{}
+	model, _ := app.handleClipboardResult(clipboardResultMsg{lines: 12})
+	if got := model.(App).lastActivity; got != "Copied 12 lines" {
+		t.Errorf("expected copy count, got %q", got)
+	}
+
+	model, _ = app.handleClipboardResult(clipboardResultMsg{err: clipboard.ErrUnavailable})
+	if got := model.(App).lastActivity; !strings.HasPrefix(got, "⚠ Nothing copied") {
+		t.Errorf("expected missing-tool warning, got %q", got)
+	}
+
+	model, _ = app.handleClipboardResult(clipboardResultMsg{err: errors.New("xclip: exit status 1")})
+	if got := model.(App).lastActivity; got != "⚠ xclip: exit status 1" {
+		t.Errorf("expected copy error, got %q", got)
+	}
+}
+
 func TestStripLineNumbers(t *testing.T) {
 	tests := []struct {
 		name     string
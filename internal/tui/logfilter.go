@@ -0,0 +1,121 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/minicodemonkey/chief/internal/loop"
+)
+
+// LogCategory groups log entries for filtering the log view.
+type LogCategory int
+
+const (
+	LogCategoryText    LogCategory = iota // Claude's assistant text
+	LogCategoryTools                      // Tool calls
+	LogCategoryResults                    // Tool results
+	LogCategoryErrors                     // Errors, retries, timeouts and failed hooks
+	LogCategoryOther                      // Story and phase markers, decisions and the rest
+)
+
+// logFilterCategories are the categories the log view can be filtered to, in
+// the order the header lists them, with the key that toggles each.
+var logFilterCategories = []struct {
+	category LogCategory
+	key      string
+	name     string
+}{
+	{LogCategoryText, "T", "text"},
+	{LogCategoryTools, "C", "tools"},
+	{LogCategoryResults, "R", "results"},
+	{LogCategoryErrors, "E", "errors"},
+}
+
+// logFilterCategoryForKey returns the category a log view key toggles.
+func logFilterCategoryForKey(key string) (LogCategory, bool) {
+	for _, c := range logFilterCategories {
+		if c.key == key {
+			return c.category, true
+		}
+	}
+	return 0, false
+}
+
+// logCategory returns the category an entry is filtered by.
+func logCategory(entry LogEntry) LogCategory {
+	if entry.Decision {
+		return LogCategoryOther
+	}
+	switch entry.Type {
+	case loop.EventAssistantText:
+		return LogCategoryText
+	case loop.EventToolStart:
+		return LogCategoryTools
+	case loop.EventToolResult:
+		return LogCategoryResults
+	case loop.EventError, loop.EventRetrying, loop.EventTimeout:
+		return LogCategoryErrors
+	case loop.EventHook, loop.EventStopCommit:
+		if entry.Failed {
+			return LogCategoryErrors
+		}
+	}
+	return LogCategoryOther
+}
+
+// shows reports whether an entry passes the filter. With no filter every
+// entry is shown; otherwise only entries in a chosen category are.
+func (l *LogViewer) shows(entry LogEntry) bool {
+	return len(l.filter) == 0 || l.filter[logCategory(entry)]
+}
+
+// ToggleFilter adds a category to the filter or removes it. Filtering to a
+// category hides everything outside the chosen categories.
+func (l *LogViewer) ToggleFilter(category LogCategory) {
+	if l.filter == nil {
+		l.filter = make(map[LogCategory]bool)
+	}
+	if l.filter[category] {
+		delete(l.filter, category)
+	} else {
+		l.filter[category] = true
+	}
+	l.applyFilter()
+}
+
+// ClearFilter shows every entry again.
+func (l *LogViewer) ClearFilter() {
+	clear(l.filter)
+	l.applyFilter()
+}
+
+// FilterLabel describes the active filter for the log header, e.g.
+// "[tools only]" or "[tools + errors only]", or returns empty when the log
+// is unfiltered.
+func (l *LogViewer) FilterLabel() string {
+	var names []string
+	for _, c := range logFilterCategories {
+		if l.filter[c.category] {
+			names = append(names, c.name)
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	return "[" + strings.Join(names, " + ") + " only]"
+}
+
+// applyFilter recounts the visible lines after the filter changes, keeping
+// the viewport within them.
+func (l *LogViewer) applyFilter() {
+	l.totalLineCount = 0
+	for i := range l.entries {
+		if l.shows(l.entries[i]) {
+			l.totalLineCount += len(l.entries[i].cachedLines)
+		}
+	}
+	if l.autoScroll {
+		l.scrollToBottom()
+	} else {
+		l.scrollPos = min(l.scrollPos, l.maxScrollPos())
+	}
+}
@@ -44,10 +44,10 @@ func renderGlamour(markdown string, width int) string {
 	return strings.TrimSpace(rendered)
 }
 
-// ansiStripRegex matches ANSI escape codes for stripping in tests.
+// ansiStripRegex matches ANSI escape codes.
 var ansiStripRegex = regexp.MustCompile(`\x1b\[[0-9;]*m`)
 
-// stripANSI removes ANSI escape codes from a string. Exported for tests.
+// stripANSI removes ANSI escape codes from a string.
 func stripANSI(s string) string {
 	return ansiStripRegex.ReplaceAllString(s, "")
 }
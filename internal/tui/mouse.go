@@ -0,0 +1,116 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// wheelScrollLines is how many lines one scroll-wheel notch moves the log
+// and diff viewers.
+const wheelScrollLines = 3
+
+// tabBarTop is the first screen row of the tab bar, below the header line.
+const tabBarTop = 1
+
+// handleMouse handles mouse input on the main views: clicking a tab switches
+// PRDs, clicking a story selects it, and the wheel scrolls the log and diff
+// or moves the story selection. Modal views ignore the mouse, and every
+// action stays available from the keyboard.
+func (a App) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	switch a.viewMode {
+	case ViewDashboard, ViewLog, ViewDiff, ViewOverview:
+	default:
+		return a, nil
+	}
+
+	switch msg.Button {
+	case tea.MouseButtonWheelUp, tea.MouseButtonWheelDown:
+		delta := 1
+		if msg.Button == tea.MouseButtonWheelUp {
+			delta = -1
+		}
+		a.scrollWheel(delta)
+		return a, nil
+	case tea.MouseButtonLeft:
+		if msg.Action != tea.MouseActionPress {
+			return a, nil
+		}
+	default:
+		return a, nil
+	}
+
+	// Tabs are bordered, so each spans three rows. The log and diff headers
+	// have no tab bar.
+	showsTabs := a.viewMode == ViewDashboard || a.viewMode == ViewOverview
+	if showsTabs && a.tabBar != nil && msg.Y >= tabBarTop && msg.Y < tabBarTop+3 {
+		index := a.tabBar.TabAt(msg.X, a.isNarrowMode())
+		if index == a.tabBar.Count() {
+			// "+ New" opens the picker to name a new PRD, like n
+			if a.readOnly {
+				a.lastActivity = readOnlyActivity
+				return a, nil
+			}
+			a.reconcileBranches()
+			a.picker.Refresh()
+			a.picker.SetSize(a.width, a.height)
+			a.picker.StartInputMode()
+			a.viewMode = ViewPicker
+			return a, nil
+		}
+		if entry := a.tabBar.GetEntry(index); entry != nil && entry.Name != a.prdName {
+			return a.switchToPRD(entry.Name, entry.Path)
+		}
+		return a, nil
+	}
+
+	if a.viewMode == ViewDashboard && !a.storyFilterInput && !a.noteEditing {
+		if story, ok := a.storyAt(msg.X, msg.Y); ok {
+			a.selectedIndex = story
+		}
+	}
+	return a, nil
+}
+
+// scrollWheel scrolls the current view by delta notches of the wheel
+// (negative is up).
+func (a *App) scrollWheel(delta int) {
+	switch a.viewMode {
+	case ViewLog:
+		for i := 0; i < wheelScrollLines; i++ {
+			if delta < 0 {
+				a.logViewer.ScrollUp()
+			} else {
+				a.logViewer.ScrollDown()
+			}
+		}
+	case ViewDiff:
+		for i := 0; i < wheelScrollLines; i++ {
+			if delta < 0 {
+				a.diffViewer.ScrollUp()
+			} else {
+				a.diffViewer.ScrollDown()
+			}
+		}
+	case ViewDashboard:
+		a.moveStorySelection(delta)
+	}
+}
+
+// storyAt returns the index of the story drawn at screen position (x, y) in
+// the stories panel, if any.
+func (a *App) storyAt(x, y int) (int, bool) {
+	if a.prd == nil {
+		return 0, false
+	}
+	width, height := a.storiesPanelSize()
+	// The panel's border adds a column on each side
+	if x < 0 || x >= width+2 {
+		return 0, false
+	}
+	// List rows start below the panel's top border, title and divider
+	row := y - a.effectiveHeaderHeight() - 3
+	rows := a.storyListRows(storyListHeight(height))
+	if row < 0 || row >= len(rows) || rows[row].kind != storyRowStory {
+		return 0, false
+	}
+	return rows[row].story, true
+}
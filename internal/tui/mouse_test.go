@@ -0,0 +1,112 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/minicodemonkey/chief/internal/prd"
+)
+
+// screenPosition returns the row and column where text first appears in a
+// rendered view, or -1, -1.
+func screenPosition(view, text string) (int, int) {
+	for y, line := range strings.Split(stripANSI(view), "\n") {
+		if x := strings.Index(line, text); x >= 0 {
+			return y, len([]rune(line[:x]))
+		}
+	}
+	return -1, -1
+}
+
+func mouseTestApp(width int) App {
+	return App{
+		viewMode: ViewDashboard,
+		width:    width,
+		height:   40,
+		prd: &prd.PRD{UserStories: []prd.UserStory{
+			{ID: "US-001", Title: "Login", Phase: "Auth"},
+			{ID: "US-002", Title: "Signup", Phase: "Auth"},
+			{ID: "US-003", Title: "Billing", Phase: "Payments"},
+		}},
+		tabBar: &TabBar{entries: []TabEntry{
+			{Name: "auth", Total: 3, IsActive: true},
+			{Name: "billing", Total: 2},
+		}},
+		logViewer: NewLogViewer(),
+	}
+}
+
+func TestClickSelectsStory(t *testing.T) {
+	for _, width := range []int{120, 90} { // Side-by-side and stacked layouts
+		app := mouseTestApp(width)
+		y, x := screenPosition(app.View(), "US-003")
+		if y < 0 {
+			t.Fatalf("width %d: US-003 not rendered", width)
+		}
+
+		model, _ := app.Update(tea.MouseMsg{X: x, Y: y, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress})
+		if got := model.(App).selectedIndex; got != 2 {
+			t.Errorf("width %d: expected clicking US-003 to select it, got index %d", width, got)
+		}
+
+		// Clicking the phase header above a story selects nothing
+		hy, hx := screenPosition(app.View(), "▸ Payments")
+		model, _ = app.Update(tea.MouseMsg{X: hx, Y: hy, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress})
+		if got := model.(App).selectedIndex; got != 0 {
+			t.Errorf("width %d: expected a phase header click to leave the selection, got %d", width, got)
+		}
+	}
+}
+
+func TestWheelMovesStorySelection(t *testing.T) {
+	var model tea.Model = mouseTestApp(120)
+	model, _ = model.Update(tea.MouseMsg{Button: tea.MouseButtonWheelDown, Action: tea.MouseActionPress})
+	model, _ = model.Update(tea.MouseMsg{Button: tea.MouseButtonWheelDown, Action: tea.MouseActionPress})
+	if got := model.(App).selectedIndex; got != 2 {
+		t.Errorf("expected two wheel notches down to select the third story, got %d", got)
+	}
+}
+
+func TestTabAt(t *testing.T) {
+	for _, compact := range []bool{false, true} {
+		app := mouseTestApp(120)
+		if compact {
+			app.width = 90
+		}
+		view := app.View()
+		y, x := screenPosition(view, "bill")
+		if y < tabBarTop || y >= tabBarTop+3 {
+			t.Fatalf("compact=%v: expected the billing tab on the tab bar rows, got row %d", compact, y)
+		}
+		if got := app.tabBar.TabAt(x, compact); got != 1 {
+			t.Errorf("compact=%v: TabAt(%d) = %d, want 1", compact, x, got)
+		}
+		if got := app.tabBar.TabAt(0, compact); got != 0 {
+			t.Errorf("compact=%v: TabAt(0) = %d, want 0", compact, got)
+		}
+		_, nx := screenPosition(view, "+")
+		if got := app.tabBar.TabAt(nx, compact); got != 2 {
+			t.Errorf("compact=%v: expected the new tab at column %d, got %d", compact, nx, got)
+		}
+		if got := app.tabBar.TabAt(app.width-1, compact); got != -1 {
+			t.Errorf("compact=%v: expected no tab past the end, got %d", compact, got)
+		}
+	}
+}
+
+func TestReadOnlyNewTabClickRefused(t *testing.T) {
+	app := mouseTestApp(120)
+	app.SetReadOnly(true)
+	app.lastActivity = ""
+	y, x := screenPosition(app.View(), "+ New")
+	if y < 0 {
+		t.Fatal("+ New not rendered")
+	}
+
+	model, _ := app.Update(tea.MouseMsg{X: x, Y: y, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress})
+	got := model.(App)
+	if got.viewMode != ViewDashboard || got.lastActivity != readOnlyActivity {
+		t.Errorf("expected the click to be refused, got view %v, activity %q", got.viewMode, got.lastActivity)
+	}
+}
@@ -0,0 +1,92 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/minicodemonkey/chief/internal/loop"
+	"github.com/minicodemonkey/chief/internal/prd"
+)
+
+// narrator writes one plain-text line per major loop transition, so a screen
+// reader can follow a run without parsing the dashboard (--accessible).
+type narrator struct {
+	w      io.Writer
+	passed map[string]map[string]bool // Stories already passing, per PRD
+}
+
+// newNarrator creates a narrator that writes to w.
+func newNarrator(w io.Writer) *narrator {
+	return &narrator{w: w, passed: make(map[string]map[string]bool)}
+}
+
+// say writes a single line for a PRD.
+func (n *narrator) say(prdName, format string, args ...interface{}) {
+	fmt.Fprintf(n.w, "chief [%s] %s\n", prdName, fmt.Sprintf(format, args...))
+}
+
+// Narrate announces the transition an event represents, if any. p is the PRD
+// as loaded after the event and may be nil.
+func (n *narrator) Narrate(prdName string, event loop.Event, p *prd.PRD) {
+	if n == nil {
+		return
+	}
+	if p != nil {
+		n.announceCompleted(prdName, p)
+	}
+
+	switch event.Type {
+	case loop.EventStoryStarted:
+		n.say(prdName, "Story started: %s", storyLabel(p, event.StoryID))
+	case loop.EventComplete:
+		n.say(prdName, "Run complete: all stories done")
+	case loop.EventMaxIterationsReached:
+		n.say(prdName, "Run stopped: max iterations reached")
+	case loop.EventAttention:
+		n.say(prdName, "Paused: %s", event.Text)
+	case loop.EventOutOfScope, loop.EventWarning:
+		n.say(prdName, "Warning: %s", event.Text)
+	case loop.EventHook, loop.EventStopCommit:
+		summary, _, _ := strings.Cut(event.Text, "\n")
+		n.say(prdName, "%s", summary)
+	case loop.EventError:
+		if event.Err != nil {
+			n.say(prdName, "Error: %v", event.Err)
+		} else {
+			n.say(prdName, "Error: the loop stopped")
+		}
+	}
+}
+
+// announceCompleted reports stories that have started passing since the last
+// call. The first call for a PRD only records what already passes.
+func (n *narrator) announceCompleted(prdName string, p *prd.PRD) {
+	seen, known := n.passed[prdName]
+	if !known {
+		seen = make(map[string]bool)
+		n.passed[prdName] = seen
+	}
+	for _, story := range p.UserStories {
+		if !story.Passes || seen[story.ID] {
+			continue
+		}
+		seen[story.ID] = true
+		if known {
+			n.say(prdName, "Story completed: %s", storyLabel(p, story.ID))
+		}
+	}
+}
+
+// storyLabel returns "ID Title" for a story, or just the ID when the title
+// isn't known.
+func storyLabel(p *prd.PRD, id string) string {
+	if p != nil {
+		for _, story := range p.UserStories {
+			if story.ID == id && story.Title != "" {
+				return id + " " + story.Title
+			}
+		}
+	}
+	return id
+}
@@ -0,0 +1,45 @@
+package tui
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/minicodemonkey/chief/internal/loop"
+	"github.com/minicodemonkey/chief/internal/prd"
+)
+
+func TestNarrator(t *testing.T) {
+	var buf bytes.Buffer
+	n := newNarrator(&buf)
+
+	p := &prd.PRD{UserStories: []prd.UserStory{
+		{ID: "US-001", Title: "Setup", Passes: true},
+		{ID: "US-002", Title: "Login form"},
+	}}
+
+	// Stories passing before the first event aren't announced
+	n.Narrate("auth", loop.Event{Type: loop.EventStoryStarted, StoryID: "US-002"}, p)
+	p.UserStories[1].Passes = true
+	n.Narrate("auth", loop.Event{Type: loop.EventComplete}, p)
+	n.Narrate("auth", loop.Event{Type: loop.EventToolStart, Tool: "Read"}, nil)
+	n.Narrate("api", loop.Event{Type: loop.EventError, Err: errors.New("claude exited")}, nil)
+
+	want := []string{
+		"chief [auth] Story started: US-002 Login form",
+		"chief [auth] Story completed: US-002 Login form",
+		"chief [auth] Run complete: all stories done",
+		"chief [api] Error: claude exited",
+	}
+	got := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected narration:\n%s\nwant:\n%s", buf.String(), strings.Join(want, "\n"))
+	}
+}
+
+func TestNarratorNil(t *testing.T) {
+	var n *narrator
+	// A nil narrator is a no-op so callers don't need to check --accessible
+	n.Narrate("auth", loop.Event{Type: loop.EventComplete}, nil)
+}
@@ -0,0 +1,172 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// overviewStats summarizes story status counts for the overview view.
+type overviewStats struct {
+	total      int
+	passed     int
+	inProgress int
+	blocked    int
+	skipped    int
+	pending    int
+}
+
+// phaseProgress records how many stories in a phase pass or were skipped.
+type phaseProgress struct {
+	name   string
+	passed int
+	total  int
+}
+
+// overviewStats counts the current PRD's stories by status.
+func (a *App) overviewStats() overviewStats {
+	var stats overviewStats
+	for i := range a.prd.UserStories {
+		story := &a.prd.UserStories[i]
+		stats.total++
+		switch {
+		case story.Passes:
+			stats.passed++
+		case story.Skipped:
+			stats.skipped++
+		case story.InProgress:
+			stats.inProgress++
+		case a.prd.IsBlocked(story):
+			stats.blocked++
+		default:
+			stats.pending++
+		}
+	}
+	return stats
+}
+
+// phaseProgress returns per-phase completion in phase order, or nil if the PRD has no phases.
+func (a *App) phaseProgress() []phaseProgress {
+	phases := a.prd.Phases()
+	if len(phases) == 0 {
+		return nil
+	}
+	progress := make([]phaseProgress, len(phases))
+	index := make(map[string]int, len(phases))
+	for i, name := range phases {
+		progress[i].name = name
+		index[name] = i
+	}
+	for _, story := range a.prd.UserStories {
+		i, ok := index[story.Phase]
+		if !ok {
+			continue
+		}
+		progress[i].total++
+		if story.Settled() {
+			progress[i].passed++
+		}
+	}
+	return progress
+}
+
+// renderOverviewView renders the PRD overview: project name, description,
+// overall progress and high-level stats, in place of the story panels.
+func (a *App) renderOverviewView() string {
+	if a.width == 0 || a.height == 0 {
+		return "Loading..."
+	}
+
+	var header, footer string
+	if a.isNarrowMode() {
+		header = a.renderNarrowHeader()
+		footer = a.renderNarrowFooter()
+	} else {
+		header = a.renderHeader()
+		footer = a.renderFooter()
+	}
+
+	contentHeight := a.height - a.effectiveHeaderHeight() - footerHeight - 2
+	panel := a.renderOverviewPanel(a.width-2, contentHeight)
+
+	return lipgloss.JoinVertical(lipgloss.Left, header, panel, footer)
+}
+
+// renderOverviewPanel renders the body of the overview view.
+func (a *App) renderOverviewPanel(width, height int) string {
+	var content strings.Builder
+
+	project := a.prd.Project
+	if project == "" {
+		project = a.prdName
+	}
+	content.WriteString(titleStyle.Render(project))
+	content.WriteString("\n")
+	content.WriteString(SubtitleStyle.Render("PRD: " + a.prdName))
+	content.WriteString("\n")
+	content.WriteString(DividerStyle.Render(strings.Repeat("─", width-4)))
+	content.WriteString("\n\n")
+
+	// Description
+	content.WriteString(labelStyle.Render("Description"))
+	content.WriteString("\n")
+	if a.prd.Description != "" {
+		content.WriteString(wrapText(a.prd.Description, width-4))
+	} else {
+		content.WriteString(SubtitleStyle.Render("No description"))
+	}
+	content.WriteString("\n\n")
+
+	// Progress
+	content.WriteString(labelStyle.Render("Progress"))
+	content.WriteString("\n")
+	content.WriteString(a.renderProgressBar(min(width-4, 60)))
+	content.WriteString("\n")
+
+	stats := a.overviewStats()
+	counts := []string{
+		statusPassedStyle.Render(fmt.Sprintf("%s %d passed", IconPassed, stats.passed)),
+		statusInProgressStyle.Render(fmt.Sprintf("%s %d in progress", IconInProgress, stats.inProgress)),
+		statusPendingStyle.Render(fmt.Sprintf("%s %d pending", IconPending, stats.pending)),
+	}
+	if stats.blocked > 0 {
+		counts = append(counts, statusBlockedStyle.Render(fmt.Sprintf("%s %d blocked", IconBlocked, stats.blocked)))
+	}
+	if stats.skipped > 0 {
+		counts = append(counts, statusSkippedStyle.Render(fmt.Sprintf("%s %d skipped", IconSkipped, stats.skipped)))
+	}
+	content.WriteString(strings.Join(counts, "  "))
+	content.WriteString("\n")
+
+	// Per-phase progress
+	if phases := a.phaseProgress(); len(phases) > 0 {
+		content.WriteString("\n")
+		content.WriteString(labelStyle.Render("Phases"))
+		content.WriteString("\n")
+		current := a.prd.CurrentPhase()
+		for _, phase := range phases {
+			icon := statusPendingStyle.Render(IconPending)
+			if phase.passed == phase.total {
+				icon = statusPassedStyle.Render(IconPassed)
+			} else if phase.name == current {
+				icon = statusInProgressStyle.Render(IconInProgress)
+			}
+			content.WriteString(fmt.Sprintf("%s %s %d/%d\n", icon, phase.name, phase.passed, phase.total))
+		}
+	}
+
+	// Run stats
+	content.WriteString("\n")
+	content.WriteString(labelStyle.Render("Run"))
+	content.WriteString("\n")
+	content.WriteString(fmt.Sprintf("State: %s\n", GetStateStyle(a.state).Render(a.state.String())))
+	content.WriteString(fmt.Sprintf("Iteration: %d/%d\n", a.iteration, a.maxIter))
+	content.WriteString(fmt.Sprintf("Time: %s\n", formatDuration(a.GetElapsedTime())))
+	if branch, dir := a.getWorktreeInfo(); branch != "" {
+		content.WriteString(fmt.Sprintf("Branch: %s\n", branch))
+		content.WriteString(fmt.Sprintf("Directory: %s\n", dir))
+	}
+
+	return panelStyle.Width(width).Height(height).Render(content.String())
+}
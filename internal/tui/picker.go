@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/minicodemonkey/chief/internal/git"
@@ -27,6 +28,10 @@ type PRDEntry struct {
 	Branch      string         // Git branch for this PRD (empty = no branch)
 	WorktreeDir string         // Worktree directory (empty = current directory)
 	Orphaned    bool           // True if worktree exists on disk but no running PRD tracks it
+	Remaining   time.Duration  // Estimated time left, from recorded story timings
+	HasEstimate bool           // Whether Remaining could be estimated
+	Current     string         // "ID: title" of the story in progress (empty = none)
+	Elapsed     time.Duration  // How long the running loop has been going
 }
 
 // MergeResult holds the result of a merge operation for display.
@@ -35,6 +40,7 @@ type MergeResult struct {
 	Message   string   // Success message or error summary
 	Conflicts []string // Conflicting file list (empty on success)
 	Branch    string   // The branch that was merged
+	Strategy  string   // Integration strategy used (git.StrategyMerge etc.; empty means merge)
 }
 
 // CleanOption represents the user's choice in the clean confirmation dialog.
@@ -48,32 +54,36 @@ const (
 
 // CleanConfirmation holds the state of the clean confirmation dialog.
 type CleanConfirmation struct {
-	EntryName    string // Name of the PRD being cleaned
-	Branch       string // Branch name to display
-	WorktreeDir  string // Worktree path to display
-	SelectedIdx  int    // Selected option index (0-2)
+	EntryName   string   // Name of the PRD being cleaned
+	Branch      string   // Branch name to display
+	WorktreeDir string   // Worktree path to display
+	SharedWith  []string // Other PRDs using the worktree or branch
+	SelectedIdx int      // Selected option index (0-2)
 }
 
 // CleanResult holds the result of a clean operation for display.
 type CleanResult struct {
 	Success bool   // Whether the clean succeeded
 	Message string // Success or error message
+	Action  string // Operation named in the title (empty = "Clean")
 }
 
 // PRDPicker manages the PRD picker modal state.
 type PRDPicker struct {
-	entries       []PRDEntry
-	selectedIndex int
-	width         int
-	height        int
-	basePath      string        // Base path where .chief/prds/ is located
-	currentPRD    string        // Name of the currently active PRD
-	inputMode     bool          // Whether we're in input mode for new PRD name
-	inputValue    string        // The current input value for new PRD name
-	manager            *loop.Manager      // Reference to the loop manager for status updates
-	mergeResult        *MergeResult       // Result of the last merge operation (nil = none)
-	cleanConfirmation  *CleanConfirmation // Active clean confirmation dialog (nil = none)
-	cleanResult        *CleanResult       // Result of the last clean operation (nil = none)
+	entries           []PRDEntry
+	selectedIndex     int
+	width             int
+	height            int
+	basePath          string             // Base path where .chief/prds/ is located
+	currentPRD        string             // Name of the currently active PRD
+	inputMode         bool               // Whether we're in input mode for new PRD name
+	inputValue        string             // The current input value for new PRD name
+	manager           *loop.Manager      // Reference to the loop manager for status updates
+	mergeResult       *MergeResult       // Result of the last merge operation (nil = none)
+	cleanConfirmation *CleanConfirmation // Active clean confirmation dialog (nil = none)
+	cleanResult       *CleanResult       // Result of the last clean operation (nil = none)
+	keys              KeyMap             // Keys shown for remappable actions
+	worktreesBase     string             // Configured worktree.baseDir (empty = chief's worktrees directory)
 }
 
 // NewPRDPicker creates a new PRD picker.
@@ -109,9 +119,6 @@ func (p *PRDPicker) Refresh() {
 		entries = nil
 	}
 
-	// Track names we've added to avoid duplicates
-	addedNames := make(map[string]bool)
-
 	for _, entry := range entries {
 		if !entry.IsDir() {
 			continue
@@ -122,19 +129,10 @@ func (p *PRDPicker) Refresh() {
 
 		prdEntry := p.loadPRDEntry(name, prdPath)
 		p.entries = append(p.entries, prdEntry)
-		addedNames[name] = true
-	}
-
-	// Also check if there's a "main" PRD at legacy location
-	mainPrdPath := filepath.Join(paths.ChiefDir(p.basePath), "prd.json")
-	if _, err := os.Stat(mainPrdPath); err == nil && !addedNames["main"] {
-		prdEntry := p.loadPRDEntry("main", mainPrdPath)
-		p.entries = append(p.entries, prdEntry)
-		addedNames["main"] = true
 	}
 
 	// Detect orphaned worktrees - worktrees on disk not tracked by any manager instance
-	diskWorktrees := git.DetectOrphanedWorktrees(p.basePath)
+	diskWorktrees := git.DetectOrphanedWorktrees(p.basePath, p.worktreesBase)
 	if len(diskWorktrees) > 0 {
 		// Build set of tracked worktree dirs from manager
 		trackedDirs := make(map[string]bool)
@@ -206,10 +204,14 @@ func (p *PRDPicker) loadPRDEntry(name, prdPath string) PRDEntry {
 			if story.Passes {
 				prdEntry.Completed++
 			}
-			if story.InProgress {
+			if story.InProgress && !prdEntry.InProgress {
 				prdEntry.InProgress = true
+				prdEntry.Current = story.ID + ": " + story.Title
 			}
 		}
+		if timings, err := prd.LoadTimings(prdPath); err == nil && prdEntry.Completed < prdEntry.Total {
+			prdEntry.Remaining, prdEntry.HasEstimate = loadedPRD.EstimateRemaining(timings)
+		}
 	}
 
 	// Get loop state and worktree info from manager if available
@@ -221,6 +223,9 @@ func (p *PRDPicker) loadPRDEntry(name, prdPath string) PRDEntry {
 		if instance := p.manager.GetInstance(name); instance != nil {
 			prdEntry.Branch = instance.Branch
 			prdEntry.WorktreeDir = instance.WorktreeDir
+			if prdEntry.LoopState == loop.LoopStateRunning && !instance.StartTime.IsZero() {
+				prdEntry.Elapsed = time.Since(instance.StartTime)
+			}
 		}
 	}
 
@@ -344,6 +349,16 @@ func (p *PRDPicker) CanClean() bool {
 	return entry.LoopState != loop.LoopStateRunning
 }
 
+// CanArchive returns true if the selected entry is a PRD that can be moved
+// to the archive: loaded, not the one open on the dashboard, and not running.
+func (p *PRDPicker) CanArchive() bool {
+	entry := p.GetSelectedEntry()
+	if entry == nil || entry.LoadError != nil || entry.Name == p.currentPRD {
+		return false
+	}
+	return entry.LoopState != loop.LoopStateRunning && entry.LoopState != loop.LoopStateQueued
+}
+
 // StartCleanConfirmation opens the clean confirmation dialog for the selected entry.
 func (p *PRDPicker) StartCleanConfirmation() {
 	entry := p.GetSelectedEntry()
@@ -356,6 +371,9 @@ func (p *PRDPicker) StartCleanConfirmation() {
 		WorktreeDir: p.worktreeDisplayPath(*entry),
 		SelectedIdx: 0,
 	}
+	if p.manager != nil {
+		p.cleanConfirmation.SharedWith = p.manager.Conflicts(entry.Name)
+	}
 }
 
 // CancelCleanConfirmation closes the clean confirmation dialog.
@@ -421,8 +439,8 @@ func (p *PRDPicker) HasCleanResult() bool {
 
 // Render renders the PRD picker modal.
 func (p *PRDPicker) Render() string {
-	// Modal dimensions
-	modalWidth := min(60, p.width-10)
+	// Modal dimensions; wide terminals get a wider modal with more per-PRD detail
+	modalWidth := min(min(max(60, p.width*3/4), 100), p.width-10)
 	modalHeight := min(20, p.height-6)
 
 	if modalWidth < 30 {
@@ -563,11 +581,11 @@ func (p *PRDPicker) renderEntry(entry PRDEntry, selected bool, width int) string
 		errorStyle := lipgloss.NewStyle().Foreground(ErrorColor)
 		line.WriteString(errorStyle.Render("[error]"))
 	} else {
-		// Progress bar
-		progressWidth := 8
+		// Progress bar, wider when there's room
+		progressWidth := pickerProgressWidth(width)
 		percentage := float64(0)
-		if entry.Total > 0 {
-			percentage = float64(entry.Completed) / float64(entry.Total) * 100
+		if entry.PRD != nil && entry.Total > 0 {
+			percentage = entry.PRD.CompletionPercentage()
 		}
 		filledWidth := int(float64(progressWidth) * percentage / 100)
 		emptyWidth := progressWidth - filledWidth
@@ -581,6 +599,13 @@ func (p *PRDPicker) renderEntry(entry PRDEntry, selected bool, width int) string
 		countStyle := lipgloss.NewStyle().Foreground(MutedColor)
 		line.WriteString(countStyle.Render(fmt.Sprintf("%d/%d", entry.Completed, entry.Total)))
 
+		// Remaining time estimate
+		estimate := ""
+		if entry.HasEstimate {
+			estimate = " " + formatEstimate(entry.Remaining)
+			line.WriteString(countStyle.Render(estimate))
+		}
+
 		// Loop state indicator
 		line.WriteString(" ")
 		line.WriteString(p.renderLoopStateIndicator(entry))
@@ -592,19 +617,9 @@ func (p *PRDPicker) renderEntry(entry PRDEntry, selected bool, width int) string
 			line.WriteString(orphanedStyle.Render("[orphaned]"))
 		}
 
-		// Branch and worktree path (only if branch is set)
-		if entry.Branch != "" {
-			branchPathStyle := lipgloss.NewStyle().Foreground(MutedColor)
-			// Calculate remaining space for branch and path info
-			// Base content uses: 2 (indicator) + 12 (name) + 1 (space) + 8 (progress) + 1 (space) + ~3 (count) + 1 (space) + ~2 (state) = ~30
-			remaining := width - 32
-			if remaining > 10 {
-				branchStr := entry.Branch
-				pathStr := p.worktreeDisplayPath(entry)
-				// Truncate to fit within remaining space: "  branch  path"
-				infoStr := p.formatBranchPath(branchStr, pathStr, remaining)
-				line.WriteString(branchPathStyle.Render(infoStr))
-			}
+		// Details, most useful first, in whatever space is left
+		if details := p.entryDetails(entry, width-lipgloss.Width(line.String())); details != "" {
+			line.WriteString(lipgloss.NewStyle().Foreground(MutedColor).Render(details))
 		}
 	}
 
@@ -618,17 +633,86 @@ func (p *PRDPicker) renderEntry(entry PRDEntry, selected bool, width int) string
 	return result
 }
 
+// pickerProgressWidth returns the width of an entry's progress bar: 8 on
+// narrow lists, growing with the space available up to 20.
+func pickerProgressWidth(width int) int {
+	if width < 70 {
+		return 8
+	}
+	return min(8+(width-70)/4, 20)
+}
+
+// entryDetails builds the detail text shown after an entry's progress and
+// state, fitted to avail columns: elapsed time while running, the story in
+// progress, then the branch and worktree path. Details that don't fit are
+// dropped; the story title is truncated to leave room for the branch.
+func (p *PRDPicker) entryDetails(entry PRDEntry, avail int) string {
+	var details string
+	if entry.LoopState == loop.LoopStateRunning && entry.Elapsed > 0 {
+		if elapsed := " " + formatDuration(entry.Elapsed.Truncate(time.Second)); len(elapsed) <= avail {
+			details = elapsed
+			avail -= len(elapsed)
+		}
+	}
+
+	branchWidth := 0
+	if entry.Branch != "" {
+		branchWidth = min(len([]rune(entry.Branch))+2, 24)
+	}
+	if entry.Current != "" {
+		if room := avail - branchWidth - 2; room >= 12 {
+			story := "  " + truncateWithEllipsis(entry.Current, room)
+			details += story
+			avail -= lipgloss.Width(story)
+		}
+	}
+
+	// Branch and worktree path (only if branch is set)
+	if entry.Branch != "" && avail > 10 {
+		details += p.formatBranchPath(entry.Branch, p.worktreeDisplayPath(entry), avail)
+	}
+	return details
+}
+
+// formatEstimate formats a remaining time estimate compactly, e.g. "~25m left".
+func formatEstimate(d time.Duration) string {
+	if d < time.Minute {
+		return "<1m left"
+	}
+	h := int(d.Hours())
+	m := int(d.Minutes()) % 60
+	if h > 0 {
+		return fmt.Sprintf("~%dh%02dm left", h, m)
+	}
+	return fmt.Sprintf("~%dm left", m)
+}
+
 // worktreeDisplayPath returns a display-friendly worktree path.
 func (p *PRDPicker) worktreeDisplayPath(entry PRDEntry) string {
 	if entry.WorktreeDir == "" {
 		return "(current directory)"
 	}
-	// Show relative path from base dir
-	rel, err := filepath.Rel(p.basePath, entry.WorktreeDir)
-	if err != nil {
-		return entry.WorktreeDir
+	return displayWorktreePath(p.basePath, entry.WorktreeDir)
+}
+
+// displayWorktreePath shortens a worktree path for display: relative to the
+// project when it's inside it, otherwise starting with "~/" when it's in the
+// home directory, otherwise absolute.
+func displayWorktreePath(baseDir, worktreeDir string) string {
+	if rel, err := filepath.Rel(baseDir, worktreeDir); err == nil && !isParentPath(rel) {
+		return rel + "/"
 	}
-	return rel + "/"
+	if home, err := os.UserHomeDir(); err == nil {
+		if rel, err := filepath.Rel(home, worktreeDir); err == nil && !isParentPath(rel) {
+			return "~/" + rel + "/"
+		}
+	}
+	return worktreeDir + "/"
+}
+
+// isParentPath reports whether a relative path climbs out of its base.
+func isParentPath(rel string) bool {
+	return rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator))
 }
 
 // formatBranchPath formats branch and path info to fit within maxWidth.
@@ -686,6 +770,9 @@ func (p *PRDPicker) renderLoopStateIndicator(entry PRDEntry) string {
 	case loop.LoopStateStopped:
 		stoppedStyle := lipgloss.NewStyle().Foreground(MutedColor)
 		return stoppedStyle.Render("■")
+	case loop.LoopStateQueued:
+		queuedStyle := lipgloss.NewStyle().Foreground(MutedColor)
+		return queuedStyle.Render("⏳ queued")
 	default:
 		// Ready state - show story status
 		if entry.InProgress {
@@ -733,15 +820,26 @@ func (p *PRDPicker) renderInputMode(width int) string {
 	return content.String()
 }
 
+// SetKeyMap sets the keys shown for remappable actions.
+func (p *PRDPicker) SetKeyMap(keys KeyMap) {
+	p.keys = keys
+}
+
+// SetWorktreesBase sets the configured worktree.baseDir, where the picker
+// looks for worktrees left on disk.
+func (p *PRDPicker) SetWorktreesBase(configured string) {
+	p.worktreesBase = configured
+}
+
 // buildFooterShortcuts builds context-sensitive shortcuts based on selected entry's state.
 func (p *PRDPicker) buildFooterShortcuts() string {
 	entry := p.GetSelectedEntry()
 	if entry == nil {
-		return "↑/k ↓/j: nav  │  n: new  │  Esc/l: close"
+		return "↑/k ↓/j: nav  │  " + p.keys.Hint(ActionNew, "new") + "  │  Esc/l: close"
 	}
 
 	// Base shortcuts
-	base := "Enter: select  │  n: new  │  e: edit  │  Esc/l: close"
+	base := "Enter: select  │  " + p.keys.Hint(ActionNew, "new") + "  │  " + p.keys.Hint(ActionEdit, "edit") + "  │  Esc/l: close"
 
 	// Add merge shortcut for completed PRDs with a branch
 	mergeHint := ""
@@ -755,16 +853,21 @@ func (p *PRDPicker) buildFooterShortcuts() string {
 		cleanHint = "c: clean  │  "
 	}
 
+	// Add archive shortcut for PRDs that aren't open or running
+	if p.CanArchive() {
+		cleanHint += p.keys.Hint(ActionSort, "archive") + "  │  "
+	}
+
 	// Add state-specific controls
 	switch entry.LoopState {
 	case loop.LoopStateReady, loop.LoopStatePaused, loop.LoopStateStopped, loop.LoopStateError:
-		return "s: start  │  " + mergeHint + cleanHint + base
-	case loop.LoopStateRunning:
-		return "p: pause  │  x: stop  │  " + base
+		return p.keys.Hint(ActionStart, "start") + "  │  " + mergeHint + cleanHint + base
+	case loop.LoopStateRunning, loop.LoopStateQueued:
+		return p.keys.Hint(ActionPause, "pause") + "  │  " + p.keys.Hint(ActionStop, "stop") + "  │  " + base
 	case loop.LoopStateComplete:
 		return mergeHint + cleanHint + base
 	default:
-		return "s: start  │  " + mergeHint + cleanHint + base
+		return p.keys.Hint(ActionStart, "start") + "  │  " + mergeHint + cleanHint + base
 	}
 }
 
@@ -838,9 +941,16 @@ func (p *PRDPicker) renderMergeResult(modalWidth, modalHeight int) string {
 			content.WriteString("\n")
 			content.WriteString(hintStyle.Render(fmt.Sprintf("  cd <project-root>")))
 			content.WriteString("\n")
-			content.WriteString(hintStyle.Render(fmt.Sprintf("  git merge %s", p.mergeResult.Branch)))
+			command, then := fmt.Sprintf("  git merge %s", p.mergeResult.Branch), "  # resolve conflicts, then git commit"
+			switch p.mergeResult.Strategy {
+			case git.StrategyRebase:
+				command, then = fmt.Sprintf("  git rebase <current-branch> %s", p.mergeResult.Branch), "  # resolve conflicts, then git rebase --continue"
+			case git.StrategySquash:
+				command = fmt.Sprintf("  git merge --squash %s", p.mergeResult.Branch)
+			}
+			content.WriteString(hintStyle.Render(command))
 			content.WriteString("\n")
-			content.WriteString(hintStyle.Render("  # resolve conflicts, then git commit"))
+			content.WriteString(hintStyle.Render(then))
 			content.WriteString("\n")
 		}
 	}
@@ -892,6 +1002,13 @@ func (p *PRDPicker) renderCleanConfirmation(modalWidth, modalHeight int) string
 		content.WriteString(infoStyle.Render(fmt.Sprintf("Branch: %s", cc.Branch)))
 		content.WriteString("\n")
 	}
+	if len(cc.SharedWith) > 0 {
+		warnStyle := lipgloss.NewStyle().
+			Foreground(WarningColor).
+			Padding(0, 1)
+		content.WriteString(warnStyle.Render(fmt.Sprintf("Also used by: %s (cleaned for them too)", strings.Join(cc.SharedWith, ", "))))
+		content.WriteString("\n")
+	}
 	content.WriteString("\n")
 
 	// Options
@@ -945,18 +1062,22 @@ func (p *PRDPicker) renderCleanConfirmation(modalWidth, modalHeight int) string
 func (p *PRDPicker) renderCleanResult(modalWidth, modalHeight int) string {
 	var content strings.Builder
 
+	action := p.cleanResult.Action
+	if action == "" {
+		action = "Clean"
+	}
 	if p.cleanResult.Success {
 		titleStyle := lipgloss.NewStyle().
 			Bold(true).
 			Foreground(SuccessColor).
 			Padding(0, 1)
-		content.WriteString(titleStyle.Render("Clean Successful"))
+		content.WriteString(titleStyle.Render(action + " Successful"))
 	} else {
 		titleStyle := lipgloss.NewStyle().
 			Bold(true).
 			Foreground(ErrorColor).
 			Padding(0, 1)
-		content.WriteString(titleStyle.Render("Clean Failed"))
+		content.WriteString(titleStyle.Render(action + " Failed"))
 	}
 	content.WriteString("\n")
 	content.WriteString(DividerStyle.Render(strings.Repeat("─", modalWidth-4)))
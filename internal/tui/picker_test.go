@@ -2,10 +2,19 @@ package tui
 
 import (
 	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 	"unicode/utf8"
 
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/minicodemonkey/chief/internal/git"
 	"github.com/minicodemonkey/chief/internal/loop"
+	"github.com/minicodemonkey/chief/internal/paths"
 )
 
 func TestRenderEntryWithBranchAndWorktree(t *testing.T) {
@@ -166,6 +175,21 @@ func TestWorktreeDisplayPathWithWorktree(t *testing.T) {
 	}
 }
 
+func TestWorktreeDisplayPathOutsideProject(t *testing.T) {
+	p := &PRDPicker{basePath: "/project"}
+
+	entry := PRDEntry{WorktreeDir: "/srv/worktrees/auth"}
+	if result := p.worktreeDisplayPath(entry); result != "/srv/worktrees/auth/" {
+		t.Errorf("expected '/srv/worktrees/auth/', got %q", result)
+	}
+
+	t.Setenv("HOME", "/home/user")
+	entry = PRDEntry{WorktreeDir: "/home/user/worktrees/auth"}
+	if result := p.worktreeDisplayPath(entry); result != "~/worktrees/auth/" {
+		t.Errorf("expected '~/worktrees/auth/', got %q", result)
+	}
+}
+
 func TestWorktreeDisplayPathWithoutWorktree(t *testing.T) {
 	p := &PRDPicker{basePath: "/project"}
 
@@ -300,6 +324,39 @@ func TestMergeResultSuccessRendering(t *testing.T) {
 	}
 }
 
+func TestMergeResultConflictHintFollowsStrategy(t *testing.T) {
+	p := &PRDPicker{
+		basePath: "/project",
+		width:    80,
+		height:   24,
+		entries:  []PRDEntry{{Name: "auth", Branch: "chief/auth"}},
+		mergeResult: &MergeResult{
+			Conflicts: []string{"src/auth.go"},
+			Branch:    "chief/auth",
+			Strategy:  git.StrategyRebase,
+		},
+	}
+
+	result := p.Render()
+	if !containsText(result, "git rebase <current-branch> chief/auth") || !containsText(result, "git rebase --continue") {
+		t.Errorf("expected rebase hint, got: %s", stripAnsi(result))
+	}
+}
+
+func TestParseMergeSuccessMessage(t *testing.T) {
+	dir := t.TempDir() // Not a repo, so the target falls back to "current branch"
+	tests := map[string]string{
+		"":                 "Merged chief/auth into current branch",
+		git.StrategyRebase: "Rebased chief/auth onto current branch and fast-forwarded",
+		git.StrategySquash: "Squashed chief/auth into one commit on current branch",
+	}
+	for strategy, want := range tests {
+		if got := parseMergeSuccessMessage(dir, "chief/auth", strategy); got != want {
+			t.Errorf("strategy %q: expected %q, got %q", strategy, want, got)
+		}
+	}
+}
+
 func TestMergeResultConflictRendering(t *testing.T) {
 	p := &PRDPicker{
 		basePath: "/project",
@@ -397,6 +454,64 @@ func TestFooterHidesMergeHintForRunningPRD(t *testing.T) {
 
 // --- Clean Action Tests ---
 
+func TestCanArchive(t *testing.T) {
+	tests := []struct {
+		name  string
+		entry PRDEntry
+		want  bool
+	}{
+		{"complete", PRDEntry{Name: "auth", LoopState: loop.LoopStateComplete}, true},
+		{"running", PRDEntry{Name: "auth", LoopState: loop.LoopStateRunning}, false},
+		{"queued", PRDEntry{Name: "auth", LoopState: loop.LoopStateQueued}, false},
+		{"open on the dashboard", PRDEntry{Name: "main", LoopState: loop.LoopStateReady}, false},
+		{"load error", PRDEntry{Name: "auth", LoadError: fmt.Errorf("bad json")}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &PRDPicker{basePath: "/project", currentPRD: "main", entries: []PRDEntry{tt.entry}}
+			if got := p.CanArchive(); got != tt.want {
+				t.Errorf("CanArchive() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPickerArchiveUsesSortKey(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+	baseDir := t.TempDir()
+	for _, name := range []string{"main", "old"} {
+		if err := os.MkdirAll(paths.PRDDir(baseDir, name), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(paths.PRDPath(baseDir, name), []byte(`{"project":"x","userStories":[{"id":"US-001","passes":true}]}`), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	keys := NewKeyMap(map[string]string{ActionSort: "z"})
+	picker := NewPRDPicker(baseDir, "main", nil)
+	picker.SetKeyMap(keys)
+	for i, entry := range picker.entries {
+		if entry.Name == "old" {
+			picker.selectedIndex = i
+		}
+	}
+	if shortcuts := picker.buildFooterShortcuts(); !containsSubstring(shortcuts, "z: archive") {
+		t.Errorf("expected the remapped archive key in the footer, got: %s", shortcuts)
+	}
+
+	var model tea.Model = App{baseDir: baseDir, prdName: "main", picker: picker, keys: keys, viewMode: ViewPicker}
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	if _, err := os.Stat(paths.PRDDir(baseDir, "old")); err != nil {
+		t.Fatalf("expected a to no longer archive once sort is remapped: %v", err)
+	}
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("z")})
+	if _, err := os.Stat(paths.PRDDir(baseDir, "old")); !os.IsNotExist(err) {
+		t.Error("expected the sort key to archive the PRD")
+	}
+}
+
 func TestCanCleanNonRunningWithWorktree(t *testing.T) {
 	p := &PRDPicker{
 		basePath: "/project",
@@ -417,6 +532,35 @@ func TestCanCleanNonRunningWithWorktree(t *testing.T) {
 	}
 }
 
+func TestCleanSharedWorktree(t *testing.T) {
+	mgr := loop.NewManager(5)
+	mgr.RegisterWithWorktree("auth", "/tmp/auth/prd.json", "/project/.chief/worktrees/auth", "chief/auth")
+	mgr.RegisterWithWorktree("login", "/tmp/login/prd.json", "/project/.chief/worktrees/auth", "chief/auth")
+	p := &PRDPicker{
+		basePath: "/project",
+		manager:  mgr,
+		entries: []PRDEntry{
+			{Name: "auth", Branch: "chief/auth", WorktreeDir: "/project/.chief/worktrees/auth"},
+		},
+	}
+	p.StartCleanConfirmation()
+	cc := p.GetCleanConfirmation()
+	if len(cc.SharedWith) != 1 || cc.SharedWith[0] != "login" {
+		t.Fatalf("expected login to be listed as sharing the worktree, got %v", cc.SharedWith)
+	}
+	if view := p.renderCleanConfirmation(80, 30); !containsSubstring(view, "Also used by: login") {
+		t.Errorf("expected the dialog to warn about login, got: %s", view)
+	}
+
+	app := App{picker: p, manager: mgr}
+	app.handleCleanResult(cleanResultMsg{prdName: "auth", success: true, clearBranch: true, sharedWith: cc.SharedWith})
+	for _, name := range []string{"auth", "login"} {
+		if inst := mgr.GetInstance(name); inst.WorktreeDir != "" || inst.Branch != "" {
+			t.Errorf("expected %s's worktree info to be cleared, got %q on %q", name, inst.WorktreeDir, inst.Branch)
+		}
+	}
+}
+
 func TestCanCleanDisabledForRunningPRD(t *testing.T) {
 	p := &PRDPicker{
 		basePath: "/project",
@@ -886,3 +1030,117 @@ func stripAnsi(s string) string {
 	}
 	return string(result)
 }
+
+func TestRenderEntryShowsRemainingEstimate(t *testing.T) {
+	p := &PRDPicker{basePath: "/project"}
+	entry := PRDEntry{
+		Name:        "auth",
+		Completed:   3,
+		Total:       8,
+		LoopState:   loop.LoopStateReady,
+		Remaining:   25 * time.Minute,
+		HasEstimate: true,
+	}
+
+	if result := p.renderEntry(entry, false, 80); !containsText(result, "~25m left") {
+		t.Errorf("expected remaining estimate in output, got: %s", result)
+	}
+
+	entry.HasEstimate = false
+	if result := p.renderEntry(entry, false, 80); containsText(result, "left") {
+		t.Errorf("expected no estimate without timings, got: %s", result)
+	}
+}
+
+func TestFormatEstimate(t *testing.T) {
+	tests := map[time.Duration]string{
+		30 * time.Second:               "<1m left",
+		25 * time.Minute:               "~25m left",
+		time.Hour + 40*time.Minute + 5: "~1h40m left",
+	}
+	for d, want := range tests {
+		if got := formatEstimate(d); got != want {
+			t.Errorf("formatEstimate(%s) = %q, want %q", d, got, want)
+		}
+	}
+}
+
+func TestRenderEntryAdaptsToWidth(t *testing.T) {
+	p := &PRDPicker{basePath: "/project"}
+	entry := PRDEntry{
+		Name:        "auth",
+		Completed:   2,
+		Total:       4,
+		LoopState:   loop.LoopStateRunning,
+		Iteration:   3,
+		Current:     "US-003: Password reset via emailed link",
+		Elapsed:     12*time.Minute + 5*time.Second,
+		Branch:      "chief/auth",
+		WorktreeDir: "/project/.chief/worktrees/auth",
+	}
+
+	wide := stripAnsi(p.renderEntry(entry, false, 140))
+	for _, want := range []string{"12m05s", "US-003: Password reset via emailed link", "chief/auth"} {
+		if !containsText(wide, want) {
+			t.Errorf("expected %q on a wide entry, got: %s", want, wide)
+		}
+	}
+	if bars := strings.Count(wide, "█") + strings.Count(wide, "░"); bars != pickerProgressWidth(140) || bars <= 8 {
+		t.Errorf("expected a wider progress bar on a wide entry, got %d cells", bars)
+	}
+
+	medium := stripAnsi(p.renderEntry(entry, false, 80))
+	if !containsText(medium, "US-003") || !containsText(medium, "chief/auth") {
+		t.Errorf("expected the story truncated to leave room for the branch, got: %s", medium)
+	}
+	if utf8.RuneCountInString(medium) > 80 {
+		t.Errorf("expected the entry to fit in 80 columns, got %d: %s", utf8.RuneCountInString(medium), medium)
+	}
+
+	narrow := stripAnsi(p.renderEntry(entry, false, 40))
+	if containsText(narrow, "US-003") || strings.Count(narrow, "█")+strings.Count(narrow, "░") != 8 {
+		t.Errorf("expected only the basic entry on a narrow list, got: %s", narrow)
+	}
+}
+
+func TestReconcileBranchesDisablesMerge(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+	dir := t.TempDir()
+	initGitRepo(t, dir,
+		[]string{"commit", "--allow-empty", "-m", "initial"},
+		[]string{"branch", "chief/auth"},
+	)
+	prdPath := paths.PRDPath(dir, "auth")
+	if err := os.MkdirAll(filepath.Dir(prdPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(prdPath, []byte(`{"project": "Auth", "userStories": [{"id": "US-001", "title": "Login", "passes": true}]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	mgr := loop.NewManager(5)
+	mgr.SetBaseDir(dir)
+	mgr.RegisterWithWorktree("auth", prdPath, "", "chief/auth")
+	app := &App{prdName: "auth", baseDir: dir, manager: mgr, picker: NewPRDPicker(dir, "auth", mgr)}
+
+	app.reconcileBranches()
+	app.picker.Refresh()
+	if !app.picker.CanMerge() || app.lastActivity != "" {
+		t.Fatalf("CanMerge() = %v, activity %q with the branch present", app.picker.CanMerge(), app.lastActivity)
+	}
+
+	c := exec.Command("git", "branch", "-D", "chief/auth")
+	c.Dir = dir
+	if out, err := c.CombinedOutput(); err != nil {
+		t.Fatalf("deleting branch failed: %s", out)
+	}
+	app.reconcileBranches()
+	app.picker.Refresh()
+	if app.picker.CanMerge() {
+		t.Error("expected CanMerge() to return false once the branch is deleted")
+	}
+	if !strings.Contains(app.lastActivity, "auth") {
+		t.Errorf("activity = %q, want it to name the PRD", app.lastActivity)
+	}
+}
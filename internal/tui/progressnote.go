@@ -0,0 +1,54 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/minicodemonkey/chief/internal/prd"
+)
+
+// startProgressNote opens the note input for the selected story.
+func (a *App) startProgressNote() {
+	story := a.GetSelectedStory()
+	if story == nil {
+		return
+	}
+	a.noteStory = story.ID
+	a.noteInput = ""
+	a.noteEditing = true
+}
+
+// saveProgressNote appends the typed note to the story's section in
+// progress.md and refreshes the details panel without waiting for the watcher.
+func (a *App) saveProgressNote() {
+	a.noteEditing = false
+	path := prd.ProgressPath(a.prdPath)
+	if err := prd.AppendProgressNote(path, a.noteStory, a.noteInput); err != nil {
+		a.lastActivity = "Note not saved: " + err.Error()
+		return
+	}
+	if progress, err := prd.ParseProgress(path); err == nil {
+		a.progress = progress
+	}
+	a.lastActivity = "Added a note to " + a.noteStory
+}
+
+// handleProgressNoteKeys handles typing a progress note. Enter saves it to
+// progress.md, Esc discards it.
+func (a App) handleProgressNoteKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		a.noteEditing = false
+	case tea.KeyEnter:
+		a.saveProgressNote()
+	case tea.KeyBackspace:
+		if runes := []rune(a.noteInput); len(runes) > 0 {
+			a.noteInput = string(runes[:len(runes)-1])
+		}
+	case tea.KeyCtrlC:
+		return a.tryQuit()
+	case tea.KeySpace:
+		a.noteInput += " "
+	case tea.KeyRunes:
+		a.noteInput += string(msg.Runes)
+	}
+	return a, nil
+}
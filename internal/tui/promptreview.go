@@ -0,0 +1,221 @@
+package tui
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// PromptReview shows the assembled first-iteration prompt for approval before
+// a loop starts.
+type PromptReview struct {
+	width        int
+	height       int
+	prdName      string
+	startStory   string
+	prompt       string
+	edited       bool
+	scrollOffset int
+}
+
+// NewPromptReview creates a new prompt review dialog.
+func NewPromptReview() *PromptReview {
+	return &PromptReview{}
+}
+
+// SetSize sets the dialog dimensions.
+func (r *PromptReview) SetSize(width, height int) {
+	r.width = width
+	r.height = height
+}
+
+// Open loads the prompt a loop for prdName is about to send.
+func (r *PromptReview) Open(prdName, startStory, prompt string) {
+	r.prdName = prdName
+	r.startStory = startStory
+	r.prompt = prompt
+	r.edited = false
+	r.scrollOffset = 0
+}
+
+// SetPrompt replaces the prompt after the user edits it.
+func (r *PromptReview) SetPrompt(prompt string) {
+	r.prompt = prompt
+	r.edited = true
+	r.scrollOffset = min(r.scrollOffset, r.maxScroll())
+}
+
+// Prompt returns the prompt under review.
+func (r *PromptReview) Prompt() string {
+	return r.prompt
+}
+
+// PRDName returns the PRD whose loop is waiting on the review.
+func (r *PromptReview) PRDName() string {
+	return r.prdName
+}
+
+// StartStory returns the story the loop will target first, if any.
+func (r *PromptReview) StartStory() string {
+	return r.startStory
+}
+
+// Edited reports whether the user changed the prompt.
+func (r *PromptReview) Edited() bool {
+	return r.edited
+}
+
+// ScrollUp scrolls the prompt up by one line.
+func (r *PromptReview) ScrollUp() {
+	if r.scrollOffset > 0 {
+		r.scrollOffset--
+	}
+}
+
+// ScrollDown scrolls the prompt down by one line.
+func (r *PromptReview) ScrollDown() {
+	if r.scrollOffset < r.maxScroll() {
+		r.scrollOffset++
+	}
+}
+
+// PageUp scrolls the prompt up by a page.
+func (r *PromptReview) PageUp() {
+	r.scrollOffset = max(0, r.scrollOffset-r.viewHeight())
+}
+
+// PageDown scrolls the prompt down by a page.
+func (r *PromptReview) PageDown() {
+	r.scrollOffset = min(r.maxScroll(), r.scrollOffset+r.viewHeight())
+}
+
+// modalWidth returns the dialog width.
+func (r *PromptReview) modalWidth() int {
+	return max(40, min(100, r.width-10))
+}
+
+// viewHeight returns how many prompt lines fit in the dialog.
+func (r *PromptReview) viewHeight() int {
+	// Border, padding, title, divider, blank line, divider, footer
+	return max(3, r.height-6-10)
+}
+
+// lines returns the prompt wrapped to the dialog width.
+func (r *PromptReview) lines() []string {
+	return strings.Split(wrapText(r.prompt, r.modalWidth()-6), "\n")
+}
+
+// maxScroll returns the largest useful scroll offset.
+func (r *PromptReview) maxScroll() int {
+	return max(0, len(r.lines())-r.viewHeight())
+}
+
+// Render renders the prompt review dialog.
+func (r *PromptReview) Render() string {
+	modalWidth := r.modalWidth()
+
+	var content strings.Builder
+
+	// Title
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(PrimaryColor)
+	title := "Review prompt for " + r.prdName
+	if r.startStory != "" {
+		title += " (starting at " + r.startStory + ")"
+	}
+	if r.edited {
+		title += " — edited"
+	}
+	content.WriteString(titleStyle.Render(title))
+	content.WriteString("\n")
+	content.WriteString(DividerStyle.Render(strings.Repeat("─", modalWidth-4)))
+	content.WriteString("\n")
+
+	// Prompt, scrolled
+	lines := r.lines()
+	end := min(len(lines), r.scrollOffset+r.viewHeight())
+	textStyle := lipgloss.NewStyle().Foreground(TextColor)
+	for i := r.scrollOffset; i < end; i++ {
+		content.WriteString(textStyle.Render(lines[i]))
+		content.WriteString("\n")
+	}
+	for i := end - r.scrollOffset; i < r.viewHeight(); i++ {
+		content.WriteString("\n")
+	}
+
+	// Footer
+	content.WriteString(DividerStyle.Render(strings.Repeat("─", modalWidth-4)))
+	content.WriteString("\n")
+	footerStyle := lipgloss.NewStyle().Foreground(MutedColor)
+	content.WriteString(footerStyle.Render("Enter: Start  e: Edit  j/k: Scroll  Esc: Cancel"))
+
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(PrimaryColor).
+		Padding(1, 2).
+		Width(modalWidth)
+
+	return r.centerModal(modalStyle.Render(content.String()))
+}
+
+// centerModal centers the modal on the screen.
+func (r *PromptReview) centerModal(modal string) string {
+	lines := strings.Split(modal, "\n")
+	modalWidth := 0
+	for _, line := range lines {
+		modalWidth = max(modalWidth, lipgloss.Width(line))
+	}
+
+	topPadding := max(0, (r.height-len(lines))/2)
+	leftPad := strings.Repeat(" ", max(0, (r.width-modalWidth)/2))
+
+	var result strings.Builder
+	result.WriteString(strings.Repeat("\n", topPadding))
+	for _, line := range lines {
+		result.WriteString(leftPad)
+		result.WriteString(line)
+		result.WriteString("\n")
+	}
+	return result.String()
+}
+
+// promptEditedMsg is sent when the editor opened on the reviewed prompt exits.
+type promptEditedMsg struct {
+	prompt string
+	err    error
+}
+
+// editPrompt writes the prompt to a temp file and opens it in $EDITOR,
+// suspending the TUI until the editor exits.
+func editPrompt(prompt string) tea.Cmd {
+	f, err := os.CreateTemp("", "chief-prompt-*.md")
+	if err != nil {
+		return func() tea.Msg { return promptEditedMsg{err: err} }
+	}
+	path := f.Name()
+	_, err = f.WriteString(prompt)
+	f.Close()
+	if err != nil {
+		os.Remove(path)
+		return func() tea.Msg { return promptEditedMsg{err: err} }
+	}
+
+	editor := strings.Fields(os.Getenv("EDITOR"))
+	if len(editor) == 0 {
+		editor = []string{"vi"}
+		if runtime.GOOS == "windows" {
+			editor = []string{"notepad"}
+		}
+	}
+	return tea.ExecProcess(exec.Command(editor[0], append(editor[1:], path)...), func(err error) tea.Msg {
+		defer os.Remove(path)
+		if err != nil {
+			return promptEditedMsg{err: err}
+		}
+		data, err := os.ReadFile(path)
+		return promptEditedMsg{prompt: string(data), err: err}
+	})
+}
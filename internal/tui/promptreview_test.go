@@ -0,0 +1,88 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/minicodemonkey/chief/internal/loop"
+)
+
+func TestDoStartLoopWaitsForPromptReview(t *testing.T) {
+	mgr := loop.NewManager(5)
+	mgr.RegisterWithWorktree("auth", "/tmp/auth/prd.json", "", "chief/auth")
+	app := App{
+		prdName:      "auth",
+		manager:      mgr,
+		viewMode:     ViewDashboard,
+		promptReview: NewPromptReview(),
+		reviewPrompt: true,
+		width:        100,
+		height:       40,
+	}
+
+	model, _ := app.doStartLoop("auth", "/tmp/auth")
+	app = model.(App)
+	if app.viewMode != ViewPromptReview {
+		t.Fatalf("expected the prompt review dialog, got view %v", app.viewMode)
+	}
+	if state, _, _ := mgr.GetState("auth"); state == loop.LoopStateRunning {
+		t.Fatal("expected the loop to wait for approval")
+	}
+	if view := stripANSI(app.View()); !strings.Contains(view, "Review prompt for auth") || !strings.Contains(view, "Enter: Start") {
+		t.Errorf("expected the review dialog to render, got:\n%s", view)
+	}
+
+	model, _ = app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	app = model.(App)
+	if app.viewMode != ViewDashboard || app.lastActivity != "Start cancelled" {
+		t.Errorf("expected Esc to cancel the start, got view %v activity %q", app.viewMode, app.lastActivity)
+	}
+	if state, _, _ := mgr.GetState("auth"); state == loop.LoopStateRunning {
+		t.Error("expected no loop after cancelling")
+	}
+}
+
+func TestHandlePromptEdited(t *testing.T) {
+	app := App{promptReview: NewPromptReview()}
+	app.promptReview.Open("auth", "", "original")
+
+	model, _ := app.handlePromptEdited(promptEditedMsg{prompt: "  \n"})
+	app = model.(App)
+	if app.promptReview.Edited() || app.promptReview.Prompt() != "original" {
+		t.Error("expected an emptied prompt to be ignored")
+	}
+
+	model, _ = app.handlePromptEdited(promptEditedMsg{err: errors.New("exit status 1")})
+	app = model.(App)
+	if app.promptReview.Edited() || !strings.Contains(app.lastActivity, "Editor failed") {
+		t.Errorf("expected editor failure to keep the prompt, got activity %q", app.lastActivity)
+	}
+
+	model, _ = app.handlePromptEdited(promptEditedMsg{prompt: "tightened"})
+	app = model.(App)
+	if !app.promptReview.Edited() || app.promptReview.Prompt() != "tightened" {
+		t.Errorf("expected the edited prompt to replace the original, got %q", app.promptReview.Prompt())
+	}
+}
+
+func TestPromptReviewScrolling(t *testing.T) {
+	r := NewPromptReview()
+	r.SetSize(80, 20)
+	r.Open("auth", "", strings.Repeat("line\n", 50))
+
+	r.ScrollUp()
+	if r.scrollOffset != 0 {
+		t.Errorf("expected scrolling up at the top to stay put, got %d", r.scrollOffset)
+	}
+	r.PageDown()
+	r.PageDown()
+	r.PageDown()
+	r.PageDown()
+	r.PageDown()
+	r.PageDown()
+	if r.scrollOffset != r.maxScroll() {
+		t.Errorf("expected paging to stop at the end (%d), got %d", r.maxScroll(), r.scrollOffset)
+	}
+}
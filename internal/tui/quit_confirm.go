@@ -10,10 +10,14 @@ import (
 type QuitConfirmOption int
 
 const (
-	QuitOptionQuit   QuitConfirmOption = iota // Quit and stop loop
-	QuitOptionCancel                          // Cancel
+	QuitOptionQuit       QuitConfirmOption = iota // Quit and stop loop
+	QuitOptionBackground                          // Quit the UI but let the loops keep running
+	QuitOptionCancel                              // Cancel
 )
 
+// quitOptionLabels are the dialog's choices, in QuitConfirmOption order.
+var quitOptionLabels = []string{"Stop loops and quit", "Keep running in background and quit", "Cancel"}
+
 // QuitConfirmation manages the quit confirmation dialog state.
 type QuitConfirmation struct {
 	width       int
@@ -24,7 +28,7 @@ type QuitConfirmation struct {
 // NewQuitConfirmation creates a new quit confirmation dialog.
 func NewQuitConfirmation() *QuitConfirmation {
 	return &QuitConfirmation{
-		selectedIdx: 1, // Default to Cancel (safe choice)
+		selectedIdx: int(QuitOptionCancel), // Default to Cancel (safe choice)
 	}
 }
 
@@ -43,22 +47,19 @@ func (q *QuitConfirmation) MoveUp() {
 
 // MoveDown moves selection down.
 func (q *QuitConfirmation) MoveDown() {
-	if q.selectedIdx < 1 {
+	if q.selectedIdx < len(quitOptionLabels)-1 {
 		q.selectedIdx++
 	}
 }
 
 // GetSelected returns the currently selected option.
 func (q *QuitConfirmation) GetSelected() QuitConfirmOption {
-	if q.selectedIdx == 0 {
-		return QuitOptionQuit
-	}
-	return QuitOptionCancel
+	return QuitConfirmOption(q.selectedIdx)
 }
 
 // Reset resets the dialog state to defaults.
 func (q *QuitConfirmation) Reset() {
-	q.selectedIdx = 1 // Default to Cancel
+	q.selectedIdx = int(QuitOptionCancel) // Default to Cancel
 }
 
 // Render renders the quit confirmation dialog.
@@ -81,15 +82,14 @@ func (q *QuitConfirmation) Render() string {
 	messageStyle := lipgloss.NewStyle().Foreground(TextColor)
 	content.WriteString(messageStyle.Render("A Ralph loop is currently running."))
 	content.WriteString("\n")
-	content.WriteString(messageStyle.Render("Exiting will stop the loop."))
+	content.WriteString(messageStyle.Render("Stopping kills Claude mid-iteration."))
 	content.WriteString("\n\n")
 
 	// Options
 	optionStyle := lipgloss.NewStyle().Foreground(TextColor)
 	selectedStyle := lipgloss.NewStyle().Foreground(PrimaryColor).Bold(true)
 
-	options := []string{"Quit and stop loop", "Cancel"}
-	for i, opt := range options {
+	for i, opt := range quitOptionLabels {
 		if i == q.selectedIdx {
 			content.WriteString(selectedStyle.Render("▶ " + opt))
 		} else {
@@ -104,6 +104,8 @@ func (q *QuitConfirmation) Render() string {
 	content.WriteString("\n")
 	footerStyle := lipgloss.NewStyle().Foreground(MutedColor)
 	content.WriteString(footerStyle.Render("↑/↓: Navigate  Enter: Select  Esc: Cancel"))
+	content.WriteString("\n")
+	content.WriteString(footerStyle.Render("q again within 2s: Stop loops and quit"))
 
 	// Modal box
 	modalStyle := lipgloss.NewStyle().
@@ -0,0 +1,64 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestQuitConfirmationOptions(t *testing.T) {
+	q := NewQuitConfirmation()
+	if q.GetSelected() != QuitOptionCancel {
+		t.Fatalf("expected Cancel by default, got %d", q.GetSelected())
+	}
+	q.MoveUp()
+	if q.GetSelected() != QuitOptionBackground {
+		t.Errorf("expected the background option above Cancel, got %d", q.GetSelected())
+	}
+	q.MoveUp()
+	q.MoveUp()
+	if q.GetSelected() != QuitOptionQuit {
+		t.Errorf("expected Stop and quit at the top, got %d", q.GetSelected())
+	}
+	q.Reset()
+	if q.GetSelected() != QuitOptionCancel {
+		t.Errorf("expected Reset to select Cancel, got %d", q.GetSelected())
+	}
+}
+
+func TestQuitConfirmKeepRunningInBackground(t *testing.T) {
+	app := App{quitConfirm: NewQuitConfirmation(), viewMode: ViewQuitConfirm}
+	app.quitConfirm.MoveUp()
+
+	model, cmd := app.handleQuitConfirmKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	app = model.(App)
+
+	if cmd == nil {
+		t.Fatal("expected the UI to quit")
+	}
+	if app.PostExitAction != PostExitDetach {
+		t.Errorf("expected PostExitDetach so the loops keep running, got %d", app.PostExitAction)
+	}
+}
+
+func TestQuitConfirmSecondQuitBypasses(t *testing.T) {
+	app := App{quitConfirm: NewQuitConfirmation(), viewMode: ViewQuitConfirm}
+
+	// A slow second q only re-arms the bypass
+	app.quitRequestedAt = time.Now().Add(-5 * time.Second)
+	model, cmd := app.handleQuitConfirmKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}})
+	app = model.(App)
+	if cmd != nil {
+		t.Fatal("expected a late second q to leave the dialog open")
+	}
+
+	model, cmd = app.handleQuitConfirmKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}})
+	app = model.(App)
+	if cmd == nil {
+		t.Fatal("expected a quick second q to stop and quit")
+	}
+	if app.PostExitAction != PostExitNone {
+		t.Errorf("expected no post-exit action when stopping, got %d", app.PostExitAction)
+	}
+}
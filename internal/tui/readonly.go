@@ -0,0 +1,66 @@
+package tui
+
+import "github.com/charmbracelet/lipgloss"
+
+// readOnlyActivity is shown when a key is refused in read-only mode.
+const readOnlyActivity = "Read-only: another chief instance is running in this project"
+
+// SetReadOnly opens the TUI as a viewer alongside another chief instance that
+// holds the project lock. Loops can't be started and nothing that writes to
+// the PRDs, worktrees or config is allowed; browsing, logs and diffs work as
+// usual.
+func (a *App) SetReadOnly(readOnly bool) {
+	a.readOnly = readOnly
+	if readOnly {
+		a.startOnLaunch = false
+		a.lastActivity = readOnlyActivity
+	}
+}
+
+// readOnlyBlocks reports whether a key resolved through the keymap would
+// change the project while in read-only mode. Only the browsing views and the
+// picker's list are checked; the dialogs that write can't be reached from
+// them.
+func (a *App) readOnlyBlocks(action string) bool {
+	if !a.readOnly {
+		return false
+	}
+	switch a.viewMode {
+	case ViewPicker:
+		if a.picker == nil || a.picker.IsInputMode() || a.picker.HasCleanResult() ||
+			a.picker.HasCleanConfirmation() || a.picker.HasMergeResult() {
+			return false
+		}
+		switch action {
+		case ActionStart, ActionPause, ActionStop, ActionNew, ActionEdit, ActionSort, "m", "c":
+			return true
+		}
+		return false
+	case ViewDashboard, ViewLog, ViewDiff, ViewOverview, ViewDecisions:
+	default:
+		return false
+	}
+	switch action {
+	case ActionStart, ActionPause, ActionStop, ActionNew, ActionEdit, ActionStartAll, ActionPauseAll, ActionSkip,
+		"S", "r", "N", "l", ",", "+", "=", "-", "_":
+		return true
+	}
+	return false
+}
+
+// refuseReadOnly reports a key refused by readOnlyBlocks. The picker covers
+// the activity line, so it gets the message as a dialog.
+func (a *App) refuseReadOnly() {
+	a.lastActivity = readOnlyActivity
+	if a.viewMode == ViewPicker {
+		a.picker.SetCleanResult(&CleanResult{Action: "Change", Message: readOnlyActivity})
+	}
+}
+
+// renderReadOnlyBadge returns the header badge for read-only mode, or "".
+func (a *App) renderReadOnlyBadge() string {
+	if !a.readOnly {
+		return ""
+	}
+	return lipgloss.NewStyle().Foreground(WarningColor).Bold(true).Render("[read-only]")
+}
@@ -1,28 +1,28 @@
 package tui
 
 import (
-	"fmt"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/minicodemonkey/chief/internal/config"
+	"github.com/minicodemonkey/chief/internal/git"
 )
 
 // SettingsItemType represents the type of a settings item.
 type SettingsItemType int
 
 const (
-	SettingsItemBool   SettingsItemType = iota
+	SettingsItemBool SettingsItemType = iota
 	SettingsItemString
 )
 
 // SettingsItem represents a single editable setting.
 type SettingsItem struct {
-	Section  string
-	Label    string
-	Key      string // config key for identification
-	Type     SettingsItemType
-	BoolVal  bool
+	Section   string
+	Label     string
+	Key       string // config key for identification
+	Type      SettingsItemType
+	BoolVal   bool
 	StringVal string
 }
 
@@ -37,10 +37,14 @@ type SettingsOverlay struct {
 	// Inline text editing
 	editing    bool
 	editBuffer string
+	editError  string // Why the edit buffer couldn't be saved
 
 	// GH CLI validation error
-	ghError    string
+	ghError     string
 	showGHError bool
+	cli         git.ProviderCLI // CLI that PR creation depends on, from git.provider
+
+	prdName string // PRD whose config overrides are being edited; empty for the project config
 }
 
 // NewSettingsOverlay creates a new settings overlay.
@@ -60,12 +64,28 @@ func (s *SettingsOverlay) LoadFromConfig(cfg *config.Config) {
 		{Section: "Worktree", Label: "Setup command", Key: "worktree.setup", Type: SettingsItemString, StringVal: cfg.Worktree.Setup},
 		{Section: "On Complete", Label: "Push to remote", Key: "onComplete.push", Type: SettingsItemBool, BoolVal: cfg.OnComplete.Push},
 		{Section: "On Complete", Label: "Create pull request", Key: "onComplete.createPR", Type: SettingsItemBool, BoolVal: cfg.OnComplete.CreatePR},
+		{Section: "Phases", Label: "Pause between phases", Key: "phases.pauseBetween", Type: SettingsItemBool, BoolVal: cfg.Phases.PauseBetween},
+		{Section: "Loop", Label: "Review prompt before starting", Key: "loop.reviewPrompt", Type: SettingsItemBool, BoolVal: cfg.Loop.ReviewPrompt},
 	}
 	s.selectedIndex = 0
 	s.editing = false
 	s.editBuffer = ""
+	s.editError = ""
 	s.ghError = ""
 	s.showGHError = false
+	s.cli = git.CLIFor(cfg.Git.Provider)
+	s.prdName = ""
+}
+
+// SetPRD makes the overlay edit a PRD's config overrides rather than the
+// project config. Call after LoadFromConfig.
+func (s *SettingsOverlay) SetPRD(name string) {
+	s.prdName = name
+}
+
+// PRD returns the PRD whose overrides are being edited, or empty for the project config.
+func (s *SettingsOverlay) PRD() string {
+	return s.prdName
 }
 
 // ApplyToConfig writes the current settings values back to a config.
@@ -78,6 +98,10 @@ func (s *SettingsOverlay) ApplyToConfig(cfg *config.Config) {
 			cfg.OnComplete.Push = item.BoolVal
 		case "onComplete.createPR":
 			cfg.OnComplete.CreatePR = item.BoolVal
+		case "phases.pauseBetween":
+			cfg.Phases.PauseBetween = item.BoolVal
+		case "loop.reviewPrompt":
+			cfg.Loop.ReviewPrompt = item.BoolVal
 		}
 	}
 }
@@ -109,24 +133,42 @@ func (s *SettingsOverlay) StartEditing() {
 	}
 }
 
-// ConfirmEdit saves the edit buffer to the selected item.
-func (s *SettingsOverlay) ConfirmEdit() {
-	if s.editing && s.selectedIndex < len(s.items) {
-		s.items[s.selectedIndex].StringVal = s.editBuffer
-		s.editing = false
-		s.editBuffer = ""
+// ConfirmEdit saves the edit buffer to the selected item. A worktree setup
+// command is syntax-checked first; if it doesn't parse, editing continues
+// with the error shown and the error is returned.
+func (s *SettingsOverlay) ConfirmEdit() error {
+	if !s.editing || s.selectedIndex >= len(s.items) {
+		return nil
+	}
+	if s.items[s.selectedIndex].Key == "worktree.setup" {
+		if err := (config.WorktreeConfig{Setup: s.editBuffer}).CheckSetup(); err != nil {
+			s.editError = err.Error()
+			return err
+		}
 	}
+	s.items[s.selectedIndex].StringVal = s.editBuffer
+	s.editing = false
+	s.editBuffer = ""
+	s.editError = ""
+	return nil
 }
 
 // CancelEdit discards the edit buffer.
 func (s *SettingsOverlay) CancelEdit() {
 	s.editing = false
 	s.editBuffer = ""
+	s.editError = ""
+}
+
+// EditError returns why the last ConfirmEdit was refused, or empty.
+func (s *SettingsOverlay) EditError() string {
+	return s.editError
 }
 
 // AddEditChar adds a character to the edit buffer.
 func (s *SettingsOverlay) AddEditChar(ch rune) {
 	s.editBuffer += string(ch)
+	s.editError = ""
 }
 
 // DeleteEditChar removes the last character from the edit buffer.
@@ -135,6 +177,7 @@ func (s *SettingsOverlay) DeleteEditChar() {
 		runes := []rune(s.editBuffer)
 		s.editBuffer = string(runes[:len(runes)-1])
 	}
+	s.editError = ""
 }
 
 // ToggleBool toggles the selected boolean value.
@@ -193,7 +236,7 @@ func (s *SettingsOverlay) Render() string {
 
 	var content strings.Builder
 
-	// Header: "Settings" left-aligned, ".chief/config.yaml" right-aligned
+	// Header: "Settings" left-aligned, the config file being edited right-aligned
 	titleStyle := lipgloss.NewStyle().
 		Bold(true).
 		Foreground(PrimaryColor)
@@ -202,6 +245,10 @@ func (s *SettingsOverlay) Render() string {
 
 	title := titleStyle.Render("Settings")
 	path := pathStyle.Render("~/.chief/projects/.../config.yaml")
+	if s.prdName != "" {
+		title = titleStyle.Render("Settings: " + s.prdName)
+		path = pathStyle.Render("~/.chief/projects/.../prds/" + s.prdName + "/config.yaml")
+	}
 	titleWidth := lipgloss.Width(title)
 	pathWidth := lipgloss.Width(path)
 	titlePadding := modalWidth - 4 - titleWidth - pathWidth
@@ -222,6 +269,15 @@ func (s *SettingsOverlay) Render() string {
 	} else {
 		// Render settings items grouped by section
 		content.WriteString(s.renderItems(modalWidth))
+		if s.editing && s.editError != "" {
+			errorStyle := lipgloss.NewStyle().
+				Foreground(ErrorColor).
+				Width(modalWidth-6).
+				Padding(0, 1)
+			content.WriteString("\n")
+			content.WriteString(errorStyle.Render("✗ " + s.editError))
+			content.WriteString("\n")
+		}
 	}
 
 	// Footer
@@ -366,7 +422,7 @@ func (s *SettingsOverlay) renderGHError(modalWidth int) string {
 		Foreground(TextColor).
 		Padding(0, 1)
 
-	result.WriteString(errorHeaderStyle.Render("GitHub CLI Error"))
+	result.WriteString(errorHeaderStyle.Render(s.cli.Name + " Error"))
 	result.WriteString("\n\n")
 	result.WriteString(errorMsgStyle.Render(s.ghError))
 	result.WriteString("\n\n")
@@ -374,7 +430,7 @@ func (s *SettingsOverlay) renderGHError(modalWidth int) string {
 	hintStyle := lipgloss.NewStyle().
 		Foreground(MutedColor).
 		Padding(0, 1)
-	result.WriteString(hintStyle.Render(fmt.Sprintf("Install: https://cli.github.com")))
+	result.WriteString(hintStyle.Render("Install: " + s.cli.InstallURL))
 	result.WriteString("\n")
 	result.WriteString(hintStyle.Render("PR creation has been disabled."))
 
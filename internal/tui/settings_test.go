@@ -20,8 +20,8 @@ func TestSettingsOverlay_LoadFromConfig(t *testing.T) {
 	}
 	s.LoadFromConfig(cfg)
 
-	if len(s.items) != 3 {
-		t.Fatalf("expected 3 items, got %d", len(s.items))
+	if len(s.items) != 5 {
+		t.Fatalf("expected 5 items, got %d", len(s.items))
 	}
 	if s.items[0].Key != "worktree.setup" || s.items[0].StringVal != "npm install" {
 		t.Errorf("worktree.setup item: got key=%s val=%s", s.items[0].Key, s.items[0].StringVal)
@@ -32,6 +32,12 @@ func TestSettingsOverlay_LoadFromConfig(t *testing.T) {
 	if s.items[2].Key != "onComplete.createPR" || s.items[2].BoolVal {
 		t.Errorf("onComplete.createPR item: got key=%s val=%v", s.items[2].Key, s.items[2].BoolVal)
 	}
+	if s.items[3].Key != "phases.pauseBetween" || s.items[3].BoolVal {
+		t.Errorf("phases.pauseBetween item: got key=%s val=%v", s.items[3].Key, s.items[3].BoolVal)
+	}
+	if s.items[4].Key != "loop.reviewPrompt" || s.items[4].BoolVal {
+		t.Errorf("loop.reviewPrompt item: got key=%s val=%v", s.items[4].Key, s.items[4].BoolVal)
+	}
 	if s.selectedIndex != 0 {
 		t.Errorf("expected selectedIndex=0, got %d", s.selectedIndex)
 	}
@@ -46,6 +52,7 @@ func TestSettingsOverlay_ApplyToConfig(t *testing.T) {
 	s.items[0].StringVal = "go mod download"
 	s.items[1].BoolVal = true
 	s.items[2].BoolVal = true
+	s.items[3].BoolVal = true
 
 	resultCfg := config.Default()
 	s.ApplyToConfig(resultCfg)
@@ -59,6 +66,9 @@ func TestSettingsOverlay_ApplyToConfig(t *testing.T) {
 	if !resultCfg.OnComplete.CreatePR {
 		t.Error("expected createPR=true")
 	}
+	if !resultCfg.Phases.PauseBetween {
+		t.Error("expected phases.pauseBetween=true")
+	}
 }
 
 func TestSettingsOverlay_Navigation(t *testing.T) {
@@ -79,20 +89,32 @@ func TestSettingsOverlay_Navigation(t *testing.T) {
 		t.Errorf("expected index=2 after second MoveDown, got %d", s.selectedIndex)
 	}
 
+	s.MoveDown()
+	if s.selectedIndex != 3 {
+		t.Errorf("expected index=3 after third MoveDown, got %d", s.selectedIndex)
+	}
+
+	s.MoveDown()
+	if s.selectedIndex != 4 {
+		t.Errorf("expected index=4 after fourth MoveDown, got %d", s.selectedIndex)
+	}
+
 	// Can't go beyond last item
 	s.MoveDown()
-	if s.selectedIndex != 2 {
-		t.Errorf("expected index=2 (clamped), got %d", s.selectedIndex)
+	if s.selectedIndex != 4 {
+		t.Errorf("expected index=4 (clamped), got %d", s.selectedIndex)
 	}
 
 	s.MoveUp()
-	if s.selectedIndex != 1 {
-		t.Errorf("expected index=1 after MoveUp, got %d", s.selectedIndex)
+	if s.selectedIndex != 3 {
+		t.Errorf("expected index=3 after MoveUp, got %d", s.selectedIndex)
 	}
 
 	// Can't go before first item
 	s.MoveUp()
 	s.MoveUp()
+	s.MoveUp()
+	s.MoveUp()
 	if s.selectedIndex != 0 {
 		t.Errorf("expected index=0 (clamped), got %d", s.selectedIndex)
 	}
@@ -192,6 +214,41 @@ func TestSettingsOverlay_StringEditing(t *testing.T) {
 	}
 }
 
+func TestSettingsOverlay_SetupSyntaxError(t *testing.T) {
+	s := NewSettingsOverlay()
+	s.LoadFromConfig(config.Default())
+
+	s.StartEditing()
+	for _, ch := range "npm install && (" {
+		s.AddEditChar(ch)
+	}
+	if err := s.ConfirmEdit(); err == nil {
+		t.Fatal("expected a syntax error for an unclosed subshell")
+	}
+	if !s.IsEditing() || s.EditError() == "" {
+		t.Error("expected to stay in the editor with the error shown")
+	}
+	if s.items[0].StringVal != "" {
+		t.Errorf("expected the command not to be saved, got %q", s.items[0].StringVal)
+	}
+
+	// Fixing the command clears the error and saves it
+	s.DeleteEditChar()
+	if s.EditError() != "" {
+		t.Error("expected editing to clear the error")
+	}
+	s.AddEditChar('t')
+	s.AddEditChar('r')
+	s.AddEditChar('u')
+	s.AddEditChar('e')
+	if err := s.ConfirmEdit(); err != nil {
+		t.Fatalf("ConfirmEdit() error = %v", err)
+	}
+	if s.items[0].StringVal != "npm install && true" {
+		t.Errorf("expected the fixed command to be saved, got %q", s.items[0].StringVal)
+	}
+}
+
 func TestSettingsOverlay_CancelEdit(t *testing.T) {
 	s := NewSettingsOverlay()
 	cfg := &config.Config{
@@ -363,3 +420,38 @@ func TestSettingsOverlay_GetSelectedItem(t *testing.T) {
 		t.Errorf("expected second item key='onComplete.push', got '%s'", item.Key)
 	}
 }
+
+func TestSettingsOverlay_RenderGHErrorGitLab(t *testing.T) {
+	s := NewSettingsOverlay()
+	cfg := config.Default()
+	cfg.Git.Provider = "gitlab"
+	s.LoadFromConfig(cfg)
+	s.SetSize(80, 24)
+
+	s.SetGHError("glab not found")
+	rendered := s.Render()
+
+	if !strings.Contains(rendered, "GitLab CLI Error") || !strings.Contains(rendered, "gitlab.com/gitlab-org/cli") {
+		t.Errorf("expected GitLab CLI error and install link, got:\n%s", rendered)
+	}
+}
+
+func TestSettingsOverlay_PRDConfig(t *testing.T) {
+	s := NewSettingsOverlay()
+	s.LoadFromConfig(config.Default())
+	s.SetPRD("auth")
+	s.SetSize(100, 30)
+
+	if s.PRD() != "auth" {
+		t.Errorf("expected PRD auth, got %q", s.PRD())
+	}
+	if rendered := s.Render(); !strings.Contains(rendered, "prds/auth/config.yaml") {
+		t.Errorf("expected the PRD's config path in the header, got:\n%s", rendered)
+	}
+
+	// Reloading goes back to the project config until SetPRD is called again
+	s.LoadFromConfig(config.Default())
+	if s.PRD() != "" {
+		t.Errorf("expected project config after reload, got PRD %q", s.PRD())
+	}
+}
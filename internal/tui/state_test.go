@@ -1,7 +1,21 @@
 package tui
 
 import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/minicodemonkey/chief/internal/config"
+	"github.com/minicodemonkey/chief/internal/loop"
+	"github.com/minicodemonkey/chief/internal/notify"
+	"github.com/minicodemonkey/chief/internal/paths"
+	"github.com/minicodemonkey/chief/internal/prd"
 )
 
 func TestAppState_String(t *testing.T) {
@@ -15,6 +29,7 @@ func TestAppState_String(t *testing.T) {
 		{StateStopped, "Stopped"},
 		{StateComplete, "Complete"},
 		{StateError, "Error"},
+		{StateQueued, "Queued"},
 		{AppState(99), "Unknown"},
 	}
 
@@ -198,3 +213,275 @@ func TestStateTransitions_AllStates(t *testing.T) {
 		})
 	}
 }
+
+func TestShouldNotifyCompletion(t *testing.T) {
+	tests := []struct {
+		scope    string
+		prdName  string
+		expected bool
+	}{
+		{"", "auth", true},
+		{config.NotifyEach, "billing", true},
+		{config.NotifyActiveOnly, "auth", true},
+		{config.NotifyActiveOnly, "billing", false},
+		{config.NotifyAllComplete, "billing", true}, // nothing else running
+	}
+
+	for _, tt := range tests {
+		app := &App{
+			prdName: "auth",
+			manager: loop.NewManager(5),
+			config:  &config.Config{Notifications: config.NotificationsConfig{Scope: tt.scope}},
+		}
+		if got := app.shouldNotifyCompletion(tt.prdName); got != tt.expected {
+			t.Errorf("scope %q, PRD %q: shouldNotifyCompletion() = %v, want %v", tt.scope, tt.prdName, got, tt.expected)
+		}
+	}
+}
+
+func TestErrorCallbackFiresForAnyPRD(t *testing.T) {
+	var failed string
+	var failErr error
+	app := App{prdName: "auth", manager: loop.NewManager(5)}
+	app.SetErrorCallback(func(prdName string, err error) {
+		failed = prdName
+		failErr = err
+	})
+
+	boom := errors.New("boom")
+	app.handleLoopEvent("billing", loop.Event{Type: loop.EventError, Err: boom})
+
+	if failed != "billing" || failErr != boom {
+		t.Errorf("expected error callback for billing with %v, got %q with %v", boom, failed, failErr)
+	}
+}
+
+func TestNotifyWebhook(t *testing.T) {
+	var payload notify.NotificationPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+	}))
+	defer server.Close()
+
+	prdPath := filepath.Join(t.TempDir(), "prd.json")
+	p := &prd.PRD{UserStories: []prd.UserStory{{ID: "US-001", Passes: true}, {ID: "US-002"}}}
+	if err := p.Save(prdPath); err != nil {
+		t.Fatalf("failed to save PRD: %v", err)
+	}
+
+	manager := loop.NewManager(5)
+	manager.RegisterWithWorktree("auth", prdPath, "", "chief/auth")
+	app := &App{manager: manager, config: &config.Config{}}
+
+	if cmd := app.notifyWebhook("auth", notify.StateError, "", errors.New("boom")); cmd != nil {
+		t.Fatal("expected no webhook command without a webhook URL")
+	}
+
+	app.config.Notifications.WebhookURL = server.URL
+	msg := app.notifyWebhook("auth", notify.StateError, "", errors.New("boom"))()
+	if result, ok := msg.(webhookResultMsg); !ok || result.err != nil {
+		t.Fatalf("expected successful webhook result, got %#v", msg)
+	}
+	if payload.PRD != "auth" || payload.State != notify.StateError || payload.Completed != 1 || payload.Total != 2 ||
+		payload.Branch != "chief/auth" || payload.Error != "boom" {
+		t.Errorf("unexpected payload: %+v", payload)
+	}
+}
+
+func TestInProgressKeptInState(t *testing.T) {
+	prdPath := filepath.Join(t.TempDir(), "prd.json")
+	onDisk := &prd.PRD{Project: "Test", UserStories: []prd.UserStory{{ID: "US-001"}, {ID: "US-002"}}}
+	if err := onDisk.Save(prdPath); err != nil {
+		t.Fatal(err)
+	}
+	if err := loop.SaveSnapshot(prdPath, loop.Snapshot{State: "Paused", Story: "US-002"}); err != nil {
+		t.Fatal(err)
+	}
+	manager := loop.NewManager(10)
+	if err := manager.Register("test", prdPath); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.Default()
+	cfg.Loop.InProgressInState = true
+	loaded, _ := prd.LoadPRD(prdPath)
+	app := &App{prd: loaded, prdPath: prdPath, prdName: "test", manager: manager, config: cfg}
+
+	// A reload from disk gets the story back from the manager
+	app.restoreInProgress()
+	if !app.prd.UserStories[1].InProgress || app.prd.UserStories[0].InProgress {
+		t.Errorf("expected only US-002 in progress after restore, got %+v", app.prd.UserStories)
+	}
+	if loaded.UserStories[1].InProgress {
+		t.Error("restoring should not modify the loaded PRD in place")
+	}
+
+	app.markStoryInProgress("US-001")
+	app.clearInProgress()
+	app.markStoryInProgress("US-001")
+	if !app.prd.UserStories[0].InProgress {
+		t.Error("expected US-001 in progress in memory")
+	}
+	if p, _ := prd.LoadPRD(prdPath); p.UserStories[0].InProgress || p.UserStories[1].InProgress {
+		t.Errorf("expected prd.json to stay free of in-progress flags, got %+v", p.UserStories)
+	}
+
+	// Without the setting the flags are written as before
+	app.config = config.Default()
+	app.markStoryInProgress("US-001")
+	if p, _ := prd.LoadPRD(prdPath); !p.UserStories[0].InProgress {
+		t.Error("expected the in-progress flag in prd.json by default")
+	}
+}
+
+func TestSaveAndResumeSession(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+	baseDir := t.TempDir()
+
+	app := &App{
+		baseDir:       baseDir,
+		prdName:       "auth",
+		prd:           &prd.PRD{UserStories: []prd.UserStory{{ID: "US-001"}, {ID: "US-002"}}},
+		selectedIndex: 1,
+	}
+	app.saveSession()
+
+	session, err := loop.LoadSession(paths.SessionPath(baseDir))
+	if err != nil || session == nil {
+		t.Fatalf("LoadSession() = %v, %v", session, err)
+	}
+	if session.PRD != "auth" || session.Story != "US-002" || session.Running {
+		t.Errorf("unexpected session %+v", session)
+	}
+
+	resumed := &App{prd: app.prd, state: StateStopped}
+	resumed.ResumeSession("US-002", true)
+	if resumed.selectedIndex != 1 || !resumed.startOnLaunch {
+		t.Errorf("expected US-002 selected and a start on launch, got index %d, start %v", resumed.selectedIndex, resumed.startOnLaunch)
+	}
+}
+
+func TestReadOnlyRefusesWrites(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+	baseDir := t.TempDir()
+
+	app := App{
+		baseDir: baseDir,
+		prdName: "auth",
+		prd:     &prd.PRD{UserStories: []prd.UserStory{{ID: "US-001"}}},
+		state:   StateReady,
+	}
+	app.SetReadOnly(true)
+
+	var model tea.Model = app
+	for _, key := range []string{"s", "e", "n", "N", "r"} {
+		model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		got := model.(App)
+		if got.lastActivity != readOnlyActivity || got.state != StateReady || got.viewMode != ViewDashboard {
+			t.Errorf("key %q: expected it to be refused, got activity %q, state %v, view %v", key, got.lastActivity, got.state, got.viewMode)
+		}
+	}
+
+	// Browsing still works
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	if got := model.(App).viewMode; got != ViewLog {
+		t.Errorf("expected the log view to open, got %v", got)
+	}
+
+	// The picker refuses its writes too
+	app.picker = NewPRDPicker(baseDir, "auth", nil)
+	app.viewMode = ViewPicker
+	for _, key := range []string{"n", "e", "s", "a", "m", "c"} {
+		model, _ = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		got := model.(App)
+		if got.picker.IsInputMode() || !got.picker.HasCleanResult() || got.viewMode != ViewPicker {
+			t.Errorf("picker key %q: expected it to be refused with a message", key)
+		}
+		got.picker.ClearCleanResult()
+	}
+
+	app.saveSession()
+	if _, err := os.Stat(paths.SessionPath(baseDir)); !os.IsNotExist(err) {
+		t.Errorf("expected no session file from a read-only instance")
+	}
+}
+
+func TestStartAllQueuesDecisions(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+	baseDir := t.TempDir()
+
+	for name, passes := range map[string]bool{"api": false, "auth": false, "done": true} {
+		p := &prd.PRD{Project: name, UserStories: []prd.UserStory{{ID: "US-001", Title: "Story", Passes: passes}}}
+		if err := os.MkdirAll(paths.PRDDir(baseDir, name), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := p.Save(paths.PRDPath(baseDir, name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	manager := loop.NewManager(5)
+	app := App{
+		baseDir:      baseDir,
+		prdName:      "api",
+		state:        StateReady,
+		manager:      manager,
+		tabBar:       NewTabBar(baseDir, "api", manager),
+		promptReview: NewPromptReview(),
+		reviewPrompt: true, // Every start waits for a decision
+	}
+
+	var model tea.Model = app
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("A")})
+	got := model.(App)
+
+	// The first PRD's dialog is open and the other waits its turn; the
+	// finished PRD isn't started
+	if got.viewMode != ViewPromptReview || got.promptReview.PRDName() != "api" {
+		t.Fatalf("expected api's prompt review, got view %v for %q", got.viewMode, got.promptReview.PRDName())
+	}
+	if len(got.startQueue) != 1 || got.startQueue[0] != "auth" {
+		t.Errorf("expected auth queued, got %v", got.startQueue)
+	}
+	if !strings.Contains(got.lastActivity, "2 waiting for a decision") {
+		t.Errorf("unexpected activity %q", got.lastActivity)
+	}
+
+	// Pause-all drops the queue
+	got.viewMode = ViewDashboard
+	model, _ = got.pauseAll()
+	if got := model.(App); len(got.startQueue) != 0 || got.lastActivity != "No PRDs are running" {
+		t.Errorf("expected the queue dropped, got %v, activity %q", got.startQueue, got.lastActivity)
+	}
+}
+
+func TestPRDWarningActivity(t *testing.T) {
+	p := &prd.PRD{UserStories: []prd.UserStory{{ID: "US-001", Title: "Fine", Steps: []string{"Works"}}}}
+	if got := prdWarningActivity(p); got != "" {
+		t.Errorf("prdWarningActivity() on a valid PRD = %q", got)
+	}
+
+	p.UserStories = append(p.UserStories, prd.UserStory{ID: "US-002"}, prd.UserStory{ID: "US-003", Title: "Empty"})
+	want := "Warning: US-002 has no title (+2 more, see chief status)"
+	if got := prdWarningActivity(p); got != want {
+		t.Errorf("prdWarningActivity() = %q, want %q", got, want)
+	}
+}
+
+func TestIterationBudget(t *testing.T) {
+	p := &prd.PRD{UserStories: []prd.UserStory{
+		{ID: "US-001", Priority: 1, Passes: true},
+		{ID: "US-002", Priority: 2},
+		{ID: "US-003", Priority: 3},
+	}}
+	cfg := &config.Config{Loop: config.LoopConfig{IterationsPerStory: 2, IterationBuffer: 3}}
+	maxIter, activity := iterationBudget(cfg, p)
+	if maxIter != 9 {
+		t.Errorf("expected 3×2 + 3 = 9 iterations, got %d", maxIter)
+	}
+	if activity != "Max iterations: 3×2 + 3 = 9" {
+		t.Errorf("unexpected activity %q", activity)
+	}
+}
@@ -0,0 +1,115 @@
+package tui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/minicodemonkey/chief/internal/prd"
+)
+
+// storyMatchesFilter reports whether a story's ID or title contains the
+// stories panel filter, ignoring case. "tag:name" terms in the filter instead
+// require the story to carry that tag. Every story matches an empty filter.
+func (a *App) storyMatchesFilter(story *prd.UserStory) bool {
+	if a.storyFilter == "" {
+		return true
+	}
+	var text []string
+	for _, term := range strings.Fields(a.storyFilter) {
+		if tag, ok := strings.CutPrefix(strings.ToLower(term), "tag:"); ok {
+			if tag != "" && !story.HasTag(tag) {
+				return false
+			}
+			continue
+		}
+		text = append(text, term)
+	}
+	if len(text) == 0 {
+		return true
+	}
+	query := strings.ToLower(strings.Join(text, " "))
+	return strings.Contains(strings.ToLower(story.ID), query) ||
+		strings.Contains(strings.ToLower(story.Title), query)
+}
+
+// visibleStoryIndices returns the indices into the PRD's stories that the
+// stories panel shows under the current filter, in display order.
+func (a *App) visibleStoryIndices() []int {
+	if a.prd == nil {
+		return nil
+	}
+	var indices []int
+	for i := range a.prd.UserStories {
+		if a.storyMatchesFilter(&a.prd.UserStories[i]) {
+			indices = append(indices, i)
+		}
+	}
+	sortStoryIndices(a.prd, indices, a.storySort)
+	return indices
+}
+
+// moveStorySelection moves the selection delta visible stories up or down,
+// stopping at either end of the filtered list.
+func (a *App) moveStorySelection(delta int) {
+	visible := a.visibleStoryIndices()
+	if len(visible) == 0 {
+		return
+	}
+	pos := 0
+	for i, idx := range visible {
+		if idx == a.selectedIndex {
+			pos = i
+			break
+		}
+	}
+	pos = max(0, min(len(visible)-1, pos+delta))
+	a.selectedIndex = visible[pos]
+}
+
+// clampSelectionToFilter moves the selection to the first visible story when
+// the filter hides the selected one.
+func (a *App) clampSelectionToFilter() {
+	visible := a.visibleStoryIndices()
+	for _, idx := range visible {
+		if idx == a.selectedIndex {
+			return
+		}
+	}
+	if len(visible) > 0 {
+		a.selectedIndex = visible[0]
+	}
+}
+
+// clearStoryFilter closes the filter input and shows every story again.
+func (a *App) clearStoryFilter() {
+	a.storyFilter = ""
+	a.storyFilterInput = false
+}
+
+// handleStoryFilterKeys handles typing into the stories panel filter. The
+// list narrows as the query changes; Enter keeps the filter and returns to
+// normal navigation, Esc clears it.
+func (a App) handleStoryFilterKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		a.clearStoryFilter()
+	case tea.KeyEnter:
+		a.storyFilterInput = false
+	case tea.KeyBackspace:
+		if runes := []rune(a.storyFilter); len(runes) > 0 {
+			a.storyFilter = string(runes[:len(runes)-1])
+		}
+	case tea.KeyUp:
+		a.moveStorySelection(-1)
+	case tea.KeyDown:
+		a.moveStorySelection(1)
+	case tea.KeyCtrlC:
+		return a.tryQuit()
+	case tea.KeySpace:
+		a.storyFilter += " "
+	case tea.KeyRunes:
+		a.storyFilter += string(msg.Runes)
+	}
+	a.clampSelectionToFilter()
+	return a, nil
+}
@@ -0,0 +1,136 @@
+package tui
+
+import (
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxJumpSuggestions caps the story IDs listed while typing a jump.
+const maxJumpSuggestions = 5
+
+// startStoryJump opens the jump-to-story input.
+func (a *App) startStoryJump() {
+	if a.prd == nil || len(a.prd.UserStories) == 0 {
+		return
+	}
+	a.jumpQuery = ""
+	a.jumpInput = true
+}
+
+// resolveStoryJump returns the index of the story a jump query names: an
+// exact ID, ignoring case, or a bare number matching the number at the end
+// of an ID, so "17" finds US-017.
+func (a *App) resolveStoryJump(query string) (int, bool) {
+	query = strings.TrimSpace(query)
+	if a.prd == nil || query == "" {
+		return 0, false
+	}
+	for i, story := range a.prd.UserStories {
+		if strings.EqualFold(story.ID, query) {
+			return i, true
+		}
+	}
+	n, err := strconv.Atoi(query)
+	if err != nil {
+		return 0, false
+	}
+	for i, story := range a.prd.UserStories {
+		if id, ok := storyIDNumber(story.ID); ok && id == n {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// storyIDNumber returns the number at the end of a story ID, e.g. 17 for
+// "US-017".
+func storyIDNumber(id string) (int, bool) {
+	start := len(id)
+	for start > 0 && id[start-1] >= '0' && id[start-1] <= '9' {
+		start--
+	}
+	n, err := strconv.Atoi(id[start:])
+	return n, err == nil
+}
+
+// storyJumpSuggestions returns the IDs of stories starting with the jump
+// query, ignoring case, in PRD order.
+func (a *App) storyJumpSuggestions() []string {
+	query := strings.ToLower(strings.TrimSpace(a.jumpQuery))
+	if a.prd == nil || query == "" {
+		return nil
+	}
+	var ids []string
+	for _, story := range a.prd.UserStories {
+		if strings.HasPrefix(strings.ToLower(story.ID), query) {
+			ids = append(ids, story.ID)
+		}
+	}
+	return ids
+}
+
+// completeStoryJump extends the query to the longest prefix the matching
+// IDs share, or to the ID itself when only one matches.
+func (a *App) completeStoryJump() {
+	ids := a.storyJumpSuggestions()
+	if len(ids) == 0 {
+		return
+	}
+	prefix := ids[0]
+	for _, id := range ids[1:] {
+		for !strings.HasPrefix(strings.ToLower(id), strings.ToLower(prefix)) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	if len(prefix) >= len(strings.TrimSpace(a.jumpQuery)) {
+		a.jumpQuery = prefix
+	}
+}
+
+// jumpToStory selects the story the query names, clearing a stories filter
+// that hides it. In the diff view the story's diff is loaded as well.
+func (a *App) jumpToStory() {
+	a.jumpInput = false
+	query := strings.TrimSpace(a.jumpQuery)
+	if query == "" {
+		return
+	}
+	index, ok := a.resolveStoryJump(query)
+	if !ok {
+		a.lastActivity = "No story matches " + query
+		return
+	}
+	story := &a.prd.UserStories[index]
+	if !a.storyMatchesFilter(story) {
+		a.clearStoryFilter()
+	}
+	a.selectedIndex = index
+	if a.viewMode == ViewDiff {
+		a.diffViewer.LoadForStory(story.ID, story.Title)
+	}
+	a.lastActivity = "Jumped to " + story.ID
+}
+
+// handleStoryJumpKeys handles typing a story ID after ":". Tab completes the
+// ID, Enter jumps to the story and Esc cancels.
+func (a App) handleStoryJumpKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		a.jumpInput = false
+	case tea.KeyEnter:
+		a.jumpToStory()
+	case tea.KeyTab:
+		a.completeStoryJump()
+	case tea.KeyBackspace:
+		if runes := []rune(a.jumpQuery); len(runes) > 0 {
+			a.jumpQuery = string(runes[:len(runes)-1])
+		}
+	case tea.KeyCtrlC:
+		return a.tryQuit()
+	case tea.KeyRunes:
+		a.jumpQuery += string(msg.Runes)
+	}
+	return a, nil
+}
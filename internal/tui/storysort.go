@@ -0,0 +1,107 @@
+package tui
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/minicodemonkey/chief/internal/prd"
+)
+
+// storySort is the order the stories panel lists stories in. It only affects
+// the display; prd.json keeps its own order.
+type storySort int
+
+const (
+	sortOriginal storySort = iota // PRD file order, grouped by phase
+	sortPriority                  // Lowest priority number first
+	sortStatus                    // In progress, pending, blocked, then passed
+	sortID                        // By ID, comparing trailing numbers numerically
+)
+
+// String returns the label shown in the stories panel header.
+func (s storySort) String() string {
+	switch s {
+	case sortPriority:
+		return "priority"
+	case sortStatus:
+		return "status"
+	case sortID:
+		return "id"
+	default:
+		return "original"
+	}
+}
+
+// next returns the sort that follows s when cycling with the sort key.
+func (s storySort) next() storySort {
+	return (s + 1) % (sortID + 1)
+}
+
+// sortStoryIndices reorders indices into p's stories by the given sort. Ties
+// keep PRD order.
+func sortStoryIndices(p *prd.PRD, indices []int, by storySort) {
+	if by == sortOriginal {
+		return
+	}
+	stories := p.UserStories
+	sort.SliceStable(indices, func(i, j int) bool {
+		a, b := &stories[indices[i]], &stories[indices[j]]
+		switch by {
+		case sortPriority:
+			return a.Priority < b.Priority
+		case sortStatus:
+			return storyStatusRank(p, a) < storyStatusRank(p, b)
+		default:
+			return lessStoryID(a.ID, b.ID)
+		}
+	})
+}
+
+// storyStatusRank orders stories by how much attention they need: the story
+// being worked on, then ones that can be picked up, then blocked, then done.
+func storyStatusRank(p *prd.PRD, story *prd.UserStory) int {
+	switch {
+	case story.InProgress:
+		return 0
+	case story.Settled():
+		return 3
+	case p.IsBlocked(story):
+		return 2
+	default:
+		return 1
+	}
+}
+
+// lessStoryID compares story IDs so that "US-2" sorts before "US-10".
+func lessStoryID(a, b string) bool {
+	aPrefix, aNum, aOK := splitTrailingNumber(a)
+	bPrefix, bNum, bOK := splitTrailingNumber(b)
+	if aOK && bOK && aPrefix == bPrefix && aNum != bNum {
+		return aNum < bNum
+	}
+	return a < b
+}
+
+// splitTrailingNumber splits an ID like "US-012" into "US-" and 12. ok is
+// false when the ID doesn't end in digits.
+func splitTrailingNumber(id string) (prefix string, n int, ok bool) {
+	i := len(id)
+	for i > 0 && id[i-1] >= '0' && id[i-1] <= '9' {
+		i--
+	}
+	if i == len(id) {
+		return id, 0, false
+	}
+	n, err := strconv.Atoi(id[i:])
+	if err != nil {
+		return id, 0, false
+	}
+	return id[:i], n, true
+}
+
+// cycleStorySort switches the stories panel to the next sort. The selection
+// is an index into the PRD's stories rather than into the displayed list, so
+// it stays on the same story.
+func (a *App) cycleStorySort() {
+	a.storySort = a.storySort.next()
+}
@@ -21,182 +21,285 @@ var (
 	TextBrightColor = lipgloss.Color("#FFFFFF") // Bright white - emphasis
 
 	// Background colors
-	BgColor         = lipgloss.Color("#1E1E2E") // Dark background
-	BgSelectedColor = lipgloss.Color("#313244") // Selected item background
+	BgColor          = lipgloss.Color("#1E1E2E") // Dark background
+	BgSelectedColor  = lipgloss.Color("#313244") // Selected item background
 	BgHighlightColor = lipgloss.Color("#45475A") // Highlight background
 )
 
 // Aliases for backward compatibility with existing code
 var (
-	primaryColor = PrimaryColor
-	successColor = SuccessColor
-	warningColor = WarningColor
-	errorColor   = ErrorColor
-	mutedColor   = MutedColor
-	borderColor  = BorderColor
+	primaryColor lipgloss.Color
+	successColor lipgloss.Color
+	warningColor lipgloss.Color
+	errorColor   lipgloss.Color
+	mutedColor   lipgloss.Color
+	borderColor  lipgloss.Color
 )
 
 // Header styles
 var (
 	// Main header style with branding
-	headerStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(PrimaryColor).
-			Padding(0, 1)
+	headerStyle lipgloss.Style
 
 	// Header border/divider
-	HeaderBorderStyle = lipgloss.NewStyle().
-				Foreground(BorderColor)
+	HeaderBorderStyle lipgloss.Style
 )
 
 // Footer styles
 var (
-	footerStyle = lipgloss.NewStyle().
-			Foreground(MutedColor).
-			Padding(0, 1)
+	footerStyle lipgloss.Style
 
 	// Shortcut key style
-	ShortcutKeyStyle = lipgloss.NewStyle().
-				Foreground(PrimaryColor).
-				Bold(true)
+	ShortcutKeyStyle lipgloss.Style
 
 	// Shortcut description style
-	ShortcutDescStyle = lipgloss.NewStyle().
-				Foreground(MutedColor)
+	ShortcutDescStyle lipgloss.Style
 )
 
 // Panel styles
 var (
-	panelStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(BorderColor).
-			Padding(0, 1)
+	panelStyle lipgloss.Style
 
 	// Panel with focus/active state
-	PanelActiveStyle = lipgloss.NewStyle().
-				Border(lipgloss.RoundedBorder()).
-				BorderForeground(PrimaryColor).
-				Padding(0, 1)
+	PanelActiveStyle lipgloss.Style
 
 	// Panel title style
-	PanelTitleStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(PrimaryColor)
+	PanelTitleStyle lipgloss.Style
 )
 
 // Selection styles
 var (
-	selectedStyle = lipgloss.NewStyle().
-			Background(BgSelectedColor).
-			Foreground(TextColor)
+	selectedStyle lipgloss.Style
 
 	// Unselected/normal item style
-	UnselectedStyle = lipgloss.NewStyle().
-			Foreground(TextColor)
+	UnselectedStyle lipgloss.Style
 )
 
 // Status badge styles - colored badges for state indicators
 var (
 	// Story status styles
-	statusPassedStyle     = lipgloss.NewStyle().Foreground(SuccessColor)
-	statusInProgressStyle = lipgloss.NewStyle().Foreground(PrimaryColor)
-	statusPendingStyle    = lipgloss.NewStyle().Foreground(MutedColor)
-	statusFailedStyle     = lipgloss.NewStyle().Foreground(ErrorColor)
-	statusPausedStyle     = lipgloss.NewStyle().Foreground(WarningColor)
+	statusPassedStyle     lipgloss.Style
+	statusInProgressStyle lipgloss.Style
+	statusPendingStyle    lipgloss.Style
+	statusFailedStyle     lipgloss.Style
+	statusPausedStyle     lipgloss.Style
+	statusBlockedStyle    lipgloss.Style
+	statusSkippedStyle    lipgloss.Style
 
 	// State badge styles (with bold for headers)
-	StateReadyStyle    = lipgloss.NewStyle().Bold(true).Foreground(MutedColor)
-	StateRunningStyle  = lipgloss.NewStyle().Bold(true).Foreground(PrimaryColor)
-	StatePausedStyle   = lipgloss.NewStyle().Bold(true).Foreground(WarningColor)
-	StateStoppedStyle  = lipgloss.NewStyle().Bold(true).Foreground(MutedColor)
-	StateCompleteStyle = lipgloss.NewStyle().Bold(true).Foreground(SuccessColor)
-	StateErrorStyle    = lipgloss.NewStyle().Bold(true).Foreground(ErrorColor)
+	StateReadyStyle    lipgloss.Style
+	StateRunningStyle  lipgloss.Style
+	StatePausedStyle   lipgloss.Style
+	StateStoppedStyle  lipgloss.Style
+	StateCompleteStyle lipgloss.Style
+	StateErrorStyle    lipgloss.Style
 )
 
 // Title and label styles
 var (
-	titleStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(TextColor)
+	titleStyle lipgloss.Style
 
-	labelStyle = lipgloss.NewStyle().
-			Foreground(PrimaryColor).
-			Bold(true)
+	labelStyle lipgloss.Style
+
+	// Story tag chip style
+	tagChipStyle lipgloss.Style
 
 	// Subtitle style
-	SubtitleStyle = lipgloss.NewStyle().
-			Foreground(MutedColor)
+	SubtitleStyle lipgloss.Style
 
 	// Description text style
-	DescriptionStyle = lipgloss.NewStyle().
-				Foreground(TextColor)
+	DescriptionStyle lipgloss.Style
 )
 
 // Progress bar styles
 var (
-	progressBarFillStyle  = lipgloss.NewStyle().Foreground(SuccessColor)
-	progressBarEmptyStyle = lipgloss.NewStyle().Foreground(MutedColor)
+	progressBarFillStyle  lipgloss.Style
+	progressBarEmptyStyle lipgloss.Style
 
 	// Progress percentage style
-	ProgressPercentStyle = lipgloss.NewStyle().
-				Foreground(MutedColor)
+	ProgressPercentStyle lipgloss.Style
 )
 
 // Activity line styles
 var (
-	ActivityRunningStyle  = lipgloss.NewStyle().Foreground(PrimaryColor).Padding(0, 1)
-	ActivityErrorStyle    = lipgloss.NewStyle().Foreground(ErrorColor).Padding(0, 1)
-	ActivityCompleteStyle = lipgloss.NewStyle().Foreground(SuccessColor).Padding(0, 1)
-	ActivityMutedStyle    = lipgloss.NewStyle().Foreground(MutedColor).Padding(0, 1)
+	ActivityRunningStyle  lipgloss.Style
+	ActivityErrorStyle    lipgloss.Style
+	ActivityCompleteStyle lipgloss.Style
+	ActivityMutedStyle    lipgloss.Style
 )
 
 // Divider styles
 var (
-	DividerStyle = lipgloss.NewStyle().
-			Foreground(BorderColor)
+	DividerStyle lipgloss.Style
 
 	// Thick divider (for section separators)
-	ThickDividerStyle = lipgloss.NewStyle().
-				Foreground(BorderColor).
-				Bold(true)
+	ThickDividerStyle lipgloss.Style
 )
 
 // Tab bar styles
 var (
 	// TabStyle - inactive tab with rounded border
-	TabStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(BorderColor).
-			Padding(0, 1)
+	TabStyle lipgloss.Style
 
 	// TabActiveStyle - active/viewed tab with primary color border and background
-	TabActiveStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(PrimaryColor).
-			Background(BgSelectedColor).
-			Bold(true).
-			Padding(0, 1)
+	TabActiveStyle lipgloss.Style
 
 	// TabRunningStyle - running state with primary color border
-	TabRunningStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(PrimaryColor).
-			Padding(0, 1)
+	TabRunningStyle lipgloss.Style
 
 	// TabErrorStyle - error state with error color border
-	TabErrorStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(ErrorColor).
-			Padding(0, 1)
+	TabErrorStyle lipgloss.Style
 
 	// TabNewStyle - "+ New" button with muted styling
-	TabNewStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(MutedColor).
-			Foreground(MutedColor).
-			Padding(0, 1)
+	TabNewStyle lipgloss.Style
 )
 
+// buildStyles derives the package's styles from the color palette. It runs at
+// init and again whenever LoadTheme changes the palette.
+func buildStyles() {
+	// Aliases for backward compatibility with existing code
+	primaryColor = PrimaryColor
+	successColor = SuccessColor
+	warningColor = WarningColor
+	errorColor = ErrorColor
+	mutedColor = MutedColor
+	borderColor = BorderColor
+
+	// Header styles
+	headerStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(PrimaryColor).
+		Padding(0, 1)
+
+	HeaderBorderStyle = lipgloss.NewStyle().
+		Foreground(BorderColor)
+
+	// Footer styles
+	footerStyle = lipgloss.NewStyle().
+		Foreground(MutedColor).
+		Padding(0, 1)
+
+	ShortcutKeyStyle = lipgloss.NewStyle().
+		Foreground(PrimaryColor).
+		Bold(true)
+
+	ShortcutDescStyle = lipgloss.NewStyle().
+		Foreground(MutedColor)
+
+	// Panel styles
+	panelStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(BorderColor).
+		Padding(0, 1)
+
+	PanelActiveStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(PrimaryColor).
+		Padding(0, 1)
+
+	PanelTitleStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(PrimaryColor)
+
+	// Selection styles
+	selectedStyle = lipgloss.NewStyle().
+		Background(BgSelectedColor).
+		Foreground(TextColor)
+
+	UnselectedStyle = lipgloss.NewStyle().
+		Foreground(TextColor)
+
+	// Status badge styles - colored badges for state indicators
+	statusPassedStyle = lipgloss.NewStyle().Foreground(SuccessColor)
+	statusInProgressStyle = lipgloss.NewStyle().Foreground(PrimaryColor)
+	statusPendingStyle = lipgloss.NewStyle().Foreground(MutedColor)
+	statusFailedStyle = lipgloss.NewStyle().Foreground(ErrorColor)
+	statusPausedStyle = lipgloss.NewStyle().Foreground(WarningColor)
+	statusBlockedStyle = lipgloss.NewStyle().Foreground(WarningColor)
+	statusSkippedStyle = lipgloss.NewStyle().Foreground(MutedColor)
+
+	StateReadyStyle = lipgloss.NewStyle().Bold(true).Foreground(MutedColor)
+	StateRunningStyle = lipgloss.NewStyle().Bold(true).Foreground(PrimaryColor)
+	StatePausedStyle = lipgloss.NewStyle().Bold(true).Foreground(WarningColor)
+	StateStoppedStyle = lipgloss.NewStyle().Bold(true).Foreground(MutedColor)
+	StateCompleteStyle = lipgloss.NewStyle().Bold(true).Foreground(SuccessColor)
+	StateErrorStyle = lipgloss.NewStyle().Bold(true).Foreground(ErrorColor)
+
+	// Title and label styles
+	titleStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(TextColor)
+
+	labelStyle = lipgloss.NewStyle().
+		Foreground(PrimaryColor).
+		Bold(true)
+
+	tagChipStyle = lipgloss.NewStyle().
+		Background(BgSelectedColor).
+		Foreground(TextColor).
+		Padding(0, 1)
+
+	SubtitleStyle = lipgloss.NewStyle().
+		Foreground(MutedColor)
+
+	DescriptionStyle = lipgloss.NewStyle().
+		Foreground(TextColor)
+
+	// Progress bar styles
+	progressBarFillStyle = lipgloss.NewStyle().Foreground(SuccessColor)
+	progressBarEmptyStyle = lipgloss.NewStyle().Foreground(MutedColor)
+
+	ProgressPercentStyle = lipgloss.NewStyle().
+		Foreground(MutedColor)
+
+	// Activity line styles
+	ActivityRunningStyle = lipgloss.NewStyle().Foreground(PrimaryColor).Padding(0, 1)
+	ActivityErrorStyle = lipgloss.NewStyle().Foreground(ErrorColor).Padding(0, 1)
+	ActivityCompleteStyle = lipgloss.NewStyle().Foreground(SuccessColor).Padding(0, 1)
+	ActivityMutedStyle = lipgloss.NewStyle().Foreground(MutedColor).Padding(0, 1)
+
+	// Divider styles
+	DividerStyle = lipgloss.NewStyle().
+		Foreground(BorderColor)
+
+	ThickDividerStyle = lipgloss.NewStyle().
+		Foreground(BorderColor).
+		Bold(true)
+
+	// Tab bar styles
+	TabStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(BorderColor).
+		Padding(0, 1)
+
+	TabActiveStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(PrimaryColor).
+		Background(BgSelectedColor).
+		Bold(true).
+		Padding(0, 1)
+
+	TabRunningStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(PrimaryColor).
+		Padding(0, 1)
+
+	TabErrorStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ErrorColor).
+		Padding(0, 1)
+
+	TabNewStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(MutedColor).
+		Foreground(MutedColor).
+		Padding(0, 1)
+}
+
+func init() {
+	buildStyles()
+}
+
 // Status icons
 const (
 	IconPassed     = "✓"
@@ -204,6 +307,8 @@ const (
 	IconPending    = "○"
 	IconFailed     = "✗"
 	IconPaused     = "◐"
+	IconBlocked    = "🔒"
+	IconSkipped    = "↷"
 )
 
 // Backward compatibility aliases
@@ -214,14 +319,21 @@ const (
 	iconFailed     = IconFailed
 )
 
-// GetStatusIcon returns the appropriate icon for a story's status.
-func GetStatusIcon(passed, inProgress bool) string {
+// GetStatusIcon returns the appropriate icon for a story's status. A story is
+// blocked while any story it depends on hasn't passed.
+func GetStatusIcon(passed, skipped, inProgress, blocked bool) string {
 	if passed {
 		return statusPassedStyle.Render(IconPassed)
 	}
+	if skipped {
+		return statusSkippedStyle.Render(IconSkipped)
+	}
 	if inProgress {
 		return statusInProgressStyle.Render(IconInProgress)
 	}
+	if blocked {
+		return statusBlockedStyle.Render(IconBlocked)
+	}
 	return statusPendingStyle.Render(IconPending)
 }
 
@@ -230,7 +342,7 @@ func GetStateStyle(state AppState) lipgloss.Style {
 	switch state {
 	case StateRunning:
 		return StateRunningStyle
-	case StatePaused:
+	case StatePaused, StateQueued:
 		return StatePausedStyle
 	case StateComplete:
 		return StateCompleteStyle
@@ -22,6 +22,7 @@ type TabEntry struct {
 	Total     int            // Total number of stories
 	Iteration int            // Current iteration if running
 	IsActive  bool           // Whether this is the currently viewed PRD
+	Conflict  bool           // Whether another PRD shares this PRD's worktree or branch
 }
 
 // TabBar manages the always-visible PRD tab bar.
@@ -32,6 +33,7 @@ type TabBar struct {
 	baseDir     string
 	manager     *loop.Manager
 	currentPRD  string
+	frames      map[string]int // Spinner frame per PRD, advanced by its loop events
 }
 
 // NewTabBar creates a new tab bar.
@@ -58,9 +60,6 @@ func (t *TabBar) Refresh() {
 		dirEntries = nil
 	}
 
-	// Track names we've added to avoid duplicates
-	addedNames := make(map[string]bool)
-
 	for _, entry := range dirEntries {
 		if !entry.IsDir() {
 			continue
@@ -71,14 +70,6 @@ func (t *TabBar) Refresh() {
 
 		tabEntry := t.loadTabEntry(name, prdPath)
 		t.entries = append(t.entries, tabEntry)
-		addedNames[name] = true
-	}
-
-	// Also check if there's a "main" PRD at legacy location
-	mainPrdPath := filepath.Join(paths.ChiefDir(t.baseDir), "prd.json")
-	if _, err := os.Stat(mainPrdPath); err == nil && !addedNames["main"] {
-		tabEntry := t.loadTabEntry("main", mainPrdPath)
-		t.entries = append(t.entries, tabEntry)
 	}
 
 	// Update active index
@@ -119,6 +110,7 @@ func (t *TabBar) loadTabEntry(name, prdPath string) TabEntry {
 		if inst := t.manager.GetInstance(name); inst != nil {
 			tabEntry.Branch = inst.Branch
 		}
+		tabEntry.Conflict = len(t.manager.Collisions(name)) > 0
 	}
 
 	return tabEntry
@@ -176,6 +168,21 @@ func (t *TabBar) Render() string {
 	return lipgloss.JoinHorizontal(lipgloss.Top, tabs...)
 }
 
+// Advance moves the named PRD's spinner on a frame. The app calls it for
+// each of the PRD's loop events, so a tab's spinner turns while its loop is
+// producing output and stops when it stalls.
+func (t *TabBar) Advance(name string) {
+	if t.frames == nil {
+		t.frames = make(map[string]int)
+	}
+	t.frames[name]++
+}
+
+// spinner returns the spinner frame shown on the named PRD's running tab.
+func (t *TabBar) spinner(name string) string {
+	return spinnerChars[t.frames[name]%len(spinnerChars)]
+}
+
 // renderTab renders a single tab.
 func (t *TabBar) renderTab(entry TabEntry, number int) string {
 	var content strings.Builder
@@ -191,9 +198,11 @@ func (t *TabBar) renderTab(entry TabEntry, number int) string {
 	var stateIndicator string
 	switch entry.LoopState {
 	case loop.LoopStateRunning:
-		stateIndicator = fmt.Sprintf(" ▶ %d", entry.Iteration)
+		stateIndicator = fmt.Sprintf(" %s %d", t.spinner(entry.Name), entry.Iteration)
 	case loop.LoopStatePaused:
 		stateIndicator = " ⏸"
+	case loop.LoopStateQueued:
+		stateIndicator = " ⏳"
 	case loop.LoopStateComplete:
 		stateIndicator = " ✓"
 	case loop.LoopStateError:
@@ -230,6 +239,9 @@ func (t *TabBar) renderTab(entry TabEntry, number int) string {
 	content.WriteString(stateIndicator)
 
 	tabContent := content.String()
+	if entry.Conflict {
+		tabContent += " ⚠ shared"
+	}
 
 	// Choose style based on state
 	var style lipgloss.Style
@@ -252,12 +264,16 @@ func (t *TabBar) renderTab(entry TabEntry, number int) string {
 		tabContent = lipgloss.NewStyle().Foreground(PrimaryColor).Render(tabContent)
 	case loop.LoopStatePaused:
 		tabContent = lipgloss.NewStyle().Foreground(WarningColor).Render(tabContent)
+	case loop.LoopStateQueued:
+		tabContent = lipgloss.NewStyle().Foreground(MutedColor).Render(tabContent)
 	case loop.LoopStateComplete:
 		tabContent = lipgloss.NewStyle().Foreground(SuccessColor).Render(tabContent)
 	case loop.LoopStateError:
 		tabContent = lipgloss.NewStyle().Foreground(ErrorColor).Render(tabContent)
 	default:
-		if entry.IsActive {
+		if entry.Conflict {
+			tabContent = lipgloss.NewStyle().Foreground(WarningColor).Render(tabContent)
+		} else if entry.IsActive {
 			tabContent = lipgloss.NewStyle().Foreground(TextBrightColor).Render(tabContent)
 		} else {
 			tabContent = lipgloss.NewStyle().Foreground(TextColor).Render(tabContent)
@@ -267,6 +283,36 @@ func (t *TabBar) renderTab(entry TabEntry, number int) string {
 	return style.Render(tabContent)
 }
 
+// TabAt returns the index of the tab at column x of the rendered tab bar,
+// Count() for the "+ New" tab, or -1 when x is past the last tab. compact
+// selects the layout used by RenderCompact.
+func (t *TabBar) TabAt(x int, compact bool) int {
+	if x < 0 {
+		return -1
+	}
+	left := 0
+	for i, entry := range t.entries {
+		var tab string
+		if compact {
+			tab = t.renderCompactTab(entry, i+1)
+		} else {
+			tab = t.renderTab(entry, i+1)
+		}
+		left += lipgloss.Width(tab)
+		if x < left {
+			return i
+		}
+	}
+	newTab := "+ New"
+	if compact {
+		newTab = "+"
+	}
+	if x < left+lipgloss.Width(TabNewStyle.Render(newTab)) {
+		return len(t.entries)
+	}
+	return -1
+}
+
 // RenderCompact renders a compact version of the tab bar for narrow terminals.
 func (t *TabBar) RenderCompact() string {
 	if len(t.entries) == 0 {
@@ -302,9 +348,11 @@ func (t *TabBar) renderCompactTab(entry TabEntry, number int) string {
 	var stateIndicator string
 	switch entry.LoopState {
 	case loop.LoopStateRunning:
-		stateIndicator = "▶"
+		stateIndicator = t.spinner(entry.Name)
 	case loop.LoopStatePaused:
 		stateIndicator = "⏸"
+	case loop.LoopStateQueued:
+		stateIndicator = "⏳"
 	case loop.LoopStateComplete:
 		stateIndicator = "✓"
 	case loop.LoopStateError:
@@ -319,6 +367,9 @@ func (t *TabBar) renderCompactTab(entry TabEntry, number int) string {
 	if stateIndicator != "" {
 		content.WriteString(stateIndicator)
 	}
+	if entry.Conflict {
+		content.WriteString("⚠")
+	}
 
 	tabContent := content.String()
 
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/minicodemonkey/chief/internal/loop"
+	"github.com/minicodemonkey/chief/internal/paths"
 )
 
 func TestRenderTabWithBranch(t *testing.T) {
@@ -130,3 +131,46 @@ func TestRenderTabEmptyBranch(t *testing.T) {
 		t.Errorf("expected empty branch to not show empty brackets, got: %s", result)
 	}
 }
+
+func TestRenderTabSharedWorkspaceWarning(t *testing.T) {
+	tb := &TabBar{}
+
+	entry := TabEntry{Name: "auth", Branch: "chief/shared", Conflict: true}
+	if result := tb.renderTab(entry, 1); !strings.Contains(result, "⚠ shared") {
+		t.Errorf("expected tab to warn about shared worktree/branch, got: %s", result)
+	}
+	if result := tb.renderCompactTab(entry, 1); !strings.Contains(result, "⚠") {
+		t.Errorf("expected compact tab to warn about shared worktree/branch, got: %s", result)
+	}
+
+	entry.Conflict = false
+	if result := tb.renderTab(entry, 1); strings.Contains(result, "⚠") {
+		t.Errorf("expected no warning without a conflict, got: %s", result)
+	}
+}
+
+func TestRenderTabRunningSpinner(t *testing.T) {
+	restore := paths.SetHomeDir(t.TempDir())
+	defer restore()
+	tb := NewTabBar(t.TempDir(), "auth", nil)
+	entry := TabEntry{Name: "auth", LoopState: loop.LoopStateRunning, Iteration: 3}
+	other := TabEntry{Name: "billing", LoopState: loop.LoopStateRunning, Iteration: 1}
+
+	first := tb.renderTab(entry, 1)
+	if !strings.Contains(first, tb.spinner("auth")+" 3") {
+		t.Errorf("expected the spinner and iteration on a running tab, got: %s", first)
+	}
+	tb.Refresh()
+	if first != tb.renderTab(entry, 1) {
+		t.Error("expected the spinner to hold still without events")
+	}
+
+	otherFirst := tb.renderTab(other, 2)
+	tb.Advance("auth")
+	if tb.renderTab(entry, 1) == first || tb.renderCompactTab(entry, 1) == "" {
+		t.Error("expected an event to advance the spinner")
+	}
+	if tb.renderTab(other, 2) != otherFirst {
+		t.Error("expected another PRD's event to leave billing's spinner alone")
+	}
+}
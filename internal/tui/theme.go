@@ -0,0 +1,151 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/minicodemonkey/chief/internal/config"
+)
+
+// Theme is a color palette for the TUI, one color per named role.
+type Theme struct {
+	Primary     lipgloss.Color
+	Success     lipgloss.Color
+	Warning     lipgloss.Color
+	Error       lipgloss.Color
+	Muted       lipgloss.Color
+	Border      lipgloss.Color
+	Text        lipgloss.Color
+	TextMuted   lipgloss.Color
+	TextBright  lipgloss.Color
+	Bg          lipgloss.Color
+	BgSelected  lipgloss.Color
+	BgHighlight lipgloss.Color
+}
+
+// themePresets are the built-in palettes selectable with theme.preset.
+var themePresets = map[string]Theme{
+	"dark": {
+		Primary: "#00D7FF", Success: "#5AF78E", Warning: "#F3F99D", Error: "#FF5C57",
+		Muted: "#6C7086", Border: "#45475A",
+		Text: "#CDD6F4", TextMuted: "#6C7086", TextBright: "#FFFFFF",
+		Bg: "#1E1E2E", BgSelected: "#313244", BgHighlight: "#45475A",
+	},
+	"light": {
+		Primary: "#0077AA", Success: "#1A7F37", Warning: "#9A6700", Error: "#CF222E",
+		Muted: "#6E7781", Border: "#D0D7DE",
+		Text: "#24292F", TextMuted: "#6E7781", TextBright: "#000000",
+		Bg: "#FFFFFF", BgSelected: "#DDF4FF", BgHighlight: "#EAEEF2",
+	},
+	// Saturated colors on black; success and error use blue and orange so
+	// they stay distinct for red-green colorblindness
+	"highContrast": {
+		Primary: "#00FFFF", Success: "#3399FF", Warning: "#FFFF00", Error: "#FF8800",
+		Muted: "#C0C0C0", Border: "#FFFFFF",
+		Text: "#FFFFFF", TextMuted: "#C0C0C0", TextBright: "#FFFFFF",
+		Bg: "#000000", BgSelected: "#0000AA", BgHighlight: "#333333",
+	},
+}
+
+// defaultThemePreset is used when no preset is configured.
+const defaultThemePreset = "dark"
+
+// hexColor matches "#RGB" and "#RRGGBB".
+var hexColor = regexp.MustCompile(`^#([0-9A-Fa-f]{3}|[0-9A-Fa-f]{6})$`)
+
+// colors returns the theme's colors keyed by the names used in
+// theme.colors, pointing into t so they can be overridden.
+func (t *Theme) colors() map[string]*lipgloss.Color {
+	return map[string]*lipgloss.Color{
+		"primary":     &t.Primary,
+		"success":     &t.Success,
+		"warning":     &t.Warning,
+		"error":       &t.Error,
+		"muted":       &t.Muted,
+		"border":      &t.Border,
+		"text":        &t.Text,
+		"textMuted":   &t.TextMuted,
+		"textBright":  &t.TextBright,
+		"bg":          &t.Bg,
+		"bgSelected":  &t.BgSelected,
+		"bgHighlight": &t.BgHighlight,
+	}
+}
+
+// ThemeFromConfig builds a theme from the configured preset and per-color
+// overrides. An unknown preset falls back to the default palette and an
+// invalid override keeps the preset's color; both are reported in the error,
+// but the returned theme is always usable.
+func ThemeFromConfig(cfg config.ThemeConfig) (Theme, error) {
+	var errs []error
+
+	preset := cfg.Preset
+	if preset == "" {
+		preset = defaultThemePreset
+	}
+	theme, ok := themePresets[preset]
+	if !ok {
+		errs = append(errs, fmt.Errorf("unknown preset %q (want dark, light or highContrast)", cfg.Preset))
+		theme = themePresets[defaultThemePreset]
+	}
+
+	// Sorted so errors come out in a stable order
+	names := make([]string, 0, len(cfg.Colors))
+	for name := range cfg.Colors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	colors := theme.colors()
+	for _, name := range names {
+		value := cfg.Colors[name]
+		color, ok := colors[name]
+		if !ok {
+			errs = append(errs, fmt.Errorf("unknown color %q", name))
+			continue
+		}
+		if !hexColor.MatchString(value) {
+			errs = append(errs, fmt.Errorf("color %s: %q is not a hex color like #00D7FF", name, value))
+			continue
+		}
+		*color = lipgloss.Color(value)
+	}
+
+	return theme, errors.Join(errs...)
+}
+
+// LoadTheme applies the configured color scheme to the package's colors and
+// rebuilds every style from them. Call it at startup, before rendering. Bad
+// settings fall back to defaults and are reported in the returned error.
+func LoadTheme(cfg *config.Config) error {
+	var themeCfg config.ThemeConfig
+	if cfg != nil {
+		themeCfg = cfg.Theme
+	}
+	theme, err := ThemeFromConfig(themeCfg)
+	applyTheme(theme)
+	return err
+}
+
+// applyTheme sets the package's colors from theme and rebuilds the styles
+// that use them.
+func applyTheme(theme Theme) {
+	PrimaryColor = theme.Primary
+	SuccessColor = theme.Success
+	WarningColor = theme.Warning
+	ErrorColor = theme.Error
+	MutedColor = theme.Muted
+	BorderColor = theme.Border
+	TextColor = theme.Text
+	TextMutedColor = theme.TextMuted
+	TextBrightColor = theme.TextBright
+	BgColor = theme.Bg
+	BgSelectedColor = theme.BgSelected
+	BgHighlightColor = theme.BgHighlight
+
+	buildStyles()
+	confettiColors = defaultConfettiColors()
+}
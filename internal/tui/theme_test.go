@@ -0,0 +1,64 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/minicodemonkey/chief/internal/config"
+)
+
+func TestThemeFromConfig(t *testing.T) {
+	theme, err := ThemeFromConfig(config.ThemeConfig{})
+	if err != nil || theme != themePresets["dark"] {
+		t.Errorf("expected the dark preset by default, got %+v, %v", theme, err)
+	}
+
+	theme, err = ThemeFromConfig(config.ThemeConfig{
+		Preset: "light",
+		Colors: map[string]string{"primary": "#123456", "error": "#abc"},
+	})
+	if err != nil {
+		t.Fatalf("ThemeFromConfig() error = %v", err)
+	}
+	if theme.Primary != "#123456" || theme.Error != "#abc" || theme.Success != themePresets["light"].Success {
+		t.Errorf("expected overrides on top of the light preset, got %+v", theme)
+	}
+
+	theme, err = ThemeFromConfig(config.ThemeConfig{
+		Preset: "solarized",
+		Colors: map[string]string{"primary": "cyan", "sparkle": "#FFFFFF", "success": "#00FF00"},
+	})
+	if err == nil {
+		t.Fatal("expected an error for bad theme settings")
+	}
+	for _, want := range []string{`unknown preset "solarized"`, `unknown color "sparkle"`, `"cyan" is not a hex color`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error to mention %q, got: %v", want, err)
+		}
+	}
+	if theme.Primary != themePresets["dark"].Primary || theme.Success != "#00FF00" {
+		t.Errorf("expected bad values to fall back to the default preset, got %+v", theme)
+	}
+}
+
+func TestLoadThemeRebuildsStyles(t *testing.T) {
+	t.Cleanup(func() { applyTheme(themePresets[defaultThemePreset]) })
+
+	cfg := config.Default()
+	cfg.Theme.Preset = "highContrast"
+	if err := LoadTheme(cfg); err != nil {
+		t.Fatalf("LoadTheme() error = %v", err)
+	}
+
+	want := themePresets["highContrast"]
+	if PrimaryColor != want.Primary || primaryColor != want.Primary {
+		t.Errorf("expected PrimaryColor %s, got %s", want.Primary, PrimaryColor)
+	}
+	if got := headerStyle.GetForeground(); got != lipgloss.TerminalColor(want.Primary) {
+		t.Errorf("expected styles rebuilt with the new palette, header foreground is %v", got)
+	}
+	if confettiColors[0] != want.Success {
+		t.Errorf("expected confetti to use the theme's colors, got %v", confettiColors[0])
+	}
+}
@@ -0,0 +1,60 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/minicodemonkey/chief/internal/notify"
+	"github.com/minicodemonkey/chief/internal/prd"
+)
+
+// webhookResultMsg is sent when a notification webhook post finishes.
+type webhookResultMsg struct {
+	prdName string
+	err     error
+}
+
+// notifyWebhook returns a tea.Cmd that posts a PRD's final state to the
+// configured notification webhook, or nil if no webhook is configured.
+// The post runs in the background so a slow webhook never blocks the TUI.
+func (a *App) notifyWebhook(prdName, state, prURL string, err error) tea.Cmd {
+	if a.config == nil || a.config.Notifications.WebhookURL == "" {
+		return nil
+	}
+	url := a.config.Notifications.WebhookURL
+
+	payload := notify.NotificationPayload{
+		PRD:   prdName,
+		State: state,
+		PRURL: prURL,
+	}
+	if err != nil {
+		payload.Error = err.Error()
+	}
+	prdPath := ""
+	if a.manager != nil {
+		if instance := a.manager.GetInstance(prdName); instance != nil {
+			payload.Branch = instance.Branch
+			prdPath = instance.PRDPath
+		}
+	}
+
+	return func() tea.Msg {
+		if p, err := prd.LoadPRD(prdPath); err == nil {
+			payload.Total = len(p.UserStories)
+			for _, story := range p.UserStories {
+				if story.Passes {
+					payload.Completed++
+				}
+			}
+		}
+		return webhookResultMsg{prdName: prdName, err: notify.PostWebhook(url, payload)}
+	}
+}
+
+// handleWebhookResult surfaces a failed webhook post in the activity line.
+// Failures are otherwise ignored; they never interrupt the loop.
+func (a App) handleWebhookResult(msg webhookResultMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		a.lastActivity = "Webhook notification failed: " + msg.err.Error()
+	}
+	return a, nil
+}
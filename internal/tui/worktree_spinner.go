@@ -22,6 +22,7 @@ type stepInfo struct {
 	label    string
 	complete bool
 	active   bool
+	skipped  bool
 	errMsg   string
 }
 
@@ -112,6 +113,34 @@ func (w *WorktreeSpinner) SetError(err string) {
 	}
 }
 
+// SetupFailed returns true if the setup command failed, so the user can
+// retry it or continue without it.
+func (w *WorktreeSpinner) SetupFailed() bool {
+	return w.HasError() && w.currentStep == SpinnerStepRunSetup
+}
+
+// RetryStep clears the error and makes the current step active again.
+func (w *WorktreeSpinner) RetryStep() {
+	w.errMsg = ""
+	idx := int(w.currentStep)
+	if idx < len(w.steps) {
+		w.steps[idx].errMsg = ""
+		w.steps[idx].active = true
+	}
+}
+
+// SkipSetup clears a setup failure and finishes without the setup command.
+func (w *WorktreeSpinner) SkipSetup() {
+	w.errMsg = ""
+	idx := int(SpinnerStepRunSetup)
+	if idx < len(w.steps) {
+		w.steps[idx].errMsg = ""
+		w.steps[idx].active = false
+		w.steps[idx].skipped = true
+	}
+	w.currentStep = SpinnerStepDone
+}
+
 // HasError returns true if there is an error.
 func (w *WorktreeSpinner) HasError() bool {
 	return w.errMsg != ""
@@ -191,6 +220,10 @@ func (w *WorktreeSpinner) Render() string {
 			completedLabel = strings.Replace(completedLabel, "Creating worktree", "Created worktree", 1)
 			completedLabel = strings.Replace(completedLabel, "Running setup", "Ran setup", 1)
 			content.WriteString(textStyle.Render(completedLabel))
+		} else if step.skipped {
+			content.WriteString(mutedStyle.Render("↷"))
+			content.WriteString(" ")
+			content.WriteString(mutedStyle.Render(strings.Replace(step.label, "Running setup", "Skipped setup", 1)))
 		} else if step.errMsg != "" {
 			content.WriteString(errorStyle.Render("✗"))
 			content.WriteString(" ")
@@ -223,7 +256,9 @@ func (w *WorktreeSpinner) Render() string {
 	content.WriteString("\n")
 
 	footerStyle := lipgloss.NewStyle().Foreground(MutedColor)
-	if w.HasError() {
+	if w.SetupFailed() {
+		content.WriteString(footerStyle.Render("r: Retry setup  c: Continue without setup  Esc: Cancel and clean up"))
+	} else if w.HasError() {
 		content.WriteString(footerStyle.Render("Esc: Cancel and clean up"))
 	} else if w.IsDone() {
 		// No footer needed when transitioning
@@ -216,3 +216,42 @@ func TestWorktreeSpinnerRenderError(t *testing.T) {
 		t.Error("rendered error state should contain cleanup hint")
 	}
 }
+
+func TestWorktreeSpinnerSetupFailure(t *testing.T) {
+	s := NewWorktreeSpinner()
+	s.Configure("auth", "chief/auth", "main", ".chief/worktrees/auth/", "npm install")
+	s.SetSize(80, 24)
+
+	// A failure creating the worktree can only be cancelled
+	s.SetError("branch already exists")
+	if s.SetupFailed() {
+		t.Error("expected a branch failure not to count as a setup failure")
+	}
+
+	s = NewWorktreeSpinner()
+	s.Configure("auth", "chief/auth", "main", ".chief/worktrees/auth/", "npm install")
+	s.SetSize(80, 24)
+	s.AdvanceStep()
+	s.AdvanceStep()
+	s.SetError("npm ERR! network")
+	if !s.SetupFailed() {
+		t.Fatal("expected a setup failure")
+	}
+	if rendered := s.Render(); !strings.Contains(rendered, "Retry setup") || !strings.Contains(rendered, "Continue without setup") {
+		t.Error("rendered setup failure should offer retrying and continuing")
+	}
+
+	s.RetryStep()
+	if s.HasError() || !s.steps[2].active || s.steps[2].errMsg != "" {
+		t.Errorf("expected retry to clear the error and reactivate setup, got %+v", s.steps[2])
+	}
+
+	s.SetError("npm ERR! network")
+	s.SkipSetup()
+	if s.HasError() || !s.IsDone() || !s.steps[2].skipped {
+		t.Errorf("expected skipping setup to finish, got %+v", s.steps[2])
+	}
+	if rendered := s.Render(); !strings.Contains(rendered, "Skipped setup: npm install") {
+		t.Error("rendered spinner should show the skipped setup")
+	}
+}